@@ -0,0 +1,59 @@
+package clui
+
+import (
+	"io"
+	"sync"
+)
+
+// AccessibilityMarker starts every line written by AccessibilityAnnounce,
+// so screen reader bridges can find announcements in the output
+const AccessibilityMarker = "clui-a11y: "
+
+var (
+	a11yMtx sync.Mutex
+	a11yOut io.Writer
+)
+
+// SetAccessibilityOutput sets the writer that receives announcements for
+// screen readers, e.g. a named pipe read by a screen reader bridge. The
+// terminal is used by termbox to draw the UI, so announcements are not
+// written to the screen. nil(default) turns announcements off
+func SetAccessibilityOutput(w io.Writer) {
+	a11yMtx.Lock()
+	defer a11yMtx.Unlock()
+	a11yOut = w
+}
+
+// AccessibilityAnnounce writes the message to the accessibility output
+// as a line that starts with AccessibilityMarker. The library announces
+// the AccessibleLabel of every control that gets focus. The function can
+// be called from any goroutine
+func AccessibilityAnnounce(msg string) {
+	a11yMtx.Lock()
+	defer a11yMtx.Unlock()
+	if a11yOut == nil || msg == "" {
+		return
+	}
+	io.WriteString(a11yOut, AccessibilityMarker+msg+"\n")
+}
+
+// announceFocus announces the control that has just got focus
+func announceFocus(ctrl Control) {
+	AccessibilityAnnounce(ctrl.AccessibleLabel())
+}
+
+// AccessibleLabel returns the text that is announced when the control
+// gets focus. By default it is the control title without color tags
+func (c *BaseControl) AccessibleLabel() string {
+	if c.a11yLabel != "" {
+		return c.a11yLabel
+	}
+	return UnColorizeText(c.title)
+}
+
+// SetAccessibleLabel changes the text that is announced when the control
+// gets focus. It is useful for controls without visible text, e.g,
+// SparkChart or ListBox. Empty label restores the default one
+func (c *BaseControl) SetAccessibleLabel(label string) {
+	c.a11yLabel = label
+}
@@ -0,0 +1,27 @@
+package clui
+
+import (
+	"bytes"
+	term "github.com/nsf/termbox-go"
+	"testing"
+)
+
+func TestAccessibilityAnnounce(t *testing.T) {
+	var out bytes.Buffer
+	SetAccessibilityOutput(&out)
+	defer SetAccessibilityOutput(nil)
+
+	wnd := CreateWindow(0, 0, 20, 10, "Test")
+	btn := CreateButton(wnd, 8, 4, "<c:red>OK", Fixed)
+	chart := CreateSparkChart(wnd, 8, 4, Fixed)
+	chart.SetTabStop(true)
+	chart.SetAccessibleLabel("CPU usage")
+
+	ActivateControl(wnd, btn)
+	wnd.ProcessEvent(Event{Type: EventKey, Key: term.KeyTab})
+
+	expected := AccessibilityMarker + "OK\n" + AccessibilityMarker + "CPU usage\n"
+	if out.String() != expected {
+		t.Errorf("Invalid announcements %q", out.String())
+	}
+}
@@ -0,0 +1,187 @@
+package clui
+
+import (
+	term "github.com/nsf/termbox-go"
+	"math"
+	"sort"
+)
+
+/*
+AreaChart is a chart that displays one or more named series
+of data as lines with filled area under them. The area spans
+from the value down to the baseline: zero if the chart displays
+both positive and negative values, or the chart bottom otherwise.
+Every character column displays one point of every series.
+The top cell of the area is drawn with the series color, the
+rest of the area is drawn with the series fill character and
+fill color. Series with higher z-order are drawn in front of
+series with lower z-order and their area hides the area of the
+series behind them. Series with equal z-order are drawn in order
+of adding: the last added series is in front.
+The same as LineChart, AreaChart uses point index as X coordinate,
+it displays vertical axis with values on the chart left if
+ValueWidth greater than 0, a row of point indices at the bottom
+if ShowXLabels is true, and chart legend on the right if
+LegendWidth is greater than 3.
+If LegendWidth is greater than half of the chart it is not
+displayed. The same is applied to ValueWidth
+*/
+type AreaChart struct {
+	seriesChart
+}
+
+/*
+CreateAreaChart creates a new area chart.
+parent - is container that keeps the control.
+w and h - are minimal size of the control.
+scale - the way of scaling the control when the parent is resized. Use DoNotScale constant if the
+control should keep its original size.
+*/
+func CreateAreaChart(parent Control, w, h int, scale int) *AreaChart {
+	c := new(AreaChart)
+	c.initSeriesChart(parent, w, h, scale, ColorAreaChartText, ColorAreaChartBack, ObjAreaChart, 1)
+	// the area of positive values is filled down to the chart
+	// bottom, so the lowest value must not be at the bottom
+	c.withZero = true
+
+	if parent != nil {
+		parent.AddChild(c)
+	}
+
+	return c
+}
+
+// Draw repaints the control on its View surface
+func (a *AreaChart) Draw() {
+	PushAttributes()
+	defer PopAttributes()
+
+	if !a.drawBackground() {
+		return
+	}
+
+	a.drawValues(a.rowValue)
+	a.drawXLabels()
+	a.drawLegend()
+	a.drawAreas()
+}
+
+// zOrdered returns the series sorted from back to front
+func (a *AreaChart) zOrdered() []chartSeries {
+	ordered := make([]chartSeries, len(a.series))
+	copy(ordered, a.series)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].z < ordered[j].z
+	})
+	return ordered
+}
+
+func (a *AreaChart) drawAreas() {
+	start, width := a.calculateChartArea()
+	h := a.chartHeight()
+	if width < 1 || h < 1 {
+		return
+	}
+
+	bottom, top := a.calculateRange()
+	if top == bottom {
+		return
+	}
+
+	PushAttributes()
+	defer PopAttributes()
+
+	parts := []rune(SysObject(ObjAreaChart))
+	chLine, chFill := parts[0], parts[0]
+	if len(parts) > 1 {
+		chFill = parts[1]
+	}
+
+	// position of a value in rows from the chart top
+	toRow := func(v float64) float64 {
+		return (top - v) / (top - bottom) * float64(h)
+	}
+	baseline := toRow(math.Min(math.Max(0, bottom), top))
+
+	bg := RealColor(a.bg, ColorAreaChartBack)
+	first, count := a.xRange()
+	for _, s := range a.zOrdered() {
+		lineClr := RealColor(s.color, ColorAreaChartText)
+		fillClr := lineClr
+		if s.fillColor != ColorDefault {
+			fillClr = s.fillColor
+		}
+		fill := chFill
+		if s.fill != 0 {
+			fill = s.fill
+		}
+
+		for i := 0; i < count; i++ {
+			idx := first + i
+			if idx < 0 || idx >= len(s.data) {
+				continue
+			}
+
+			// a cell belongs to the area if its center is inside the area
+			pos := toRow(s.data[idx])
+			from := int(math.Floor(math.Min(pos, baseline) + 0.5))
+			to := int(math.Floor(math.Max(pos, baseline) - 0.5))
+			edge := from
+			if pos > baseline {
+				edge = to
+			}
+
+			for row := from; row <= to; row++ {
+				if row < 0 || row >= h {
+					continue
+				}
+				SetBackColor(bg)
+				if row == edge {
+					SetTextColor(lineClr)
+					PutChar(a.x+start+i, a.y+row, chLine)
+				} else {
+					SetTextColor(fillClr)
+					PutChar(a.x+start+i, a.y+row, fill)
+				}
+			}
+		}
+	}
+}
+
+// rowValue returns the value at the top of the row dy
+func (a *AreaChart) rowValue(dy int, bottom, top float64) float64 {
+	return top - float64(dy)/float64(a.chartHeight())*(top-bottom)
+}
+
+// SetSeriesFill changes the character and the color used to fill
+// the area under the series line. Use 0 for ch to fill with the
+// default theme character and ColorDefault for color to fill with
+// the series color
+func (a *AreaChart) SetSeriesFill(id SeriesID, ch rune, color term.Attribute) {
+	if id < 0 || int(id) >= len(a.series) {
+		return
+	}
+
+	a.series[id].fill = ch
+	a.series[id].fillColor = color
+}
+
+// SeriesZOrder returns z-order of the series
+func (a *AreaChart) SeriesZOrder(id SeriesID) int {
+	if id < 0 || int(id) >= len(a.series) {
+		return 0
+	}
+
+	return a.series[id].z
+}
+
+// SetSeriesZOrder changes the z-order of the series. Series with
+// higher z-order are drawn in front of other series. All series
+// have z-order 0 by default
+func (a *AreaChart) SetSeriesZOrder(id SeriesID, z int) {
+	if id < 0 || int(id) >= len(a.series) {
+		return
+	}
+
+	a.series[id].z = z
+}
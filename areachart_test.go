@@ -0,0 +1,72 @@
+package clui
+
+import (
+	"testing"
+)
+
+func TestAreaChartDraw(t *testing.T) {
+	cases := []struct {
+		data [][]float64
+		w, h int
+		want string
+	}{
+		{nil, 3, 3, "   \n   \n   "},
+		{[][]float64{{1, 2, 3}}, 3, 3, "  █\n █░\n█░░"},
+		// negative values are filled up to the zero baseline
+		{[][]float64{{2, -2}}, 2, 4, "█ \n░ \n ░\n █"},
+		// only the last points that fit the chart are displayed
+		{[][]float64{{5, 5, 1, 2, 3}}, 3, 3, "  █\n █░\n█░░"},
+	}
+
+	for _, c := range cases {
+		chart := CreateAreaChart(nil, c.w, c.h, Fixed)
+		for _, data := range c.data {
+			id := chart.AddSeries("s", ColorDefault)
+			for _, v := range data {
+				chart.AppendPoint(id, v)
+			}
+		}
+		if got := renderToString(chart); got != c.want {
+			t.Errorf("Area chart %v ==\n%v\nwant\n%v", c.data, got, c.want)
+		}
+	}
+}
+
+func TestAreaChartZOrder(t *testing.T) {
+	mock := CreateMockCanvas(20, 3)
+	defer mock.Close()
+
+	chart := CreateAreaChart(nil, 20, 3, Fixed)
+	chart.SetValueWidth(4)
+	chart.SetLegendWidth(7)
+	back := chart.AddSeries("bg", ColorGreen)
+	front := chart.AddSeries("fg", ColorRed)
+	chart.SetSeriesFill(back, '#', ColorBlue)
+	for i := 0; i < 2; i++ {
+		chart.AppendPoint(back, 3)
+		chart.AppendPoint(front, 1)
+	}
+
+	// the last added series is in front by default
+	chart.Draw()
+	want := "3.00██       █ - bg \n    ##       █ - fg \n1.00██              "
+	if got := mock.String(); got != want {
+		t.Errorf("Invalid area chart:\n%v", got)
+	}
+	if fg := mock.Cell(4, 1).Fg; fg != ColorBlue {
+		t.Errorf("Invalid fill color %v", fg)
+	}
+	if fg := mock.Cell(4, 2).Fg; fg != ColorRed {
+		t.Errorf("The front series line color expected, got %v", fg)
+	}
+
+	chart.SetSeriesZOrder(back, 1)
+	if chart.SeriesZOrder(back) != 1 {
+		t.Errorf("Invalid z-order %v", chart.SeriesZOrder(back))
+	}
+	chart.Draw()
+	want = "3.00██       █ - bg \n    ##       █ - fg \n1.00##              "
+	if got := mock.String(); got != want {
+		t.Errorf("The series with higher z-order must be in front:\n%v", got)
+	}
+}
@@ -4,8 +4,13 @@ import (
 	"fmt"
 	xs "github.com/huandu/xstrings"
 	term "github.com/nsf/termbox-go"
+	"sync"
+	"time"
 )
 
+// barAnimationFPS is the number of animation frames per second
+const barAnimationFPS = 30
+
 // BarData is info about one bar in the chart. Every
 // bar can be customized by setting its own colors and
 // rune to draw the bar. Use ColorDefault for Fg and Bg,
@@ -37,6 +42,22 @@ type BarDataCell struct {
 	Ch rune
 }
 
+// barSeries is one data series of a stacked chart: its values
+// for every bar and the color of its segments
+type barSeries struct {
+	name   string
+	color  term.Attribute
+	values []float64
+}
+
+// stackSegment is a part of a stacked bar that displays one series
+// value: the segment occupies cells from `from` to `to`-1 counting
+// from the bar base
+type stackSegment struct {
+	from, to int
+	color    term.Attribute
+}
+
 /*
 BarChart is a chart that represents grouped data with
 rectangular bars. It can be monochrome - defaut behavior.
@@ -53,7 +74,27 @@ if ShowTitles is true (to enable displaying marks on horizontal
 axis, set ShowMarks to true), and chart legend on the right if
 LegendWidth is greater than 3.
 If LegendWidth is greater than half of the chart it is not
-displayed. The same is applied to ValueWidth
+displayed. The same is applied to ValueWidth.
+In horizontal orientation bars grow from left to right: bar
+titles are on the left, values are at the top, and BarSize
+is the bar height.
+In stacked mode bars display data series added with AddSeries:
+every bar is a stack of series values drawn in series colors
+from bottom to top, the legend shows series, and the axis scales
+to the highest stack. Bar titles are still taken from bar data.
+A scrollable chart displays a scrollbar at the bottom if not all
+bars fit the chart. A user pans through bars with arrow keys or
+mouse wheel, the value axis and legend stay in place.
+An animated chart does not change bar heights at once when data
+is set or added: bars grow or shrink to new values smoothly during
+the animation duration. Stacked bars are not animated.
+A user can select a bar by clicking it, the selected bar is
+highlighted and Escape removes the selection.
+
+Events:
+
+	OnSelect - called when a user clicks a bar. The callback gets
+	    the bar index and its value(the stack sum in stacked mode)
 */
 type BarChart struct {
 	BaseControl
@@ -66,6 +107,21 @@ type BarChart struct {
 	showMarks   bool
 	showTitles  bool
 	onDrawCell  func(*BarDataCell)
+	direction   Direction
+	stacked     bool
+	series      []barSeries
+	scrolling   bool
+	scrollPos   int
+	selected    int
+	onSelect    func(int, float64)
+
+	animated bool
+	animTime time.Duration
+	// animMtx guards displayed values changed by the animation
+	// goroutine. shown is nil if no animation is in progress
+	animMtx  sync.Mutex
+	shown    []float64
+	animStop chan struct{}
 }
 
 /*
@@ -93,6 +149,9 @@ func CreateBarChart(parent Control, w, h int, scale int) *BarChart {
 	c.tabSkip = true
 	c.showTitles = true
 	c.barWidth = 3
+	c.direction = Vertical
+	c.selected = -1
+	c.animTime = 300 * time.Millisecond
 	c.data = make([]BarData, 0)
 	c.SetScale(scale)
 
@@ -114,28 +173,188 @@ func (b *BarChart) Draw() {
 
 	FillRect(b.x, b.y, b.width, b.height, ' ')
 
-	if len(b.data) == 0 {
+	if b.barCount() == 0 {
 		return
 	}
 
+	b.SetScrollOffset(b.scrollPos)
 	b.drawRulers()
 	b.drawValues()
 	b.drawLegend()
 	b.drawBars()
+	b.drawScrollBar()
+}
+
+// barCount returns the number of bars: the number of values in
+// the longest series in stacked mode, and the number of bar data
+// items otherwise
+func (b *BarChart) barCount() int {
+	if !b.stacked {
+		return len(b.data)
+	}
+
+	cnt := 0
+	for _, s := range b.series {
+		if len(s.values) > cnt {
+			cnt = len(s.values)
+		}
+	}
+	return cnt
+}
+
+// bar returns info about the bar. In stacked mode the bar value
+// is the sum of its series values, negative values are skipped
+func (b *BarChart) bar(idx int) BarData {
+	var d BarData
+	if idx < len(b.data) {
+		d = b.data[idx]
+	}
+	if !b.stacked {
+		return d
+	}
+
+	d.Value = 0
+	for _, s := range b.series {
+		if idx < len(s.values) && s.values[idx] > 0 {
+			d.Value += s.values[idx]
+		}
+	}
+	return d
+}
+
+// stackSegments returns the parts of the stacked bar ordered from
+// the bar base. Segments are calculated from running totals, so
+// rounding never makes the stack longer than the bar
+func (b *BarChart) stackSegments(idx int, coeff float64) []stackSegment {
+	var segs []stackSegment
+	sum := 0.0
+	from := 0
+	for _, s := range b.series {
+		if idx >= len(s.values) || s.values[idx] <= 0 {
+			continue
+		}
+		sum += s.values[idx]
+		to := int(sum * coeff)
+		if to > from {
+			segs = append(segs, stackSegment{from: from, to: to, color: s.color})
+		}
+		from = to
+	}
+	return segs
 }
 
 func (b *BarChart) barHeight() int {
+	h := b.height
 	if b.showTitles {
-		return b.height - 2
+		h -= 2
+	}
+	if b.scrollBarVisible() {
+		h--
+	}
+	return h
+}
+
+// barRows returns the first row and the number of rows of the bar
+// area in horizontal orientation. The top row displays values
+func (b *BarChart) barRows() (int, int) {
+	top, rows := 0, b.height
+	if b.valueWidth > 0 && b.height > 2 {
+		top, rows = 1, b.height-1
+	}
+	if b.scrollBarVisible() {
+		rows--
 	}
-	return b.height
+	return top, rows
+}
+
+// scrollBarVisible returns true if the chart is scrollable and bars
+// of minimal width do not fit the bar area. The scrollbar takes the
+// bottom row of the chart
+func (b *BarChart) scrollBarVisible() bool {
+	cnt := b.barCount()
+	if !b.scrolling || cnt == 0 {
+		return false
+	}
+
+	length := b.height
+	if b.direction == Horizontal {
+		if b.valueWidth > 0 && b.height > 2 {
+			length--
+		}
+	} else {
+		_, length = b.calculateBarArea()
+	}
+	return cnt*b.barWidth+(cnt-1)*b.gap > length
+}
+
+// visibleBars returns how many bars fit the bar area
+func (b *BarChart) visibleBars() int {
+	barW := b.calculateBarWidth()
+	if barW == 0 {
+		return 0
+	}
+
+	_, length := b.calculateBarArea()
+	if b.direction == Horizontal {
+		_, length = b.barRows()
+	}
+	return (length + b.gap) / (barW + b.gap)
+}
+
+// maxScrollOffset returns the index of the first displayed bar when
+// the chart is scrolled to the end
+func (b *BarChart) maxScrollOffset() int {
+	if !b.scrolling {
+		return 0
+	}
+	if diff := b.barCount() - b.visibleBars(); diff > 0 {
+		return diff
+	}
+	return 0
+}
+
+// barLength returns the length of the longest bar: the height of
+// the bar area in vertical orientation and its width otherwise
+func (b *BarChart) barLength() int {
+	if b.direction == Horizontal {
+		_, width := b.calculateBarArea()
+		return width
+	}
+	return b.barHeight()
+}
+
+// titleWidth returns the width of bar titles in horizontal
+// orientation. Titles take no more than a third of the chart
+func (b *BarChart) titleWidth() int {
+	if !b.showTitles {
+		return 0
+	}
+
+	w := 0
+	for _, d := range b.data {
+		if l := xs.Len(d.Title); l > w {
+			w = l
+		}
+	}
+	if w > b.width/3 {
+		w = b.width / 3
+	}
+	return w
 }
 
 func (b *BarChart) drawBars() {
-	if len(b.data) == 0 {
+	if b.barCount() == 0 {
 		return
 	}
 
+	if b.direction == Horizontal {
+		b.drawHorizontalBars()
+	} else {
+		b.drawVerticalBars()
+	}
+}
+
+func (b *BarChart) drawVerticalBars() {
 	start, width := b.calculateBarArea()
 	if width < 2 {
 		return
@@ -158,12 +377,16 @@ func (b *BarChart) drawBars() {
 	pos := start
 	parts := []rune(SysObject(ObjBarChart))
 	fg, bg := TextColor(), BackColor()
+	selFg, selBg := RealColor(ColorDefault, ColorBarChartSelectedText), RealColor(ColorDefault, ColorBarChartSelectedBack)
 
-	for idx, d := range b.data {
+	for idx := b.scrollPos; idx < b.barCount(); idx++ {
 		if pos+barW > start+width {
 			break
 		}
 
+		d := b.bar(idx)
+		d.Value = b.displayValue(idx, d.Value, max)
+
 		fColor, bColor := d.Fg, d.Bg
 		ch := d.Ch
 		if fColor == ColorDefault {
@@ -175,9 +398,18 @@ func (b *BarChart) drawBars() {
 		if ch == 0 {
 			ch = parts[0]
 		}
+		if idx == b.selected {
+			fColor, bColor = selFg, selBg
+		}
 
 		barH := int(d.Value * coeff)
-		if b.onDrawCell == nil {
+		if b.stacked {
+			SetBackColor(bColor)
+			for _, seg := range b.stackSegments(idx, coeff) {
+				SetTextColor(seg.color)
+				FillRect(b.x+pos, b.y+h-seg.to, barW, seg.to-seg.from, ch)
+			}
+		} else if b.onDrawCell == nil {
 			SetTextColor(fColor)
 			SetBackColor(bColor)
 			FillRect(b.x+pos, b.y+h-barH, barW, barH, ch)
@@ -218,6 +450,108 @@ func (b *BarChart) drawBars() {
 	}
 }
 
+func (b *BarChart) drawHorizontalBars() {
+	start, width := b.calculateBarArea()
+	if width < 2 {
+		return
+	}
+
+	barH := b.calculateBarWidth()
+	if barH == 0 {
+		return
+	}
+
+	coeff, max := b.calculateMultiplier()
+	if coeff == 0.0 {
+		return
+	}
+
+	PushAttributes()
+	defer PopAttributes()
+
+	top, rows := b.barRows()
+	pos := top
+	parts := []rune(SysObject(ObjBarChart))
+	fg, bg := TextColor(), BackColor()
+	selFg, selBg := RealColor(ColorDefault, ColorBarChartSelectedText), RealColor(ColorDefault, ColorBarChartSelectedBack)
+
+	for idx := b.scrollPos; idx < b.barCount(); idx++ {
+		if pos+barH > top+rows {
+			break
+		}
+
+		d := b.bar(idx)
+		d.Value = b.displayValue(idx, d.Value, max)
+
+		fColor, bColor := d.Fg, d.Bg
+		ch := d.Ch
+		if fColor == ColorDefault {
+			fColor = fg
+		}
+		if bColor == ColorDefault {
+			bColor = bg
+		}
+		if ch == 0 {
+			ch = parts[0]
+		}
+		if idx == b.selected {
+			fColor, bColor = selFg, selBg
+		}
+
+		barW := int(d.Value * coeff)
+		if b.stacked {
+			SetBackColor(bColor)
+			for _, seg := range b.stackSegments(idx, coeff) {
+				SetTextColor(seg.color)
+				FillRect(b.x+start+seg.from, b.y+pos, seg.to-seg.from, barH, ch)
+			}
+		} else if b.onDrawCell == nil {
+			SetTextColor(fColor)
+			SetBackColor(bColor)
+			FillRect(b.x+start, b.y+pos, barW, barH, ch)
+		} else {
+			cellDef := BarDataCell{Item: d.Title, ID: idx,
+				Value: 0, BarMax: d.Value, TotalMax: max,
+				Fg: fColor, Bg: bColor, Ch: ch}
+			for dx := 0; dx < barW; dx++ {
+				req := cellDef
+				req.Value = max * float64(dx+1) / float64(width)
+				b.onDrawCell(&req)
+				SetTextColor(req.Fg)
+				SetBackColor(req.Bg)
+				for dy := 0; dy < barH; dy++ {
+					PutChar(b.x+start+dx, b.y+pos+dy, req.Ch)
+				}
+			}
+		}
+
+		if b.showTitles {
+			SetTextColor(fg)
+			SetBackColor(bg)
+			if b.showMarks {
+				PutChar(b.x+start-1, b.y+pos+barH/2, parts[10])
+			}
+			DrawRawText(b.x, b.y+pos+barH/2, CutText(d.Title, b.titleWidth()))
+		}
+
+		pos += barH + b.gap
+	}
+}
+
+// drawScrollBar draws the scrollbar under the bar area
+func (b *BarChart) drawScrollBar() {
+	if !b.scrollBarVisible() {
+		return
+	}
+
+	start, width := b.calculateBarArea()
+	if width < 3 {
+		return
+	}
+	pos := ThumbPosition(b.scrollPos, b.maxScrollOffset()+1, width)
+	DrawScrollBar(b.x+start, b.y+b.height-1, width, 1, pos)
+}
+
 func (b *BarChart) drawLegend() {
 	pos, width := b.calculateBarArea()
 	if pos+width >= b.width-3 {
@@ -230,6 +564,21 @@ func (b *BarChart) drawLegend() {
 
 	parts := []rune(SysObject(ObjBarChart))
 	defRune := parts[0]
+	if b.stacked {
+		for idx, s := range b.series {
+			if idx >= b.height {
+				break
+			}
+
+			SetTextColor(s.color)
+			SetBackColor(bg)
+			PutChar(b.x+pos+width, b.y+idx, defRune)
+			SetTextColor(fg)
+			DrawRawText(b.x+pos+width+1, b.y+idx, CutText(fmt.Sprintf(" - %v", s.name), b.legendWidth))
+		}
+		return
+	}
+
 	for idx, d := range b.data {
 		if idx >= b.height {
 			break
@@ -254,6 +603,11 @@ func (b *BarChart) drawValues() {
 		return
 	}
 
+	if b.direction == Horizontal {
+		b.drawHorizontalValues()
+		return
+	}
+
 	pos, _ := b.calculateBarArea()
 	if pos == 0 {
 		return
@@ -277,11 +631,43 @@ func (b *BarChart) drawValues() {
 	}
 }
 
+// drawHorizontalValues draws values at the top of the bar area in
+// horizontal orientation
+func (b *BarChart) drawHorizontalValues() {
+	if top, _ := b.barRows(); top == 0 {
+		return
+	}
+
+	start, width := b.calculateBarArea()
+	coeff, max := b.calculateMultiplier()
+	if coeff == 0.0 {
+		return
+	}
+
+	format := fmt.Sprintf("%%-%v.2f", b.valueWidth)
+	for dx := 0; dx+b.valueWidth <= width; dx += b.valueWidth + 2 {
+		v := float64(dx) / float64(width) * max
+		DrawRawText(b.x+start+dx, b.y, CutText(fmt.Sprintf(format, v), b.valueWidth))
+	}
+}
+
 func (b *BarChart) drawRulers() {
 	if b.valueWidth <= 0 && b.legendWidth <= 0 && !b.showTitles {
 		return
 	}
 
+	if b.direction == Horizontal {
+		if b.showTitles {
+			start, _ := b.calculateBarArea()
+			top, rows := b.barRows()
+			cV := []rune(SysObject(ObjBarChart))[2]
+			for dy := top; dy < top+rows; dy++ {
+				PutChar(b.x+start-1, b.y+dy, cV)
+			}
+		}
+		return
+	}
+
 	pos, vWidth := b.calculateBarArea()
 
 	parts := []rune(SysObject(ObjBarChart))
@@ -314,6 +700,17 @@ func (b *BarChart) calculateBarArea() (int, int) {
 	w := b.width
 	pos := 0
 
+	if b.direction == Horizontal {
+		if tw := b.titleWidth(); tw > 0 {
+			pos = tw + 1
+			w -= pos
+		}
+		if b.legendWidth < w/2 {
+			w -= b.legendWidth
+		}
+		return pos, w
+	}
+
 	if b.valueWidth < w/2 {
 		w = w - b.valueWidth - 1
 		pos = b.valueWidth + 1
@@ -327,7 +724,7 @@ func (b *BarChart) calculateBarArea() (int, int) {
 }
 
 func (b *BarChart) calculateBarWidth() int {
-	if len(b.data) == 0 {
+	if b.barCount() == 0 {
 		return 0
 	}
 
@@ -335,15 +732,20 @@ func (b *BarChart) calculateBarWidth() int {
 		return b.barWidth
 	}
 
-	w := b.width
-	if b.valueWidth < w/2 {
-		w = w - b.valueWidth - 1
-	}
-	if b.legendWidth < w/2 {
-		w -= b.legendWidth
+	var w int
+	if b.direction == Horizontal {
+		_, w = b.barRows()
+	} else {
+		w = b.width
+		if b.valueWidth < w/2 {
+			w = w - b.valueWidth - 1
+		}
+		if b.legendWidth < w/2 {
+			w -= b.legendWidth
+		}
 	}
 
-	dataCount := len(b.data)
+	dataCount := b.barCount()
 	minSize := dataCount*b.barWidth + (dataCount-1)*b.gap
 	if minSize >= w {
 		return b.barWidth
@@ -358,19 +760,20 @@ func (b *BarChart) calculateBarWidth() int {
 }
 
 func (b *BarChart) calculateMultiplier() (float64, float64) {
-	if len(b.data) == 0 {
+	cnt := b.barCount()
+	if cnt == 0 {
 		return 0, 0
 	}
 
-	h := b.barHeight()
+	h := b.barLength()
 	if h <= 1 {
 		return 0, 0
 	}
 
-	max := b.data[0].Value
-	for _, val := range b.data {
-		if val.Value > max {
-			max = val.Value
+	max := b.bar(0).Value
+	for idx := 1; idx < cnt; idx++ {
+		if v := b.bar(idx).Value; v > max {
+			max = v
 		}
 	}
 
@@ -383,18 +786,166 @@ func (b *BarChart) calculateMultiplier() (float64, float64) {
 
 // AddData appends a new bar to a chart
 func (b *BarChart) AddData(val BarData) {
+	from := b.displayedValues()
 	b.data = append(b.data, val)
+	b.animate(from)
 }
 
 // ClearData removes all bar from chart
 func (b *BarChart) ClearData() {
+	b.stopAnimation()
 	b.data = make([]BarData, 0)
+	b.selected = -1
 }
 
 // SetData assign a new bar list to a chart
 func (b *BarChart) SetData(data []BarData) {
+	from := b.displayedValues()
 	b.data = make([]BarData, len(data))
 	copy(b.data, data)
+	b.animate(from)
+}
+
+// displayedValues returns values of bars that are displayed now
+func (b *BarChart) displayedValues() []float64 {
+	b.animMtx.Lock()
+	defer b.animMtx.Unlock()
+
+	if b.shown != nil {
+		return append([]float64(nil), b.shown...)
+	}
+	values := make([]float64, len(b.data))
+	for idx, d := range b.data {
+		values[idx] = d.Value
+	}
+	return values
+}
+
+// displayValue returns the value to draw the bar: the intermediate
+// value while the animation is in progress and the bar value otherwise.
+// The result never exceeds max
+func (b *BarChart) displayValue(idx int, value, max float64) float64 {
+	if !b.stacked {
+		b.animMtx.Lock()
+		if idx < len(b.shown) {
+			value = b.shown[idx]
+		}
+		b.animMtx.Unlock()
+	}
+	if value > max {
+		value = max
+	}
+	return value
+}
+
+// animate starts the animation from the displayed values to the
+// current bar values. The animation in progress is canceled, and
+// the new one starts from the values it has reached
+func (b *BarChart) animate(from []float64) {
+	b.stopAnimation()
+	if !b.animated || b.stacked || b.animTime <= 0 {
+		return
+	}
+
+	to := make([]float64, len(b.data))
+	for idx, d := range b.data {
+		to[idx] = d.Value
+	}
+	// new bars grow from zero
+	for len(from) < len(to) {
+		from = append(from, 0)
+	}
+	from = from[:len(to)]
+
+	b.animMtx.Lock()
+	b.shown = append([]float64(nil), from...)
+	b.animMtx.Unlock()
+
+	stop := make(chan struct{})
+	b.animStop = stop
+	start, duration := time.Now(), b.animTime
+	ticker := time.NewTicker(time.Second / barAnimationFPS)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case now := <-ticker.C:
+				progress := float64(now.Sub(start)) / float64(duration)
+				done := b.animationStep(stop, from, to, progress)
+				if loop != nil {
+					PutEvent(Event{Type: EventRedraw})
+				}
+				if done {
+					return
+				}
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// animationStep moves displayed values to the point of the animation.
+// progress is the part of the duration passed since the animation start.
+// Returns true if the animation is finished or canceled
+func (b *BarChart) animationStep(stop chan struct{}, from, to []float64, progress float64) bool {
+	b.animMtx.Lock()
+	defer b.animMtx.Unlock()
+
+	select {
+	case <-stop:
+		return true
+	default:
+	}
+
+	if progress >= 1 {
+		b.shown = nil
+		return true
+	}
+	for idx := range to {
+		b.shown[idx] = from[idx] + (to[idx]-from[idx])*progress
+	}
+	return false
+}
+
+// stopAnimation cancels the animation in progress. Bars display
+// their values after that
+func (b *BarChart) stopAnimation() {
+	if b.animStop == nil {
+		return
+	}
+
+	b.animMtx.Lock()
+	close(b.animStop)
+	b.shown = nil
+	b.animMtx.Unlock()
+	b.animStop = nil
+}
+
+// Animated returns if bars change their heights smoothly
+func (b *BarChart) Animated() bool {
+	return b.animated
+}
+
+// SetAnimated turns on and off animation of bar changes made by
+// SetData and AddData. Turning animation off stops the animation
+// in progress
+func (b *BarChart) SetAnimated(animated bool) {
+	b.animated = animated
+	if !animated {
+		b.stopAnimation()
+	}
+}
+
+// AnimationDuration returns how long bars change their heights
+func (b *BarChart) AnimationDuration() time.Duration {
+	return b.animTime
+}
+
+// SetAnimationDuration changes how long bars change their heights.
+// The new duration is used by the next animation
+func (b *BarChart) SetAnimationDuration(d time.Duration) {
+	b.animTime = d
 }
 
 // AutoSize returns whether automatic bar width
@@ -478,6 +1029,218 @@ func (b *BarChart) OnDrawCell(fn func(*BarDataCell)) {
 	b.onDrawCell = fn
 }
 
+// Orientation returns the direction in which bars grow
+func (b *BarChart) Orientation() Direction {
+	return b.direction
+}
+
+// SetOrientation sets the direction in which bars grow: Vertical(default)
+// - from bottom to top, or Horizontal - from left to right. Horizontal
+// orientation is useful for ranking lists with long bar titles
+func (b *BarChart) SetOrientation(dir Direction) {
+	b.direction = dir
+}
+
+// Stacked returns if the chart displays data series as stacked bars
+func (b *BarChart) Stacked() bool {
+	return b.stacked
+}
+
+// SetStacked turns on and off stacked mode. In stacked mode the
+// chart displays series added with AddSeries instead of bar values.
+// OnDrawCell callback is not called for stacked bars
+func (b *BarChart) SetStacked(stacked bool) {
+	b.stacked = stacked
+}
+
+// AddSeries adds a new data series for stacked mode. Segments of the
+// series are drawn with color. If a series with the same name exists
+// only its color is changed
+func (b *BarChart) AddSeries(name string, color term.Attribute) {
+	for idx := range b.series {
+		if b.series[idx].name == name {
+			b.series[idx].color = color
+			return
+		}
+	}
+	b.series = append(b.series, barSeries{name: name, color: color})
+}
+
+// AddSeriesValue appends the value for the next bar to the series.
+// Returns false if the series does not exist
+func (b *BarChart) AddSeriesValue(name string, value float64) bool {
+	for idx := range b.series {
+		if b.series[idx].name == name {
+			b.series[idx].values = append(b.series[idx].values, value)
+			return true
+		}
+	}
+	return false
+}
+
+// ClearSeries removes all data series
+func (b *BarChart) ClearSeries() {
+	b.series = nil
+}
+
+// Scrollable returns if a user can scroll bars that do not fit
+// the chart
+func (b *BarChart) Scrollable() bool {
+	return b.scrolling
+}
+
+// SetScrollable turns on and off scrolling. A scrollable chart gets
+// keyboard focus, and pans through bars with Left and Right arrow
+// keys, Home and End, and mouse wheel. Enabling scrolling replaces
+// the callback set with OnMouseScroll
+func (b *BarChart) SetScrollable(scrollable bool) {
+	b.scrolling = scrollable
+	b.SetTabStop(scrollable || b.onSelect != nil)
+	if !scrollable {
+		b.scrollPos = 0
+		b.OnMouseScroll(nil)
+		return
+	}
+	b.OnMouseScroll(func(delta int) {
+		b.SetScrollOffset(b.scrollPos + delta)
+	})
+}
+
+// ScrollOffset returns the index of the first displayed bar
+func (b *BarChart) ScrollOffset() int {
+	return b.scrollPos
+}
+
+// SetScrollOffset makes the bar with index offset the first
+// displayed one. The offset is kept in range from 0 to the
+// offset that makes the last bar visible. It is always 0 if the
+// chart is not scrollable
+func (b *BarChart) SetScrollOffset(offset int) {
+	if max := b.maxScrollOffset(); offset > max {
+		offset = max
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	b.scrollPos = offset
+}
+
+/*
+ProcessEvent processes all events come from the control parent. If a control
+processes an event it should return true. If the method returns false it means
+that the control do not want or cannot process the event and the caller sends
+the event to the control parent
+*/
+func (b *BarChart) ProcessEvent(event Event) bool {
+	if !b.Enabled() {
+		return false
+	}
+	if event.Type == EventMouse {
+		return b.processMouseClick(event)
+	}
+	if !b.Active() || event.Type != EventKey {
+		return false
+	}
+
+	if event.Key == term.KeyEsc && b.selected != -1 {
+		b.SetSelectedBar(-1)
+		return true
+	}
+	if !b.scrolling {
+		return false
+	}
+
+	switch event.Key {
+	case term.KeyArrowLeft:
+		b.SetScrollOffset(b.scrollPos - 1)
+	case term.KeyArrowRight:
+		b.SetScrollOffset(b.scrollPos + 1)
+	case term.KeyHome:
+		b.SetScrollOffset(0)
+	case term.KeyEnd:
+		b.SetScrollOffset(b.maxScrollOffset())
+	default:
+		return false
+	}
+	return true
+}
+
+// processMouseClick selects the bar under the mouse cursor
+func (b *BarChart) processMouseClick(ev Event) bool {
+	if ev.Key != term.MouseLeft {
+		return false
+	}
+
+	idx := b.barAt(ev.X, ev.Y)
+	if idx == -1 {
+		return false
+	}
+
+	b.selected = idx
+	if b.onSelect != nil {
+		b.onSelect(idx, b.bar(idx).Value)
+	}
+	return true
+}
+
+// barAt returns the index of the bar at the screen point, or -1 if the
+// point is outside bars. The bar occupies its part of the bar area
+// together with its title. The geometry is the same as in drawBars
+func (b *BarChart) barAt(x, y int) int {
+	barW := b.calculateBarWidth()
+	if barW == 0 {
+		return -1
+	}
+
+	start, width := b.calculateBarArea()
+	var pos, length int
+	if b.direction == Horizontal {
+		top, rows := b.barRows()
+		if x < b.x || x >= b.x+start+width {
+			return -1
+		}
+		pos, length = y-b.y-top, rows
+	} else {
+		if y < b.y || y >= b.y+b.height || (b.scrollBarVisible() && y == b.y+b.height-1) {
+			return -1
+		}
+		pos, length = x-b.x-start, width
+	}
+
+	slot := barW + b.gap
+	if pos < 0 || pos%slot >= barW || pos-pos%slot+barW > length {
+		return -1
+	}
+	idx := b.scrollPos + pos/slot
+	if idx >= b.barCount() {
+		return -1
+	}
+	return idx
+}
+
+// OnSelect sets the callback that is called when a user clicks a bar
+func (b *BarChart) OnSelect(fn func(int, float64)) {
+	b.onSelect = fn
+	b.SetTabStop(b.scrolling || fn != nil)
+}
+
+// SelectedBar returns the index of the selected bar or -1 if no bar
+// is selected
+func (b *BarChart) SelectedBar() int {
+	return b.selected
+}
+
+// SetSelectedBar selects the bar with index idx. Use -1 to remove the
+// selection. OnSelect callback is not called. Returns false if idx is
+// out of range
+func (b *BarChart) SetSelectedBar(idx int) bool {
+	if idx < -1 || idx >= b.barCount() {
+		return false
+	}
+	b.selected = idx
+	return true
+}
+
 // ShowMarks returns if horizontal axis has mark under each
 // bar. To show marks, ShowTitles must be enabled.
 func (b *BarChart) ShowMarks() bool {
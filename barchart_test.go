@@ -0,0 +1,170 @@
+package clui
+
+import (
+	term "github.com/nsf/termbox-go"
+	"testing"
+	"time"
+)
+
+func TestBarChartHorizontal(t *testing.T) {
+	initThemeManager()
+	chart := CreateBarChart(nil, 12, 3, Fixed)
+	chart.SetOrientation(Horizontal)
+	chart.SetMinBarWidth(1)
+	chart.SetData([]BarData{{Value: 4, Title: "A"}, {Value: 8, Title: "Bb"}, {Value: 2, Title: "Long"}})
+
+	want := "A   │███    \nBb  │███████\nLong│█      "
+	if got := renderToString(chart); got != want {
+		t.Errorf("Invalid horizontal chart:\n%v", got)
+	}
+}
+
+func TestBarChartStacked(t *testing.T) {
+	mock := CreateMockCanvas(4, 4)
+	defer mock.Close()
+
+	chart := CreateBarChart(nil, 4, 4, Fixed)
+	chart.SetShowTitles(false)
+	chart.SetMinBarWidth(1)
+	chart.SetStacked(true)
+	chart.AddSeries("cpu", ColorRed)
+	chart.AddSeries("io", ColorGreen)
+	for _, v := range []float64{1, 1} {
+		chart.AddSeriesValue("cpu", v)
+	}
+	for _, v := range []float64{1, 3} {
+		chart.AddSeriesValue("io", v)
+	}
+	if chart.AddSeriesValue("mem", 1) {
+		t.Error("Value added to unknown series")
+	}
+
+	// the axis scales to the highest stack: 1+3
+	if coeff, max := chart.calculateMultiplier(); coeff != 1 || max != 4 {
+		t.Errorf("Invalid multiplier %v and max %v", coeff, max)
+	}
+
+	chart.Draw()
+	// the first column is reserved for the value axis
+	want := "  █ \n  █ \n ██ \n ██ "
+	if got := mock.String(); got != want {
+		t.Errorf("Invalid stacked chart:\n%v", got)
+	}
+	if fg := mock.Cell(1, 3).Fg; fg != ColorRed {
+		t.Errorf("Invalid bottom segment color %v", fg)
+	}
+	for dy := 0; dy < 3; dy++ {
+		if fg := mock.Cell(2, dy).Fg; fg != ColorGreen {
+			t.Errorf("Invalid top segment color %v at row %v", fg, dy)
+		}
+	}
+}
+
+func TestBarChartScroll(t *testing.T) {
+	initThemeManager()
+	chart := CreateBarChart(nil, 5, 4, Fixed)
+	chart.SetShowTitles(false)
+	chart.SetMinBarWidth(1)
+	chart.SetData([]BarData{{Value: 1}, {Value: 2}, {Value: 3}, {Value: 2}, {Value: 1}, {Value: 3}})
+
+	chart.SetScrollOffset(2)
+	if chart.ScrollOffset() != 0 {
+		t.Errorf("Not scrollable chart scrolled to %v", chart.ScrollOffset())
+	}
+
+	chart.SetScrollable(true)
+	// 4 of 6 bars fit the area at the right of the value axis
+	chart.SetScrollOffset(10)
+	if chart.ScrollOffset() != 2 {
+		t.Errorf("Scroll offset %v, expected 2", chart.ScrollOffset())
+	}
+
+	chart.SetActive(true)
+	if !chart.ProcessEvent(Event{Type: EventKey, Key: term.KeyArrowLeft}) || chart.ScrollOffset() != 1 {
+		t.Errorf("Arrow left scrolled to %v", chart.ScrollOffset())
+	}
+	chart.mouseScroll(-1)
+	if chart.ScrollOffset() != 0 {
+		t.Errorf("Mouse wheel scrolled to %v", chart.ScrollOffset())
+	}
+
+	want := "   █ \n  ███\n ████\n ◄■░►"
+	if got := renderToString(chart); got != want {
+		t.Errorf("Invalid scrolled chart:\n%v", got)
+	}
+}
+
+func TestBarChartAnimation(t *testing.T) {
+	chart := CreateBarChart(nil, 10, 5, Fixed)
+	chart.SetData([]BarData{{Value: 2}})
+	chart.SetAnimated(true)
+	chart.SetAnimationDuration(100 * time.Millisecond)
+
+	chart.SetData([]BarData{{Value: 10}, {Value: 4}})
+	values := chart.displayedValues()
+	if len(values) != 2 || values[0] >= 10 || values[1] >= 4 {
+		t.Errorf("Animation did not start: %v", values)
+	}
+
+	// a new update cancels the animation and starts from the reached values
+	time.Sleep(40 * time.Millisecond)
+	chart.SetData([]BarData{{Value: 0}, {Value: 4}})
+	values = chart.displayedValues()
+	if values[0] <= 2 || values[0] >= 10 {
+		t.Errorf("Animation restarted from %v", values)
+	}
+
+	time.Sleep(250 * time.Millisecond)
+	values = chart.displayedValues()
+	if len(values) != 2 || values[0] != 0 || values[1] != 4 {
+		t.Errorf("Animation did not finish: %v", values)
+	}
+	if chart.displayValue(1, 4, 3) != 3 {
+		t.Error("Displayed value exceeds maximum")
+	}
+}
+
+func TestBarChartSelect(t *testing.T) {
+	initThemeManager()
+	chart := CreateBarChart(nil, 10, 4, Fixed)
+	chart.SetPos(2, 1)
+	chart.SetShowTitles(false)
+	chart.SetMinBarWidth(2)
+	chart.SetBarGap(1)
+	chart.SetData([]BarData{{Value: 1}, {Value: 2}, {Value: 3}})
+
+	selIdx, selValue := -1, 0.0
+	chart.OnSelect(func(idx int, value float64) {
+		selIdx, selValue = idx, value
+	})
+
+	// the bar area starts after the value axis: bars at 3-4, 6-7, and 9-10
+	click := Event{Type: EventMouse, Key: term.MouseLeft, X: 7, Y: 2}
+	if !chart.ProcessEvent(click) || selIdx != 1 || selValue != 2 || chart.SelectedBar() != 1 {
+		t.Errorf("Clicked bar %v with value %v, selected %v", selIdx, selValue, chart.SelectedBar())
+	}
+	click.X = 8
+	if chart.ProcessEvent(click) || chart.SelectedBar() != 1 {
+		t.Error("Click on the gap changed selection")
+	}
+
+	want := RealColor(ColorDefault, ColorBarChartSelectedText)
+	saved := canvas
+	canvas = newMemoryCanvas(12, 5)
+	chart.Draw()
+	if fg := canvas.cells[3*12+7].Fg; fg != want {
+		t.Errorf("Selected bar color %v, expected %v", fg, want)
+	}
+	if fg := canvas.cells[4*12+4].Fg; fg == want {
+		t.Error("Not selected bar is highlighted")
+	}
+	canvas = saved
+
+	chart.SetActive(true)
+	if !chart.ProcessEvent(Event{Type: EventKey, Key: term.KeyEsc}) || chart.SelectedBar() != -1 {
+		t.Error("Escape did not remove selection")
+	}
+	if chart.SetSelectedBar(3) || !chart.SetSelectedBar(2) || chart.SelectedBar() != 2 {
+		t.Error("Invalid programmatic selection")
+	}
+}
@@ -17,6 +17,9 @@ type BaseControl struct {
 	fgActive      term.Attribute
 	bgActive      term.Attribute
 	tabSkip       bool
+	tabOrder      int
+	skipFocus     bool
+	focusGroup    string
 	disabled      bool
 	align         Align
 	parent        Control
@@ -26,6 +29,22 @@ type BaseControl struct {
 	gapX, gapY    int
 	pack          PackType
 	children      []Control
+	layout        LayoutManager
+	tooltip       string
+	fullscreen    bool
+	hidden        bool
+	id            string
+	a11yLabel     string
+	// mouse hover callbacks and state
+	onMouseEnter func()
+	onMouseLeave func()
+	hovered      bool
+	// mouse drag callbacks
+	onDragStart func(int, int)
+	onDragMove  func(int, int)
+	onDragEnd   func(int, int)
+	// mouse wheel callback
+	onMouseScroll func(int)
 }
 
 func (c *BaseControl) Title() string {
@@ -102,6 +121,30 @@ func (c *BaseControl) SetTabStop(tabstop bool) {
 	c.tabSkip = !tabstop
 }
 
+func (c *BaseControl) TabOrder() int {
+	return c.tabOrder
+}
+
+func (c *BaseControl) SetTabOrder(order int) {
+	c.tabOrder = order
+}
+
+func (c *BaseControl) SkipFocus() bool {
+	return c.skipFocus
+}
+
+func (c *BaseControl) SetSkipFocus(skip bool) {
+	c.skipFocus = skip
+}
+
+func (c *BaseControl) FocusGroup() string {
+	return c.focusGroup
+}
+
+func (c *BaseControl) SetFocusGroup(name string) {
+	c.focusGroup = name
+}
+
 func (c *BaseControl) Enabled() bool {
 	return !c.disabled
 }
@@ -128,6 +171,38 @@ func (c *BaseControl) SetModal(modal bool) {
 	c.modal = modal
 }
 
+func (c *BaseControl) Tooltip() string {
+	return c.tooltip
+}
+
+func (c *BaseControl) SetTooltip(text string) {
+	c.tooltip = text
+}
+
+func (c *BaseControl) Visible() bool {
+	return !c.hidden
+}
+
+// SetVisible hides or shows the control. After the change the Window
+// that contains the control rearranges its children, so the remaining
+// controls take the space of the hidden one
+func (c *BaseControl) SetVisible(visible bool) {
+	if c.hidden != visible {
+		return
+	}
+
+	c.hidden = !visible
+	if c.parent == nil {
+		return
+	}
+	root := c.parent
+	for root.Parent() != nil {
+		root = root.Parent()
+	}
+	root.ResizeChildren()
+	root.PlaceChildren()
+}
+
 func (c *BaseControl) Paddings() (px int, py int) {
 	return c.padX, c.padY
 }
@@ -200,19 +275,27 @@ func (c *BaseControl) ResizeChildren() {
 	if len(c.children) == 0 {
 		return
 	}
+	children := visibleChildren(c)
+	if c.layout != nil {
+		c.layout.Arrange(c)
+		for _, ctrl := range c.children {
+			ctrl.ResizeChildren()
+		}
+		return
+	}
 
 	fullWidth := c.width - 2*c.padX
 	fullHeight := c.height - 2*c.padY
 	if c.pack == Horizontal {
-		fullWidth -= (len(c.children) - 1) * c.gapX
+		fullWidth -= (len(children) - 1) * c.gapX
 	} else {
-		fullHeight -= (len(c.children) - 1) * c.gapY
+		fullHeight -= (len(children) - 1) * c.gapY
 	}
 
 	totalSc := c.ChildrenScale()
 	minWidth := 0
 	minHeight := 0
-	for _, child := range c.children {
+	for _, child := range children {
 		cw, ch := child.MinimalSize()
 		if c.pack == Horizontal {
 			minWidth += cw
@@ -230,7 +313,7 @@ func (c *BaseControl) ResizeChildren() {
 		aStep = int(float32(diff) / float32(totalSc))
 	}
 
-	for _, ctrl := range c.children {
+	for _, ctrl := range children {
 		tw, th := ctrl.MinimalSize()
 		sc := ctrl.Scale()
 		d := int(ctrl.Scale() * aStep)
@@ -274,6 +357,9 @@ func (c *BaseControl) AddChild(control Control) {
 
 		c.children = append(c.children, control)
 	}
+	// style sheet properties are applied after the control is created
+	// and before it takes its place in the parent
+	applyStyle(control, true)
 
 	var ctrl Control
 	var mainCtrl Control
@@ -329,7 +415,7 @@ func (c *BaseControl) ChildrenScale() int {
 	}
 
 	total := 0
-	for _, ctrl := range c.children {
+	for _, ctrl := range visibleChildren(c) {
 		total += ctrl.Scale()
 	}
 
@@ -340,17 +426,30 @@ func (c *BaseControl) MinimalSize() (w int, h int) {
 	if len(c.children) == 0 {
 		return c.minW, c.minH
 	}
+	if c.layout != nil {
+		w, h = c.layout.MinimalSize(c)
+		if w < c.minW {
+			w = c.minW
+		}
+		if h < c.minH {
+			h = c.minH
+		}
+		return w, h
+	}
 
 	totalX := 2 * c.padX
 	totalY := 2 * c.padY
 
-	if c.pack == Vertical {
-		totalY += (len(c.children) - 1) * c.gapY
-	} else {
-		totalX += (len(c.children) - 1) * c.gapX
+	children := visibleChildren(c)
+	if len(children) > 0 {
+		if c.pack == Vertical {
+			totalY += (len(children) - 1) * c.gapY
+		} else {
+			totalX += (len(children) - 1) * c.gapX
+		}
 	}
 
-	for _, ctrl := range c.children {
+	for _, ctrl := range children {
 		ww, hh := ctrl.MinimalSize()
 		if c.pack == Vertical {
 			totalY += hh
@@ -380,13 +479,13 @@ func (c *BaseControl) Draw() {
 }
 
 func (c *BaseControl) DrawChildren() {
-	PushClip()
+	PushClipRect(c.x+c.padX, c.y+c.padY, c.width-2*c.padX, c.height-2*c.padY)
 	defer PopClip()
 
-	SetClipRect(c.x+c.padX, c.y+c.padY, c.width-2*c.padX, c.height-2*c.padY)
-
 	for _, child := range c.children {
-		child.Draw()
+		if child.Visible() {
+			child.Draw()
+		}
 	}
 }
 
@@ -417,9 +516,16 @@ func (c *BaseControl) PlaceChildren() {
 	if c.children == nil || len(c.children) == 0 {
 		return
 	}
+	if c.layout != nil {
+		c.layout.Arrange(c)
+		for _, ctrl := range c.children {
+			ctrl.PlaceChildren()
+		}
+		return
+	}
 
 	xx, yy := c.x+c.padX, c.y+c.padY
-	for _, ctrl := range c.children {
+	for _, ctrl := range visibleChildren(c) {
 		ctrl.SetPos(xx, yy)
 
 		ww, hh := ctrl.Size()
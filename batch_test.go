@@ -0,0 +1,44 @@
+package clui
+
+import (
+	term "github.com/nsf/termbox-go"
+	"testing"
+)
+
+func TestBatch(t *testing.T) {
+	saved := canvas
+	canvas = new(Canvas)
+	canvas.width, canvas.height = 10, 4
+	canvas.clipW, canvas.clipH = 10, 4
+	defer func() { canvas = saved }()
+
+	BeginBatch()
+	BeginBatch()
+	DrawRawText(2, 1, "abc")
+	if canvas.batching != 2 || len(canvas.batch) != 3 {
+		t.Fatalf("Cells must be collected: %v", canvas.batch)
+	}
+	if c := canvas.batch[1]; c.X != 3 || c.Y != 1 || c.Cell.Ch != 'b' {
+		t.Errorf("Invalid collected cell %v", c)
+	}
+}
+
+func TestBulkFill(t *testing.T) {
+	saved := canvas
+	canvas = newMemoryCanvas(10, 4)
+	defer func() { canvas = saved }()
+
+	PushClipRect(0, 0, 5, 4)
+	defer PopClip()
+	BulkFill([]BulkCell{
+		{X: 1, Y: 2, Cell: term.Cell{Ch: 'x', Fg: ColorRed, Bg: ColorBlue}},
+		{X: 7, Y: 2, Cell: term.Cell{Ch: 'y'}},
+	})
+
+	if c, _ := Symbol(1, 2); c.Ch != 'x' || c.Fg != ColorRed || c.Bg != ColorBlue {
+		t.Errorf("Cell must be drawn with its colors: %v", c)
+	}
+	if c, _ := Symbol(7, 2); c.Ch == 'y' {
+		t.Error("Cell outside clipping rectangle must be skipped")
+	}
+}
@@ -0,0 +1,185 @@
+package clui
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// DataChangeEvent is the type of the custom event that notifies bound
+// controls about changes of a data source. The event payload is
+// DataChange. Use Window method NotifyDataChange to post it
+const DataChangeEvent = "DataChange"
+
+// DataSource is a model that provides values for bound controls
+type DataSource interface {
+	// Get returns the current value of the field
+	Get(field string) interface{}
+	// Set changes the value of the field
+	Set(field string, v interface{})
+}
+
+// DataChange is the payload of DataChangeEvent: the data source and the
+// name of its changed field
+type DataChange struct {
+	Source DataSource
+	Field  string
+}
+
+// Bindable is implemented by controls that display a field of a data
+// source. A bound control reads the field value when it is bound and
+// every time the data source notifies about the field change
+type Bindable interface {
+	// Bind attaches the control to the field of the data source. The
+	// control must be inside a Window to get change notifications
+	Bind(source DataSource, field string)
+	// Unbind detaches the control from its data source
+	Unbind()
+}
+
+// binding keeps the data source of a bound control and its subscription
+// to change notifications
+type binding struct {
+	source DataSource
+	field  string
+	wnd    *Window
+	token  SubscribeToken
+}
+
+// bind attaches the control to the field and updates the control with
+// the current field value
+func (b *binding) bind(ctrl Control, source DataSource, field string, update func(interface{})) {
+	b.unbind()
+	if source == nil {
+		return
+	}
+
+	b.source, b.field = source, field
+	for p := ctrl; p != nil; p = p.Parent() {
+		if wnd, ok := p.(*Window); ok {
+			b.wnd = wnd
+		}
+	}
+	if b.wnd != nil {
+		b.token = b.wnd.Subscribe(DataChangeEvent, func(ev CustomEvent) {
+			change, ok := ev.Payload.(DataChange)
+			if ok && change.Field == b.field && sameSource(change.Source, b.source) {
+				update(b.source.Get(b.field))
+			}
+		})
+	}
+	update(source.Get(field))
+}
+
+// sameSource returns true if both values are the same data source. Data
+// sources of map type cannot be compared with ==, so they are compared
+// by their addresses
+func sameSource(a, b DataSource) bool {
+	ta, tb := reflect.TypeOf(a), reflect.TypeOf(b)
+	if ta == nil || ta != tb {
+		return false
+	}
+	if ta.Comparable() {
+		return a == b
+	}
+
+	switch ta.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Func:
+		return reflect.ValueOf(a).Pointer() == reflect.ValueOf(b).Pointer()
+	}
+	return false
+}
+
+// unbind removes the subscription to change notifications
+func (b *binding) unbind() {
+	if b.wnd != nil {
+		b.wnd.Unsubscribe(b.token)
+	}
+	*b = binding{}
+}
+
+// NotifyDataChange posts DataChangeEvent, so all controls of the Window
+// bound to the field of the data source update themselves. The function
+// can be called from any goroutine
+func (c *Window) NotifyDataChange(source DataSource, field string) {
+	c.PostEvent(CustomEvent{Type: DataChangeEvent, Payload: DataChange{Source: source, Field: field}})
+}
+
+// toFloat converts a value of any numeric type to float64
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int8:
+		return float64(n), true
+	case int16:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint8:
+		return float64(n), true
+	case uint16:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+// Bind makes the Label display the field value as its text
+func (l *Label) Bind(source DataSource, field string) {
+	l.bound.bind(l, source, field, func(v interface{}) {
+		if v == nil {
+			l.SetTitle("")
+			return
+		}
+		l.SetTitle(fmt.Sprint(v))
+	})
+}
+
+// Unbind detaches the Label from its data source
+func (l *Label) Unbind() {
+	l.bound.unbind()
+}
+
+// Bind makes the ProgressBar display the field value. The field must be
+// a number
+func (b *ProgressBar) Bind(source DataSource, field string) {
+	b.bound.bind(b, source, field, func(v interface{}) {
+		if f, ok := toFloat(v); ok {
+			b.SetValue(int(f))
+		}
+	})
+}
+
+// Unbind detaches the ProgressBar from its data source
+func (b *ProgressBar) Unbind() {
+	b.bound.unbind()
+}
+
+// Bind makes the SparkChart display the field value. If the field is a
+// slice of float64, it replaces the chart data. If the field is a
+// number, it is appended to the chart data
+func (b *SparkChart) Bind(source DataSource, field string) {
+	b.bound.bind(b, source, field, func(v interface{}) {
+		if data, ok := v.([]float64); ok {
+			b.SetData(data)
+		} else if f, ok := toFloat(v); ok {
+			b.AddData(f)
+		}
+	})
+}
+
+// Unbind detaches the SparkChart from its data source
+func (b *SparkChart) Unbind() {
+	b.bound.unbind()
+}
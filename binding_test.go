@@ -0,0 +1,54 @@
+package clui
+
+import (
+	"testing"
+)
+
+type mapSource map[string]interface{}
+
+func (m mapSource) Get(field string) interface{} {
+	return m[field]
+}
+
+func (m mapSource) Set(field string, v interface{}) {
+	m[field] = v
+}
+
+func TestBinding(t *testing.T) {
+	wnd := CreateWindow(0, 0, 30, 8, "Test")
+	lbl := CreateLabel(wnd, 10, 1, "", Fixed)
+	pb := CreateProgressBar(wnd, 10, 1, Fixed)
+	pb.SetLimits(0, 100)
+	chart := CreateSparkChart(wnd, 10, 3, Fixed)
+
+	src := mapSource{"name": "first", "done": 30, "load": []float64{1, 2}}
+	var _ Bindable = lbl
+	lbl.Bind(src, "name")
+	pb.Bind(src, "done")
+	chart.Bind(src, "load")
+	if lbl.Title() != "first" || pb.Value() != 30 || len(chart.data) != 2 {
+		t.Fatal("Binding must display the current values")
+	}
+
+	src.Set("name", "second")
+	src.Set("done", 50.0)
+	src.Set("load", 3)
+	wnd.NotifyDataChange(src, "name")
+	wnd.NotifyDataChange(src, "done")
+	wnd.NotifyDataChange(src, "load")
+	wnd.bus.deliver()
+	if lbl.Title() != "second" || pb.Value() != 50 {
+		t.Errorf("Bound controls must update on change: %v %v", lbl.Title(), pb.Value())
+	}
+	if len(chart.data) != 3 || chart.data[2] != 3 {
+		t.Errorf("A number must be appended to the chart data: %v", chart.data)
+	}
+
+	lbl.Unbind()
+	src.Set("name", "third")
+	wnd.NotifyDataChange(src, "name")
+	wnd.bus.deliver()
+	if lbl.Title() != "second" {
+		t.Error("Unbound control must not update")
+	}
+}
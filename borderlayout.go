@@ -0,0 +1,109 @@
+package clui
+
+// borderRegion is a region of BorderLayout: a control and its fixed size
+type borderRegion struct {
+	ctrl Control
+	size int
+}
+
+/*
+BorderLayout is a layout manager that splits the container into five
+regions. North and South regions take the full container width and have
+fixed heights, West and East regions take the height between North and
+South and have fixed widths, and Center region fills the remaining
+space. Any region can be empty. Container gaps are used as space between
+regions. A typical application Window has a toolbar in the North, a
+status bar in the South, a sidebar in the West, and the main content in
+the Center.
+*/
+type BorderLayout struct {
+	north, south, east, west borderRegion
+	center                   Control
+}
+
+// CreateBorderLayout creates a new BorderLayout with empty regions. Set
+// it as the layout of a container with SetLayout
+func CreateBorderLayout() *BorderLayout {
+	return new(BorderLayout)
+}
+
+// SetNorth puts the container child to the top region
+func (b *BorderLayout) SetNorth(ctrl Control, height int) {
+	b.north = borderRegion{ctrl: ctrl, size: height}
+}
+
+// SetSouth puts the container child to the bottom region
+func (b *BorderLayout) SetSouth(ctrl Control, height int) {
+	b.south = borderRegion{ctrl: ctrl, size: height}
+}
+
+// SetEast puts the container child to the right region
+func (b *BorderLayout) SetEast(ctrl Control, width int) {
+	b.east = borderRegion{ctrl: ctrl, size: width}
+}
+
+// SetWest puts the container child to the left region
+func (b *BorderLayout) SetWest(ctrl Control, width int) {
+	b.west = borderRegion{ctrl: ctrl, size: width}
+}
+
+// SetCenter puts the container child to the central region
+func (b *BorderLayout) SetCenter(ctrl Control) {
+	b.center = ctrl
+}
+
+// regionSize returns the size of the region and the gap after it. Empty
+// region or region with hidden control takes no space
+func regionSize(r borderRegion, gap int) int {
+	if r.ctrl == nil || !r.ctrl.Visible() {
+		return 0
+	}
+	return r.size + gap
+}
+
+// MinimalSize returns the size of the container that fits all regions
+// with the minimal size of the central one
+func (b *BorderLayout) MinimalSize(parent Control) (int, int) {
+	gx, gy := parent.Gaps()
+	px, py := parent.Paddings()
+
+	cw, ch := 0, 0
+	if b.center != nil && b.center.Visible() {
+		cw, ch = b.center.MinimalSize()
+	}
+	w := regionSize(b.west, gx) + cw + regionSize(b.east, gx)
+	h := regionSize(b.north, gy) + ch + regionSize(b.south, gy)
+	return w + 2*px, h + 2*py
+}
+
+// Arrange calculates sizes and positions of the container children
+func (b *BorderLayout) Arrange(parent Control) {
+	x, y, w, h := layoutArea(parent)
+	gx, gy := parent.Gaps()
+
+	top := regionSize(b.north, gy)
+	bottom := regionSize(b.south, gy)
+	left := regionSize(b.west, gx)
+	right := regionSize(b.east, gx)
+	middle := h - top - bottom
+	if middle < 0 {
+		middle = 0
+	}
+
+	place := func(ctrl Control, cx, cy, cw, ch int) {
+		if ctrl == nil || !ctrl.Visible() {
+			return
+		}
+		if cw < 0 {
+			cw = 0
+		}
+		ctrl.SetSize(cw, ch)
+		ctrl.SetPos(cx, cy)
+	}
+
+	place(b.north.ctrl, x, y, w, b.north.size)
+	place(b.south.ctrl, x, y+h-b.south.size, w, b.south.size)
+	place(b.west.ctrl, x, y+top, b.west.size, middle)
+	place(b.east.ctrl, x+w-b.east.size, y+top, b.east.size, middle)
+	place(b.center, x+left, y+top, w-left-right, middle)
+}
@@ -0,0 +1,32 @@
+package clui
+
+import (
+	"testing"
+)
+
+func TestBorderLayout(t *testing.T) {
+	parent := CreateFrame(nil, 1, 1, BorderNone, Fixed)
+	toolbar := CreateFrame(parent, 1, 1, BorderNone, Fixed)
+	status := CreateFrame(parent, 1, 1, BorderNone, Fixed)
+	sidebar := CreateFrame(parent, 1, 1, BorderNone, Fixed)
+	content := CreateFrame(parent, 5, 3, BorderNone, Fixed)
+
+	border := CreateBorderLayout()
+	border.SetNorth(toolbar, 1)
+	border.SetSouth(status, 1)
+	border.SetWest(sidebar, 10)
+	border.SetCenter(content)
+	parent.SetLayout(border)
+	parent.SetGaps(1, 0)
+
+	parent.SetSize(40, 12)
+	parent.PlaceChildren()
+
+	want := "[0:0 40x1 0:11 40x1 0:1 10x10 11:1 29x10]"
+	if got := flexGeometry(toolbar, status, sidebar, content); got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if w, h := parent.MinimalSize(); w != 16 || h != 5 {
+		t.Errorf("Invalid minimal size %vx%v", w, h)
+	}
+}
@@ -117,9 +117,7 @@ func (b *Button) ProcessEvent(event Event) bool {
 				b.Draw()
 				PutEvent(ev)
 			}()
-			if b.onClick != nil {
-				b.onClick(event)
-			}
+			b.click(event)
 			return true
 		} else if event.Key == term.KeyEsc && b.pressed {
 			b.pressed = false
@@ -134,9 +132,7 @@ func (b *Button) ProcessEvent(event Event) bool {
 		} else if event.Key == term.MouseRelease && b.pressed {
 			ReleaseEvents()
 			if event.X >= b.x && event.Y >= b.y && event.X < b.x+b.width && event.Y < b.y+b.height {
-				if b.onClick != nil {
-					b.onClick(event)
-				}
+				b.click(event)
 			}
 			b.pressed = false
 			return true
@@ -146,6 +142,28 @@ func (b *Button) ProcessEvent(event Event) bool {
 	return false
 }
 
+// click calls OnClick callback. If the button is the default one
+// of its window the callback is called only if all window
+// EditFields are valid
+func (b *Button) click(event Event) {
+	if b.onClick == nil {
+		return
+	}
+
+	var parent Control = b
+	for parent != nil {
+		if wnd, ok := parent.(*Window); ok {
+			if wnd.defButton == b && !ValidateChildren(wnd) {
+				return
+			}
+			break
+		}
+		parent = parent.Parent()
+	}
+
+	b.onClick(event)
+}
+
 // OnClick sets the callback that is called when one clicks button
 // with mouse or pressing space on keyboard while the button is active
 func (b *Button) OnClick(fn func(Event)) {
@@ -0,0 +1,349 @@
+package clui
+
+import (
+	"fmt"
+	"math"
+)
+
+// candle is one period of OHLC data
+type candle struct {
+	open, high, low, close float64
+	label                  string
+}
+
+/*
+CandlestickChart is a chart that displays OHLC(open, high, low, close)
+financial data. Every candle is a vertical line from the low price to
+the high one with a body rectangle from the open price to the close
+one. Bullish candles(close is greater than open) are drawn with
+ColorCandlestickBull color, bearish ones(close is less than open) with
+ColorCandlestickBear color, and candles with equal open and close with
+the chart text color.
+The chart uses the same layout as BarChart: vertical axis with values
+on the left if ValueWidth is greater than 0(it is not displayed if
+ValueWidth is greater than half of the chart), and horizontal axis with
+candle labels under it if ShowTitles is true. All candles have the same
+width, the candles that do not fit the chart are not displayed.
+If MAOverlay is greater than 0 the chart draws a moving average of
+close prices with that window over the candles
+*/
+type CandlestickChart struct {
+	BaseControl
+	candles     []candle
+	candleWidth int
+	gap         int
+	valueWidth  int
+	showTitles  bool
+	maWindow    int
+}
+
+/*
+CreateCandlestickChart creates a new candlestick chart.
+parent - is container that keeps the control.
+w and h - are minimal size of the control.
+scale - the way of scaling the control when the parent is resized. Use DoNotScale constant if the
+control should keep its original size.
+*/
+func CreateCandlestickChart(parent Control, w, h int, scale int) *CandlestickChart {
+	c := new(CandlestickChart)
+
+	if w == AutoSize {
+		w = 20
+	}
+	if h == AutoSize {
+		h = 10
+	}
+
+	c.parent = parent
+
+	c.SetSize(w, h)
+	c.SetConstraints(w, h)
+	c.tabSkip = true
+	c.candleWidth = 1
+	c.gap = 1
+	c.showTitles = true
+	c.SetScale(scale)
+
+	if parent != nil {
+		parent.AddChild(c)
+	}
+
+	return c
+}
+
+// Draw repaints the control on its View surface
+func (c *CandlestickChart) Draw() {
+	PushAttributes()
+	defer PopAttributes()
+
+	fg, bg := RealColor(c.fg, ColorCandlestickText), RealColor(c.bg, ColorCandlestickBack)
+	SetTextColor(fg)
+	SetBackColor(bg)
+	FillRect(c.x, c.y, c.width, c.height, ' ')
+
+	if len(c.candles) == 0 {
+		return
+	}
+
+	c.drawRulers()
+	c.drawValues()
+	c.drawCandles()
+	c.drawMA()
+}
+
+// chartHeight returns the number of rows for candles
+func (c *CandlestickChart) chartHeight() int {
+	if c.showTitles {
+		return c.height - 2
+	}
+	return c.height
+}
+
+// calculateChartArea returns the first column and the width of the
+// area for candles
+func (c *CandlestickChart) calculateChartArea() (int, int) {
+	if c.valueWidth > 0 && c.valueWidth < c.width/2 {
+		return c.valueWidth + 1, c.width - c.valueWidth - 1
+	}
+	return 0, c.width
+}
+
+// visibleCandles returns the number of candles that fit the chart
+func (c *CandlestickChart) visibleCandles() int {
+	_, width := c.calculateChartArea()
+	if c.candleWidth <= 0 {
+		return 0
+	}
+	n := (width + c.gap) / (c.candleWidth + c.gap)
+	if n > len(c.candles) {
+		n = len(c.candles)
+	}
+	return n
+}
+
+// limits returns the lowest and the highest prices of displayed candles
+func (c *CandlestickChart) limits() (float64, float64) {
+	n := c.visibleCandles()
+	if n == 0 {
+		return 0, 0
+	}
+
+	lo, hi := c.candles[0].low, c.candles[0].high
+	for _, cd := range c.candles[:n] {
+		lo, hi = math.Min(lo, cd.low), math.Max(hi, cd.high)
+	}
+	if lo == hi {
+		lo, hi = lo-1, hi+1
+	}
+	return lo, hi
+}
+
+// rowOf returns the chart row that displays the price
+func (c *CandlestickChart) rowOf(v, lo, hi float64) int {
+	h := c.chartHeight()
+	return int(math.Round((hi - v) / (hi - lo) * float64(h-1)))
+}
+
+// candleX returns the first column of the candle
+func (c *CandlestickChart) candleX(idx int) int {
+	start, _ := c.calculateChartArea()
+	return c.x + start + idx*(c.candleWidth+c.gap)
+}
+
+func (c *CandlestickChart) drawCandles() {
+	if c.chartHeight() < 1 {
+		return
+	}
+
+	PushAttributes()
+	defer PopAttributes()
+
+	fg := TextColor()
+	bull := RealColor(ColorDefault, ColorCandlestickBull)
+	bear := RealColor(ColorDefault, ColorCandlestickBear)
+	parts := []rune(SysObject(ObjBarChart))
+	ch, cV := parts[0], parts[2]
+
+	lo, hi := c.limits()
+	h := c.chartHeight()
+	for idx, cd := range c.candles[:c.visibleCandles()] {
+		x := c.candleX(idx)
+		switch {
+		case cd.close > cd.open:
+			SetTextColor(bull)
+		case cd.close < cd.open:
+			SetTextColor(bear)
+		default:
+			SetTextColor(fg)
+		}
+
+		top, bottom := c.rowOf(cd.high, lo, hi), c.rowOf(cd.low, lo, hi)
+		for row := top; row <= bottom; row++ {
+			PutChar(x+c.candleWidth/2, c.y+row, cV)
+		}
+		top = c.rowOf(math.Max(cd.open, cd.close), lo, hi)
+		bottom = c.rowOf(math.Min(cd.open, cd.close), lo, hi)
+		FillRect(x, c.y+top, c.candleWidth, bottom-top+1, ch)
+
+		if c.showTitles {
+			SetTextColor(fg)
+			shift, s := AlignText(cd.label, c.candleWidth, AlignCenter)
+			DrawRawText(x+shift, c.y+h+1, s)
+		}
+	}
+}
+
+// drawMA draws a mark at the center of every candle that has enough
+// previous candles to calculate the moving average
+func (c *CandlestickChart) drawMA() {
+	if c.maWindow <= 0 || c.chartHeight() < 1 {
+		return
+	}
+
+	PushAttributes()
+	defer PopAttributes()
+	SetTextColor(RealColor(ColorDefault, ColorCandlestickMA))
+	mark := []rune(SysObject(ObjSparkChartMA))[0]
+
+	lo, hi := c.limits()
+	for idx, v := range c.MovingAverage()[:c.visibleCandles()] {
+		if idx < c.maWindow-1 || v < lo || v > hi {
+			continue
+		}
+		PutChar(c.candleX(idx)+c.candleWidth/2, c.y+c.rowOf(v, lo, hi), mark)
+	}
+}
+
+func (c *CandlestickChart) drawRulers() {
+	pos, width := c.calculateChartArea()
+	h := c.chartHeight()
+	parts := []rune(SysObject(ObjBarChart))
+	cH, cV, cC := parts[1], parts[2], parts[5]
+
+	if pos > 0 {
+		for dy := 0; dy < h; dy++ {
+			PutChar(c.x+pos-1, c.y+dy, cV)
+		}
+	}
+	if c.showTitles {
+		for dx := 0; dx < width; dx++ {
+			PutChar(c.x+pos+dx, c.y+h, cH)
+		}
+		if pos > 0 {
+			PutChar(c.x+pos-1, c.y+h, cC)
+		}
+	}
+}
+
+func (c *CandlestickChart) drawValues() {
+	pos, _ := c.calculateChartArea()
+	h := c.chartHeight()
+	if pos == 0 || h < 2 {
+		return
+	}
+
+	lo, hi := c.limits()
+	format := fmt.Sprintf("%%%v.2f", c.valueWidth)
+	for dy := 0; dy < h; dy += 2 {
+		v := hi - float64(dy)/float64(h-1)*(hi-lo)
+		DrawRawText(c.x, c.y+dy, CutText(fmt.Sprintf(format, v), c.valueWidth))
+	}
+}
+
+// AddCandle appends a new candle to the chart
+func (c *CandlestickChart) AddCandle(open, high, low, close float64, label string) {
+	c.candles = append(c.candles, candle{open: open, high: high, low: low, close: close, label: label})
+}
+
+// ClearCandles removes all candles from the chart
+func (c *CandlestickChart) ClearCandles() {
+	c.candles = nil
+}
+
+// MovingAverage returns the moving average of close prices for every
+// candle. The average of the first candles is calculated for fewer
+// candles than the window. Returns nil if MAOverlay is 0
+func (c *CandlestickChart) MovingAverage() []float64 {
+	if c.maWindow <= 0 {
+		return nil
+	}
+
+	ma := make([]float64, len(c.candles))
+	sum := 0.0
+	for idx, cd := range c.candles {
+		sum += cd.close
+		count := idx + 1
+		if idx >= c.maWindow {
+			sum -= c.candles[idx-c.maWindow].close
+			count = c.maWindow
+		}
+		ma[idx] = sum / float64(count)
+	}
+	return ma
+}
+
+// MAOverlay returns the window of the moving average overlay. 0 means
+// that the overlay is not displayed
+func (c *CandlestickChart) MAOverlay() int {
+	return c.maWindow
+}
+
+// SetMAOverlay sets the number of candles to calculate the moving
+// average of close prices. The average is drawn over candles with
+// ColorCandlestickMA color. Use 0 to hide it
+func (c *CandlestickChart) SetMAOverlay(window int) {
+	if window < 0 {
+		window = 0
+	}
+	c.maWindow = window
+}
+
+// CandleWidth returns the width of candle bodies
+func (c *CandlestickChart) CandleWidth() int {
+	return c.candleWidth
+}
+
+// SetCandleWidth changes the width of candle bodies. The line from
+// the low to the high price is drawn in the middle of the body
+func (c *CandlestickChart) SetCandleWidth(n int) {
+	if n < 1 {
+		n = 1
+	}
+	c.candleWidth = n
+}
+
+// CandleGap returns the space width between two adjacent candles
+func (c *CandlestickChart) CandleGap() int {
+	return c.gap
+}
+
+// SetCandleGap changes the space width between two adjacent candles.
+// Negative values are treated as 0
+func (c *CandlestickChart) SetCandleGap(gap int) {
+	if gap < 0 {
+		gap = 0
+	}
+	c.gap = gap
+}
+
+// ValueWidth returns the width of the area at the left of chart used
+// to draw values. Set it to 0 to turn off the value panel
+func (c *CandlestickChart) ValueWidth() int {
+	return c.valueWidth
+}
+
+// SetValueWidth changes width of the value panel on the left
+func (c *CandlestickChart) SetValueWidth(width int) {
+	c.valueWidth = width
+}
+
+// ShowTitles returns if chart displays horizontal axis and candle
+// labels under it
+func (c *CandlestickChart) ShowTitles() bool {
+	return c.showTitles
+}
+
+// SetShowTitles turns on and off horizontal axis and candle labels
+func (c *CandlestickChart) SetShowTitles(show bool) {
+	c.showTitles = show
+}
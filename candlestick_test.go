@@ -0,0 +1,59 @@
+package clui
+
+import (
+	"testing"
+)
+
+func TestCandlestickChart(t *testing.T) {
+	mock := CreateMockCanvas(5, 6)
+	defer mock.Close()
+
+	chart := CreateCandlestickChart(nil, 5, 6, Fixed)
+	chart.AddCandle(2, 4, 1, 3, "a")
+	chart.AddCandle(3, 3.5, 0, 1, "b")
+	chart.SetMAOverlay(2)
+
+	if ma := chart.MovingAverage(); len(ma) != 2 || ma[0] != 3 || ma[1] != 2 {
+		t.Errorf("Invalid moving average %v", ma)
+	}
+
+	chart.Draw()
+	want := "│ │  \n█ █  \n█ •  \n  │  \n─────\na b  "
+	if got := mock.String(); got != want {
+		t.Errorf("Invalid candlestick chart:\n%v", got)
+	}
+	if fg := mock.Cell(0, 1).Fg; fg != RealColor(ColorDefault, ColorCandlestickBull) {
+		t.Errorf("Bullish candle color %v", fg)
+	}
+	if fg := mock.Cell(2, 1).Fg; fg != RealColor(ColorDefault, ColorCandlestickBear) {
+		t.Errorf("Bearish candle color %v", fg)
+	}
+}
+
+func TestCandlestickChartGap(t *testing.T) {
+	mock := CreateMockCanvas(5, 6)
+	defer mock.Close()
+
+	chart := CreateCandlestickChart(nil, 5, 6, Fixed)
+	chart.AddCandle(2, 4, 1, 3, "a")
+	chart.AddCandle(3, 3.5, 0, 1, "b")
+
+	for _, gap := range []int{-1, -2, 0} {
+		chart.SetCandleGap(gap)
+		if chart.CandleGap() != 0 {
+			t.Errorf("Gap %v must be clamped to 0, got %v", gap, chart.CandleGap())
+		}
+		chart.Draw()
+		want := "││   \n██   \n██   \n │   \n─────\nab   "
+		if got := mock.String(); got != want {
+			t.Errorf("Invalid chart for gap %v:\n%v", gap, got)
+		}
+	}
+
+	chart.SetCandleGap(3)
+	chart.Draw()
+	want := "│   │\n█   █\n█   █\n    │\n─────\na   b"
+	if got := mock.String(); got != want {
+		t.Errorf("Invalid chart for gap 3:\n%v", got)
+	}
+}
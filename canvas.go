@@ -3,6 +3,8 @@ package clui
 import (
 	xs "github.com/huandu/xstrings"
 	term "github.com/nsf/termbox-go"
+	"math"
+	"os"
 	"strings"
 )
 
@@ -29,6 +31,31 @@ type Canvas struct {
 	clipH     int
 	attrStack []attr
 	clipStack []rect
+	// if cells is not nil the canvas draws to memory instead of terminal
+	cells []term.Cell
+	// shift of all coordinates, it is set by VirtualCanvas. The clipping
+	// rectangle is in shifted coordinates as well
+	originX int
+	originY int
+	// cells changed since the last repaint and the flag that only the
+	// changed cells are being redrawn now
+	dirty   dirtyTracker
+	partial bool
+	// if back is not nil the canvas draws to the back buffer and Flush
+	// sends to terminal only cells that differ from the front buffer
+	back  []term.Cell
+	front []term.Cell
+	// cells collected between BeginBatch and EndBatch
+	batching int
+	batch    []BulkCell
+	// MockCanvas that records drawing calls
+	mock *MockCanvas
+}
+
+// BulkCell is a cell with its position for BulkFill
+type BulkCell struct {
+	X, Y int
+	Cell term.Cell
 }
 
 var (
@@ -40,7 +67,15 @@ func initCanvas() bool {
 	if err != nil {
 		return false
 	}
-	term.SetInputMode(term.InputEsc | term.InputMouse)
+	// Alt mode: Esc followed by a key is reported as Alt+key, it is
+	// used by menu hotkeys and Alt+Arrow shortcuts
+	mode := term.SetInputMode(term.InputAlt | term.InputMouse)
+	if TermSupportsRGB() {
+		colorMode = term.SetOutputMode(term.OutputRGB)
+	} else if SupportsColor256() {
+		colorMode = term.SetOutputMode(term.Output256)
+	}
+	mouseHover = startMouseHover(os.Stdout, mode)
 
 	canvas = new(Canvas)
 	Reset()
@@ -48,6 +83,67 @@ func initCanvas() bool {
 	return true
 }
 
+// newMemoryCanvas creates a canvas that draws to memory buffer
+// of the given size instead of terminal
+func newMemoryCanvas(width, height int) *Canvas {
+	c := new(Canvas)
+	c.width, c.height = width, height
+	c.clipW, c.clipH = width, height
+	c.textColor = ColorWhite
+	c.backColor = ColorBlack
+	c.attrStack = make([]attr, 0)
+	c.clipStack = make([]rect, 0)
+	c.cells = make([]term.Cell, width*height)
+	for i := range c.cells {
+		c.cells[i] = term.Cell{Ch: ' ', Fg: c.textColor, Bg: c.backColor}
+	}
+
+	return c
+}
+
+// renderToString draws the control to a memory canvas of the
+// control size and returns the drawn characters as newline
+// separated rows. Colors are ignored. The control is drawn as
+// if it is at the top left corner of the screen
+func renderToString(ctrl Control) string {
+	if themeManager == nil {
+		initThemeManager()
+	}
+
+	w, h := ctrl.Size()
+	if w <= 0 || h <= 0 {
+		return ""
+	}
+
+	saved := canvas
+	x, y := ctrl.Pos()
+	canvas = newMemoryCanvas(w, h)
+	ctrl.SetPos(0, 0)
+	defer func() {
+		ctrl.SetPos(x, y)
+		canvas = saved
+	}()
+
+	ctrl.Draw()
+
+	return cellsToString(canvas)
+}
+
+// cellsToString returns the characters of a memory canvas as newline
+// separated rows
+func cellsToString(c *Canvas) string {
+	rows := make([]string, c.height)
+	line := make([]rune, c.width)
+	for yy := 0; yy < c.height; yy++ {
+		for xx := 0; xx < c.width; xx++ {
+			line[xx] = c.cells[yy*c.width+xx].Ch
+		}
+		rows[yy] = string(line)
+	}
+
+	return strings.Join(rows, "\n")
+}
+
 // PushAttributes saves the current back and fore colors. Useful when used with
 // PopAttributes: you can save colors then change them to anything you like and
 // as the final step just restore original colors
@@ -74,14 +170,23 @@ func PushClip() {
 	canvas.clipStack = append(canvas.clipStack, c)
 }
 
-// PopClip restores saved with PushClip clipping window
+// PushClipRect saves the current clipping window and limits the drawing
+// area with the rectangle: the new clipping window is the intersection
+// of the current one and the rectangle. So, nested PushClipRect calls
+// never extend the drawing area. Restore the previous window with PopClip
+func PushClipRect(x, y, w, h int) {
+	PushClip()
+	canvas.clipX, canvas.clipY, canvas.clipW, canvas.clipH = clip(x, y, w, h)
+}
+
+// PopClip restores saved with PushClip or PushClipRect clipping window
 func PopClip() {
 	if len(canvas.clipStack) == 0 {
 		return
 	}
 	c := canvas.clipStack[len(canvas.clipStack)-1]
 	canvas.clipStack = canvas.clipStack[:len(canvas.clipStack)-1]
-	SetClipRect(c.x, c.y, c.w, c.h)
+	canvas.clipX, canvas.clipY, canvas.clipW, canvas.clipH = c.x, c.y, c.w, c.h
 }
 
 // Reset reinitializes canvas: set clipping rectangle to the whole
@@ -107,8 +212,8 @@ func InClipRect(x, y int) bool {
 }
 
 func clip(x, y, w, h int) (cx int, cy int, cw int, ch int) {
-	if x+w < canvas.clipX || x > canvas.clipX+canvas.clipW ||
-		y+h < canvas.clipY || y > canvas.clipY+canvas.clipH {
+	if x+w <= canvas.clipX || x >= canvas.clipX+canvas.clipW ||
+		y+h <= canvas.clipY || y >= canvas.clipY+canvas.clipH {
 		return 0, 0, 0, 0
 	}
 
@@ -130,11 +235,75 @@ func clip(x, y, w, h int) (cx int, cy int, cw int, ch int) {
 	return x, y, w, h
 }
 
-// Flush makes termbox to draw everything to screen
+// Flush makes termbox to draw everything to screen. In double buffer
+// mode only the cells changed since the previous Flush are sent
 func Flush() {
+	if canvas != nil && canvas.cells != nil {
+		return
+	}
+	if canvas != nil && canvas.back != nil {
+		flushBackBuffer()
+	}
 	term.Flush()
 }
 
+// flushBackBuffer sends to termbox the cells of the back buffer that
+// differ from the front buffer and returns the number of sent cells
+func flushBackBuffer() int {
+	sent := 0
+	for i, c := range canvas.back {
+		if c != canvas.front[i] {
+			term.SetCell(i%canvas.width, i/canvas.width, c.Ch, c.Fg, c.Bg)
+			canvas.front[i] = c
+			sent++
+		}
+	}
+	return sent
+}
+
+// SetDoubleBuffer turns double buffer mode on or off. In double buffer
+// mode all drawing goes to the back buffer, and Flush compares it with
+// the front buffer - the screen content after the previous Flush - and
+// sends to terminal only changed cells. The mode is off by default
+func SetDoubleBuffer(on bool) {
+	if on == DoubleBuffer() {
+		return
+	}
+
+	if !on {
+		canvas.back, canvas.front = nil, nil
+		return
+	}
+	canvas.back = make([]term.Cell, canvas.width*canvas.height)
+	canvas.front = make([]term.Cell, canvas.width*canvas.height)
+	copy(canvas.back, term.CellBuffer())
+	copy(canvas.front, canvas.back)
+}
+
+// DoubleBuffer returns true if double buffer mode is on
+func DoubleBuffer() bool {
+	return canvas != nil && canvas.back != nil
+}
+
+// clearScreen fills the whole screen with spaces of default colors
+func clearScreen() {
+	if canvas.cells != nil {
+		for i := range canvas.cells {
+			canvas.cells[i] = term.Cell{Ch: ' ', Fg: ColorWhite, Bg: ColorBlack}
+		}
+		return
+	}
+
+	fg, bg := adjustColor(ColorWhite), adjustColor(ColorBlack)
+	if canvas.back == nil {
+		term.Clear(fg, bg)
+		return
+	}
+	for i := range canvas.back {
+		canvas.back[i] = term.Cell{Ch: ' ', Fg: fg, Bg: bg}
+	}
+}
+
 // SetSize sets the new Canvas size. If new size does not
 // equal old size then Canvas is recreated and cleared
 // with default colors. Both Canvas width and height must
@@ -146,6 +315,16 @@ func SetScreenSize(width int, height int) {
 
 	canvas.width = width
 	canvas.height = height
+	if canvas.cells != nil {
+		canvas.cells = make([]term.Cell, width*height)
+		clearScreen()
+	}
+	if canvas.back != nil {
+		// the terminal content is unknown after resizing, so
+		// empty front buffer makes the next Flush send all cells
+		canvas.back = make([]term.Cell, width*height)
+		canvas.front = make([]term.Cell, width*height)
+	}
 
 	canvas.clipStack = make([]rect, 0)
 	SetClipRect(0, 0, width, height)
@@ -158,6 +337,13 @@ func ScreenSize() (width int, height int) {
 
 // SetCursorPos sets text caret position. Used by controls like EditField
 func SetCursorPos(x int, y int) {
+	// memory canvas does not have a caret
+	if canvas != nil {
+		if canvas.cells != nil {
+			return
+		}
+		x, y = x+canvas.originX, y+canvas.originY
+	}
 	term.SetCursor(x, y)
 }
 
@@ -165,8 +351,9 @@ func SetCursorPos(x int, y int) {
 // operation: e.g, if the symbol position is outside Canvas the operation fails
 // and the function returns false
 func PutChar(x, y int, r rune) bool {
+	recordCall(DrawCall{Func: "PutChar", X: x, Y: y, Text: string(r)})
 	if InClipRect(x, y) {
-		term.SetCell(x, y, r, canvas.textColor, canvas.backColor)
+		putCharUnsafe(x, y, r)
 		return true
 	}
 
@@ -174,12 +361,84 @@ func PutChar(x, y int, r rune) bool {
 }
 
 func putCharUnsafe(x, y int, r rune) {
-	term.SetCell(x, y, r, canvas.textColor, canvas.backColor)
+	putCellUnsafe(x, y, term.Cell{Ch: r, Fg: canvas.textColor, Bg: canvas.backColor})
+}
+
+func putCellUnsafe(x, y int, cell term.Cell) {
+	x, y = x+canvas.originX, y+canvas.originY
+	if canvas.partial && !canvas.dirty.isDirty(x, y) {
+		return
+	}
+	if canvas.cells != nil {
+		if x >= 0 && x < canvas.width && y >= 0 && y < canvas.height {
+			canvas.cells[y*canvas.width+x] = cell
+		}
+		return
+	}
+
+	// colors set directly(e.g, with color tags) skip RealColor, so
+	// they are converted to the terminal color mode here
+	cell.Fg, cell.Bg = adjustColor(cell.Fg), adjustColor(cell.Bg)
+	if canvas.back != nil {
+		if x >= 0 && x < canvas.width && y >= 0 && y < canvas.height {
+			canvas.back[y*canvas.width+x] = cell
+		}
+		return
+	}
+	if canvas.batching > 0 {
+		canvas.batch = append(canvas.batch, BulkCell{X: x, Y: y, Cell: cell})
+		return
+	}
+
+	term.SetCell(x, y, cell.Ch, cell.Fg, cell.Bg)
+}
+
+// BeginBatch starts collecting all drawn cells instead of sending them
+// to termbox one by one. The collected cells are sent by EndBatch.
+// Batches can be nested, the cells are sent by the outermost EndBatch
+func BeginBatch() {
+	canvas.batching++
+}
+
+// EndBatch sends all cells drawn since BeginBatch to termbox and flushes
+// the screen once
+func EndBatch() {
+	if canvas.batching == 0 {
+		return
+	}
+	canvas.batching--
+	if canvas.batching > 0 {
+		return
+	}
+
+	for _, c := range canvas.batch {
+		term.SetCell(c.X, c.Y, c.Cell.Ch, c.Cell.Fg, c.Cell.Bg)
+	}
+	canvas.batch = canvas.batch[:0]
+	Flush()
+}
+
+// BulkFill draws a list of cells with their own characters and colors.
+// It is useful for controls that prepare their whole content in advance.
+// Cells outside the clipping rectangle are skipped
+func BulkFill(cells []BulkCell) {
+	for _, c := range cells {
+		if InClipRect(c.X, c.Y) {
+			putCellUnsafe(c.X, c.Y, c.Cell)
+		}
+	}
 }
 
 // Symbol returns the character and its attributes by its coordinates
 func Symbol(x, y int) (term.Cell, bool) {
+	x, y = x+canvas.originX, y+canvas.originY
 	if x >= 0 && x < canvas.width && y >= 0 && y < canvas.height {
+		if canvas.cells != nil {
+			return canvas.cells[y*canvas.width+x], true
+		}
+		if canvas.back != nil {
+			return canvas.back[y*canvas.width+x], true
+		}
 		cells := term.CellBuffer()
 		return cells[y*canvas.width+x], true
 	}
@@ -217,7 +476,7 @@ func SetClipRect(x, y, w, h int) {
 		w = canvas.width - x
 	}
 	if y+h > canvas.height {
-		h = canvas.height - h
+		h = canvas.height - y
 	}
 
 	canvas.clipX = x
@@ -257,10 +516,80 @@ func DrawVerticalLine(x, y, h int, r rune) {
 	}
 }
 
+// cellAspect is how many times a terminal cell is taller than wide
+const cellAspect = 2
+
+// arrowHeads are arrow characters for 8 directions starting from
+// the right one clockwise
+var arrowHeads = []rune("→↘↓↙←↖↑↗")
+
+// linePoints returns the cells of the line from x1:y1 to x2:y2 using
+// Bresenham's algorithm. The line is calculated in the coordinates
+// where a cell is square(vertical coordinates are multiplied by the
+// cell aspect ratio), so the line looks straight on the screen
+func linePoints(x1, y1, x2, y2 int) [][2]int {
+	dx, dy := x2-x1, (y2-y1)*cellAspect
+	sx, sy := 1, 1
+	if dx < 0 {
+		dx, sx = -dx, -1
+	}
+	if dy < 0 {
+		dy, sy = -dy, -1
+	}
+
+	points := make([][2]int, 0, dx+dy/cellAspect+1)
+	x, y, endY := x1, y1*cellAspect, y2*cellAspect
+	err := dx - dy
+	for {
+		// a point between two rows is drawn in the lower one
+		cy := int(math.Floor(float64(y+cellAspect/2) / cellAspect))
+		if l := len(points); l == 0 || points[l-1] != [2]int{x, cy} {
+			points = append(points, [2]int{x, cy})
+		}
+		if x == x2 && y == endY {
+			break
+		}
+
+		e2 := 2 * err
+		if e2 > -dy {
+			err -= dy
+			x += sx
+		}
+		if e2 < dx {
+			err += dx
+			y += sy
+		}
+	}
+
+	return points
+}
+
+// DrawLine draws the part of the line from x1:y1 to x2:y2 that is inside
+// current clipping rectangle. The line can be diagonal
+func DrawLine(x1, y1, x2, y2 int, r rune) {
+	for _, p := range linePoints(x1, y1, x2, y2) {
+		PutChar(p[0], p[1], r)
+	}
+}
+
+// DrawArrow draws the line from x1:y1 to x2:y2 like DrawLine does, and
+// an arrowhead that points to the line direction at x2:y2
+func DrawArrow(x1, y1, x2, y2 int, r rune) {
+	DrawLine(x1, y1, x2, y2, r)
+	if x1 == x2 && y1 == y2 {
+		return
+	}
+
+	angle := math.Atan2(float64((y2-y1)*cellAspect), float64(x2-x1))
+	sector := int(math.Floor(angle/(math.Pi/4)+0.5)+8) % 8
+	PutChar(x2, y2, arrowHeads[sector])
+}
+
 // DrawText draws the part of text that is inside the current clipping
 // rectangle. DrawText always paints colorized string. If you want to draw
 // raw string then use DrawRawText function
 func DrawText(x, y int, text string) {
+	recordCall(DrawCall{Func: "DrawText", X: x, Y: y, Text: text})
 	PushAttributes()
 	defer PopAttributes()
 
@@ -284,11 +613,20 @@ func DrawText(x, y int, text string) {
 	}
 }
 
+// DrawTextAligned draws the colorized text aligned inside the area of
+// width cells that starts at x. The text is truncated if it is longer
+// than width - for right and center alignment its beginning is cut
+func DrawTextAligned(x, y, width int, text string, align Align) {
+	shift, str := AlignColorizedText(text, width, align)
+	DrawText(x+shift, y, str)
+}
+
 // DrawRawText draws the part of text that is inside the current clipping
 // rectangle. DrawRawText always paints string as is - no color changes.
 // If you want to draw string with color changing commands included then
 // use DrawText function
 func DrawRawText(x, y int, text string) {
+	recordCall(DrawCall{Func: "DrawRawText", X: x, Y: y, Text: text})
 	cx, cy, cw, ch := ClipRect()
 	if x >= cx+cw || y < cy || y >= cy+ch {
 		return
@@ -304,7 +642,7 @@ func DrawRawText(x, y int, text string) {
 		length = length - (cx - x)
 		x = cx
 	}
-	text = CutText(text, cw)
+	text = CutText(text, cx+cw-x)
 
 	dx := 0
 	for _, ch := range text {
@@ -360,7 +698,7 @@ func DrawRawTextVertical(x, y int, text string) {
 		length = length - (cy - y)
 		y = cy
 	}
-	text = CutText(text, ch)
+	text = CutText(text, cy+ch-y)
 
 	dy := 0
 	for _, ch := range text {
@@ -372,9 +710,14 @@ func DrawRawTextVertical(x, y int, text string) {
 // DrawFrame paints the frame without changing area inside it
 func DrawFrame(x, y, w, h int, border BorderStyle) {
 	var chars string
-	if border == BorderThick {
+	switch border {
+	case BorderThick:
 		chars = SysObject(ObjDoubleBorder)
-	} else {
+	case BorderRounded:
+		chars = SysObject(ObjRoundedBorder)
+	case BorderBold:
+		chars = SysObject(ObjBoldBorder)
+	default:
 		chars = SysObject(ObjSingleBorder)
 	}
 
@@ -488,6 +831,7 @@ func DrawScrollBar(x, y, w, h, pos int) {
 
 // FillRect paints the area with r character using the current colors
 func FillRect(x, y, w, h int, r rune) {
+	recordCall(DrawCall{Func: "FillRect", X: x, Y: y, W: w, H: h, Text: string(r)})
 	x, y, w, h = clip(x, y, w, h)
 	if w < 1 || y < -1 {
 		return
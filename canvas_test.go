@@ -0,0 +1,113 @@
+package clui
+
+import (
+	"testing"
+)
+
+func TestDrawTextAligned(t *testing.T) {
+	cases := []struct {
+		text  string
+		align Align
+		want  string
+	}{
+		{"abc", AlignLeft, "abc     "},
+		{"abc", AlignRight, "     abc"},
+		{"abc", AlignCenter, "  abc   "},
+		{"<c:red>ab<c:>c", AlignRight, "     abc"},
+		{"abcdefghij", AlignLeft, "abcdefgh"},
+		{"abcdefghij", AlignRight, "cdefghij"},
+	}
+
+	for _, c := range cases {
+		lbl := CreateLabel(nil, 8, 1, c.text, Fixed)
+		lbl.SetAlign(c.align)
+		if out := renderToString(lbl); out != c.want {
+			t.Errorf("%q aligned %v: got %q, want %q", c.text, c.align, out, c.want)
+		}
+	}
+}
+
+func drawToString(w, h int, draw func()) string {
+	saved := canvas
+	canvas = newMemoryCanvas(w, h)
+	defer func() { canvas = saved }()
+
+	draw()
+
+	out := ""
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			out += string(canvas.cells[y*w+x].Ch)
+		}
+		out += "\n"
+	}
+	return out
+}
+
+func TestDrawLine(t *testing.T) {
+	out := drawToString(5, 3, func() { DrawLine(4, 1, 0, 1, '-') })
+	if out != "     \n-----\n     \n" {
+		t.Errorf("Invalid horizontal line:\n%v", out)
+	}
+
+	out = drawToString(7, 4, func() { DrawLine(0, 0, 6, 3, '*') })
+	if out != "*      \n **    \n   **  \n     **\n" {
+		t.Errorf("Invalid diagonal line:\n%v", out)
+	}
+
+	out = drawToString(3, 3, func() { DrawLine(-5, 1, 10, 1, '-') })
+	if out != "   \n---\n   \n" {
+		t.Errorf("Line must be clipped:\n%v", out)
+	}
+
+	out = drawToString(4, 4, func() { DrawArrow(0, 3, 0, 0, '|') })
+	if out != "↑   \n|   \n|   \n|   \n" {
+		t.Errorf("Invalid up arrow:\n%v", out)
+	}
+
+	out = drawToString(5, 3, func() { DrawArrow(0, 0, 4, 2, '*') })
+	if out != "*    \n **  \n   *↘\n" {
+		t.Errorf("Invalid diagonal arrow:\n%v", out)
+	}
+}
+
+func TestDrawFrameStyles(t *testing.T) {
+	if themeManager == nil {
+		initThemeManager()
+	}
+
+	cases := []struct {
+		border BorderStyle
+		want   string
+	}{
+		{BorderThin, "┌─┐\n│ │\n└─┘\n"},
+		{BorderThick, "╔═╗\n║ ║\n╚═╝\n"},
+		{BorderRounded, "╭─╮\n│ │\n╰─╯\n"},
+		{BorderBold, "┏━┓\n┃ ┃\n┗━┛\n"},
+	}
+	for _, c := range cases {
+		out := drawToString(3, 3, func() { DrawFrame(0, 0, 3, 3, c.border) })
+		if out != c.want {
+			t.Errorf("Invalid frame %v:\n%v", c.border, out)
+		}
+	}
+}
+
+func TestClipRect(t *testing.T) {
+	out := drawToString(8, 3, func() {
+		PushClipRect(1, 0, 6, 3)
+		PushClipRect(3, 1, 10, 10)
+		if x, y, w, h := ClipRect(); x != 3 || y != 1 || w != 4 || h != 2 {
+			t.Errorf("Nested clip must be the intersection: %v:%v:%v:%v", x, y, w, h)
+		}
+		DrawRawText(4, 1, "abcdef")
+		FillRect(0, 2, 8, 1, '#')
+		PopClip()
+		DrawRawText(0, 0, "12345678")
+		PopClip()
+	})
+	want := " 234567 \n    abc \n   #### \n"
+	if out != want {
+		t.Errorf("Invalid clipping:\n%v", out)
+	}
+}
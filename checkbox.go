@@ -5,13 +5,32 @@ import (
 	term "github.com/nsf/termbox-go"
 )
 
+// CheckState is a state of CheckBox
+type CheckState int
+
+// CheckBox states
+const (
+	// CheckStateUnchecked - the check box is off
+	CheckStateUnchecked CheckState = iota
+	// CheckStateChecked - the check box is on
+	CheckStateChecked
+	// CheckStateIndeterminate - the third state, e.g, when only
+	// some of child items are selected
+	CheckStateIndeterminate
+)
+
 /*
 CheckBox control. It can be two-state one(on and off) - it is default mode - or three-state.
-State values are 0=off, 1=on, 2=third state
+State values are CheckStateUnchecked(0), CheckStateChecked(1), and
+CheckStateIndeterminate(2).
+A two-state CheckBox can be set to indeterminate state only by an
+application. A user click or Space turns the indeterminate state into
+checked one, after that the CheckBox switches between unchecked and
+checked states.
 */
 type CheckBox struct {
 	BaseControl
-	state       int
+	state       CheckState
 	allow3state bool
 
 	onChange func(int)
@@ -36,7 +55,7 @@ func CreateCheckBox(parent Control, width int, title string, scale int) *CheckBo
 
 	c.SetSize(width, 1) // TODO: only one line checkboxes are supported at that moment
 	c.SetConstraints(width, 1)
-	c.state = 0
+	c.state = CheckStateUnchecked
 	c.SetTitle(title)
 	c.SetTabStop(true)
 	c.allow3state = false
@@ -85,8 +104,7 @@ func (c *CheckBox) Draw() {
 		return
 	}
 
-	shift, text := AlignColorizedText(c.title, w-4, c.align)
-	DrawText(x+4+shift, y, text)
+	DrawTextAligned(x+4, y, w-4, c.title, c.align)
 }
 
 //ProcessEvent processes all events come from the control parent. If a control
@@ -99,16 +117,17 @@ func (c *CheckBox) ProcessEvent(event Event) bool {
 	}
 
 	if (event.Type == EventKey && event.Key == term.KeySpace) || (event.Type == EventClick) {
-		if c.state == 0 {
-			c.SetState(1)
-		} else if c.state == 2 {
-			c.SetState(0)
-		} else {
-			if c.allow3state {
-				c.SetState(2)
-			} else {
-				c.SetState(0)
-			}
+		switch {
+		case c.state == CheckStateUnchecked:
+			c.SetState(CheckStateChecked)
+		case c.state == CheckStateIndeterminate && !c.allow3state:
+			c.SetState(CheckStateChecked)
+		case c.state == CheckStateIndeterminate:
+			c.SetState(CheckStateUnchecked)
+		case c.allow3state:
+			c.SetState(CheckStateIndeterminate)
+		default:
+			c.SetState(CheckStateUnchecked)
 		}
 		return true
 	}
@@ -117,41 +136,43 @@ func (c *CheckBox) ProcessEvent(event Event) bool {
 }
 
 // SetState changes the current state of CheckBox
-// Value must be 0 or 1 if Allow3State is off,
-// and 0, 1, or 2 if Allow3State is on
-func (c *CheckBox) SetState(val int) {
+// An application can set CheckStateIndeterminate even if
+// Allow3State is off
+func (c *CheckBox) SetState(val CheckState) {
 	if val == c.state {
 		return
 	}
 
-	if val < 0 {
-		val = 0
-	}
-	if val > 1 && !c.allow3state {
-		val = 1
+	if val < CheckStateUnchecked {
+		val = CheckStateUnchecked
 	}
-	if val > 2 {
-		val = 2
+	if val > CheckStateIndeterminate {
+		val = CheckStateIndeterminate
 	}
 
 	c.state = val
 
 	if c.onChange != nil {
-		go c.onChange(val)
+		go c.onChange(int(val))
 	}
 }
 
 // State returns current state of CheckBox
-func (c *CheckBox) State() int {
+func (c *CheckBox) State() CheckState {
 	return c.state
 }
 
+// GetValue returns true only if the CheckBox is checked
+func (c *CheckBox) GetValue() bool {
+	return c.state == CheckStateChecked
+}
+
 // SetAllow3State sets if ComboBox should use 3 states. If the current
 // state is unknown and one disables Allow3State option then the current
 // value resets to off
 func (c *CheckBox) SetAllow3State(enable bool) {
-	if !enable && c.state == 2 {
-		c.state = 0
+	if !enable && c.state == CheckStateIndeterminate {
+		c.state = CheckStateUnchecked
 	}
 	c.allow3state = enable
 }
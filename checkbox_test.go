@@ -0,0 +1,37 @@
+package clui
+
+import (
+	term "github.com/nsf/termbox-go"
+	"testing"
+)
+
+func TestCheckBoxStates(t *testing.T) {
+	chk := CreateCheckBox(nil, 10, "check", Fixed)
+	chk.SetActive(true)
+	space := Event{Type: EventKey, Key: term.KeySpace}
+
+	chk.SetState(CheckStateIndeterminate)
+	if chk.State() != CheckStateIndeterminate || chk.GetValue() {
+		t.Errorf("Indeterminate state must be set by application: %v", chk.State())
+	}
+
+	expected := []CheckState{CheckStateChecked, CheckStateUnchecked, CheckStateChecked}
+	for _, st := range expected {
+		chk.ProcessEvent(space)
+		if chk.State() != st {
+			t.Errorf("Invalid two-state cycle: %v instead of %v", chk.State(), st)
+		}
+	}
+	if !chk.GetValue() {
+		t.Error("GetValue must be true for checked state")
+	}
+
+	chk.SetAllow3State(true)
+	expected = []CheckState{CheckStateIndeterminate, CheckStateUnchecked, CheckStateChecked}
+	for _, st := range expected {
+		chk.ProcessEvent(space)
+		if chk.State() != st {
+			t.Errorf("Invalid three-state cycle: %v instead of %v", chk.State(), st)
+		}
+	}
+}
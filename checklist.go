@@ -0,0 +1,346 @@
+package clui
+
+import (
+	term "github.com/nsf/termbox-go"
+)
+
+// ItemID is an identifier of a CheckList item
+type ItemID int
+
+// checkItem is a CheckList item: its label and state
+type checkItem struct {
+	label   string
+	checked bool
+}
+
+/*
+CheckList is a scrollable list of labeled check boxes. Every item is
+displayed as a check box followed by its label.
+
+Arrow keys, Home, End, PgUp, and PgDn move the focus between items.
+Space or a mouse click toggles the focused item. Ctrl+A checks all
+items and Ctrl+D unchecks all items.
+
+Events:
+
+	OnChange - called every time an item is checked or unchecked.
+	    The callback gets the item identifier and its new state
+*/
+type CheckList struct {
+	BaseControl
+	items         []checkItem
+	currSelection int
+	topLine       int
+
+	onChange   func(ItemID, bool)
+	onKeyPress func(term.Key) bool
+}
+
+/*
+CreateCheckList creates a new check list.
+parent - is container that keeps the control.
+width and heigth - are minimal size of the control.
+scale - the way of scaling the control when the parent is resized. Use DoNotScale constant if the
+control should keep its original size.
+*/
+func CreateCheckList(parent Control, width, height int, scale int) *CheckList {
+	c := new(CheckList)
+
+	if height == AutoSize {
+		height = 3
+	}
+	if width == AutoSize {
+		width = 10
+	}
+
+	c.SetSize(width, height)
+	c.SetConstraints(width, height)
+	c.currSelection = -1
+	c.items = make([]checkItem, 0)
+	c.parent = parent
+
+	c.SetTabStop(true)
+	c.SetScale(scale)
+
+	if parent != nil {
+		parent.AddChild(c)
+	}
+
+	return c
+}
+
+func (c *CheckList) drawItems() {
+	PushAttributes()
+	defer PopAttributes()
+
+	fg, bg := RealColor(c.fg, ColorEditText), RealColor(c.bg, ColorEditBack)
+	if c.Active() {
+		fg, bg = RealColor(c.fg, ColorEditActiveText), RealColor(c.bg, ColorEditActiveBack)
+	}
+	fgSel, bgSel := RealColor(c.fgActive, ColorSelectionText), RealColor(c.bgActive, ColorSelectionBack)
+
+	parts := []rune(SysObject(ObjCheckBox))
+	cOpen, cClose, cEmpty, cCheck := parts[0], parts[1], parts[2], parts[3]
+
+	maxWidth := c.width - 1
+	if len(c.items) <= c.height {
+		maxWidth = c.width
+	}
+
+	for dy := 0; dy < c.height && c.topLine+dy < len(c.items); dy++ {
+		idx := c.topLine + dy
+		f, b := fg, bg
+		if idx == c.currSelection {
+			f, b = fgSel, bgSel
+		}
+
+		SetTextColor(f)
+		SetBackColor(b)
+		FillRect(c.x, c.y+dy, maxWidth, 1, ' ')
+		if maxWidth < 3 {
+			continue
+		}
+
+		state := cEmpty
+		if c.items[idx].checked {
+			state = cCheck
+		}
+		PutChar(c.x, c.y+dy, cOpen)
+		PutChar(c.x+1, c.y+dy, state)
+		PutChar(c.x+2, c.y+dy, cClose)
+
+		if maxWidth > 4 {
+			DrawText(c.x+4, c.y+dy, SliceColorized(c.items[idx].label, 0, maxWidth-4))
+		}
+	}
+}
+
+// Draw repaints the control on its View surface
+func (c *CheckList) Draw() {
+	PushAttributes()
+	defer PopAttributes()
+
+	fg, bg := RealColor(c.fg, ColorEditText), RealColor(c.bg, ColorEditBack)
+	if c.Active() {
+		fg, bg = RealColor(c.fg, ColorEditActiveText), RealColor(c.bg, ColorEditActiveBack)
+	}
+	SetTextColor(fg)
+	SetBackColor(bg)
+	FillRect(c.x, c.y, c.width, c.height, ' ')
+	c.drawItems()
+
+	if len(c.items) > c.height {
+		pos := ThumbPosition(c.currSelection, len(c.items), c.height)
+		DrawScrollBar(c.x+c.width-1, c.y, 1, c.height, pos)
+	}
+}
+
+// moveTo moves the focus to the item and scrolls the list to
+// make the item visible
+func (c *CheckList) moveTo(idx int) {
+	if len(c.items) == 0 {
+		return
+	}
+
+	if idx < 0 {
+		idx = 0
+	}
+	if idx >= len(c.items) {
+		idx = len(c.items) - 1
+	}
+	c.currSelection = idx
+	c.EnsureVisible()
+}
+
+// EnsureVisible makes the focused item visible and scrolls the item list if it is required
+func (c *CheckList) EnsureVisible() {
+	if c.currSelection == -1 {
+		return
+	}
+
+	if c.currSelection < c.topLine {
+		c.topLine = c.currSelection
+	} else if c.currSelection >= c.topLine+c.height {
+		c.topLine = c.currSelection - c.height + 1
+	}
+}
+
+// setChecked changes the item state and calls OnChange callback
+// if the state is changed
+func (c *CheckList) setChecked(idx int, checked bool) {
+	if c.items[idx].checked == checked {
+		return
+	}
+
+	c.items[idx].checked = checked
+	if c.onChange != nil {
+		go c.onChange(ItemID(idx), checked)
+	}
+}
+
+func (c *CheckList) processMouseClick(ev Event) bool {
+	if ev.Key != term.MouseLeft {
+		return false
+	}
+
+	dx := ev.X - c.x
+	dy := ev.Y - c.y
+	if dx < 0 || dx >= c.width || dy < 0 || dy >= c.height {
+		return true
+	}
+
+	if len(c.items) > c.height && dx == c.width-1 {
+		switch {
+		case dy == 0:
+			c.moveTo(c.currSelection - 1)
+		case dy == c.height-1:
+			c.moveTo(c.currSelection + 1)
+		default:
+			c.moveTo(ItemByThumbPosition(dy, len(c.items), c.height))
+		}
+		return true
+	}
+
+	idx := c.topLine + dy
+	if idx < len(c.items) {
+		c.currSelection = idx
+		c.setChecked(idx, !c.items[idx].checked)
+	}
+
+	return true
+}
+
+/*
+ProcessEvent processes all events come from the control parent. If a control
+processes an event it should return true. If the method returns false it means
+that the control do not want or cannot process the event and the caller sends
+the event to the control parent
+*/
+func (c *CheckList) ProcessEvent(event Event) bool {
+	if !c.Active() || !c.Enabled() {
+		return false
+	}
+
+	switch event.Type {
+	case EventKey:
+		if c.onKeyPress != nil && c.onKeyPress(event.Key) {
+			return true
+		}
+
+		switch event.Key {
+		case term.KeyHome:
+			c.moveTo(0)
+		case term.KeyEnd:
+			c.moveTo(len(c.items) - 1)
+		case term.KeyArrowUp:
+			c.moveTo(c.currSelection - 1)
+		case term.KeyArrowDown:
+			c.moveTo(c.currSelection + 1)
+		case term.KeyPgup:
+			c.moveTo(c.currSelection - c.height)
+		case term.KeyPgdn:
+			c.moveTo(c.currSelection + c.height)
+		case term.KeySpace:
+			if c.currSelection != -1 {
+				c.setChecked(c.currSelection, !c.items[c.currSelection].checked)
+			}
+		case term.KeyCtrlA:
+			c.SetAllChecked(true)
+		case term.KeyCtrlD:
+			c.SetAllChecked(false)
+		default:
+			return false
+		}
+		return true
+	case EventMouse:
+		return c.processMouseClick(event)
+	}
+
+	return false
+}
+
+// own methods
+
+// AddItem appends a new item to the list. Returns the item identifier
+func (c *CheckList) AddItem(label string, checked bool) ItemID {
+	c.items = append(c.items, checkItem{label: label, checked: checked})
+	if c.currSelection == -1 {
+		c.currSelection = 0
+	}
+	return ItemID(len(c.items) - 1)
+}
+
+// ItemCount returns the number of items in the list
+func (c *CheckList) ItemCount() int {
+	return len(c.items)
+}
+
+// ItemLabel returns the label of the item or empty string
+// if the item does not exist
+func (c *CheckList) ItemLabel(id ItemID) string {
+	if id < 0 || int(id) >= len(c.items) {
+		return ""
+	}
+	return c.items[id].label
+}
+
+// SetChecked checks or unchecks the item
+func (c *CheckList) SetChecked(id ItemID, checked bool) {
+	if id < 0 || int(id) >= len(c.items) {
+		return
+	}
+	c.setChecked(int(id), checked)
+}
+
+// IsChecked returns true if the item is checked
+func (c *CheckList) IsChecked(id ItemID) bool {
+	if id < 0 || int(id) >= len(c.items) {
+		return false
+	}
+	return c.items[id].checked
+}
+
+// SetAllChecked checks or unchecks all items
+func (c *CheckList) SetAllChecked(checked bool) {
+	for idx := range c.items {
+		c.setChecked(idx, checked)
+	}
+}
+
+// CheckedItems returns identifiers of all checked items
+func (c *CheckList) CheckedItems() []ItemID {
+	ids := make([]ItemID, 0)
+	for idx, itm := range c.items {
+		if itm.checked {
+			ids = append(ids, ItemID(idx))
+		}
+	}
+	return ids
+}
+
+// SelectedItem returns the focused item or -1 if the list is empty
+func (c *CheckList) SelectedItem() ItemID {
+	return ItemID(c.currSelection)
+}
+
+// SelectItem moves the focus to the item and makes it visible
+func (c *CheckList) SelectItem(id ItemID) {
+	if id < 0 || int(id) >= len(c.items) {
+		return
+	}
+	c.moveTo(int(id))
+}
+
+// OnChange sets a callback that is called every time an item
+// is checked or unchecked
+func (c *CheckList) OnChange(fn func(ItemID, bool)) {
+	c.onChange = fn
+}
+
+// OnKeyPress sets the callback that is called when a user presses a Key while
+// the controls is active. If a handler processes the key it should return
+// true. If handler returns false it means that the default handler will
+// process the key
+func (c *CheckList) OnKeyPress(fn func(term.Key) bool) {
+	c.onKeyPress = fn
+}
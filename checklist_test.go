@@ -0,0 +1,45 @@
+package clui
+
+import (
+	"fmt"
+	term "github.com/nsf/termbox-go"
+	"strings"
+	"testing"
+)
+
+func TestCheckList(t *testing.T) {
+	list := CreateCheckList(nil, 12, 2, Fixed)
+	list.SetActive(true)
+	first := list.AddItem("first", false)
+	list.AddItem("second", true)
+	third := list.AddItem("third", false)
+
+	if list.IsChecked(first) || !list.IsChecked(1) {
+		t.Error("Invalid initial item states")
+	}
+
+	list.ProcessEvent(Event{Type: EventKey, Key: term.KeySpace})
+	if !list.IsChecked(first) {
+		t.Error("Space must check the focused item")
+	}
+
+	list.ProcessEvent(Event{Type: EventKey, Key: term.KeyEnd})
+	if list.SelectedItem() != third || list.topLine != 1 {
+		t.Errorf("The last item must be visible: top line %v", list.topLine)
+	}
+
+	list.ProcessEvent(Event{Type: EventKey, Key: term.KeyCtrlA})
+	if fmt.Sprint(list.CheckedItems()) != "[0 1 2]" {
+		t.Errorf("All items must be checked: %v", list.CheckedItems())
+	}
+	list.ProcessEvent(Event{Type: EventKey, Key: term.KeyCtrlD})
+	if len(list.CheckedItems()) != 0 {
+		t.Errorf("All items must be unchecked: %v", list.CheckedItems())
+	}
+
+	list.SetChecked(third, true)
+	out := renderToString(list)
+	if !strings.Contains(out, "[ ] second") || !strings.Contains(out, "[X] third") {
+		t.Errorf("Invalid output:\n%v", out)
+	}
+}
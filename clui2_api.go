@@ -2,6 +2,7 @@ package clui
 
 import (
 	term "github.com/nsf/termbox-go"
+	"os"
 )
 
 func InitLibrary() bool {
@@ -13,6 +14,8 @@ func InitLibrary() bool {
 
 // Close closes console management and makes a console cursor visible
 func DeinitLibrary() {
+	schemes.stopWatch()
 	term.SetCursor(3, 3)
+	stopMouseHover(os.Stdout)
 	term.Close()
 }
@@ -0,0 +1,131 @@
+package clui
+
+import (
+	term "github.com/nsf/termbox-go"
+	"os"
+	"runtime"
+	"strings"
+)
+
+// colorMode is the termbox output mode selected by initCanvas
+var colorMode = term.OutputNormal
+
+// basic16 are RGB values of the first 16 palette colors. They are used
+// to find the closest basic color if the terminal does not support
+// 256 colors
+var basic16 = [16][3]int{
+	{0, 0, 0}, {128, 0, 0}, {0, 128, 0}, {128, 128, 0},
+	{0, 0, 128}, {128, 0, 128}, {0, 128, 128}, {192, 192, 192},
+	{128, 128, 128}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{0, 0, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// Color256 returns the attribute for the color from the 256-color
+// palette. The encoding is the same termbox uses in 256-color mode:
+// the attribute is the palette index plus one, so the first 8 indexes
+// are the basic colors ColorBlack..ColorWhite. Combine the result with
+// modifiers as usual, e.g: Color256(208) | term.AttrBold
+func Color256(index uint8) term.Attribute {
+	return term.Attribute(index) + 1
+}
+
+// SupportsColor256 returns true if the terminal can display 256 colors.
+// The check is based on TERM and COLORTERM environment variables
+func SupportsColor256() bool {
+	// termbox supports only 16 colors in Windows console
+	if runtime.GOOS == "windows" {
+		return false
+	}
+	if os.Getenv("COLORTERM") != "" {
+		return true
+	}
+	return strings.Contains(os.Getenv("TERM"), "256color")
+}
+
+// Set256Color sets the theme color to the color from 256-color palette
+func (t *Theme) Set256Color(id string, index uint8) {
+	t.SetColor(id, Color256(index))
+}
+
+// paletteRGB returns RGB value of the color from 256-color palette
+func paletteRGB(index int) (int, int, int) {
+	if index < 16 {
+		c := basic16[index]
+		return c[0], c[1], c[2]
+	}
+	if index >= 232 {
+		v := 8 + (index-232)*10
+		return v, v, v
+	}
+
+	levels := []int{0, 95, 135, 175, 215, 255}
+	index -= 16
+	return levels[index/36], levels[index/6%6], levels[index%6]
+}
+
+// closestColor returns the attribute of a palette color in the range
+// [from, to) that is the closest to the RGB value
+func closestColor(r, g, b, from, to int) term.Attribute {
+	best, dist := from, -1
+	for i := from; i < to; i++ {
+		pr, pg, pb := paletteRGB(i)
+		d := (pr-r)*(pr-r) + (pg-g)*(pg-g) + (pb-b)*(pb-b)
+		if dist < 0 || d < dist {
+			best, dist = i, d
+		}
+	}
+	return term.Attribute(best) + 1
+}
+
+// colorTo16 returns the closest of 16 basic colors for the palette index
+func colorTo16(index int) term.Attribute {
+	if index < 16 {
+		return term.Attribute(index) + 1
+	}
+
+	r, g, b := paletteRGB(index)
+	return closestColor(r, g, b, 0, 16)
+}
+
+// adjustColor converts the attribute to the current terminal color mode.
+// In 16-color mode palette and RGB colors are replaced with the closest
+// basic ones, in 256-color mode RGB colors are replaced with the closest
+// palette ones, and in true color mode all colors are converted to RGB.
+// Bold basic colors are replaced with their bright variants in 256-color
+// and true color modes, because terminals brighten only the basic colors
+func adjustColor(clr term.Attribute) term.Attribute {
+	if isRGBColor(clr) {
+		if colorMode == term.OutputRGB {
+			return clr
+		}
+		mods := clr & attrMask
+		r, g, b := term.AttributeToRGB(clr)
+		if colorMode == term.Output256 {
+			// basic colors are skipped: terminals often redefine them
+			return mods | closestColor(int(r), int(g), int(b), 16, 256)
+		}
+		return mods | closestColor(int(r), int(g), int(b), 0, 16)
+	}
+
+	idx := clr & colorMask
+	if idx == ColorDefault {
+		return clr
+	}
+
+	switch colorMode {
+	case term.Output256, term.OutputRGB:
+		if idx <= term.ColorWhite && clr&term.AttrBold != 0 {
+			clr, idx = clr+8, idx+8
+		}
+		if colorMode == term.OutputRGB {
+			r, g, b := paletteRGB(int(idx) - 1)
+			return clr&attrMask | RGBColor(uint8(r), uint8(g), uint8(b))
+		}
+		return clr
+	}
+
+	if idx > 16 {
+		return clr&^colorMask | colorTo16(int(idx)-1)
+	}
+	return clr
+}
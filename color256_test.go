@@ -0,0 +1,76 @@
+package clui
+
+import (
+	term "github.com/nsf/termbox-go"
+	"os"
+	"runtime"
+	"testing"
+)
+
+func TestColor256(t *testing.T) {
+	if Color256(1) != ColorRed || Color256(7) != ColorWhite {
+		t.Error("The first palette colors must be the basic ones")
+	}
+
+	cases := []struct {
+		index int
+		clr   term.Attribute
+	}{
+		{196, term.ColorLightRed},
+		{22, term.ColorGreen},
+		{21, term.ColorLightBlue},
+		{232, term.ColorBlack},
+		{250, term.ColorWhite},
+		{231, term.ColorLightGray},
+	}
+	for _, c := range cases {
+		if clr := colorTo16(c.index); clr != c.clr {
+			t.Errorf("Index %v: expected %v, got %v", c.index, c.clr, clr)
+		}
+	}
+
+	defer func(mode term.OutputMode) { colorMode = mode }(colorMode)
+
+	colorMode = term.OutputNormal
+	if clr := adjustColor(Color256(196) | term.AttrUnderline); clr != term.ColorLightRed|term.AttrUnderline {
+		t.Errorf("Palette color must be converted to the basic one: %v", clr)
+	}
+	if clr := adjustColor(ColorWhiteBold); clr != ColorWhiteBold {
+		t.Errorf("Basic colors must not change: %v", clr)
+	}
+
+	colorMode = term.Output256
+	if clr := adjustColor(Color256(208)); clr != Color256(208) {
+		t.Errorf("Palette colors must not change: %v", clr)
+	}
+	if clr := adjustColor(ColorWhiteBold); clr != Color256(15)|term.AttrBold {
+		t.Errorf("Bold basic color must be bright: %v", clr)
+	}
+}
+
+func TestSupportsColor256(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Windows console does not support 256 colors")
+	}
+
+	oldTerm, oldColor := os.Getenv("TERM"), os.Getenv("COLORTERM")
+	defer func() {
+		os.Setenv("TERM", oldTerm)
+		os.Setenv("COLORTERM", oldColor)
+	}()
+
+	os.Setenv("COLORTERM", "")
+	os.Setenv("TERM", "xterm")
+	if SupportsColor256() {
+		t.Error("xterm must not support 256 colors")
+	}
+	os.Setenv("TERM", "xterm-256color")
+	if !SupportsColor256() {
+		t.Error("xterm-256color must support 256 colors")
+	}
+	os.Setenv("TERM", "xterm")
+	os.Setenv("COLORTERM", "truecolor")
+	if !SupportsColor256() {
+		t.Error("COLORTERM must enable 256 colors")
+	}
+}
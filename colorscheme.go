@@ -0,0 +1,253 @@
+package clui
+
+import (
+	term "github.com/nsf/termbox-go"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ColorScheme is a terminal color scheme: dark or light background
+type ColorScheme int
+
+// Color schemes
+const (
+	// ColorSchemeDark - light text on dark background
+	ColorSchemeDark ColorScheme = iota
+	// ColorSchemeLight - dark text on light background
+	ColorSchemeLight
+)
+
+// oscBackgroundQuery asks the terminal for its background color. The
+// terminal replies with ESC ] 11 ; rgb:RRRR/GGGG/BBBB BEL
+const oscBackgroundQuery = "\x1b]11;?\x07"
+
+// oscReplyMaxLen is the maximal length of the terminal reply. Longer
+// sequences are not the reply
+const oscReplyMaxLen = 32
+
+// oscReplyTimeout is how long the terminal reply is expected after the
+// query. Keys that come later are processed as usual
+const oscReplyTimeout = time.Second
+
+// ColorSchemePollInterval is how often the terminal background is
+// checked after SetOnColorSchemeChange or SetColorSchemeThemes call
+var ColorSchemePollInterval = 5 * time.Second
+
+// schemeWatcher tracks the terminal color scheme. The terminal reply
+// comes as a sequence of key events, so the watcher is fed with all
+// keys while a query is pending. Only the first key after the query
+// can start the reply
+type schemeWatcher struct {
+	mtx      sync.Mutex
+	onChange func(ColorScheme)
+	// names of dark and light theme variants
+	themes  [2]string
+	scheme  ColorScheme
+	replied bool
+	stop    chan struct{}
+
+	// the time the reply is expected until, zero if there is no query
+	deadline time.Time
+	parsing  bool
+	reply    []rune
+}
+
+var schemes = new(schemeWatcher)
+
+// schemeFromColorFGBG guesses the color scheme by COLORFGBG environment
+// variable: it looks like "15;0" where the last number is the background
+// color. Returns false if the variable is not set or invalid
+func schemeFromColorFGBG(value string) (ColorScheme, bool) {
+	parts := strings.Split(value, ";")
+	bg, err := strconv.Atoi(parts[len(parts)-1])
+	if err != nil || bg < 0 || bg > 15 {
+		return ColorSchemeDark, false
+	}
+	if bg == 7 || bg > 8 {
+		return ColorSchemeLight, true
+	}
+	return ColorSchemeDark, true
+}
+
+// schemeFromOSCReply detects the color scheme by the terminal reply to
+// the background color query, e.g, "11;rgb:ffff/ffff/dddd"
+func schemeFromOSCReply(reply string) (ColorScheme, bool) {
+	idx := strings.Index(reply, "rgb:")
+	if idx == -1 {
+		return ColorSchemeDark, false
+	}
+	parts := strings.Split(reply[idx+4:], "/")
+	if len(parts) != 3 {
+		return ColorSchemeDark, false
+	}
+
+	var rgb [3]float64
+	for i, part := range parts {
+		if len(part) == 0 || len(part) > 4 {
+			return ColorSchemeDark, false
+		}
+		v, err := strconv.ParseUint(part, 16, 16)
+		if err != nil {
+			return ColorSchemeDark, false
+		}
+		rgb[i] = float64(v) / float64(uint64(1)<<(4*uint(len(part)))-1)
+	}
+
+	if 0.299*rgb[0]+0.587*rgb[1]+0.114*rgb[2] > 0.5 {
+		return ColorSchemeLight, true
+	}
+	return ColorSchemeDark, true
+}
+
+// DetectColorScheme returns the terminal color scheme. The library
+// uses the terminal reply to the background color query(OSC 11) if the
+// terminal has answered it, otherwise COLORFGBG environment variable.
+// If neither is available the scheme is dark
+func DetectColorScheme() ColorScheme {
+	schemes.mtx.Lock()
+	if schemes.replied {
+		defer schemes.mtx.Unlock()
+		return schemes.scheme
+	}
+	schemes.mtx.Unlock()
+
+	scheme, _ := schemeFromColorFGBG(os.Getenv("COLORFGBG"))
+	return scheme
+}
+
+// SetOnColorSchemeChange sets the callback that is called when the
+// terminal color scheme changes, e.g, when a user toggles the system
+// dark mode. The library asks the terminal for its background color
+// every ColorSchemePollInterval. Terminals that do not answer the
+// query never change the scheme. Call it after InitLibrary
+func SetOnColorSchemeChange(fn func(ColorScheme)) {
+	schemes.mtx.Lock()
+	schemes.onChange = fn
+	schemes.mtx.Unlock()
+
+	schemes.watch()
+}
+
+// SetColorSchemeThemes sets the names of the themes for dark and light
+// color schemes. The library selects the theme for the current scheme
+// and switches themes when the scheme changes. Empty names disable
+// switching. Call it after InitLibrary
+func SetColorSchemeThemes(dark, light string) {
+	schemes.mtx.Lock()
+	schemes.themes = [2]string{dark, light}
+	schemes.mtx.Unlock()
+
+	if name := [2]string{dark, light}[DetectColorScheme()]; name != "" {
+		SetCurrentTheme(name)
+	}
+	schemes.watch()
+}
+
+// watch starts polling the terminal if it is not started yet
+func (s *schemeWatcher) watch() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.stop != nil || loop == nil || runtime.GOOS == "windows" {
+		return
+	}
+
+	if !s.replied {
+		s.scheme, _ = schemeFromColorFGBG(os.Getenv("COLORFGBG"))
+	}
+	s.stop = make(chan struct{})
+	go func(stop chan struct{}) {
+		ticker := time.NewTicker(ColorSchemePollInterval)
+		defer ticker.Stop()
+		PutEvent(Event{Type: EventColorSchemeQuery})
+		for {
+			select {
+			case <-ticker.C:
+				PutEvent(Event{Type: EventColorSchemeQuery})
+			case <-stop:
+				return
+			}
+		}
+	}(s.stop)
+}
+
+// stopWatch stops polling the terminal
+func (s *schemeWatcher) stopWatch() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.stop != nil {
+		close(s.stop)
+		s.stop = nil
+	}
+}
+
+// query sends the background color query to the terminal. It is
+// called from the main loop, so the query does not mix with screen
+// updates
+func (s *schemeWatcher) query() {
+	if canvas == nil || canvas.cells != nil || !isTerminal(os.Stdout) {
+		return
+	}
+
+	s.mtx.Lock()
+	s.deadline = time.Now().Add(oscReplyTimeout)
+	s.mtx.Unlock()
+	os.Stdout.WriteString(oscBackgroundQuery)
+}
+
+// feed processes a key event while the terminal reply is expected.
+// Returns true if the event is a part of the reply
+func (s *schemeWatcher) feed(ev Event) bool {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if !s.parsing {
+		pending := !s.deadline.IsZero() && time.Now().Before(s.deadline)
+		s.deadline = time.Time{}
+		if pending && ev.Mod == term.ModAlt && ev.Ch == ']' {
+			s.parsing = true
+			s.reply = s.reply[:0]
+			return true
+		}
+		return false
+	}
+
+	// the reply ends with BEL or ESC \
+	if ev.Key == term.KeyCtrlG || (ev.Mod == term.ModAlt && ev.Ch == '\\') {
+		s.parsing = false
+		if scheme, ok := schemeFromOSCReply(string(s.reply)); ok {
+			s.replied = true
+			if scheme != s.scheme {
+				s.scheme = scheme
+				s.changed(scheme)
+			}
+		}
+		return true
+	}
+
+	if ev.Ch == 0 || len(s.reply) >= oscReplyMaxLen {
+		s.parsing = false
+		return false
+	}
+	s.reply = append(s.reply, ev.Ch)
+	return true
+}
+
+// changed switches the theme and calls the callback after a color
+// scheme change. It is called with the mutex locked
+func (s *schemeWatcher) changed(scheme ColorScheme) {
+	if name := s.themes[scheme]; name != "" {
+		SetCurrentTheme(name)
+		if loop != nil {
+			PutEvent(Event{Type: EventRedraw})
+		}
+	}
+	if s.onChange != nil {
+		go s.onChange(scheme)
+	}
+}
@@ -0,0 +1,110 @@
+package clui
+
+import (
+	term "github.com/nsf/termbox-go"
+	"testing"
+	"time"
+)
+
+func TestColorSchemeDetection(t *testing.T) {
+	fgbg := []struct {
+		value  string
+		scheme ColorScheme
+		ok     bool
+	}{
+		{"15;0", ColorSchemeDark, true},
+		{"0;15", ColorSchemeLight, true},
+		{"0;default;7", ColorSchemeLight, true},
+		{"7;8", ColorSchemeDark, true},
+		{"15;default", ColorSchemeDark, false},
+		{"", ColorSchemeDark, false},
+	}
+	for _, c := range fgbg {
+		if scheme, ok := schemeFromColorFGBG(c.value); scheme != c.scheme || ok != c.ok {
+			t.Errorf("COLORFGBG %q: got %v/%v", c.value, scheme, ok)
+		}
+	}
+
+	osc := []struct {
+		reply  string
+		scheme ColorScheme
+		ok     bool
+	}{
+		{"11;rgb:ffff/ffff/dddd", ColorSchemeLight, true},
+		{"11;rgb:1e1e/1e1e/1e1e", ColorSchemeDark, true},
+		{"11;rgb:f/f/f", ColorSchemeLight, true},
+		{"11;rgb:ffff/ffff", ColorSchemeDark, false},
+		{"11;?", ColorSchemeDark, false},
+	}
+	for _, c := range osc {
+		if scheme, ok := schemeFromOSCReply(c.reply); scheme != c.scheme || ok != c.ok {
+			t.Errorf("Reply %q: got %v/%v", c.reply, scheme, ok)
+		}
+	}
+}
+
+func TestColorSchemeReply(t *testing.T) {
+	initThemeManager()
+	defer ThemeReset()
+	AddTheme("light", Theme{})
+
+	s := new(schemeWatcher)
+	s.themes = [2]string{"", "light"}
+	changed := make(chan ColorScheme, 1)
+	s.onChange = func(scheme ColorScheme) { changed <- scheme }
+
+	if s.feed(Event{Type: EventKey, Mod: term.ModAlt, Ch: ']'}) {
+		t.Error("Keys must not be consumed without a query")
+	}
+
+	s.deadline = time.Now().Add(oscReplyTimeout)
+	events := []Event{{Type: EventKey, Mod: term.ModAlt, Ch: ']'}}
+	for _, r := range "11;rgb:ffff/ffff/ffff" {
+		events = append(events, Event{Type: EventKey, Ch: r})
+	}
+	events = append(events, Event{Type: EventKey, Key: term.KeyCtrlG})
+	for _, ev := range events {
+		if !s.feed(ev) {
+			t.Fatalf("Reply event %v must be consumed", ev)
+		}
+	}
+
+	if scheme := <-changed; scheme != ColorSchemeLight {
+		t.Errorf("Light scheme expected, got %v", scheme)
+	}
+	if CurrentTheme() != "light" {
+		t.Errorf("Light theme must be selected, got %v", CurrentTheme())
+	}
+	if s.feed(Event{Type: EventKey, Ch: 'a'}) {
+		t.Error("Keys after the reply must not be consumed")
+	}
+}
+
+func TestColorSchemeNoReply(t *testing.T) {
+	s := new(schemeWatcher)
+	altBracket := Event{Type: EventKey, Mod: term.ModAlt, Ch: ']'}
+
+	s.deadline = time.Now().Add(oscReplyTimeout)
+	if s.feed(Event{Type: EventKey, Ch: 'a'}) {
+		t.Error("A key that does not start the reply must not be consumed")
+	}
+	if s.feed(altBracket) {
+		t.Error("The query must be dropped after the first key")
+	}
+
+	s.deadline = time.Now().Add(-time.Millisecond)
+	if s.feed(altBracket) {
+		t.Error("Keys after the reply timeout must not be consumed")
+	}
+
+	s.deadline = time.Now().Add(oscReplyTimeout)
+	if !s.feed(altBracket) {
+		t.Fatal("The reply start must be consumed")
+	}
+	if s.feed(Event{Type: EventKey, Key: term.KeyArrowUp}) {
+		t.Error("A non-character key must abort the reply")
+	}
+	if s.feed(altBracket) {
+		t.Error("The query must be dropped after the reply is aborted")
+	}
+}
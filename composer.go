@@ -2,6 +2,7 @@ package clui
 
 import (
 	term "github.com/nsf/termbox-go"
+	"time"
 )
 
 // Composer is a service object that manages Views and console, processes
@@ -11,6 +12,23 @@ type Composer struct {
 	// list of visible Views
 	windows  []Control
 	consumer Control
+	// floating panels(e.g, dropdown menus) that are drawn above
+	// all Views
+	overlays []Control
+	// the control under mouse cursor that has a tooltip, the timer to
+	// display the tooltip, and the displayed tooltip. hoverSeq changes
+	// every time the mouse leaves the control to skip outdated timers
+	hover      Control
+	hoverSeq   int
+	hoverTimer *time.Timer
+	tooltip    *tooltipBox
+	// the innermost control under mouse cursor
+	mouseOver Control
+	// the control that gets drag events while the left mouse
+	// button is down, and the last position of the cursor
+	dragCtrl             Control
+	dragStarted          bool
+	dragLastX, dragLastY int
 	// last pressed key - to make repeatable actions simpler, e.g, at first
 	// one presses Ctrl+S and then just repeatedly presses arrow lest to
 	// resize Window
@@ -31,6 +49,7 @@ var (
 func initComposer() {
 	comp = new(Composer)
 	comp.windows = make([]Control, 0)
+	comp.overlays = make([]Control, 0)
 	comp.consumer = nil
 	comp.lastKey = term.KeyEsc
 }
@@ -62,7 +81,7 @@ func termboxEventToLocal(ev term.Event) Event {
 
 // Repaints everything on the screen
 func RefreshScreen() {
-	term.Clear(ColorWhite, ColorBlack)
+	clearScreen()
 
 	for _, wnd := range comp.windows {
 		v := comp.topWindow().(*Window)
@@ -71,7 +90,76 @@ func RefreshScreen() {
 		}
 	}
 
-	term.Flush()
+	for _, ov := range comp.overlays {
+		ov.Draw()
+	}
+
+	Flush()
+	ClearDirty()
+}
+
+// RefreshDirty repaints only the screen cells marked with MarkDirty
+// and flushes them to the terminal. All other cells are not sent to
+// termbox. It is much faster than RefreshScreen for dashboards that
+// update a small part of the screen often
+func RefreshDirty() {
+	x, y, w, h, ok := canvas.dirty.bounds()
+	if !ok {
+		return
+	}
+
+	canvas.partial = true
+	PushAttributes()
+	SetTextColor(ColorWhite)
+	SetBackColor(ColorBlack)
+	FillRect(x, y, w, h, ' ')
+	PopAttributes()
+
+	for _, wnd := range comp.windows {
+		if v, ok := wnd.(*Window); ok && !v.Visible() {
+			continue
+		}
+		if intersects(wnd, x, y, w, h) {
+			wnd.Draw()
+		}
+	}
+	for _, ov := range comp.overlays {
+		if intersects(ov, x, y, w, h) {
+			ov.Draw()
+		}
+	}
+	canvas.partial = false
+
+	Flush()
+	ClearDirty()
+}
+
+// intersects returns true if the control overlaps the rectangle
+func intersects(ctrl Control, x, y, w, h int) bool {
+	cx, cy := ctrl.Pos()
+	cw, ch := ctrl.Size()
+	return cx < x+w && x < cx+cw && cy < y+h && y < cy+ch
+}
+
+// showOverlay adds the control to the overlay layer. Overlays are
+// drawn after all Views, so they are always on top of the screen
+func showOverlay(c Control) {
+	for _, ov := range comp.overlays {
+		if ov == c {
+			return
+		}
+	}
+	comp.overlays = append(comp.overlays, c)
+}
+
+// hideOverlay removes the control from the overlay layer
+func hideOverlay(c Control) {
+	for idx, ov := range comp.overlays {
+		if ov == c {
+			comp.overlays = append(comp.overlays[:idx], comp.overlays[idx+1:]...)
+			return
+		}
+	}
 }
 
 // AddWindow constucts a new Window, adds it to the composer automatically,
@@ -92,6 +180,17 @@ func AddWindow(posX, posY, width, height int, title string) *Window {
 	return window
 }
 
+// ActivateWindow makes the Window the top one, so it receives keyboard
+// events. Returns false if the Window is not added to the composer or
+// the top Window is modal
+func ActivateWindow(window Control) bool {
+	top := comp.topWindow()
+	if top != nil && top != window && top.Modal() {
+		return false
+	}
+	return comp.activateWindow(window)
+}
+
 func (c *Composer) checkWindowUnderMouse(screenX, screenY int) (Control, HitResult) {
 	if len(c.windows) == 0 {
 		return nil, HitOutside
@@ -239,7 +338,7 @@ func (c *Composer) moveTopWindow(ev Event) bool {
 			x, y := view.Pos()
 			w, h := view.Size()
 			x1, y1 := x, y
-			cx, cy := term.Size()
+			cx, cy := ScreenSize()
 			if ev.Key == term.KeyArrowUp && y > 0 {
 				y--
 			} else if ev.Key == term.KeyArrowDown && y+h < cy {
@@ -412,19 +511,44 @@ func (c *Composer) processWindowDrag(ev Event) {
 }
 
 func (c *Composer) processMouse(ev Event) {
+	if c.consumer == nil {
+		c.updateMouseOver(ev.X, ev.Y)
+	}
+	if ev.Key == term.MouseRelease && ev.Mod == term.ModMotion {
+		// the mouse moves without pressed buttons
+		if c.consumer == nil {
+			c.processHover(ev)
+		}
+		return
+	}
+	c.hideTooltip()
+
 	if c.consumer != nil {
 		tmp := c.consumer
 		tmp.ProcessEvent(ev)
 		tmp.Draw()
-		term.Flush()
+		Flush()
+		return
+	}
+
+	// floating windows are above all Views unless a View or its control
+	// is being dragged
+	if c.dragType == DragNone && c.dragCtrl == nil && c.processOverlayMouse(ev) {
 		return
 	}
 
 	view, hit := c.checkWindowUnderMouse(ev.X, ev.Y)
-	if c.dragType != DragNone {
+	if c.dragType != DragNone || c.dragCtrl != nil {
 		view = c.topWindow()
 	}
 
+	if c.topWindow() == view && c.processControlDrag(ev, hit) {
+		return
+	}
+	if c.topWindow() == view && c.processWheel(ev, hit) {
+		return
+	}
+
 	if c.topWindow() == view {
 		if ev.Key == term.MouseRelease && c.dragType != DragNone {
 			c.dragType = DragNone
@@ -533,7 +657,20 @@ func IsDeadKey(key term.Key) bool {
 	return false
 }
 
+func (c *Composer) hasShortcut(ev Event) bool {
+	if c.consumer != nil {
+		return false
+	}
+	wnd, ok := c.topWindow().(*Window)
+	return ok && wnd.shortcutFor(ev) != nil
+}
+
 func (c *Composer) processKey(ev Event) {
+	if schemes.feed(ev) {
+		return
+	}
+	c.hideTooltip()
+
 	if ev.Key == term.KeyEsc {
 		if IsDeadKey(c.lastKey) {
 			c.lastKey = term.KeyEsc
@@ -541,21 +678,26 @@ func (c *Composer) processKey(ev Event) {
 		}
 	}
 
-	if IsDeadKey(ev.Key) && !IsDeadKey(c.lastKey) {
+	// a registered shortcut overrides a hotkey sequence
+	// that starts with the same key
+	shortcut := !IsDeadKey(c.lastKey) && c.hasShortcut(ev)
+	if IsDeadKey(ev.Key) && !IsDeadKey(c.lastKey) && !shortcut {
 		c.lastKey = ev.Key
 		return
 	}
 
-	if !IsDeadKey(ev.Key) {
+	if !IsDeadKey(ev.Key) || shortcut {
 		if c.consumer != nil {
 			tmp := c.consumer
 			tmp.ProcessEvent(ev)
 			tmp.Draw()
-			term.Flush()
+			Flush()
+		} else if fw := c.activeOverlay(); fw != nil {
+			fw.ProcessEvent(ev)
 		} else {
 			c.sendEventToActiveWindow(ev)
 			c.topWindow().Draw()
-			term.Flush()
+			Flush()
 		}
 	}
 
@@ -613,17 +755,23 @@ func ProcessEvent(ev Event) {
 		comp.closeTopWindow()
 	case EventRedraw:
 		RefreshScreen()
+	case EventTooltip:
+		comp.showTooltip(ev.X)
+	case EventColorSchemeQuery:
+		schemes.query()
+	case EventCustom:
+		comp.deliverCustomEvents()
 	case EventResize:
 		SetScreenSize(ev.Width, ev.Height)
 		for _, c := range comp.windows {
 			wnd := c.(*Window)
-			if wnd.Maximized() {
-				wnd.SetSize(ev.Width, ev.Height)
-				wnd.ResizeChildren()
-				wnd.PlaceChildren()
-				RefreshScreen()
+			if wnd.AutoResize() {
+				wnd.fitScreen(ev.Width, ev.Height)
+			} else if wnd.fullscreen != nil {
+				wnd.fitFullscreen()
 			}
 		}
+		RefreshScreen()
 	case EventKey:
 		comp.processKey(ev)
 	case EventMouse:
@@ -21,7 +21,8 @@ const (
 
 // Predefined types
 type (
-	// BorderStyle is a kind of frame: none, single, and double
+	// BorderStyle is a kind of frame: none, single, double, rounded,
+	// and bold
 	BorderStyle int
 	// ViewButton is a set of buttons displayed in a view title
 	ViewButton int
@@ -46,7 +47,17 @@ type (
 	TableAction int
 	// SortOrder is a way of sorting rows in TableView
 	SortOrder int
-	DragType  int
+	// SparkRenderMode is a way of drawing bars in SparkChart
+	SparkRenderMode int
+	// AxisSide is a side of a chart that displays the value axis
+	AxisSide int
+	// GaugeStyle is a way of drawing GaugeChart
+	GaugeStyle int
+	// HeatMapColorScale is a built-in set of colors for HeatMap
+	HeatMapColorScale int
+	// DotMatrixStyle is a way of drawing DotMatrix dots
+	DotMatrixStyle int
+	DragType       int
 )
 
 const (
@@ -85,6 +96,30 @@ type Event struct {
 	// For resize event - new terminal size
 	Width  int
 	Height int
+	// delivery state shared by all copies of the event while it
+	// bubbles from a control to its parents
+	state *eventState
+}
+
+// eventState keeps the event delivery state
+type eventState struct {
+	stopped  bool
+	bubbling bool
+}
+
+// StopPropagation prevents the event from bubbling: parents of the
+// control that calls it do not get the event even if the control
+// ProcessEvent returns false
+func (ev Event) StopPropagation() {
+	if ev.state != nil {
+		ev.state.stopped = true
+	}
+}
+
+// PropagationStopped returns true if StopPropagation was called for
+// the event
+func (ev Event) PropagationStopped() bool {
+	return ev.state != nil && ev.state.stopped
 }
 
 // BorderStyle constants
@@ -92,6 +127,10 @@ const (
 	BorderNone BorderStyle = iota
 	BorderThin
 	BorderThick
+	// BorderRounded - single line frame with rounded corners
+	BorderRounded
+	// BorderBold - heavy single line frame
+	BorderBold
 )
 
 // Color predefined values
@@ -131,6 +170,7 @@ const (
 	HitButtonClose
 	HitButtonBottom
 	HitButtonMaximize
+	HitButtonMinimize
 )
 
 // VeiwButton values - list of buttons available for using in View title
@@ -143,6 +183,8 @@ const (
 	ButtonBottom = 1 << 1
 	// ButtonMaximaize - maximize and restore View
 	ButtonMaximize = 1 << 2
+	// ButtonMinimize - collapse DraggableWindow to its title bar and restore it
+	ButtonMinimize = 1 << 3
 )
 
 // Alignment constants
@@ -162,9 +204,11 @@ const (
 
 // Available object identifiers that can be used in themes
 const (
-	ObjSingleBorder = "SingleBorder"
-	ObjDoubleBorder = "DoubleBorder"
-	ObjEdit         = "Edit"
+	ObjSingleBorder  = "SingleBorder"
+	ObjDoubleBorder  = "DoubleBorder"
+	ObjRoundedBorder = "RoundedBorder"
+	ObjBoldBorder    = "BoldBorder"
+	ObjEdit          = "Edit"
 	ObjScrollBar    = "ScrollBar"
 	ObjViewButtons  = "ViewButtons"
 	ObjCheckBox     = "CheckBox"
@@ -173,6 +217,21 @@ const (
 	ObjBarChart     = "BarChart"
 	ObjSparkChart   = "SparkChart"
 	ObjTableView    = "TableView"
+
+	ObjSparkChartThreshold = "SparkChartThreshold"
+	ObjSparkChartMA        = "SparkChartMA"
+	ObjLineChart           = "LineChart"
+	ObjAreaChart           = "AreaChart"
+	ObjGauge               = "Gauge"
+	ObjHistogram           = "Histogram"
+	ObjTreeView            = "TreeView"
+	ObjListBox             = "ListBox"
+	ObjSlider              = "Slider"
+	ObjToggle              = "Toggle"
+	ObjSplitPane           = "SplitPane"
+	ObjDraggableButtons    = "DraggableButtons"
+	ObjProgressBarFill     = "ProgressBarFill"
+	ObjProgressBarEmpty    = "ProgressBarEmpty"
 )
 
 // Available color identifiers that can be used in themes
@@ -188,12 +247,20 @@ const (
 	ColorDisabledBack = "GrayBack"
 
 	// editable & listbox-like controls
-	ColorEditBack       = "EditBack"
-	ColorEditText       = "EditText"
-	ColorEditActiveBack = "EditActiveBack"
-	ColorEditActiveText = "EditActiveText"
-	ColorSelectionText  = "SelectionText"
-	ColorSelectionBack  = "SelectionBack"
+	ColorEditBack              = "EditBack"
+	ColorEditText              = "EditText"
+	ColorEditActiveBack        = "EditActiveBack"
+	ColorEditActiveText        = "EditActiveText"
+	ColorEditPlaceholder       = "EditPlaceholder"
+	ColorEditActivePlaceholder = "EditActivePlaceholder"
+	ColorEditInvalidBack       = "EditInvalidBack"
+	ColorSelectionText         = "SelectionText"
+	ColorSelectionBack         = "SelectionBack"
+	ColorFilterMatchText       = "FilterMatchText"
+	ColorFilterBarText         = "FilterBarText"
+	ColorFilterBarBack         = "FilterBarBack"
+	ColorListGroupText         = "ListGroupText"
+	ColorListGroupBack         = "ListGroupBack"
 
 	// button control
 	ColorButtonBack         = "ButtonBack"
@@ -225,18 +292,166 @@ const (
 	ColorProgressActiveBack = "ProgressActiveBack"
 	ColorProgressActiveText = "ProgressActiveText"
 	ColorProgressTitleText  = "ProgressTitle"
+	ColorProgressBuffer     = "ProgressBufferText"
+
+	// slider colors
+	ColorSliderBack       = "SliderBack"
+	ColorSliderText       = "SliderText"
+	ColorSliderActiveBack = "SliderActiveBack"
+	ColorSliderActiveText = "SliderActiveText"
+	ColorSliderThumb      = "SliderThumbText"
+
+	// toggle colors
+	ColorToggleOnText  = "ToggleOnText"
+	ColorToggleOnBack  = "ToggleOnBack"
+	ColorToggleOffText = "ToggleOffText"
+	ColorToggleOffBack = "ToggleOffBack"
+
+	// SplitPane divider colors, active ones are used while dragging
+	ColorSplitPaneText       = "SplitPaneText"
+	ColorSplitPaneBack       = "SplitPaneBack"
+	ColorSplitPaneActiveText = "SplitPaneActiveText"
+	ColorSplitPaneActiveBack = "SplitPaneActiveBack"
+
+	// spinner colors
+	ColorSpinnerText = "SpinnerText"
+
+	// status bar colors
+	ColorStatusBarText = "StatusBarText"
+	ColorStatusBarBack = "StatusBarBack"
+
+	// toolbar colors
+	ColorToolbarText         = "ToolbarText"
+	ColorToolbarBack         = "ToolbarBack"
+	ColorToolbarActiveText   = "ToolbarActiveText"
+	ColorToolbarActiveBack   = "ToolbarActiveBack"
+	ColorToolbarDisabledText = "ToolbarDisabledText"
+
+	// menu colors
+	ColorMenuText         = "MenuText"
+	ColorMenuBack         = "MenuBack"
+	ColorMenuActiveText   = "MenuActiveText"
+	ColorMenuActiveBack   = "MenuActiveBack"
+	ColorMenuDisabledText = "MenuDisabledText"
+
+	// tooltip colors
+	ColorTooltipText = "TooltipText"
+	ColorTooltipBack = "TooltipBack"
+
+	// modal dialog colors
+	ColorModalBackdrop = "ModalBackdropBack"
+
+	// notification colors
+	ColorNotificationInfoText    = "NotificationInfoText"
+	ColorNotificationInfoBack    = "NotificationInfoBack"
+	ColorNotificationWarningText = "NotificationWarningText"
+	ColorNotificationWarningBack = "NotificationWarningBack"
+	ColorNotificationErrorText   = "NotificationErrorText"
+	ColorNotificationErrorBack   = "NotificationErrorBack"
 
 	// barchart colors
-	ColorBarChartBack = "BarChartBack"
-	ColorBarChartText = "BarChartText"
+	ColorBarChartBack         = "BarChartBack"
+	ColorBarChartText         = "BarChartText"
+	ColorBarChartSelectedBack = "BarChartSelectedBack"
+	ColorBarChartSelectedText = "BarChartSelectedText"
 
 	// sparkchart colors
-	ColorSparkChartBack    = "SparkChartBack"
-	ColorSparkChartText    = "SparkChartText"
-	ColorSparkChartBarBack = "SparkChartBarBack"
-	ColorSparkChartBarText = "SparkChartBarText"
-	ColorSparkChartMaxBack = "SparkChartMaxBack"
-	ColorSparkChartMaxText = "SparkChartMaxText"
+	ColorSparkChartBack      = "SparkChartBack"
+	ColorSparkChartText      = "SparkChartText"
+	ColorSparkChartBarBack   = "SparkChartBarBack"
+	ColorSparkChartBarText   = "SparkChartBarText"
+	ColorSparkChartMaxBack   = "SparkChartMaxBack"
+	ColorSparkChartMaxText   = "SparkChartMaxText"
+	ColorSparkChartNegBack   = "SparkChartNegBack"
+	ColorSparkChartNegText   = "SparkChartNegText"
+	ColorSparkChartMinBack   = "SparkChartMinBack"
+	ColorSparkChartMinText   = "SparkChartMinText"
+	ColorSparkChartOverBack  = "SparkChartOverBack"
+	ColorSparkChartOverText  = "SparkChartOverText"
+	ColorSparkChartStatsBack = "SparkChartStatsBack"
+	ColorSparkChartStatsText = "SparkChartStatsText"
+	ColorSparkChartMABack    = "SparkChartMABack"
+	ColorSparkChartMAText    = "SparkChartMAText"
+
+	// linechart colors
+	ColorLineChartBack = "LineChartBack"
+	ColorLineChartText = "LineChartText"
+
+	// areachart colors
+	ColorAreaChartBack = "AreaChartBack"
+	ColorAreaChartText = "AreaChartText"
+
+	// gaugechart colors
+	ColorGaugeBack   = "GaugeBack"
+	ColorGaugeText   = "GaugeText"
+	ColorGaugeNormal = "GaugeNormal"
+	ColorGaugeWarn   = "GaugeWarn"
+	ColorGaugeCrit   = "GaugeCrit"
+
+	// histogramchart colors
+	ColorHistogramBack      = "HistogramBack"
+	ColorHistogramText      = "HistogramText"
+	ColorHistogramBarBack   = "HistogramBarBack"
+	ColorHistogramBarText   = "HistogramBarText"
+	ColorHistogramCDFBack   = "HistogramCDFBack"
+	ColorHistogramCDFText   = "HistogramCDFText"
+	ColorHistogramStatsBack = "HistogramStatsBack"
+	ColorHistogramStatsText = "HistogramStatsText"
+
+	// heatmap colors
+	ColorHeatMapBack     = "HeatMapBack"
+	ColorHeatMapText     = "HeatMapText"
+	ColorHeatMapCellBack = "HeatMapCellBack"
+
+	// waterfall chart colors
+	ColorWaterfallBack  = "WaterfallBack"
+	ColorWaterfallText  = "WaterfallText"
+	ColorWaterfallUp    = "WaterfallUp"
+	ColorWaterfallDown  = "WaterfallDown"
+	ColorWaterfallTotal = "WaterfallTotal"
+
+	// dot matrix colors
+	ColorDotMatrixOnText  = "DotMatrixOnText"
+	ColorDotMatrixOnBack  = "DotMatrixOnBack"
+	ColorDotMatrixOffText = "DotMatrixOffText"
+	ColorDotMatrixOffBack = "DotMatrixOffBack"
+
+	// VU meter colors
+	ColorVUMeterBack   = "VUMeterBack"
+	ColorVUMeterText   = "VUMeterText"
+	ColorVUMeterNormal = "VUMeterNormal"
+	ColorVUMeterHigh   = "VUMeterHigh"
+	ColorVUMeterClip   = "VUMeterClip"
+
+	// scatter plot colors
+	ColorScatterPlotBack  = "ScatterPlotBack"
+	ColorScatterPlotText  = "ScatterPlotText"
+	ColorScatterPlotPoint = "ScatterPlotPoint"
+
+	// candlestick chart colors
+	ColorCandlestickBack = "CandlestickBack"
+	ColorCandlestickText = "CandlestickText"
+	ColorCandlestickBull = "CandlestickBull"
+	ColorCandlestickBear = "CandlestickBear"
+	ColorCandlestickMA   = "CandlestickMA"
+
+	// process list colors
+	ColorProcessListNormal = "ProcessListNormal"
+	ColorProcessListWarn   = "ProcessListWarn"
+	ColorProcessListCrit   = "ProcessListCrit"
+
+	// hex dump colors
+	ColorHexDumpBack          = "HexDumpBack"
+	ColorHexDumpText          = "HexDumpText"
+	ColorHexDumpOffset        = "HexDumpOffset"
+	ColorHexDumpHighlightBack = "HexDumpHighlightBack"
+	ColorHexDumpHighlightText = "HexDumpHighlightText"
+
+	// timeline colors
+	ColorTimelineBack = "TimelineBack"
+	ColorTimelineText = "TimelineText"
+	ColorTimelineTask = "TimelineTask"
+	ColorTimelineNow  = "TimelineNow"
 
 	// tableview colors
 	ColorTableText           = "TableText"
@@ -248,6 +463,12 @@ const (
 	ColorTableLineText       = "TableLineText"
 	ColorTableHeaderText     = "TableHeaderText"
 	ColorTableHeaderBack     = "TableHeaderBack"
+
+	// datagrid colors
+	ColorDataGridRowText    = "DataGridRowText"
+	ColorDataGridRowBack    = "DataGridRowBack"
+	ColorDataGridAltRowText = "DataGridAltRowText"
+	ColorDataGridAltRowBack = "DataGridAltRowBack"
 )
 
 // EventType is event that window or control may process
@@ -288,6 +509,15 @@ const (
 	EventQuit
     // Close top window - or application is there is only one window
     EventCloseWindow
+	// Show the tooltip of the control under mouse cursor. X is the hover
+	// sequence number. The event is used by the library internally
+	EventTooltip
+	// Ask the terminal for its background color. The event is used by
+	// the library internally
+	EventColorSchemeQuery
+	// Deliver custom events posted with Window.PostEvent. The event is
+	// used by the library internally
+	EventCustom
 )
 
 // ConfirmationDialog and SelectDialog exit codes
@@ -340,3 +570,56 @@ const (
 	// Sort descending
 	SortDesc
 )
+
+// SparkRenderMode constants
+const (
+	// Every bar is a column of block characters, vertical resolution
+	// equals the chart height
+	SparkRenderBlock SparkRenderMode = iota
+	// Bars are drawn with braille characters: every character cell
+	// displays two bars and vertical resolution is four times the
+	// chart height
+	SparkRenderBraille
+)
+
+// AxisSide constants
+const (
+	// The value axis is on the left side of the chart
+	AxisLeft AxisSide = iota
+	// The value axis is on the right side of the chart
+	AxisRight
+)
+
+// GaugeChart styles
+const (
+	// The value is displayed as a horizontal bar
+	GaugeBar GaugeStyle = iota
+	// The value is displayed as a half of a ring
+	GaugeArc
+)
+
+// HeatMap built-in color scales
+const (
+	// Black and white scale
+	HeatMapGrayscale HeatMapColorScale = iota
+	// Colors from blue to red and magenta
+	HeatMapRainbow
+	// Colors from black to red, yellow, and white
+	HeatMapHeat
+)
+
+// DotMatrix drawing styles
+const (
+	// DotMatrixBlock - quadrant block characters, 2x2 dots per character
+	DotMatrixBlock DotMatrixStyle = iota
+	// DotMatrixBraille - Braille patterns, 2x4 dots per character
+	DotMatrixBraille
+)
+
+// ProcessList columns
+const (
+	ProcessColumnPID = iota
+	ProcessColumnCPU
+	ProcessColumnMem
+	ProcessColumnName
+)
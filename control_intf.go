@@ -35,6 +35,22 @@ type Control interface {
 	// controls using TAB key
 	TabStop() bool
 	SetTabStop(tabstop bool)
+	// TabOrder returns the position of a control in the TAB key cycle.
+	// Controls with lower order get focus first, controls with the same
+	// order get focus in the order they were added to their parents.
+	// Default order is 0
+	TabOrder() int
+	SetTabOrder(order int)
+	// SkipFocus returns if a control is excluded from the TAB key cycle
+	// regardless of its TabStop value, e.g, a helper widget created by
+	// another control
+	SkipFocus() bool
+	SetSkipFocus(skip bool)
+	// FocusGroup returns the name of the focus group of a control. TAB
+	// key moves the focus only between controls of the same group.
+	// Controls without a group belong to the group of their parent
+	FocusGroup() string
+	SetFocusGroup(name string)
 	// Enable return if a control can process keyboard and mouse events
 	Enabled() bool
 	SetEnabled(enabled bool)
@@ -49,6 +65,27 @@ type Control interface {
 	// controls it does nothing
 	Modal() bool
 	SetModal(modal bool)
+	// Tooltip returns the text displayed when the mouse cursor hovers
+	// over the control for a while. Empty text means no tooltip
+	Tooltip() string
+	SetTooltip(text string)
+	// IsFullscreen returns if a control temporarily occupies the whole
+	// screen instead of its place in the Window
+	IsFullscreen() bool
+	SetFullscreen(full bool)
+	// Visible returns if a control is displayed. Hidden control is not
+	// drawn, does not get focus and events, and takes no space in its
+	// parent layout
+	Visible() bool
+	SetVisible(visible bool)
+	// ID returns the identifier of a control that style sheet selectors
+	// use to find the control
+	ID() string
+	SetID(id string)
+	// AccessibleLabel returns the text announced by screen readers when
+	// a control gets focus
+	AccessibleLabel() string
+	SetAccessibleLabel(label string)
 	// Paddings returns a number of spaces used to auto-arrange children inside
 	// a container: indent from left and right sides, indent from top and bottom
 	// sides.
@@ -145,3 +182,19 @@ type Control interface {
 	// the event to the control parent
 	ProcessEvent(ev Event) bool
 }
+
+// tabConsumer is implemented by controls that move the focus between
+// their own parts with Tab, e.g Toolbar. Window calls nextTabStop
+// before activating the next control. The method returns false if
+// the focus should leave the control
+type tabConsumer interface {
+	nextTabStop(forward bool) bool
+}
+
+// hotKeyHandler is implemented by controls that process Alt+character
+// keys for the whole Window, e.g MenuBar. Window calls processHotKey
+// before sending a key to the active control. The method returns
+// false if the key is not a hotkey of the control
+type hotKeyHandler interface {
+	processHotKey(ev Event) bool
+}
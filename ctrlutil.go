@@ -2,6 +2,7 @@ package clui
 
 import (
 	term "github.com/nsf/termbox-go"
+	"sort"
 )
 
 // ThumbPosition returns a scrollbar thumb position depending
@@ -71,6 +72,9 @@ func ChildAt(parent Control, x, y int) Control {
 	var ctrl Control
 	ctrl = parent
 	for _, child := range parent.Children() {
+		if !child.Visible() {
+			continue
+		}
 		check := ChildAt(child, x, y)
 		if check != nil {
 			ctrl = check
@@ -104,6 +108,7 @@ func ActivateControl(parent, control Control) bool {
 		if !ctrl.Active() {
 			ctrl.ProcessEvent(Event{Type: EventActivate, X: 1})
 			ctrl.SetActive(true)
+			announceFocus(ctrl)
 		}
 	}
 
@@ -181,88 +186,119 @@ func FindLastControl(parent Control, fn func(Control) bool) Control {
 	return last
 }
 
+// ValidateChildren validates all EditFields inside the parent
+// and returns true if all of them are valid. All fields are
+// validated, so every invalid field displays its error
+func ValidateChildren(parent Control) bool {
+	valid := true
+	for _, child := range parent.Children() {
+		if edit, ok := child.(*EditField); ok && edit.Validate() != nil {
+			valid = false
+		}
+
+		if !ValidateChildren(child) {
+			valid = false
+		}
+	}
+
+	return valid
+}
+
 // ActiveControl returns the active child of the parent or nil if no child is
 // active
 func ActiveControl(parent Control) Control {
 	fnActive := func(c Control) bool {
-		return c.Active()
+		return c.Active() && shown(c)
 	}
 	return FindFirstControl(parent, fnActive)
 }
 
-func _nextControl(parent Control, curr, prev Control, foundPrev, next bool) (bool, Control) {
-	found := foundPrev
-	if parent == curr {
-		if next {
-			found = true
-		} else {
-			return false, prev
+// shown returns true if the control and all its parents are visible
+func shown(ctrl Control) bool {
+	for ; ctrl != nil; ctrl = ctrl.Parent() {
+		if !ctrl.Visible() {
+			return false
 		}
 	}
+	return true
+}
 
-	p := prev
-	for _, ctrl := range parent.Children() {
-		if ctrl == curr {
-			if next {
-				found = true
+// focusControls returns all children of the parent that take part in
+// the TAB key cycle, sorted by their tab order
+func focusControls(parent Control) []Control {
+	var list []Control
+	var collect func(Control)
+	collect = func(p Control) {
+		for _, ctrl := range p.Children() {
+			if !ctrl.Visible() {
 				continue
-			} else {
-				return found, p
 			}
-		}
-
-		if ctrl.Enabled() && ctrl.TabStop() {
-			if found {
-				return found, ctrl
-			} else if !next {
-				p = ctrl
+			if ctrl.Enabled() && ctrl.TabStop() && !ctrl.SkipFocus() {
+				list = append(list, ctrl)
 			}
-		}
-
-		fnd, nn := _nextControl(ctrl, curr, p, found, next)
-		if nn != nil {
-			return fnd, nn
+			collect(ctrl)
 		}
 	}
+	collect(parent)
 
-	return found, nil
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].TabOrder() < list[j].TabOrder()
+	})
+	return list
 }
 
 // NextControl returns the next or previous child (depends on next parameter)
-// that has tab-stop feature on. Used by library when processing TAB key
+// that has tab-stop feature on. Used by library when processing TAB key.
+// Children are traversed by their tab order, and the controls with the
+// same order are traversed in the order they were added. If curr belongs
+// to a focus group, only controls of the same group are traversed
 func NextControl(parent Control, curr Control, next bool) Control {
-	fnTab := func(c Control) bool {
-		return c.TabStop()
-	}
-
-	var defControl Control
-	if next {
-		defControl = FindFirstControl(parent, fnTab)
-	} else {
-		defControl = FindLastControl(parent, fnTab)
+	list := focusControls(parent)
+	if curr != nil {
+		group := focusGroup(curr)
+		var inGroup []Control
+		for _, ctrl := range list {
+			if focusGroup(ctrl) == group {
+				inGroup = append(inGroup, ctrl)
+			}
+		}
+		list = inGroup
 	}
-
-	if defControl == nil {
+	if len(list) == 0 {
 		return nil
 	}
-	if curr == nil {
-		return defControl
-	}
 
-	_, cNext := _nextControl(parent, curr, nil, false, next)
-	if cNext == nil {
-		cNext = defControl
+	for idx, ctrl := range list {
+		if ctrl != curr {
+			continue
+		}
+		if next {
+			return list[(idx+1)%len(list)]
+		}
+		return list[(idx+len(list)-1)%len(list)]
 	}
 
-	return cNext
+	if next {
+		return list[0]
+	}
+	return list[len(list)-1]
 }
 
 // SendEventToChild tries to find a child control that should recieve the evetn
 // For mouse click events it looks for a control at coordinates of event,
 // makes it active, and then sends the event to it.
 // If it is not mouse click event then it looks for the first active child and
-// sends the event to it if it is not nil
+// sends the event to it if it is not nil.
+// Keyboard events bubble: if the active child does not process the event,
+// the event is sent to the child parent, and so on up to the parent (the
+// parent itself does not get the event). A control can call StopPropagation
+// to prevent it, in this case the event is considered processed
 func SendEventToChild(parent Control, ev Event) bool {
+	// the parent got the event from its child - do not send it back
+	if ev.state != nil && ev.state.bubbling {
+		return false
+	}
+
 	var child Control
 	if IsMouseClickEvent(ev) {
 		child = ChildAt(parent, ev.X, ev.Y)
@@ -274,7 +310,24 @@ func SendEventToChild(parent Control, ev Event) bool {
 	}
 
 	if child != nil && child != parent {
-		return child.ProcessEvent(ev)
+		if ev.Type != EventKey {
+			return child.ProcessEvent(ev)
+		}
+		return bubbleEvent(parent, child, ev)
+	}
+
+	return false
+}
+
+// bubbleEvent sends the event to the control and then to its parents
+// until one of them processes the event or the root is reached
+func bubbleEvent(root, ctrl Control, ev Event) bool {
+	ev.state = new(eventState)
+	for c := ctrl; c != nil && c != root; c = c.Parent() {
+		if c.ProcessEvent(ev) || ev.state.stopped {
+			return true
+		}
+		ev.state.bubbling = true
 	}
 
 	return false
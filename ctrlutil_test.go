@@ -0,0 +1,179 @@
+package clui
+
+import (
+	term "github.com/nsf/termbox-go"
+	"testing"
+)
+
+func TestEventBubbling(t *testing.T) {
+	wnd := CreateWindow(0, 0, 30, 12, "Test")
+	outer := CreateModalFrame(20, 8, "")
+	wnd.AddChild(outer)
+	outer.SetParent(wnd)
+	inner := CreateModalFrame(10, 6, "")
+	outer.AddChild(inner)
+	inner.SetParent(outer)
+	btn := CreateButton(inner, 6, AutoSize, "Ok", Fixed)
+	ActivateControl(wnd, btn)
+
+	var outerKeys, innerKeys []term.Key
+	inner.OnKeyDown(func(ev Event) bool {
+		innerKeys = append(innerKeys, ev.Key)
+		if ev.Key == term.KeyArrowUp {
+			ev.StopPropagation()
+		}
+		return false
+	})
+	outer.OnKeyDown(func(ev Event) bool {
+		outerKeys = append(outerKeys, ev.Key)
+		return ev.Key == term.KeyArrowDown
+	})
+
+	if !wnd.ProcessEvent(Event{Type: EventKey, Key: term.KeyArrowDown}) {
+		t.Error("The event must be processed by the outer frame")
+	}
+	if len(innerKeys) != 1 || len(outerKeys) != 1 {
+		t.Errorf("The event must bubble through all parents: %v %v", innerKeys, outerKeys)
+	}
+
+	if !wnd.ProcessEvent(Event{Type: EventKey, Key: term.KeyArrowUp}) {
+		t.Error("Stopped event must be considered processed")
+	}
+	if len(innerKeys) != 2 || len(outerKeys) != 1 {
+		t.Errorf("Stopped event must not reach the outer frame: %v %v", innerKeys, outerKeys)
+	}
+}
+
+func TestTabOrder(t *testing.T) {
+	wnd := CreateWindow(0, 0, 30, 10, "Test")
+	bottom := CreateEditField(wnd, 5, "", Fixed)
+	frame := CreateFrame(wnd, 10, 3, BorderNone, Fixed)
+	top := CreateEditField(frame, 5, "", Fixed)
+	middle := CreateEditField(wnd, 5, "", Fixed)
+	helper := CreateEditField(wnd, 5, "", Fixed)
+	bottom.SetTabOrder(2)
+	middle.SetTabOrder(1)
+	helper.SetTabOrder(1)
+	helper.SetSkipFocus(true)
+
+	want := []Control{top, middle, bottom, top}
+	var curr Control
+	for i, ctrl := range want {
+		curr = NextControl(wnd, curr, true)
+		if curr != ctrl {
+			t.Errorf("%v. Invalid next control", i)
+		}
+	}
+	if NextControl(wnd, top, false) != bottom {
+		t.Error("Previous control must wrap to the last one")
+	}
+}
+
+func TestFocusGroups(t *testing.T) {
+	wnd := CreateWindow(0, 0, 40, 10, "Test")
+	left := CreateFrame(wnd, 10, 3, BorderNone, Fixed)
+	left.SetFocusGroup("left")
+	l1 := CreateEditField(left, 5, "", Fixed)
+	l2 := CreateEditField(left, 5, "", Fixed)
+	right := CreateFrame(wnd, 10, 3, BorderNone, Fixed)
+	right.SetFocusGroup("right")
+	r1 := CreateEditField(right, 5, "", Fixed)
+	r2 := CreateEditField(right, 5, "", Fixed)
+	ActivateControl(wnd, l1)
+
+	if NextControl(wnd, l2, true) != l1 {
+		t.Error("TAB must cycle inside the group")
+	}
+
+	nav := KeyCombo{Key: term.KeyCtrlN}
+	wnd.SetGroupNavigation("left", "right", nav)
+	wnd.SetGroupNavigation("right", "left", nav)
+	wnd.ProcessEvent(Event{Type: EventKey, Key: term.KeyCtrlN})
+	if ActiveControl(wnd) != r1 || l1.Active() {
+		t.Fatal("The key must move the focus to the first control of the group")
+	}
+	wnd.ProcessEvent(Event{Type: EventKey, Key: term.KeyTab})
+	if ActiveControl(wnd) != r2 {
+		t.Error("TAB must move the focus inside the new group")
+	}
+	wnd.ProcessEvent(Event{Type: EventKey, Key: term.KeyCtrlN})
+	if ActiveControl(wnd) != l1 {
+		t.Error("The key must move the focus back")
+	}
+}
+
+func TestFocusTrap(t *testing.T) {
+	wnd := CreateWindow(0, 0, 40, 12, "Test")
+	outside := CreateEditField(wnd, 5, "", Fixed)
+	frame := CreateModalFrame(20, 6, "")
+	wnd.AddChild(frame)
+	frame.SetParent(wnd)
+	first := CreateEditField(frame, 5, "", Fixed)
+	second := CreateEditField(frame, 5, "", Fixed)
+	wnd.PlaceChildren()
+	ActivateControl(wnd, outside)
+
+	closed := make(chan bool, 1)
+	frame.OnClose(func() { closed <- true })
+	wnd.PushFocusTrap(frame)
+	if ActiveControl(wnd) != first {
+		t.Fatal("The focus must move inside the trap")
+	}
+
+	tab := Event{Type: EventKey, Key: term.KeyTab}
+	wnd.ProcessEvent(tab)
+	wnd.ProcessEvent(tab)
+	if ActiveControl(wnd) != first || second.Active() {
+		t.Error("TAB must cycle inside the trap")
+	}
+
+	x, y := outside.Pos()
+	wnd.ProcessEvent(Event{Type: EventMouse, Key: term.MouseLeft, X: x, Y: y})
+	if ActiveControl(wnd) != first {
+		t.Error("Clicking outside the trap must not move the focus")
+	}
+
+	wnd.ProcessEvent(Event{Type: EventKey, Key: term.KeyEsc})
+	if len(wnd.traps) != 0 || ActiveControl(wnd) != outside {
+		t.Error("Escape must pop the trap and restore the focus")
+	}
+	if !<-closed {
+		t.Error("OnClose must be called")
+	}
+}
+
+func TestHiddenControl(t *testing.T) {
+	wnd := CreateWindow(0, 0, 30, 5, "Test")
+	wnd.SetPack(Horizontal)
+	wnd.SetGaps(0, 0)
+	left := CreateFrame(wnd, 5, 3, BorderNone, 1)
+	middle := CreateFrame(wnd, 5, 3, BorderNone, 1)
+	right := CreateFrame(wnd, 5, 3, BorderNone, 1)
+	wnd.ResizeChildren()
+	wnd.PlaceChildren()
+
+	middle.SetVisible(false)
+	if w, _ := left.Size(); w != 14 {
+		t.Errorf("Visible controls must take the space of the hidden one: %v", w)
+	}
+	if x, _ := right.Pos(); x != 15 {
+		t.Errorf("Invalid position of the control after the hidden one: %v", x)
+	}
+	if ChildAt(wnd, 12, 2) != left {
+		t.Error("Hidden control must not get mouse events")
+	}
+	ActivateControl(wnd, middle)
+	if ActiveControl(wnd) != nil {
+		t.Error("Hidden control must not be active")
+	}
+	for _, ctrl := range focusControls(wnd) {
+		if ctrl == middle {
+			t.Error("Hidden control must not get focus")
+		}
+	}
+
+	middle.SetVisible(true)
+	if x, _ := middle.Pos(); x != 10 || !middle.Visible() {
+		t.Errorf("Shown control must get its place back: %v", x)
+	}
+}
@@ -0,0 +1,364 @@
+package clui
+
+import (
+	term "github.com/nsf/termbox-go"
+	"sort"
+	"strconv"
+)
+
+// RowID is an identifier of a DataGrid row. The identifier
+// does not change when the rows are sorted
+type RowID int
+
+// ColumnID is an identifier of a DataGrid column
+type ColumnID int
+
+// gridRow is a row of DataGrid: its identifier and cell values
+type gridRow struct {
+	id    RowID
+	cells []string
+}
+
+/*
+DataGrid is a TableView that keeps its data. All TableView
+navigation hotkeys work in DataGrid as well, and the grid scrolls
+horizontally if the columns do not fit the control width.
+
+Clicking a column header, pressing Enter or F4 sorts rows by the
+selected column: the first time in ascending order, the second time
+in descending order, and the third time the original order of rows
+is restored. Values that are numbers are compared as numbers, all
+other values are compared as strings.
+
+Alt+Arrow Right and Alt+Arrow Left make the selected column wider
+and narrower respectively. Please note that terminals do not report
+Shift modifier for arrow keys, so Alt is used instead.
+
+Odd and even rows are displayed with different colors.
+
+Events:
+
+	OnRowSelect - called every time the selected row is changed.
+	    The argument is the identifier of the selected row
+	OnDrawCell, OnAction, OnSelectCell - the same as for TableView.
+	    OnDrawCell is called after the grid fills the cell text and
+	    colors, so the callback can customize any cell
+*/
+type DataGrid struct {
+	TableView
+	rows   []gridRow
+	nextID RowID
+
+	onRowSelect    func(RowID)
+	onUserDrawCell func(*ColumnDrawInfo)
+	onUserAction   func(TableEvent)
+	onUserSelect   func(int, int)
+}
+
+/*
+CreateDataGrid creates a new data grid.
+parent - is container that keeps the control.
+width and heigth - are minimal size of the control.
+scale - the way of scaling the control when the parent is resized. Use DoNotScale constant if the
+control should keep its original size.
+*/
+func CreateDataGrid(parent Control, width, height int, scale int) *DataGrid {
+	g := new(DataGrid)
+
+	if height == AutoSize {
+		height = 3
+	}
+	if width == AutoSize {
+		width = 10
+	}
+
+	g.SetSize(width, height)
+	g.SetConstraints(width, height)
+	g.selectedCol = 0
+	g.selectedRow = 0
+	g.parent = parent
+	g.columns = make([]Column, 0)
+	g.rows = make([]gridRow, 0)
+	g.SetScale(scale)
+
+	g.SetTabStop(true)
+
+	g.lastEventCol = -1
+	g.lastEventRow = -1
+	g.TableView.OnDrawCell(g.drawCell)
+	g.TableView.OnAction(g.processAction)
+	g.TableView.OnSelectCell(g.selectCell)
+	g.OnMouseScroll(func(delta int) {
+		g.scrollRows(delta * wheelScrollLines)
+	})
+
+	if parent != nil {
+		parent.AddChild(g)
+	}
+
+	return g
+}
+
+func (g *DataGrid) drawCell(info *ColumnDrawInfo) {
+	if info.Row >= 0 && info.Row < len(g.rows) {
+		cells := g.rows[info.Row].cells
+		if info.Col < len(cells) {
+			info.Text = cells[info.Col]
+		}
+	}
+
+	if !info.RowSelected && !info.CellSelected {
+		if info.Row%2 == 0 {
+			info.Fg, info.Bg = RealColor(g.fg, ColorDataGridRowText), RealColor(g.bg, ColorDataGridRowBack)
+		} else {
+			info.Fg, info.Bg = RealColor(g.fg, ColorDataGridAltRowText), RealColor(g.bg, ColorDataGridAltRowBack)
+		}
+	}
+
+	if g.onUserDrawCell != nil {
+		g.onUserDrawCell(info)
+	}
+}
+
+func (g *DataGrid) processAction(ev TableEvent) {
+	if ev.Action == TableActionSort && ev.Col >= 0 {
+		g.sortRows(ev.Col, ev.Sort)
+	}
+
+	if g.onUserAction != nil {
+		g.onUserAction(ev)
+	}
+}
+
+func (g *DataGrid) selectCell(col, row int) {
+	if g.onRowSelect != nil && row >= 0 && row < len(g.rows) {
+		go g.onRowSelect(g.rows[row].id)
+	}
+
+	if g.onUserSelect != nil {
+		g.onUserSelect(col, row)
+	}
+}
+
+// lessCell compares two cell values: as numbers if both values
+// are numbers, and as strings otherwise
+func lessCell(a, b string) bool {
+	fa, errA := strconv.ParseFloat(a, 64)
+	fb, errB := strconv.ParseFloat(b, 64)
+	if errA == nil && errB == nil {
+		return fa < fb
+	}
+	return a < b
+}
+
+// sortRows sorts rows by the column values. SortNone restores
+// the order in which rows were added
+func (g *DataGrid) sortRows(col int, order SortOrder) {
+	var selected RowID = -1
+	if g.selectedRow >= 0 && g.selectedRow < len(g.rows) {
+		selected = g.rows[g.selectedRow].id
+	}
+
+	cell := func(idx int) string {
+		if col < len(g.rows[idx].cells) {
+			return g.rows[idx].cells[col]
+		}
+		return ""
+	}
+
+	sort.SliceStable(g.rows, func(i, j int) bool {
+		switch order {
+		case SortAsc:
+			return lessCell(cell(i), cell(j))
+		case SortDesc:
+			return lessCell(cell(j), cell(i))
+		}
+		return g.rows[i].id < g.rows[j].id
+	})
+
+	for idx := range g.columns {
+		g.columns[idx].Sort = SortNone
+	}
+	if col < len(g.columns) {
+		g.columns[col].Sort = order
+	}
+
+	// keep the same row selected after sorting
+	if idx := g.rowIndex(selected); idx != -1 {
+		g.selectedRow = idx
+		g.lastEventRow = idx
+		g.EnsureRowVisible()
+	}
+}
+
+// rowIndex returns the current position of the row or -1
+// if the row does not exist
+func (g *DataGrid) rowIndex(id RowID) int {
+	for idx, r := range g.rows {
+		if r.id == id {
+			return idx
+		}
+	}
+	return -1
+}
+
+// nextSort returns the sort order that follows the current one
+func nextSort(order SortOrder) SortOrder {
+	switch order {
+	case SortNone:
+		return SortAsc
+	case SortAsc:
+		return SortDesc
+	}
+	return SortNone
+}
+
+/*
+ProcessEvent processes all events come from the control parent. If a control
+processes an event it should return true. If the method returns false it means
+that the control do not want or cannot process the event and the caller sends
+the event to the control parent
+*/
+func (g *DataGrid) ProcessEvent(event Event) bool {
+	if !g.Active() || !g.Enabled() {
+		return false
+	}
+
+	if event.Type == EventKey && g.processKey(event) {
+		return true
+	}
+
+	return g.TableView.ProcessEvent(event)
+}
+
+// processKey handles hotkeys that TableView does not know
+// about. It returns false if the key is not a DataGrid hotkey
+func (g *DataGrid) processKey(event Event) bool {
+	sortKey := event.Key == term.KeyCtrlM
+	resizeKey := event.Mod == term.ModAlt &&
+		(event.Key == term.KeyArrowRight || event.Key == term.KeyArrowLeft)
+	if !sortKey && !resizeKey {
+		return false
+	}
+
+	if g.onKeyPress != nil && g.onKeyPress(event.Key) {
+		return true
+	}
+
+	col := g.selectedCol
+	if col < 0 || col >= len(g.columns) {
+		return true
+	}
+
+	switch {
+	case sortKey:
+		order := nextSort(g.columns[col].Sort)
+		g.processAction(TableEvent{Action: TableActionSort, Col: col, Row: -1, Sort: order})
+	case event.Key == term.KeyArrowRight:
+		g.columns[col].Width++
+		g.EnsureColVisible()
+	case g.columns[col].Width > 1:
+		g.columns[col].Width--
+	}
+
+	return true
+}
+
+// own methods
+
+// AddColumn appends a new column to the grid. Existing rows get
+// empty values in the column
+func (g *DataGrid) AddColumn(title string, width int, align Align) ColumnID {
+	if width < 1 {
+		width = 1
+	}
+	g.columns = append(g.columns, Column{Title: title, Width: width, Alignment: align})
+	return ColumnID(len(g.columns) - 1)
+}
+
+// AddRow appends a new row to the grid. Returns the identifier
+// of the row that does not change after sorting
+func (g *DataGrid) AddRow(cells []string) RowID {
+	row := gridRow{id: g.nextID, cells: make([]string, len(cells))}
+	copy(row.cells, cells)
+	g.nextID++
+
+	g.rows = append(g.rows, row)
+	g.rowCount = len(g.rows)
+	return row.id
+}
+
+// CellValue returns the value of the cell. It returns empty
+// string if the row or the column does not exist
+func (g *DataGrid) CellValue(row RowID, col ColumnID) string {
+	idx := g.rowIndex(row)
+	if idx == -1 || col < 0 || int(col) >= len(g.rows[idx].cells) {
+		return ""
+	}
+
+	return g.rows[idx].cells[col]
+}
+
+// SetCellValue changes the value of the cell. The function does
+// nothing if the row or the column does not exist
+func (g *DataGrid) SetCellValue(row RowID, col ColumnID, value string) {
+	idx := g.rowIndex(row)
+	if idx == -1 || col < 0 || int(col) >= len(g.columns) {
+		return
+	}
+
+	for len(g.rows[idx].cells) <= int(col) {
+		g.rows[idx].cells = append(g.rows[idx].cells, "")
+	}
+	g.rows[idx].cells[col] = value
+}
+
+// SortByColumn sorts rows by values of the column in ascending
+// or descending order
+func (g *DataGrid) SortByColumn(col ColumnID, asc bool) {
+	if col < 0 || int(col) >= len(g.columns) {
+		return
+	}
+
+	order := SortDesc
+	if asc {
+		order = SortAsc
+	}
+	g.sortRows(int(col), order)
+}
+
+// SelectedRow returns the identifier of the selected row or
+// -1 if no row is selected
+func (g *DataGrid) SelectedRow() RowID {
+	if g.selectedRow < 0 || g.selectedRow >= len(g.rows) {
+		return -1
+	}
+
+	return g.rows[g.selectedRow].id
+}
+
+// OnRowSelect sets a callback that is called every time
+// the selected row is changed
+func (g *DataGrid) OnRowSelect(fn func(RowID)) {
+	g.onRowSelect = fn
+}
+
+// OnDrawCell sets a callback that is called every time the grid
+// is going to display a cell. The cell text and colors are already
+// filled by the grid
+func (g *DataGrid) OnDrawCell(fn func(*ColumnDrawInfo)) {
+	g.onUserDrawCell = fn
+}
+
+// OnAction is called when the grid wants a user application to
+// do some job like add, delete, or edit data. Sort action is
+// processed by the grid before calling the callback
+func (g *DataGrid) OnAction(fn func(TableEvent)) {
+	g.onUserAction = fn
+}
+
+// OnSelectCell sets a callback that is called every time
+// the selected cell is changed
+func (g *DataGrid) OnSelectCell(fn func(int, int)) {
+	g.onUserSelect = fn
+}
@@ -0,0 +1,73 @@
+package clui
+
+import (
+	"testing"
+)
+
+func TestDataGridSort(t *testing.T) {
+	g := CreateDataGrid(nil, 20, 10, Fixed)
+	name := g.AddColumn("Name", 10, AlignLeft)
+	size := g.AddColumn("Size", 5, AlignRight)
+	a := g.AddRow([]string{"b", "10"})
+	b := g.AddRow([]string{"a", "9"})
+	c := g.AddRow([]string{"c", "100"})
+
+	order := func() []RowID {
+		ids := make([]RowID, 0, len(g.rows))
+		for _, r := range g.rows {
+			ids = append(ids, r.id)
+		}
+		return ids
+	}
+	check := func(title string, expected []RowID) {
+		got := order()
+		for idx := range expected {
+			if got[idx] != expected[idx] {
+				t.Errorf("%s: expected order %v, got %v", title, expected, got)
+				return
+			}
+		}
+	}
+
+	if g.SelectedRow() != a {
+		t.Errorf("Expected row %v selected, got %v", a, g.SelectedRow())
+	}
+
+	g.SortByColumn(name, true)
+	check("name asc", []RowID{b, a, c})
+	if g.columns[name].Sort != SortAsc || g.columns[size].Sort != SortNone {
+		t.Error("Invalid sort indicators after sorting by name")
+	}
+	if g.SelectedRow() != a {
+		t.Errorf("Selection must follow the row: expected %v, got %v", a, g.SelectedRow())
+	}
+
+	// numbers are compared as numbers
+	g.SortByColumn(size, false)
+	check("size desc", []RowID{c, a, b})
+	if g.columns[name].Sort != SortNone || g.columns[size].Sort != SortDesc {
+		t.Error("Invalid sort indicators after sorting by size")
+	}
+
+	g.sortRows(int(size), SortNone)
+	check("original", []RowID{a, b, c})
+}
+
+func TestDataGridCellValue(t *testing.T) {
+	g := CreateDataGrid(nil, 20, 10, Fixed)
+	g.AddColumn("Name", 10, AlignLeft)
+	col := g.AddColumn("Size", 5, AlignRight)
+	row := g.AddRow([]string{"a"})
+
+	if v := g.CellValue(row, col); v != "" {
+		t.Errorf("Expected empty value, got %q", v)
+	}
+	g.SetCellValue(row, col, "42")
+	if v := g.CellValue(row, col); v != "42" {
+		t.Errorf("Expected 42, got %q", v)
+	}
+	g.SetCellValue(row, col+1, "x")
+	if v := g.CellValue(row, col+1); v != "" {
+		t.Errorf("Value of nonexistent column must be empty, got %q", v)
+	}
+}
@@ -22,10 +22,10 @@ func updateProgress(value string, pb *ui.ProgressBar) {
 	pb.SetValue(v)
 }
 
-func changeTheme(lb *ui.ListBox, btn *ui.Button, tp int) {
+func changeTheme(lb *ui.ListBox, btn *ui.Button, tp ui.CheckState) {
 	items := ui.ThemeNames()
 	dlgType := ui.SelectDialogRadio
-	if tp == 1 {
+	if tp == ui.CheckStateChecked {
 		dlgType = ui.SelectDialogList
 	}
 
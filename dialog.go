@@ -1,9 +1,5 @@
 package clui
 
-import (
-	term "github.com/nsf/termbox-go"
-)
-
 // ConfirmationDialog is a simple dialog to get a user
 // choice or confirmation. The dialog can contain upto
 // three button with custom titles. There are a few
@@ -48,7 +44,7 @@ func CreateConfirmationDialog(title, question string, buttons []string, defaultB
 		buttons = []string{"OK"}
 	}
 
-	cw, ch := term.Size()
+	cw, ch := ScreenSize()
 
 	dlg.view = AddWindow(cw/2-12, ch/2-8, 30, 3, title)
 	dlg.view.SetConstraints(30, 3)
@@ -159,7 +155,7 @@ func CreateSelectDialog(title string, items []string, selectedItem int, typ Sele
 		return nil
 	}
 
-	cw, ch := term.Size()
+	cw, ch := ScreenSize()
 
 	dlg.typ = typ
 	dlg.view = AddWindow(cw/2-12, ch/2-8, 20, 10, title)
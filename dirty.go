@@ -0,0 +1,117 @@
+package clui
+
+// dirtyTracker keeps the screen cells that have changed since the last
+// repaint. While a partial repaint is in progress only dirty cells are
+// written to the terminal, all other cells keep their current content
+type dirtyTracker struct {
+	width, height int
+	cells         []bool
+	count         int
+	// bounding rectangle of the dirty cells
+	minX, minY int
+	maxX, maxY int
+}
+
+// resize changes the size of the tracked area. All cells become clean
+func (d *dirtyTracker) resize(width, height int) {
+	d.width, d.height = width, height
+	d.cells = make([]bool, width*height)
+	d.count = 0
+}
+
+// mark makes all cells of the rectangle dirty. The rectangle is clipped
+// by the tracked area
+func (d *dirtyTracker) mark(x, y, w, h int) {
+	if x < 0 {
+		w += x
+		x = 0
+	}
+	if y < 0 {
+		h += y
+		y = 0
+	}
+	if x+w > d.width {
+		w = d.width - x
+	}
+	if y+h > d.height {
+		h = d.height - y
+	}
+	if w <= 0 || h <= 0 {
+		return
+	}
+
+	if d.count == 0 {
+		d.minX, d.minY, d.maxX, d.maxY = x, y, x+w-1, y+h-1
+	}
+	if x < d.minX {
+		d.minX = x
+	}
+	if y < d.minY {
+		d.minY = y
+	}
+	if x+w-1 > d.maxX {
+		d.maxX = x + w - 1
+	}
+	if y+h-1 > d.maxY {
+		d.maxY = y + h - 1
+	}
+
+	for yy := y; yy < y+h; yy++ {
+		for xx := x; xx < x+w; xx++ {
+			idx := yy*d.width + xx
+			if !d.cells[idx] {
+				d.cells[idx] = true
+				d.count++
+			}
+		}
+	}
+}
+
+// clear makes all cells clean
+func (d *dirtyTracker) clear() {
+	if d.count == 0 {
+		return
+	}
+	for i := range d.cells {
+		d.cells[i] = false
+	}
+	d.count = 0
+}
+
+// isDirty returns true if the cell must be repainted
+func (d *dirtyTracker) isDirty(x, y int) bool {
+	if x < 0 || y < 0 || x >= d.width || y >= d.height {
+		return false
+	}
+	return d.cells[y*d.width+x]
+}
+
+// bounds returns the smallest rectangle that contains all dirty cells.
+// The last value is false if there is no dirty cell
+func (d *dirtyTracker) bounds() (int, int, int, int, bool) {
+	if d.count == 0 {
+		return 0, 0, 0, 0, false
+	}
+	return d.minX, d.minY, d.maxX - d.minX + 1, d.maxY - d.minY + 1, true
+}
+
+// MarkDirty marks the screen rectangle to be repainted by the next call
+// of RefreshDirty. Controls that change only a small part of their area
+// mark it dirty to avoid repainting the whole screen
+func MarkDirty(x, y, w, h int) {
+	if canvas == nil {
+		return
+	}
+	if canvas.dirty.width != canvas.width || canvas.dirty.height != canvas.height {
+		canvas.dirty.resize(canvas.width, canvas.height)
+	}
+	canvas.dirty.mark(x, y, w, h)
+}
+
+// ClearDirty forgets all rectangles marked by MarkDirty
+func ClearDirty() {
+	if canvas == nil {
+		return
+	}
+	canvas.dirty.clear()
+}
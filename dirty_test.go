@@ -0,0 +1,60 @@
+package clui
+
+import (
+	"testing"
+)
+
+func TestDirtyRepaint(t *testing.T) {
+	saved := canvas
+	canvas = newMemoryCanvas(10, 5)
+	defer func() { canvas = saved }()
+
+	FillRect(0, 0, 10, 5, 'a')
+	MarkDirty(2, 1, 3, 2)
+	MarkDirty(8, 4, 5, 5)
+	if x, y, w, h, ok := canvas.dirty.bounds(); !ok || x != 2 || y != 1 || w != 8 || h != 4 {
+		t.Errorf("Invalid dirty bounds %v:%v %vx%v", x, y, w, h)
+	}
+
+	canvas.partial = true
+	FillRect(0, 0, 10, 5, 'b')
+	canvas.partial = false
+	for y := 0; y < 5; y++ {
+		for x := 0; x < 10; x++ {
+			c, _ := Symbol(x, y)
+			dirty := (x >= 2 && x < 5 && y >= 1 && y < 3) || (x >= 8 && y == 4)
+			if dirty != (c.Ch == 'b') {
+				t.Errorf("Cell %v:%v must be repainted only if it is dirty: %q", x, y, c.Ch)
+			}
+		}
+	}
+
+	ClearDirty()
+	if _, _, _, _, ok := canvas.dirty.bounds(); ok {
+		t.Error("ClearDirty must clean all cells")
+	}
+}
+
+func TestSparkChartDirtyColumn(t *testing.T) {
+	saved := canvas
+	canvas = newMemoryCanvas(40, 20)
+	defer func() { canvas = saved }()
+
+	b := CreateSparkChart(nil, 10, 5, Fixed)
+	b.SetPos(3, 2)
+	b.SetData([]float64{1, 5, 3})
+
+	ClearDirty()
+	b.AddData(2)
+	x, y, w, h, ok := canvas.dirty.bounds()
+	if !ok || x != 6 || y != 2 || w != 1 || h != 5 {
+		t.Errorf("Only the new column must be dirty: %v:%v %vx%v", x, y, w, h)
+	}
+
+	ClearDirty()
+	b.AddData(10)
+	x, y, w, h, _ = canvas.dirty.bounds()
+	if x != 3 || y != 2 || w != 10 || h != 5 {
+		t.Errorf("New maximum must mark the whole chart: %v:%v %vx%v", x, y, w, h)
+	}
+}
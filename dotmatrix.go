@@ -0,0 +1,265 @@
+package clui
+
+import (
+	term "github.com/nsf/termbox-go"
+)
+
+// dot bits of a Braille pattern character for every dot of the 2x4
+// character cell: dotMatrixBraille[row][col]
+var dotMatrixBraille = [4][2]rune{
+	{0x01, 0x08},
+	{0x02, 0x10},
+	{0x04, 0x20},
+	{0x40, 0x80},
+}
+
+/*
+DotMatrix is a control that displays a grid of on/off dots, e.g,
+a state of binary flags, a game of life board, or a LED display.
+Dots are smaller than a character: in block style(default one)
+every character displays 2x2 dots with quadrant characters, and
+in Braille style every character displays 2x4 dots.
+Characters that contain at least one lit dot are drawn with "on"
+colors, other characters are drawn with "off" colors.
+A pattern set with SetPattern can be wider than the grid: every
+call of Tick scrolls it one dot to the left, so the DotMatrix can
+display marquee text. The pattern wraps around when its end is
+reached
+*/
+type DotMatrix struct {
+	BaseControl
+	rows, cols int
+	dots       []bool
+	style      DotMatrixStyle
+
+	onFg, onBg   term.Attribute
+	offFg, offBg term.Attribute
+
+	pattern [][]bool
+	frame   int
+}
+
+/*
+CreateDotMatrix creates a new dot matrix. The grid is empty until
+SetDimensions is called.
+parent - is container that keeps the control.
+w and h - are minimal size of the control.
+scale - the way of scaling the control when the parent is resized. Use DoNotScale constant if the
+control should keep its original size.
+*/
+func CreateDotMatrix(parent Control, w, h int, scale int) *DotMatrix {
+	c := new(DotMatrix)
+
+	if w == AutoSize {
+		w = 10
+	}
+	if h == AutoSize {
+		h = 5
+	}
+
+	c.parent = parent
+
+	c.SetSize(w, h)
+	c.SetConstraints(w, h)
+	c.tabSkip = true
+	c.onFg, c.onBg = ColorDefault, ColorDefault
+	c.offFg, c.offBg = ColorDefault, ColorDefault
+	c.SetScale(scale)
+
+	if parent != nil {
+		parent.AddChild(c)
+	}
+
+	return c
+}
+
+// dotsPerChar returns how many dots one character displays
+// horizontally and vertically
+func (m *DotMatrix) dotsPerChar() (int, int) {
+	if m.style == DotMatrixBraille {
+		return 2, 4
+	}
+	return 2, 2
+}
+
+// Draw repaints the control on its View surface
+func (m *DotMatrix) Draw() {
+	PushAttributes()
+	defer PopAttributes()
+
+	onFg, onBg := RealColor(m.onFg, ColorDotMatrixOnText), RealColor(m.onBg, ColorDotMatrixOnBack)
+	offFg, offBg := RealColor(m.offFg, ColorDotMatrixOffText), RealColor(m.offBg, ColorDotMatrixOffBack)
+	SetTextColor(offFg)
+	SetBackColor(offBg)
+	FillRect(m.x, m.y, m.width, m.height, ' ')
+
+	dx, dy := m.dotsPerChar()
+	for y := 0; y < m.height && y*dy < m.rows; y++ {
+		for x := 0; x < m.width && x*dx < m.cols; x++ {
+			ch := m.charAt(x*dx, y*dy)
+			if ch == 0 {
+				continue
+			}
+			SetTextColor(onFg)
+			SetBackColor(onBg)
+			PutChar(m.x+x, m.y+y, ch)
+		}
+	}
+}
+
+// charAt returns the character that displays dots starting from the
+// column col and the row row. Returns 0 if all dots are off
+func (m *DotMatrix) charAt(col, row int) rune {
+	var bits rune
+	if m.style == DotMatrixBraille {
+		for r := 0; r < 4; r++ {
+			for c := 0; c < 2; c++ {
+				if m.Cell(row+r, col+c) {
+					bits |= dotMatrixBraille[r][c]
+				}
+			}
+		}
+		if bits == 0 {
+			return 0
+		}
+		return 0x2800 + bits
+	}
+
+	for r := 0; r < 2; r++ {
+		for c := 0; c < 2; c++ {
+			if m.Cell(row+r, col+c) {
+				bits |= 1 << uint(r*2+c)
+			}
+		}
+	}
+	if bits == 0 {
+		return 0
+	}
+	return gaugeQuadrants[bits]
+}
+
+// Dimensions returns the number of dot rows and columns
+func (m *DotMatrix) Dimensions() (int, int) {
+	return m.rows, m.cols
+}
+
+// SetDimensions changes the number of dot rows and columns. The dots
+// that are inside both old and new grids keep their state
+func (m *DotMatrix) SetDimensions(rows, cols int) {
+	if rows < 0 {
+		rows = 0
+	}
+	if cols < 0 {
+		cols = 0
+	}
+
+	dots := make([]bool, rows*cols)
+	for r := 0; r < rows && r < m.rows; r++ {
+		for c := 0; c < cols && c < m.cols; c++ {
+			dots[r*cols+c] = m.dots[r*m.cols+c]
+		}
+	}
+	m.rows, m.cols, m.dots = rows, cols, dots
+}
+
+// Cell returns true if the dot is on. Dots outside the grid are off
+func (m *DotMatrix) Cell(row, col int) bool {
+	if row < 0 || col < 0 || row >= m.rows || col >= m.cols {
+		return false
+	}
+	return m.dots[row*m.cols+col]
+}
+
+// SetCell turns the dot on or off. Dots outside the grid are ignored
+func (m *DotMatrix) SetCell(row, col int, on bool) {
+	if row < 0 || col < 0 || row >= m.rows || col >= m.cols {
+		return
+	}
+	m.dots[row*m.cols+col] = on
+}
+
+// Clear turns all dots off
+func (m *DotMatrix) Clear() {
+	for i := range m.dots {
+		m.dots[i] = false
+	}
+}
+
+// SetCellColors changes colors of characters with lit dots(onFg and
+// onBg) and without them(offFg and offBg). Use ColorDefault to
+// use theme colors
+func (m *DotMatrix) SetCellColors(onFg, onBg, offFg, offBg term.Attribute) {
+	m.onFg, m.onBg = onFg, onBg
+	m.offFg, m.offBg = offFg, offBg
+}
+
+// Style returns the way of drawing dots
+func (m *DotMatrix) Style() DotMatrixStyle {
+	return m.style
+}
+
+// SetStyle changes the way of drawing dots: DotMatrixBlock or
+// DotMatrixBraille
+func (m *DotMatrix) SetStyle(style DotMatrixStyle) {
+	m.style = style
+}
+
+// SetPattern sets the pattern to display: data[row][col] is the state
+// of the dot. Rows can have different lengths, missing dots are off.
+// The pattern is displayed from its first column, call Tick to scroll
+// it. Use nil to remove the pattern
+func (m *DotMatrix) SetPattern(data [][]bool) {
+	m.pattern = make([][]bool, len(data))
+	for i, row := range data {
+		m.pattern[i] = append([]bool(nil), row...)
+	}
+	m.frame = 0
+	m.applyPattern()
+}
+
+// Tick advances the animation: the pattern is scrolled one dot to the
+// left. It does nothing if there is no pattern
+func (m *DotMatrix) Tick() {
+	width := m.patternWidth()
+	if width == 0 {
+		return
+	}
+	m.frame = (m.frame + 1) % width
+	m.applyPattern()
+}
+
+// Frame returns the number of dots the pattern is scrolled by
+func (m *DotMatrix) Frame() int {
+	return m.frame
+}
+
+// patternWidth returns the length of the longest pattern row
+func (m *DotMatrix) patternWidth() int {
+	width := 0
+	for _, row := range m.pattern {
+		if len(row) > width {
+			width = len(row)
+		}
+	}
+	return width
+}
+
+// applyPattern copies the pattern scrolled by the current frame to
+// the grid
+func (m *DotMatrix) applyPattern() {
+	width := m.patternWidth()
+	if width == 0 {
+		return
+	}
+
+	for r := 0; r < m.rows; r++ {
+		for c := 0; c < m.cols; c++ {
+			on := false
+			if r < len(m.pattern) {
+				pc := (c + m.frame) % width
+				on = pc < len(m.pattern[r]) && m.pattern[r][pc]
+			}
+			m.dots[r*m.cols+c] = on
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package clui
+
+import (
+	"testing"
+)
+
+func TestDotMatrixDraw(t *testing.T) {
+	initThemeManager()
+	m := CreateDotMatrix(nil, 3, 1, Fixed)
+	m.SetDimensions(2, 4)
+	m.SetCell(0, 0, true)
+	m.SetCell(1, 1, true)
+	m.SetCell(0, 3, true)
+	m.SetCell(5, 5, true)
+
+	if got := renderToString(m); got != "▚▝ " {
+		t.Errorf("Invalid block matrix: %q", got)
+	}
+
+	m.SetStyle(DotMatrixBraille)
+	if got := renderToString(m); got != "⠑⠈ " {
+		t.Errorf("Invalid Braille matrix: %q", got)
+	}
+
+	m.SetDimensions(1, 2)
+	if !m.Cell(0, 0) || m.Cell(0, 1) {
+		t.Error("Dots are not kept after resize")
+	}
+}
+
+func TestDotMatrixTick(t *testing.T) {
+	m := CreateDotMatrix(nil, 3, 1, Fixed)
+	m.SetDimensions(1, 3)
+	m.SetPattern([][]bool{{true, false, false, false}})
+
+	want := []string{"100", "000", "001", "010", "100"}
+	for i, w := range want {
+		got := ""
+		for c := 0; c < 3; c++ {
+			if m.Cell(0, c) {
+				got += "1"
+			} else {
+				got += "0"
+			}
+		}
+		if got != w {
+			t.Errorf("Frame %v: %v, want %v", i, got, w)
+		}
+		m.Tick()
+	}
+}
@@ -0,0 +1,39 @@
+package clui
+
+import (
+	"testing"
+)
+
+func TestDoubleBuffer(t *testing.T) {
+	saved := canvas
+	canvas = new(Canvas)
+	canvas.width, canvas.height = 10, 4
+	canvas.clipW, canvas.clipH = 10, 4
+	defer func() { canvas = saved }()
+
+	SetDoubleBuffer(true)
+	if !DoubleBuffer() {
+		t.Fatal("Double buffer must be on")
+	}
+	clearScreen()
+	if sent := flushBackBuffer(); sent != 40 {
+		t.Errorf("The first flush must send all cells: %v", sent)
+	}
+
+	clearScreen()
+	DrawRawText(2, 1, "abc")
+	if c, _ := Symbol(3, 1); c.Ch != 'b' {
+		t.Errorf("Drawing must go to the back buffer: %q", c.Ch)
+	}
+	if sent := flushBackBuffer(); sent != 3 {
+		t.Errorf("Only changed cells must be sent: %v", sent)
+	}
+	if sent := flushBackBuffer(); sent != 0 {
+		t.Errorf("Unchanged buffer must not be sent: %v", sent)
+	}
+
+	SetDoubleBuffer(false)
+	if DoubleBuffer() {
+		t.Error("Double buffer must be off")
+	}
+}
@@ -0,0 +1,455 @@
+package clui
+
+import (
+	xs "github.com/huandu/xstrings"
+	term "github.com/nsf/termbox-go"
+)
+
+/*
+DraggableWindow is a floating window that is drawn on the overlay layer
+above all Views. It has a frame, a title bar, and a set of buttons in the
+title bar: close button is always displayed, minimize and maximize ones
+are optional. A user moves the window by dragging its title bar with
+mouse and, if the window is resizable, changes its size by dragging the
+bottom-right corner. Several DraggableWindows keep their z-order: the
+window clicked last is displayed above the others and gets keyboard
+events.
+
+A DraggableWindow is a container: create its children with it as the
+parent, the same way as for a Window. The window is not displayed until
+Show is called.
+
+Events:
+
+	OnClose - called when a user clicks the close button or the window
+	is closed with Close. If the callback returns false the window
+	remains open
+*/
+type DraggableWindow struct {
+	BaseControl
+	buttons   ViewButton
+	resizable bool
+	minimized bool
+	maximized bool
+	// size and position before maximizing
+	origX, origY int
+	origW, origH int
+	// mouse dragging: what is changed and the last cursor position
+	dragType     DragType
+	dragX, dragY int
+	// position of the last mouse button press inside the window to
+	// detect click
+	downX, downY int
+
+	onClose func(Event) bool
+}
+
+/*
+CreateDraggableWindow creates a new hidden DraggableWindow.
+x and y - are the screen position of the top left corner.
+width and height - are the initial window size.
+title - is the text displayed in the title bar.
+*/
+func CreateDraggableWindow(x, y, width, height int, title string) *DraggableWindow {
+	d := new(DraggableWindow)
+
+	if width == AutoSize || width < 1 {
+		width = 10
+	}
+	if height == AutoSize || height < 1 {
+		height = 5
+	}
+
+	d.buttons = ButtonClose
+	d.SetConstraints(d.buttonCount()+4, 3)
+	d.SetSize(width, height)
+	d.SetPos(x, y)
+	d.SetTitle(title)
+	d.children = make([]Control, 0)
+	d.SetPaddings(1, 1)
+	d.SetGaps(1, 0)
+	d.SetScale(1)
+	d.SetActive(false)
+
+	return d
+}
+
+// buttonCount returns the number of buttons in the title bar
+func (d *DraggableWindow) buttonCount() int {
+	cnt := 0
+	for _, b := range []ViewButton{ButtonMinimize, ButtonMaximize, ButtonClose} {
+		if d.buttons&b == b {
+			cnt++
+		}
+	}
+	return cnt
+}
+
+// visibleHeight returns the visible height of the window: minimized window
+// displays its title bar only
+func (d *DraggableWindow) visibleHeight() int {
+	if d.minimized {
+		return 1
+	}
+	return d.height
+}
+
+// Draw repaints the window, its children, and the title bar
+func (d *DraggableWindow) Draw() {
+	PushAttributes()
+	defer PopAttributes()
+
+	fg, bg := RealColor(d.fg, ColorViewText), RealColor(d.bg, ColorViewBack)
+	SetBackColor(bg)
+	FillRect(d.x, d.y, d.width, d.visibleHeight(), ' ')
+
+	if !d.minimized {
+		d.DrawChildren()
+	}
+
+	SetBackColor(bg)
+	SetTextColor(fg)
+
+	if !d.minimized {
+		bs := BorderThick
+		if d.inactive {
+			bs = BorderThin
+		}
+		DrawFrame(d.x, d.y, d.width, d.height, bs)
+	}
+	d.drawTitle()
+	d.drawButtons()
+}
+
+// drawTitle draws the title text that fits the space between the left
+// border and the buttons
+func (d *DraggableWindow) drawTitle() {
+	maxw := d.width - 2 - d.buttonCount() - 2
+	if maxw <= 0 {
+		return
+	}
+
+	fitTitle := d.title
+	rawText := UnColorizeText(fitTitle)
+	if xs.Len(rawText) > maxw {
+		fitTitle = SliceColorized(fitTitle, 0, maxw-3) + "..."
+	}
+
+	DrawText(d.x+1, d.y, fitTitle)
+}
+
+// drawButtons draws the title bar buttons in brackets near the right
+// border
+func (d *DraggableWindow) drawButtons() {
+	chars := []rune(SysObject(ObjDraggableButtons))
+	cMin, cMax, cClose, cOpenB, cCloseB := chars[0], chars[1], chars[2], chars[3], chars[4]
+
+	pos := d.x + d.width - d.buttonCount() - 2
+	putCharUnsafe(pos, d.y, cOpenB)
+	pos++
+	for _, b := range []struct {
+		btn ViewButton
+		ch  rune
+	}{{ButtonMinimize, cMin}, {ButtonMaximize, cMax}, {ButtonClose, cClose}} {
+		if d.buttons&b.btn == b.btn {
+			putCharUnsafe(pos, d.y, b.ch)
+			pos++
+		}
+	}
+	putCharUnsafe(pos, d.y, cCloseB)
+}
+
+// HitTest returns the area of the window at the screen point: a title
+// bar button, the title bar, the bottom-right corner, or the inside
+func (d *DraggableWindow) HitTest(x, y int) HitResult {
+	if x < d.x || x >= d.x+d.width || y < d.y || y >= d.y+d.visibleHeight() {
+		return HitOutside
+	}
+
+	if y == d.y {
+		first := d.x + d.width - d.buttonCount() - 1
+		if x < first || x >= d.x+d.width-1 {
+			return HitTop
+		}
+
+		var hits []HitResult
+		if d.buttons&ButtonMinimize == ButtonMinimize {
+			hits = append(hits, HitButtonMinimize)
+		}
+		if d.buttons&ButtonMaximize == ButtonMaximize {
+			hits = append(hits, HitButtonMaximize)
+		}
+		hits = append(hits, HitButtonClose)
+		return hits[x-first]
+	}
+
+	if d.resizable && !d.maximized && x == d.x+d.width-1 && y == d.y+d.height-1 {
+		return HitBottomRight
+	}
+
+	return HitInside
+}
+
+// moveBy moves the window if it remains inside the screen
+func (d *DraggableWindow) moveBy(dx, dy int) {
+	sw, sh := ScreenSize()
+	x, y := d.x+dx, d.y+dy
+	if x < 0 || y < 0 || x+d.width > sw || y+d.visibleHeight() > sh {
+		return
+	}
+
+	d.SetPos(x, y)
+	d.PlaceChildren()
+}
+
+// resizeBy changes the window size if it remains inside the screen
+func (d *DraggableWindow) resizeBy(dx, dy int) {
+	sw, sh := ScreenSize()
+	w, h := d.width+dx, d.height+dy
+	if d.x+w > sw || d.y+h > sh {
+		return
+	}
+
+	d.SetSize(w, h)
+	d.ResizeChildren()
+	d.PlaceChildren()
+}
+
+// startDrag makes the window get all mouse events until a user releases
+// the mouse button
+func (d *DraggableWindow) startDrag(dt DragType, ev Event) {
+	d.dragType = dt
+	d.dragX, d.dragY = ev.X, ev.Y
+	GrabEvents(d)
+}
+
+// processMouse moves and resizes the window, handles title bar buttons,
+// and sends the rest of the mouse events to the children
+func (d *DraggableWindow) processMouse(ev Event) bool {
+	if d.dragType != DragNone {
+		switch {
+		case ev.Key == term.MouseRelease:
+			d.dragType = DragNone
+			ReleaseEvents()
+		case ev.Mod == term.ModMotion:
+			dx, dy := ev.X-d.dragX, ev.Y-d.dragY
+			d.dragX, d.dragY = ev.X, ev.Y
+			if d.dragType == DragMove {
+				d.moveBy(dx, dy)
+			} else {
+				d.resizeBy(dx, dy)
+			}
+		}
+		return true
+	}
+
+	hit := d.HitTest(ev.X, ev.Y)
+	if ev.Key == term.MouseLeft && ev.Mod == 0 {
+		d.BringToFront()
+		switch hit {
+		case HitButtonClose:
+			d.Close()
+			return true
+		case HitButtonMinimize:
+			d.SetMinimized(!d.minimized)
+			return true
+		case HitButtonMaximize:
+			d.SetMaximized(!d.maximized)
+			return true
+		case HitTop:
+			if !d.maximized {
+				d.startDrag(DragMove, ev)
+			}
+			return true
+		case HitBottomRight:
+			d.startDrag(DragResizeBottomRight, ev)
+			return true
+		}
+		d.downX, d.downY = ev.X, ev.Y
+	}
+
+	if hit != HitInside || d.minimized {
+		return true
+	}
+
+	SendEventToChild(d, ev)
+	if ev.Key == term.MouseRelease && ev.X == d.downX && ev.Y == d.downY {
+		ev.Type = EventClick
+		SendEventToChild(d, ev)
+	}
+	return true
+}
+
+/*
+ProcessEvent processes all events come from the control parent. If a control
+processes an event it should return true. If the method returns false it means
+that the control do not want or cannot process the event and the caller sends
+the event to the control parent
+*/
+func (d *DraggableWindow) ProcessEvent(ev Event) bool {
+	switch ev.Type {
+	case EventMouse:
+		return d.processMouse(ev)
+	case EventKey:
+		if d.minimized {
+			return false
+		}
+		if ev.Key == term.KeyTab {
+			tabFocus(d)
+			return true
+		}
+	}
+
+	return SendEventToChild(d, ev)
+}
+
+//----------------- own methods -------------------------
+
+// Show adds the window to the overlay layer above all other windows
+func (d *DraggableWindow) Show() {
+	d.ResizeChildren()
+	d.PlaceChildren()
+	d.BringToFront()
+}
+
+// Close hides the window unless the OnClose callback forbids it
+func (d *DraggableWindow) Close() {
+	if d.onClose != nil && !d.onClose(Event{Type: EventClose}) {
+		return
+	}
+
+	if comp.consumer == d {
+		ReleaseEvents()
+	}
+	d.dragType = DragNone
+	d.SetActive(false)
+	hideOverlay(d)
+}
+
+// Shown returns true if the window is displayed
+func (d *DraggableWindow) Shown() bool {
+	for _, ov := range comp.overlays {
+		if ov == d {
+			return true
+		}
+	}
+	return false
+}
+
+// BringToFront displays the window above all other floating windows
+// and makes it receive keyboard events
+func (d *DraggableWindow) BringToFront() {
+	hideOverlay(d)
+	for _, ov := range comp.overlays {
+		if fw, ok := ov.(*DraggableWindow); ok {
+			fw.SetActive(false)
+		}
+	}
+	showOverlay(d)
+	d.SetActive(true)
+}
+
+// SendToBack displays the window below all other floating windows. The
+// window stops receiving keyboard events
+func (d *DraggableWindow) SendToBack() {
+	hideOverlay(d)
+	comp.overlays = append([]Control{d}, comp.overlays...)
+	d.SetActive(false)
+}
+
+// Buttons returns the set of buttons displayed in the title bar
+func (d *DraggableWindow) Buttons() ViewButton {
+	return d.buttons
+}
+
+// SetButtons sets the buttons displayed in the title bar: ButtonMinimize
+// and ButtonMaximize are optional, the close button is always displayed
+func (d *DraggableWindow) SetButtons(bi ViewButton) {
+	d.buttons = (bi & (ButtonMinimize | ButtonMaximize)) | ButtonClose
+	d.SetConstraints(d.buttonCount()+4, 3)
+}
+
+// Resizable returns true if a user can resize the window with mouse
+func (d *DraggableWindow) Resizable() bool {
+	return d.resizable
+}
+
+// SetResizable allows or forbids changing the window size by dragging
+// its bottom-right corner
+func (d *DraggableWindow) SetResizable(resizable bool) {
+	d.resizable = resizable
+}
+
+// Minimized returns true if only the title bar of the window is displayed
+func (d *DraggableWindow) Minimized() bool {
+	return d.minimized
+}
+
+// SetMinimized collapses the window to its title bar or restores it
+func (d *DraggableWindow) SetMinimized(minimize bool) {
+	d.minimized = minimize
+}
+
+// Maximized returns true if the window takes the whole screen
+func (d *DraggableWindow) Maximized() bool {
+	return d.maximized
+}
+
+// SetMaximized opens the window to full screen or restores its
+// previous size and position
+func (d *DraggableWindow) SetMaximized(maximize bool) {
+	if maximize == d.maximized {
+		return
+	}
+
+	if maximize {
+		d.origX, d.origY = d.Pos()
+		d.origW, d.origH = d.Size()
+		d.SetPos(0, 0)
+		d.SetSize(ScreenSize())
+	} else {
+		d.SetPos(d.origX, d.origY)
+		d.SetSize(d.origW, d.origH)
+	}
+	d.maximized = maximize
+	d.minimized = false
+	d.ResizeChildren()
+	d.PlaceChildren()
+}
+
+// OnClose sets the callback that is called before the window is closed.
+// If the callback returns false the window remains open
+func (d *DraggableWindow) OnClose(fn func(Event) bool) {
+	d.onClose = fn
+}
+
+// activeOverlay returns the floating window that gets keyboard events
+func (c *Composer) activeOverlay() *DraggableWindow {
+	if len(c.overlays) == 0 {
+		return nil
+	}
+	if fw, ok := c.overlays[len(c.overlays)-1].(*DraggableWindow); ok && fw.Active() {
+		return fw
+	}
+	return nil
+}
+
+// processOverlayMouse sends the mouse event to the topmost floating
+// window under the cursor. Clicking outside all floating windows makes
+// them inactive, so keyboard events go to Views again
+func (c *Composer) processOverlayMouse(ev Event) bool {
+	for i := len(c.overlays) - 1; i >= 0; i-- {
+		fw, ok := c.overlays[i].(*DraggableWindow)
+		if ok && fw.HitTest(ev.X, ev.Y) != HitOutside {
+			fw.ProcessEvent(ev)
+			return true
+		}
+	}
+
+	if ev.Key == term.MouseLeft {
+		if fw := c.activeOverlay(); fw != nil {
+			fw.SetActive(false)
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package clui
+
+import (
+	term "github.com/nsf/termbox-go"
+	"testing"
+)
+
+func TestDraggableWindow(t *testing.T) {
+	initThemeManager()
+	initComposer()
+	saved := canvas
+	canvas = newMemoryCanvas(40, 20)
+	defer func() { canvas = saved }()
+
+	first := CreateDraggableWindow(2, 2, 12, 6, "First")
+	second := CreateDraggableWindow(20, 2, 12, 6, "Second")
+	first.Show()
+	second.Show()
+	if comp.activeOverlay() != second || first.Active() {
+		t.Fatal("The window shown last must be on top")
+	}
+
+	// clicking the title bar brings the window to front and starts dragging
+	comp.processMouse(Event{Type: EventMouse, Key: term.MouseLeft, X: 4, Y: 2})
+	if comp.activeOverlay() != first || comp.consumer != first {
+		t.Fatal("Clicking the window must bring it to front")
+	}
+	first.ProcessEvent(Event{Type: EventMouse, Key: term.MouseLeft, Mod: term.ModMotion, X: 7, Y: 4})
+	first.ProcessEvent(Event{Type: EventMouse, Key: term.MouseRelease, X: 7, Y: 4})
+	if x, y := first.Pos(); x != 5 || y != 4 || comp.consumer != nil {
+		t.Errorf("Dragging the title bar must move the window: %v:%v", x, y)
+	}
+
+	// bottom-right corner resizes only resizable windows
+	first.ProcessEvent(Event{Type: EventMouse, Key: term.MouseLeft, X: 16, Y: 9})
+	if comp.consumer != nil {
+		t.Error("Not resizable window must not start resizing")
+	}
+	first.SetResizable(true)
+	first.ProcessEvent(Event{Type: EventMouse, Key: term.MouseLeft, X: 16, Y: 9})
+	first.ProcessEvent(Event{Type: EventMouse, Key: term.MouseLeft, Mod: term.ModMotion, X: 19, Y: 10})
+	first.ProcessEvent(Event{Type: EventMouse, Key: term.MouseRelease, X: 19, Y: 10})
+	if w, h := first.Size(); w != 15 || h != 7 {
+		t.Errorf("Dragging the corner must resize the window: %vx%v", w, h)
+	}
+
+	first.SendToBack()
+	if comp.overlays[0] != first || comp.activeOverlay() != nil {
+		t.Error("SendToBack must move the window below others")
+	}
+	first.BringToFront()
+
+	closed := false
+	first.OnClose(func(ev Event) bool {
+		closed = true
+		return true
+	})
+	first.Draw()
+	comp.processMouse(Event{Type: EventMouse, Key: term.MouseLeft, X: 18, Y: 4})
+	if !closed || first.Shown() || !second.Shown() {
+		t.Error("Clicking the close button must close the window")
+	}
+}
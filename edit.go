@@ -4,6 +4,7 @@ import (
 	"github.com/atotto/clipboard"
 	xs "github.com/huandu/xstrings"
 	term "github.com/nsf/termbox-go"
+	"unicode"
 )
 
 /*
@@ -14,6 +15,23 @@ Edit text can be limited. By default a user can enter text of any length.
 Use SetMaxWidth to limit the maximum text length. If the text is longer than
 maximun then the text is automatically truncated.
 EditField calls onChage in case of its text is changed. Event field Msg contains the new text
+If a validator is set, EditField checks its text after every change and
+when the field loses focus. Invalid field is displayed with EditInvalid
+back color, and while the field is active the error message is displayed
+in the row below the field if the row is inside the parent client area.
+EditField with input mask accepts only characters that match the mask:
+'#' is a digit, 'A' is a letter, '*' is any character, and all other
+mask characters are literals that are added automatically. Empty
+positions of the mask are displayed as '_'.
+Every change made by a user can be reverted with Ctrl+Z and restored
+with Ctrl+Y. The field remembers up to 50 changes by default, and
+SetTitle clears the history.
+Alt+Arrow Left, Alt+Arrow Right, Alt+Home, and Alt+End select text,
+Ctrl+A selects all text(terminals do not report Shift modifier for
+arrow keys, so Alt is used instead). Ctrl+C copies selected text or
+the whole text if nothing is selected, Ctrl+X cuts selected text, and
+typing, Ctrl+V, Delete, and Backspace replace selected text. Fields
+with input mask do not support selection
 */
 type EditField struct {
 	BaseControl
@@ -23,11 +41,36 @@ type EditField struct {
 	offset   int
 	readonly bool
 	maxWidth int
+	// text displayed when the field is empty
+	placeholder string
+	validator   func(string) error
+	validErr    error
+	// input mask and the characters entered by a user
+	mask       []rune
+	maskRaw    []rune
+	maskCursor int
+	returnRaw  bool
+	// undo and redo history
+	undo      []editFieldState
+	redo      []editFieldState
+	undoLimit int
+	// selected text is between selAnchor and cursorPos
+	selAnchor int
+	selecting bool
 
 	onChange   func(Event)
 	onKeyPress func(term.Key) bool
 }
 
+// editFieldState is EditField text and cursor position saved
+// to undo history
+type editFieldState struct {
+	text       string
+	cursorPos  int
+	offset     int
+	maskCursor int
+}
+
 // NewEditField creates a new EditField control
 // view - is a View that manages the control
 // parent - is container that keeps the control. The same View can be a view and a parent at the same time.
@@ -38,6 +81,7 @@ type EditField struct {
 func CreateEditField(parent Control, width int, text string, scale int) *EditField {
 	e := new(EditField)
 	e.onChange = nil
+	e.undoLimit = 50
 	e.SetTitle(text)
 	e.SetEnabled(true)
 
@@ -77,10 +121,28 @@ func (e *EditField) OnKeyPress(fn func(term.Key) bool) {
 	e.onKeyPress = fn
 }
 
-// SetTitle changes the EditField content and emits OnChage eventif the new value does not equal to old one
+// SetTitle changes the EditField content and emits OnChage eventif the new value does not equal to old one.
+// Undo history is cleared
 func (e *EditField) SetTitle(title string) {
+	e.undo, e.redo = nil, nil
+	e.setTitle(title)
+}
+
+// setTitle changes the content without clearing undo history
+func (e *EditField) setTitle(title string) {
+	if len(e.mask) != 0 {
+		e.maskRaw = e.parseMasked(title)
+		if e.maskCursor > len(e.maskRaw) {
+			e.maskCursor = len(e.maskRaw)
+		}
+		title = e.formatMask()
+		e.cursorPos = e.maskSlotPos(e.maskCursor)
+	}
+
 	if e.title != title {
 		e.title = title
+		e.selecting = false
+		e.Validate()
 		if e.onChange != nil {
 			ev := Event{Msg: title}
 			go e.onChange(ev)
@@ -101,6 +163,8 @@ func (e *EditField) Draw() {
 
 	var textOut string
 	curOff := 0
+	// columns that display text characters, not scroll arrows
+	firstCol, lastCol := 0, w
 	if e.offset == 0 && xs.Len(e.title) < e.width {
 		textOut = e.title
 	} else {
@@ -110,34 +174,84 @@ func (e *EditField) Draw() {
 			toIdx = e.width - 1
 			textOut = xs.Slice(e.title, 0, toIdx) + chRight
 			curOff = -e.offset
+			lastCol = w - 1
 		} else {
 			curOff = 1 - e.offset
 			fromIdx = e.offset
+			firstCol = 1
 			if e.width-1 <= xs.Len(e.title)-e.offset {
 				toIdx = e.offset + e.width - 2
 				textOut = chLeft + xs.Slice(e.title, fromIdx, toIdx) + chRight
+				lastCol = w - 1
 			} else {
 				textOut = chLeft + xs.Slice(e.title, fromIdx, -1)
 			}
 		}
 	}
 
+	if len(e.mask) != 0 {
+		textOut = CutText(e.maskDisplay(), w)
+		curOff = 0
+	}
+
 	fg, bg := RealColor(e.fg, ColorEditText), RealColor(e.bg, ColorEditBack)
 	if !e.Enabled() {
 		fg, bg = RealColor(e.fg, ColorDisabledText), RealColor(e.fg, ColorDisabledBack)
 	} else if e.Active() {
 		fg, bg = RealColor(e.fg, ColorEditActiveText), RealColor(e.bg, ColorEditActiveBack)
 	}
+	if e.validErr != nil && e.Enabled() {
+		bg = RealColor(ColorDefault, ColorEditInvalidBack)
+	}
 
 	SetTextColor(fg)
 	SetBackColor(bg)
 	FillRect(x, y, w, 1, ' ')
+	if e.title == "" && e.placeholder != "" && e.Enabled() && len(e.mask) == 0 {
+		if e.Active() {
+			SetTextColor(RealColor(ColorDefault, ColorEditActivePlaceholder))
+		} else {
+			SetTextColor(RealColor(ColorDefault, ColorEditPlaceholder))
+		}
+		textOut = CutText(e.placeholder, w)
+	}
 	DrawRawText(x, y, textOut)
+	if from, to, ok := e.selection(); ok && len(e.mask) == 0 {
+		SetTextColor(RealColor(e.fgActive, ColorSelectionText))
+		SetBackColor(RealColor(e.bgActive, ColorSelectionBack))
+		text := []rune(e.title)
+		for idx := from; idx < to; idx++ {
+			col := idx + curOff
+			if col >= firstCol && col < lastCol {
+				PutChar(x+col, y, text[idx])
+			}
+		}
+	}
 	if e.Active() {
+		e.drawError()
 		SetCursorPos(e.cursorPos+e.x+curOff, e.y)
 	}
 }
 
+// drawError displays the validation error in the row below the
+// field if the row is inside the client area of the field parent
+func (e *EditField) drawError() {
+	if e.validErr == nil || e.parent == nil {
+		return
+	}
+
+	_, py := e.parent.Pos()
+	_, ph := e.parent.Size()
+	_, padY := e.parent.Paddings()
+	if e.y+1 >= py+ph-padY {
+		return
+	}
+
+	SetTextColor(RealColor(e.fg, ColorEditText))
+	SetBackColor(RealColor(ColorDefault, ColorEditInvalidBack))
+	DrawRawText(e.x, e.y+1, e.validErr.Error())
+}
+
 func (e *EditField) insertRune(ch rune) {
 	if e.readonly {
 		return
@@ -150,11 +264,11 @@ func (e *EditField) insertRune(ch rune) {
 	idx := e.cursorPos
 
 	if idx == 0 {
-		e.SetTitle(string(ch) + e.title)
+		e.setTitle(string(ch) + e.title)
 	} else if idx >= xs.Len(e.title) {
-		e.SetTitle(e.title + string(ch))
+		e.setTitle(e.title + string(ch))
 	} else {
-		e.SetTitle(xs.Slice(e.title, 0, idx) + string(ch) + xs.Slice(e.title, idx, -1))
+		e.setTitle(xs.Slice(e.title, 0, idx) + string(ch) + xs.Slice(e.title, idx, -1))
 	}
 
 	e.cursorPos++
@@ -176,14 +290,14 @@ func (e *EditField) backspace() {
 	length := xs.Len(e.title)
 	if e.cursorPos >= length {
 		e.cursorPos--
-		e.SetTitle(xs.Slice(e.title, 0, length-1))
+		e.setTitle(xs.Slice(e.title, 0, length-1))
 	} else if e.cursorPos == 1 {
 		e.cursorPos = 0
-		e.SetTitle(xs.Slice(e.title, 1, -1))
+		e.setTitle(xs.Slice(e.title, 1, -1))
 		e.offset = 0
 	} else {
 		e.cursorPos--
-		e.SetTitle(xs.Slice(e.title, 0, e.cursorPos) + xs.Slice(e.title, e.cursorPos+1, -1))
+		e.setTitle(xs.Slice(e.title, 0, e.cursorPos) + xs.Slice(e.title, e.cursorPos+1, -1))
 	}
 
 	if length-1 < e.width {
@@ -199,9 +313,9 @@ func (e *EditField) del() {
 	}
 
 	if e.cursorPos == length-1 {
-		e.SetTitle(xs.Slice(e.title, 0, length-1))
+		e.setTitle(xs.Slice(e.title, 0, length-1))
 	} else {
-		e.SetTitle(xs.Slice(e.title, 0, e.cursorPos) + xs.Slice(e.title, e.cursorPos+1, -1))
+		e.setTitle(xs.Slice(e.title, 0, e.cursorPos) + xs.Slice(e.title, e.cursorPos+1, -1))
 	}
 
 	if length-1 < e.width {
@@ -249,10 +363,85 @@ func (e *EditField) end() {
 	e.offset = length - (e.width - 2)
 }
 
+// fixOffset changes the first displayed character to make
+// the cursor visible
+func (e *EditField) fixOffset() {
+	length := xs.Len(e.title)
+	if length < e.width {
+		e.offset = 0
+	} else if e.cursorPos < e.offset {
+		e.offset = e.cursorPos
+	} else if e.cursorPos > e.offset+e.width-2 {
+		e.offset = e.cursorPos - (e.width - 2)
+	}
+}
+
+// selection returns the start and the end of selected text
+func (e *EditField) selection() (int, int, bool) {
+	if !e.selecting || e.selAnchor == e.cursorPos {
+		return e.cursorPos, e.cursorPos, false
+	}
+
+	if e.selAnchor < e.cursorPos {
+		return e.selAnchor, e.cursorPos, true
+	}
+	return e.cursorPos, e.selAnchor, true
+}
+
+// startSelection starts or continues selection if sel is true,
+// and removes selection otherwise
+func (e *EditField) startSelection(sel bool) {
+	if sel && !e.selecting {
+		e.selAnchor = e.cursorPos
+		e.selecting = true
+	} else if !sel {
+		e.selecting = false
+	}
+}
+
+// replaceSelection replaces selected text with a new text. It
+// returns false if nothing is selected
+func (e *EditField) replaceSelection(text string) bool {
+	from, to, ok := e.selection()
+	if !ok || e.readonly {
+		return false
+	}
+
+	if e.maxWidth > 0 && xs.Len(e.title)-(to-from)+xs.Len(text) > e.maxWidth {
+		return true
+	}
+
+	e.setTitle(xs.Slice(e.title, 0, from) + text + xs.Slice(e.title, to, -1))
+	e.cursorPos = from + xs.Len(text)
+	e.fixOffset()
+	return true
+}
+
+// SelectedText returns the selected text or empty string
+// if nothing is selected
+func (e *EditField) SelectedText() string {
+	from, to, ok := e.selection()
+	if !ok {
+		return ""
+	}
+	return xs.Slice(e.title, from, to)
+}
+
+// SelectAll selects the whole text
+func (e *EditField) SelectAll() {
+	if len(e.mask) != 0 {
+		return
+	}
+
+	e.selAnchor = 0
+	e.selecting = true
+	e.end()
+}
+
 // Clear empties the EditField and emits OnChange event
 func (e *EditField) Clear() {
 	e.home()
-	e.SetTitle("")
+	e.setTitle("")
 }
 
 /*
@@ -262,6 +451,11 @@ that the control do not want or cannot process the event and the caller sends
 the event to the control parent
 */
 func (e *EditField) ProcessEvent(event Event) bool {
+	// a control is deactivated before it gets the event
+	if event.Type == EventActivate && event.X == 0 {
+		e.Validate()
+	}
+
 	if !e.Active() || !e.Enabled() {
 		return false
 	}
@@ -278,25 +472,57 @@ func (e *EditField) ProcessEvent(event Event) bool {
 			}
 		}
 
+		switch event.Key {
+		case term.KeyCtrlZ:
+			e.Undo()
+			return true
+		case term.KeyCtrlY:
+			e.Redo()
+			return true
+		}
+
+		// every key that changes the text is a separate undo step
+		before := e.state()
+		defer func() {
+			if e.title != before.text {
+				e.pushState(&e.undo, before)
+				e.redo = nil
+			}
+		}()
+
+		if len(e.mask) != 0 {
+			return e.processMaskKey(event)
+		}
+
+		sel := event.Mod == term.ModAlt
 		switch event.Key {
 		case term.KeyEnter:
 			return false
 		case term.KeySpace:
-			e.insertRune(' ')
+			if !e.replaceSelection(" ") {
+				e.insertRune(' ')
+			}
 			return true
 		case term.KeyBackspace:
-			e.backspace()
+			if !e.replaceSelection("") {
+				e.backspace()
+			}
 			return true
 		case term.KeyDelete:
-			e.del()
+			if !e.replaceSelection("") {
+				e.del()
+			}
 			return true
 		case term.KeyArrowLeft:
+			e.startSelection(sel)
 			e.charLeft()
 			return true
 		case term.KeyHome:
+			e.startSelection(sel)
 			e.home()
 			return true
 		case term.KeyEnd:
+			e.startSelection(sel)
 			e.end()
 			return true
 		case term.KeyCtrlR:
@@ -305,21 +531,39 @@ func (e *EditField) ProcessEvent(event Event) bool {
 			}
 			return true
 		case term.KeyArrowRight:
+			e.startSelection(sel)
 			e.charRight()
 			return true
+		case term.KeyCtrlA:
+			e.SelectAll()
+			return true
 		case term.KeyCtrlC:
-			clipboard.WriteAll(e.Title())
+			if _, _, ok := e.selection(); ok {
+				clipboard.WriteAll(e.SelectedText())
+			} else {
+				clipboard.WriteAll(e.Title())
+			}
+			return true
+		case term.KeyCtrlX:
+			if _, _, ok := e.selection(); ok && !e.readonly {
+				clipboard.WriteAll(e.SelectedText())
+				e.replaceSelection("")
+			}
 			return true
 		case term.KeyCtrlV:
 			if !e.readonly {
 				s, _ := clipboard.ReadAll()
-				e.SetTitle(s)
-				e.end()
+				if !e.replaceSelection(s) {
+					e.setTitle(s)
+					e.end()
+				}
 			}
 			return true
 		default:
 			if event.Ch != 0 {
-				e.insertRune(event.Ch)
+				if !e.replaceSelection(string(event.Ch)) {
+					e.insertRune(event.Ch)
+				}
 				return true
 			}
 		}
@@ -329,13 +573,375 @@ func (e *EditField) ProcessEvent(event Event) bool {
 	return false
 }
 
-// SetMaxWidth sets the maximum lenght of the EditField text. If the current text is longer it is truncated
+// maskAccepts returns true if the character can be entered
+// in the mask position
+func maskAccepts(slot, ch rune) bool {
+	switch slot {
+	case '#':
+		return unicode.IsDigit(ch)
+	case 'A':
+		return unicode.IsLetter(ch)
+	case '*':
+		return true
+	}
+	return false
+}
+
+func isMaskSlot(ch rune) bool {
+	return ch == '#' || ch == 'A' || ch == '*'
+}
+
+// maskSlots returns the number of positions a user can fill
+func (e *EditField) maskSlots() int {
+	cnt := 0
+	for _, ch := range e.mask {
+		if isMaskSlot(ch) {
+			cnt++
+		}
+	}
+	return cnt
+}
+
+// maskSlotPos returns the position of the character in the
+// formatted text by its index in the entered characters. If the
+// index is out of the mask it returns the mask length
+func (e *EditField) maskSlotPos(idx int) int {
+	for pos, ch := range e.mask {
+		if !isMaskSlot(ch) {
+			continue
+		}
+		if idx == 0 {
+			return pos
+		}
+		idx--
+	}
+	return len(e.mask)
+}
+
+// parseMasked picks characters that fit the mask from the text.
+// Literals of the mask that are in the text are skipped
+func (e *EditField) parseMasked(text string) []rune {
+	raw := make([]rune, 0, len(e.mask))
+	pos := 0
+	for _, ch := range text {
+		for pos < len(e.mask) && !isMaskSlot(e.mask[pos]) && e.mask[pos] != ch {
+			pos++
+		}
+		if pos >= len(e.mask) {
+			break
+		}
+
+		if !isMaskSlot(e.mask[pos]) {
+			pos++
+		} else if maskAccepts(e.mask[pos], ch) {
+			raw = append(raw, ch)
+			pos++
+		}
+	}
+	return raw
+}
+
+// formatMask returns entered characters with mask literals. The
+// text ends with the last entered character
+func (e *EditField) formatMask() string {
+	out := make([]rune, 0, len(e.mask))
+	idx := 0
+	for _, ch := range e.mask {
+		if idx >= len(e.maskRaw) {
+			break
+		}
+		if isMaskSlot(ch) {
+			out = append(out, e.maskRaw[idx])
+			idx++
+		} else {
+			out = append(out, ch)
+		}
+	}
+	return string(out)
+}
+
+// maskDisplay returns the text to display: the mask with entered
+// characters and empty positions replaced with '_'
+func (e *EditField) maskDisplay() string {
+	out := make([]rune, 0, len(e.mask))
+	idx := 0
+	for _, ch := range e.mask {
+		if !isMaskSlot(ch) {
+			out = append(out, ch)
+		} else if idx < len(e.maskRaw) {
+			out = append(out, e.maskRaw[idx])
+			idx++
+		} else {
+			out = append(out, '_')
+		}
+	}
+	return string(out)
+}
+
+// setMaskRaw replaces entered characters if all of them fit the mask
+func (e *EditField) setMaskRaw(raw []rune, cursor int) bool {
+	if len(raw) > e.maskSlots() {
+		return false
+	}
+	for idx, ch := range raw {
+		if !maskAccepts(e.mask[e.maskSlotPos(idx)], ch) {
+			return false
+		}
+	}
+
+	e.maskCursor = cursor
+	e.maskRaw = raw
+	e.setTitle(e.formatMask())
+	return true
+}
+
+// insertMasked inserts characters at the cursor position
+func (e *EditField) insertMasked(text []rune) {
+	if e.readonly {
+		return
+	}
+
+	c := e.maskCursor
+	raw := make([]rune, 0, len(e.maskRaw)+len(text))
+	raw = append(raw, e.maskRaw[:c]...)
+	raw = append(raw, text...)
+	raw = append(raw, e.maskRaw[c:]...)
+	e.setMaskRaw(raw, c+len(text))
+}
+
+// deleteMasked removes the entered character by its index
+func (e *EditField) deleteMasked(idx, cursor int) {
+	if e.readonly || idx < 0 || idx >= len(e.maskRaw) {
+		return
+	}
+
+	raw := make([]rune, 0, len(e.maskRaw))
+	raw = append(raw, e.maskRaw[:idx]...)
+	raw = append(raw, e.maskRaw[idx+1:]...)
+	e.setMaskRaw(raw, cursor)
+}
+
+func (e *EditField) moveMaskCursor(idx int) {
+	if idx < 0 {
+		idx = 0
+	}
+	if idx > len(e.maskRaw) {
+		idx = len(e.maskRaw)
+	}
+	e.maskCursor = idx
+	e.cursorPos = e.maskSlotPos(idx)
+}
+
+// processMaskKey processes keys if the field has input mask
+func (e *EditField) processMaskKey(event Event) bool {
+	switch event.Key {
+	case term.KeyEnter:
+		return false
+	case term.KeySpace:
+		e.insertMasked([]rune{' '})
+	case term.KeyBackspace:
+		e.deleteMasked(e.maskCursor-1, e.maskCursor-1)
+	case term.KeyDelete:
+		e.deleteMasked(e.maskCursor, e.maskCursor)
+	case term.KeyArrowLeft:
+		e.moveMaskCursor(e.maskCursor - 1)
+	case term.KeyArrowRight:
+		e.moveMaskCursor(e.maskCursor + 1)
+	case term.KeyHome:
+		e.moveMaskCursor(0)
+	case term.KeyEnd:
+		e.moveMaskCursor(len(e.maskRaw))
+	case term.KeyCtrlR:
+		if !e.readonly {
+			e.setMaskRaw([]rune{}, 0)
+		}
+	case term.KeyCtrlC:
+		clipboard.WriteAll(e.GetText())
+	case term.KeyCtrlV:
+		if !e.readonly {
+			s, _ := clipboard.ReadAll()
+			e.setTitle(s)
+			e.moveMaskCursor(len(e.maskRaw))
+		}
+	default:
+		if event.Ch == 0 {
+			return false
+		}
+		e.insertMasked([]rune{event.Ch})
+	}
+	return true
+}
+
+// InputMask returns the current input mask
+func (e *EditField) InputMask() string {
+	return string(e.mask)
+}
+
+// SetInputMask sets the format of the field text: '#' is a digit,
+// 'A' is a letter, '*' is any character, and other characters are
+// literals, e.g. "###-###-####" for a phone number. The characters
+// of the current text that fit the mask are kept. Empty pattern
+// removes the mask
+func (e *EditField) SetInputMask(pattern string) {
+	e.mask = []rune(pattern)
+	e.maskRaw = nil
+	e.maskCursor = 0
+	e.offset = 0
+
+	e.SetTitle(e.title)
+	if len(e.mask) != 0 {
+		e.moveMaskCursor(len(e.maskRaw))
+	} else {
+		e.end()
+	}
+}
+
+// MaskReturnRaw returns if GetText returns only the characters
+// entered by a user
+func (e *EditField) MaskReturnRaw() bool {
+	return e.returnRaw
+}
+
+// SetMaskReturnRaw selects what GetText returns for the field with
+// input mask: only the characters entered by a user(true) or the
+// text with mask literals(false, default one)
+func (e *EditField) SetMaskReturnRaw(raw bool) {
+	e.returnRaw = raw
+}
+
+// GetText returns the field text. For the field with input mask
+// and enabled MaskReturnRaw it returns the characters entered by
+// a user without mask literals
+func (e *EditField) GetText() string {
+	if len(e.mask) != 0 && e.returnRaw {
+		return string(e.maskRaw)
+	}
+	return e.title
+}
+
+func (e *EditField) state() editFieldState {
+	return editFieldState{text: e.title, cursorPos: e.cursorPos, offset: e.offset, maskCursor: e.maskCursor}
+}
+
+func (e *EditField) restoreState(st editFieldState) {
+	e.setTitle(st.text)
+	e.cursorPos, e.offset = st.cursorPos, st.offset
+	if len(e.mask) != 0 {
+		e.moveMaskCursor(st.maskCursor)
+	}
+}
+
+// pushState adds the state to the history respecting the undo limit
+func (e *EditField) pushState(stack *[]editFieldState, st editFieldState) {
+	if e.undoLimit == 0 {
+		return
+	}
+
+	*stack = append(*stack, st)
+	if len(*stack) > e.undoLimit {
+		*stack = (*stack)[len(*stack)-e.undoLimit:]
+	}
+}
+
+// Undo reverts the last change made by a user
+func (e *EditField) Undo() {
+	if e.readonly || len(e.undo) == 0 {
+		return
+	}
+
+	st := e.undo[len(e.undo)-1]
+	e.undo = e.undo[:len(e.undo)-1]
+	e.pushState(&e.redo, e.state())
+	e.restoreState(st)
+}
+
+// Redo restores the last change reverted with Undo
+func (e *EditField) Redo() {
+	if e.readonly || len(e.redo) == 0 {
+		return
+	}
+
+	st := e.redo[len(e.redo)-1]
+	e.redo = e.redo[:len(e.redo)-1]
+	e.pushState(&e.undo, e.state())
+	e.restoreState(st)
+}
+
+// UndoLimit returns the maximum number of changes that can be undone
+func (e *EditField) UndoLimit() int {
+	return e.undoLimit
+}
+
+// SetUndoLimit sets the maximum number of changes that can be
+// undone. Zero disables undo
+func (e *EditField) SetUndoLimit(n int) {
+	if n < 0 {
+		n = 0
+	}
+	e.undoLimit = n
+
+	if len(e.undo) > n {
+		e.undo = e.undo[len(e.undo)-n:]
+	}
+	if len(e.redo) > n {
+		e.redo = e.redo[len(e.redo)-n:]
+	}
+}
+
+// SetMaxWidth sets the maximum lenght of the EditField text. If the current text is longer it is truncated,
+// the selection is removed and the cursor moves to the end of the text
 func (e *EditField) SetMaxWidth(w int) {
 	e.maxWidth = w
 	if w > 0 && xs.Len(e.title) > w {
 		e.title = xs.Slice(e.title, 0, w)
+		e.selecting = false
+		e.selAnchor = 0
+		e.Validate()
 		e.end()
+		e.fixOffset()
+	}
+}
+
+// Placeholder returns the hint text displayed when the field is empty
+func (e *EditField) Placeholder() string {
+	return e.placeholder
+}
+
+// SetPlaceholder sets the hint text that is displayed when the field
+// is empty. The hint is not a part of the field text and disappears
+// as soon as a user types anything
+func (e *EditField) SetPlaceholder(text string) {
+	e.placeholder = text
+}
+
+// SetValidator sets a function that checks the field text. The
+// function should return nil if the text is valid and an error that
+// describes the problem otherwise. Pass nil to remove the validator.
+// The current text is checked immediately
+func (e *EditField) SetValidator(fn func(s string) error) {
+	e.validator = fn
+	e.Validate()
+}
+
+// Validate checks the field text with the validator and returns
+// the validation result. The field without validator is always valid
+func (e *EditField) Validate() error {
+	e.validErr = nil
+	if e.validator != nil {
+		e.validErr = e.validator(e.title)
 	}
+	return e.validErr
+}
+
+// IsValid returns true if the field text passed the last validation
+func (e *EditField) IsValid() bool {
+	return e.validErr == nil
+}
+
+// ValidationError returns the error of the last validation or nil
+// if the text is valid
+func (e *EditField) ValidationError() error {
+	return e.validErr
 }
 
 // MaxWidth returns the current maximum text length. Zero means no limit
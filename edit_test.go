@@ -0,0 +1,264 @@
+package clui
+
+import (
+	"errors"
+	term "github.com/nsf/termbox-go"
+	"testing"
+)
+
+func TestEditFieldValidator(t *testing.T) {
+	e := CreateEditField(nil, 10, "", Fixed)
+	if !e.IsValid() || e.ValidationError() != nil {
+		t.Error("Field without validator must be valid")
+	}
+
+	errEmpty := errors.New("value is required")
+	e.SetValidator(func(s string) error {
+		if s == "" {
+			return errEmpty
+		}
+		return nil
+	})
+	if e.IsValid() || e.ValidationError() != errEmpty {
+		t.Errorf("Empty field must be invalid, got %v", e.ValidationError())
+	}
+
+	e.SetTitle("text")
+	if !e.IsValid() {
+		t.Errorf("Field must be valid after change, got %v", e.ValidationError())
+	}
+}
+
+func TestValidateChildren(t *testing.T) {
+	wnd := CreateWindow(0, 0, 20, 10, "")
+	frame := CreateFrame(wnd, 10, 5, BorderNone, Fixed)
+	first := CreateEditField(wnd, 5, "", Fixed)
+	second := CreateEditField(frame, 5, "", Fixed)
+
+	if !ValidateChildren(wnd) {
+		t.Error("Fields without validators must be valid")
+	}
+
+	second.SetValidator(func(s string) error {
+		if s != "ok" {
+			return errors.New("invalid")
+		}
+		return nil
+	})
+	first.SetValidator(second.validator)
+	second.SetTitle("ok")
+	if ValidateChildren(wnd) {
+		t.Error("Window with invalid field must be invalid")
+	}
+
+	first.SetTitle("ok")
+	if !ValidateChildren(wnd) {
+		t.Error("Window with valid fields must be valid")
+	}
+}
+
+func TestEditFieldPlaceholder(t *testing.T) {
+	mock := CreateMockCanvas(10, 1)
+	defer mock.Close()
+
+	e := CreateEditField(nil, 10, "", Fixed)
+	e.SetActive(false)
+	e.SetPlaceholder("Search")
+	if e.Placeholder() != "Search" {
+		t.Errorf("Invalid placeholder %q", e.Placeholder())
+	}
+
+	e.Draw()
+	mock.AssertTextAt(t, 0, 0, "Search    ")
+	if fg := mock.Cell(0, 0).Fg; fg != RealColor(ColorDefault, ColorEditPlaceholder) {
+		t.Errorf("Invalid placeholder color %v", fg)
+	}
+
+	e.SetActive(true)
+	e.Draw()
+	mock.AssertTextAt(t, 0, 0, "Search    ")
+	if fg := mock.Cell(0, 0).Fg; fg != RealColor(ColorDefault, ColorEditActivePlaceholder) {
+		t.Errorf("Invalid active placeholder color %v", fg)
+	}
+
+	// the placeholder is not a part of the text and hides after typing
+	e.ProcessEvent(Event{Type: EventKey, Ch: 'a'})
+	if e.Title() != "a" {
+		t.Errorf("Expected 'a', got %q", e.Title())
+	}
+	e.Draw()
+	mock.AssertTextAt(t, 0, 0, "a         ")
+
+	e.ProcessEvent(Event{Type: EventKey, Key: term.KeyBackspace})
+	e.SetActive(false)
+	e.Draw()
+	mock.AssertTextAt(t, 0, 0, "Search    ")
+
+	e.SetEnabled(false)
+	e.Draw()
+	mock.AssertTextAt(t, 0, 0, "          ")
+}
+
+func TestEditFieldInputMask(t *testing.T) {
+	e := CreateEditField(nil, 15, "", Fixed)
+	e.SetActive(true)
+	e.SetInputMask("(###) ###-####")
+
+	for _, ch := range "55a51234" {
+		e.ProcessEvent(Event{Type: EventKey, Ch: ch})
+	}
+	if s := e.GetText(); s != "(555) 123-4" {
+		t.Errorf("Expected '(555) 123-4', got %q", s)
+	}
+	if s := e.maskDisplay(); s != "(555) 123-4___" {
+		t.Errorf("Expected '(555) 123-4___', got %q", s)
+	}
+	if e.cursorPos != 11 {
+		t.Errorf("Expected cursor at 11, got %v", e.cursorPos)
+	}
+
+	// backspace removes the last digit and skips the literal
+	e.ProcessEvent(Event{Type: EventKey, Key: term.KeyBackspace})
+	e.ProcessEvent(Event{Type: EventKey, Key: term.KeyBackspace})
+	if s := e.GetText(); s != "(555) 12" || e.cursorPos != 8 {
+		t.Errorf("Expected '(555) 12' and cursor at 8, got %q and %v", e.GetText(), e.cursorPos)
+	}
+
+	e.SetMaskReturnRaw(true)
+	if s := e.GetText(); s != "55512" {
+		t.Errorf("Expected raw '55512', got %q", s)
+	}
+
+	e.SetTitle("(123) 456-7890")
+	if s := e.GetText(); s != "1234567890" {
+		t.Errorf("Expected raw '1234567890', got %q", s)
+	}
+	e.ProcessEvent(Event{Type: EventKey, Ch: '1'})
+	if s := e.Title(); s != "(123) 456-7890" {
+		t.Errorf("Full mask must not accept characters, got %q", s)
+	}
+}
+
+func TestEditFieldMaskClasses(t *testing.T) {
+	e := CreateEditField(nil, 15, "ab12cd34", Fixed)
+	e.SetInputMask("AA-##")
+	if s := e.Title(); s != "ab-12" {
+		t.Errorf("Expected 'ab-12', got %q", s)
+	}
+
+	e.SetInputMask("")
+	if s := e.Title(); s != "ab-12" {
+		t.Errorf("Text must be kept after removing the mask, got %q", s)
+	}
+}
+
+func TestEditFieldUndo(t *testing.T) {
+	e := CreateEditField(nil, 15, "", Fixed)
+	e.SetActive(true)
+	for _, ch := range "abc" {
+		e.ProcessEvent(Event{Type: EventKey, Ch: ch})
+	}
+	e.ProcessEvent(Event{Type: EventKey, Key: term.KeyBackspace})
+	if e.Title() != "ab" {
+		t.Errorf("Expected 'ab', got %q", e.Title())
+	}
+
+	e.ProcessEvent(Event{Type: EventKey, Key: term.KeyCtrlZ})
+	e.ProcessEvent(Event{Type: EventKey, Key: term.KeyCtrlZ})
+	if e.Title() != "ab" || e.cursorPos != 2 {
+		t.Errorf("Expected 'ab' with cursor at 2, got %q at %v", e.Title(), e.cursorPos)
+	}
+
+	e.ProcessEvent(Event{Type: EventKey, Key: term.KeyCtrlY})
+	if e.Title() != "abc" {
+		t.Errorf("Expected 'abc' after redo, got %q", e.Title())
+	}
+
+	// a new change clears redo history
+	e.ProcessEvent(Event{Type: EventKey, Ch: 'd'})
+	e.Redo()
+	if e.Title() != "abcd" {
+		t.Errorf("Expected 'abcd', got %q", e.Title())
+	}
+
+	e.SetUndoLimit(2)
+	for i := 0; i < 5; i++ {
+		e.Undo()
+	}
+	if e.Title() != "ab" {
+		t.Errorf("Expected 'ab' after limited undo, got %q", e.Title())
+	}
+
+	e.SetTitle("new")
+	e.Undo()
+	if e.Title() != "new" {
+		t.Errorf("SetTitle must clear undo history, got %q", e.Title())
+	}
+}
+
+func TestEditFieldSelection(t *testing.T) {
+	e := CreateEditField(nil, 15, "hello world", Fixed)
+	e.SetActive(true)
+
+	// select "world" from the end
+	for i := 0; i < 5; i++ {
+		e.ProcessEvent(Event{Type: EventKey, Key: term.KeyArrowLeft, Mod: term.ModAlt})
+	}
+	if s := e.SelectedText(); s != "world" {
+		t.Errorf("Expected 'world' selected, got %q", s)
+	}
+
+	for _, ch := range "all" {
+		e.ProcessEvent(Event{Type: EventKey, Ch: ch})
+	}
+	if e.Title() != "hello all" || e.SelectedText() != "" {
+		t.Errorf("Expected 'hello all' without selection, got %q and %q", e.Title(), e.SelectedText())
+	}
+
+	// moving without Alt removes selection
+	e.ProcessEvent(Event{Type: EventKey, Key: term.KeyHome, Mod: term.ModAlt})
+	e.ProcessEvent(Event{Type: EventKey, Key: term.KeyArrowRight})
+	if e.SelectedText() != "" {
+		t.Errorf("Selection must be removed, got %q", e.SelectedText())
+	}
+
+	e.ProcessEvent(Event{Type: EventKey, Key: term.KeyCtrlA})
+	if s := e.SelectedText(); s != "hello all" {
+		t.Errorf("Expected all text selected, got %q", s)
+	}
+	e.ProcessEvent(Event{Type: EventKey, Key: term.KeyBackspace})
+	if e.Title() != "" {
+		t.Errorf("Expected empty text, got %q", e.Title())
+	}
+}
+
+func TestEditFieldMaxWidthSelection(t *testing.T) {
+	mock := CreateMockCanvas(10, 1)
+	defer mock.Close()
+
+	e := CreateEditField(nil, 10, "hello world", Fixed)
+	e.SetActive(true)
+	e.SelectAll()
+	e.SetMaxWidth(3)
+	if e.Title() != "hel" || e.SelectedText() != "" {
+		t.Errorf("Expected 'hel' without selection, got %q and %q", e.Title(), e.SelectedText())
+	}
+	e.Draw()
+	mock.AssertTextAt(t, 0, 0, "hel       ")
+
+	// the field that scrolls its text
+	e = CreateEditField(nil, 5, "hello world", Fixed)
+	e.SetActive(true)
+	e.SelectAll()
+	e.SetMaxWidth(4)
+	if e.Title() != "hell" || e.SelectedText() != "" {
+		t.Errorf("Expected 'hell' without selection, got %q and %q", e.Title(), e.SelectedText())
+	}
+	e.Draw()
+	mock.AssertTextAt(t, 0, 0, "hell ")
+
+	e.ProcessEvent(Event{Type: EventKey, Ch: 'o'})
+	if e.Title() != "hell" {
+		t.Errorf("Text must not be longer than MaxWidth, got %q", e.Title())
+	}
+}
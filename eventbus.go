@@ -0,0 +1,125 @@
+package clui
+
+import (
+	"sync"
+)
+
+// CustomEvent is a user-defined event that controls send to each other
+// through the Window event bus
+type CustomEvent struct {
+	// Type is an event name that subscribers use to select events
+	Type string
+	// Source is a control that posted the event, it may be nil
+	Source Control
+	// Payload is any data attached to the event
+	Payload interface{}
+}
+
+// SubscribeToken identifies a subscription. Use it to unsubscribe
+type SubscribeToken int
+
+type subscription struct {
+	token   SubscribeToken
+	handler func(CustomEvent)
+}
+
+// eventBus queues custom events of a Window and delivers them to
+// subscribers. PostEvent can be called from any goroutine, so all
+// fields are guarded with the mutex
+type eventBus struct {
+	mtx       sync.Mutex
+	queue     []CustomEvent
+	calls     []func()
+	subs      map[string][]subscription
+	lastToken SubscribeToken
+}
+
+func newEventBus() *eventBus {
+	return &eventBus{subs: make(map[string][]subscription)}
+}
+
+// PostEvent queues the event. The event is delivered to subscribers
+// later by the main loop, so handlers are always called in the goroutine
+// that processes all other events and can change controls safely.
+// The function can be called from any goroutine
+func (c *Window) PostEvent(ev CustomEvent) {
+	c.bus.mtx.Lock()
+	c.bus.queue = append(c.bus.queue, ev)
+	c.bus.mtx.Unlock()
+
+	if loop != nil {
+		PutEvent(Event{Type: EventCustom})
+	}
+}
+
+// runOnLoop queues the function to be called by the main loop the same
+// way as PostEvent queues events. Control callbacks are called in new
+// goroutines, so they use it to change controls. The screen is
+// refreshed after the call
+func (c *Window) runOnLoop(fn func()) {
+	c.bus.mtx.Lock()
+	c.bus.calls = append(c.bus.calls, fn)
+	c.bus.mtx.Unlock()
+
+	if loop != nil {
+		PutEvent(Event{Type: EventCustom})
+	}
+}
+
+// Subscribe adds the handler for events of the eventType. Returns the
+// token to remove the handler with Unsubscribe
+func (c *Window) Subscribe(eventType string, handler func(CustomEvent)) SubscribeToken {
+	c.bus.mtx.Lock()
+	defer c.bus.mtx.Unlock()
+
+	c.bus.lastToken++
+	sub := subscription{token: c.bus.lastToken, handler: handler}
+	c.bus.subs[eventType] = append(c.bus.subs[eventType], sub)
+	return sub.token
+}
+
+// Unsubscribe removes the handler added with Subscribe. It does nothing
+// if the token is unknown
+func (c *Window) Unsubscribe(token SubscribeToken) {
+	c.bus.mtx.Lock()
+	defer c.bus.mtx.Unlock()
+
+	for evType, subs := range c.bus.subs {
+		for i, sub := range subs {
+			if sub.token == token {
+				c.bus.subs[evType] = append(subs[:i:i], subs[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// deliver calls all queued functions and subscribers of all queued
+// events. Events posted by handlers are delivered during the next call
+func (b *eventBus) deliver() {
+	b.mtx.Lock()
+	queue, calls := b.queue, b.calls
+	b.queue, b.calls = nil, nil
+	b.mtx.Unlock()
+
+	for _, fn := range calls {
+		fn()
+	}
+	for _, ev := range queue {
+		b.mtx.Lock()
+		subs := b.subs[ev.Type]
+		b.mtx.Unlock()
+
+		for _, sub := range subs {
+			sub.handler(ev)
+		}
+	}
+}
+
+// deliverCustomEvents delivers queued custom events of all Windows
+func (c *Composer) deliverCustomEvents() {
+	for _, wnd := range c.windows {
+		wnd.(*Window).bus.deliver()
+	}
+	RefreshScreen()
+}
@@ -0,0 +1,40 @@
+package clui
+
+import (
+	"testing"
+)
+
+func TestEventBus(t *testing.T) {
+	wnd := CreateWindow(0, 0, 20, 8, "Test")
+	lbox := CreateListBox(wnd, 10, 5, Fixed)
+
+	var got []string
+	token := wnd.Subscribe("SelectionChanged", func(ev CustomEvent) {
+		if ev.Source != lbox {
+			t.Error("Invalid event source")
+		}
+		got = append(got, ev.Payload.(string))
+	})
+	wnd.Subscribe("Other", func(ev CustomEvent) {
+		got = append(got, "other")
+	})
+
+	wnd.PostEvent(CustomEvent{Type: "SelectionChanged", Source: lbox, Payload: "first"})
+	wnd.PostEvent(CustomEvent{Type: "SelectionChanged", Source: lbox, Payload: "second"})
+	if len(got) != 0 {
+		t.Error("Events must be delivered by the main loop")
+	}
+
+	wnd.bus.deliver()
+	if len(got) != 2 || got[0] != "first" || got[1] != "second" {
+		t.Errorf("Events must be delivered in order: %v", got)
+	}
+
+	wnd.Unsubscribe(token)
+	wnd.PostEvent(CustomEvent{Type: "SelectionChanged", Source: lbox, Payload: "third"})
+	wnd.PostEvent(CustomEvent{Type: "Other"})
+	wnd.bus.deliver()
+	if len(got) != 3 || got[2] != "other" {
+		t.Errorf("Unsubscribed handler must not be called: %v", got)
+	}
+}
@@ -0,0 +1,375 @@
+package clui
+
+import (
+	term "github.com/nsf/termbox-go"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// FileDialogMode defines what a FileDialog is used for
+type FileDialogMode int
+
+// FileDialog modes
+const (
+	// FileDialogOpen - select an existing file
+	FileDialogOpen FileDialogMode = iota
+	// FileDialogSave - select a directory and enter a file name
+	FileDialogSave
+)
+
+// fileDialogParent is the ListBox item to go to the parent directory
+const fileDialogParent = "../"
+
+// doubleClickTime is the maximal time between two clicks of a double click
+const doubleClickTime = 500 * time.Millisecond
+
+// fileDialog keeps the state of a displayed FileDialog
+type fileDialog struct {
+	frame  *ModalFrame
+	mode   FileDialogMode
+	dir    string
+	tree   *TreeView
+	list   *ListBox
+	filter *EditField
+	name   *EditField
+
+	// paths of tree nodes and nodes which subdirectories are loaded
+	paths  map[*TreeNode]string
+	loaded map[*TreeNode]bool
+
+	lastClick     time.Time
+	lastClickItem string
+
+	done func(string, bool)
+}
+
+// fileDialogSize returns the dialog size: 80% of the screen but not
+// greater than the Window
+func fileDialogSize(view *Window) (int, int) {
+	sw, sh := ScreenSize()
+	w, h := sw*8/10, sh*8/10
+	if vw, vh := view.Size(); vw > 0 && vh > 0 {
+		if w > vw-2 {
+			w = vw - 2
+		}
+		if h > vh-2 {
+			h = vh - 2
+		}
+	}
+	if w < 40 {
+		w = 40
+	}
+	if h < 14 {
+		h = 14
+	}
+	return w, h
+}
+
+// createFileDialog builds the FileDialog. done is called with the
+// selected path when a user selects a file or presses Cancel. Control
+// callbacks are called in new goroutines, so they change the dialog
+// through Window.runOnLoop and done is always called by the main loop
+func createFileDialog(view *Window, mode FileDialogMode, startDir string, done func(string, bool)) *fileDialog {
+	d := new(fileDialog)
+	d.mode = mode
+	d.done = done
+	d.paths = make(map[*TreeNode]string)
+	d.loaded = make(map[*TreeNode]bool)
+
+	title := "Open File"
+	if mode == FileDialogSave {
+		title = "Save File"
+	}
+
+	w, h := fileDialogSize(view)
+	d.frame = CreateModalFrame(w, h, title)
+	d.frame.SetGaps(0, 1)
+
+	top := CreateFrame(d.frame, 1, 1, BorderNone, Fixed)
+	top.SetGaps(1, 0)
+	CreateLabel(top, AutoSize, 1, "Filter:", Fixed)
+	d.filter = CreateEditField(top, 10, "*", 1)
+	d.filter.OnChange(func(ev Event) {
+		view.runOnLoop(d.refreshList)
+	})
+
+	body := CreateFrame(d.frame, 1, 1, BorderNone, 1)
+	body.SetGaps(1, 0)
+	d.tree = CreateTreeView(body, 10, 3, 1)
+	d.tree.OnSelect(func(node *TreeNode) {
+		view.runOnLoop(func() {
+			if path, ok := d.paths[node]; ok && path != d.dir {
+				d.chdir(path)
+			}
+		})
+	})
+	d.list = CreateListBox(body, 20, 3, 2)
+	d.list.OnKeyPress(func(key term.Key) bool {
+		if key == term.KeyCtrlM {
+			d.activate(d.list.SelectedItemText())
+			return true
+		}
+		return false
+	})
+	d.list.OnSelectItem(func(ev Event) {
+		// the callback is called for every mouse click
+		now := time.Now()
+		view.runOnLoop(func() {
+			d.selectItem(ev.Msg, now)
+		})
+	})
+
+	if mode == FileDialogSave {
+		bottom := CreateFrame(d.frame, 1, 1, BorderNone, Fixed)
+		bottom.SetGaps(1, 0)
+		CreateLabel(bottom, AutoSize, 1, "Name:  ", Fixed)
+		d.name = CreateEditField(bottom, 10, "", 1)
+	}
+
+	row := CreateFrame(d.frame, 1, 4, BorderNone, Fixed)
+	row.SetGaps(1, 0)
+	CreateFrame(row, 1, 1, BorderNone, 1)
+	btnOK := CreateButton(row, 10, AutoSize, "OK", Fixed)
+	btnOK.OnClick(func(ev Event) {
+		view.runOnLoop(d.accept)
+	})
+	btnCancel := CreateButton(row, 10, AutoSize, "Cancel", Fixed)
+	btnCancel.OnClick(func(ev Event) {
+		view.runOnLoop(func() {
+			d.done("", false)
+		})
+	})
+	CreateFrame(row, 1, 1, BorderNone, 1)
+
+	d.frame.OnKeyDown(func(ev Event) bool {
+		switch ev.Key {
+		case term.KeyCtrlM:
+			d.accept()
+		case term.KeyEsc:
+			d.done("", false)
+		default:
+			return false
+		}
+		return true
+	})
+
+	dir, err := filepath.Abs(startDir)
+	if err != nil {
+		dir, _ = os.Getwd()
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		dir = filepath.Dir(dir)
+	}
+	d.chdir(dir)
+
+	return d
+}
+
+// subdirs returns sorted names of subdirectories
+func subdirs(dir string) []string {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	names := make([]string, 0)
+	for _, info := range infos {
+		if info.IsDir() {
+			names = append(names, info.Name())
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// loadNode adds subdirectories of the node directory as its children
+func (d *fileDialog) loadNode(node *TreeNode) {
+	if d.loaded[node] {
+		return
+	}
+
+	d.loaded[node] = true
+	path := d.paths[node]
+	for _, name := range subdirs(path) {
+		child := node.AddChild(name)
+		d.paths[child] = filepath.Join(path, name)
+	}
+}
+
+// nodeFor returns the tree node of the directory. The directory
+// ancestors are loaded and expanded
+func (d *fileDialog) nodeFor(dir string) *TreeNode {
+	root := d.tree.Root()
+	rootPath := filepath.VolumeName(dir) + string(filepath.Separator)
+	if root == nil || d.paths[root] != rootPath {
+		root = &TreeNode{Label: rootPath}
+		d.paths = map[*TreeNode]string{root: rootPath}
+		d.loaded = make(map[*TreeNode]bool)
+		d.tree.SetRoot(root)
+	}
+
+	node := root
+	rel := strings.TrimPrefix(dir, rootPath)
+	if rel == "" {
+		return node
+	}
+	for _, part := range strings.Split(rel, string(filepath.Separator)) {
+		d.loadNode(node)
+		node.Expanded = true
+
+		var next *TreeNode
+		for _, child := range node.Children {
+			if child.Label == part {
+				next = child
+				break
+			}
+		}
+		if next == nil {
+			break
+		}
+		node = next
+	}
+	return node
+}
+
+// chdir displays the directory content and selects the directory
+// in the tree
+func (d *fileDialog) chdir(dir string) {
+	d.dir = dir
+	node := d.nodeFor(dir)
+	d.loadNode(node)
+	if d.tree.SelectedNode() != node {
+		d.tree.SelectNode(node)
+	}
+	d.refreshList()
+}
+
+// refreshList fills the ListBox with subdirectories and files that
+// match the filter of the current directory
+func (d *fileDialog) refreshList() {
+	d.list.Clear()
+	if filepath.Dir(d.dir) != d.dir {
+		d.list.AddItem(fileDialogParent)
+	}
+
+	infos, err := ioutil.ReadDir(d.dir)
+	if err != nil {
+		return
+	}
+
+	pattern := strings.TrimSpace(d.filter.Title())
+	if pattern == "" {
+		pattern = "*"
+	}
+	files := make([]string, 0)
+	for _, info := range infos {
+		if info.IsDir() {
+			d.list.AddItem(info.Name() + "/")
+			continue
+		}
+		if ok, _ := filepath.Match(pattern, info.Name()); ok {
+			files = append(files, info.Name())
+		}
+	}
+	for _, name := range files {
+		d.list.AddItem(name)
+	}
+
+	if d.list.ItemCount() > 0 {
+		d.list.SelectItem(0)
+	}
+}
+
+// selectItem processes a click on the ListBox item: the second click
+// on the same item opens it, the first one in save mode copies the
+// file name to the name EditField
+func (d *fileDialog) selectItem(item string, now time.Time) {
+	if item == d.lastClickItem && now.Sub(d.lastClick) < doubleClickTime {
+		d.lastClickItem = ""
+		d.activate(item)
+		return
+	}
+	d.lastClick, d.lastClickItem = now, item
+	if d.mode == FileDialogSave && item != "" && !strings.HasSuffix(item, "/") {
+		d.name.SetTitle(item)
+	}
+}
+
+// activate opens the directory or selects the file displayed by the
+// ListBox item
+func (d *fileDialog) activate(item string) {
+	switch {
+	case item == "":
+		return
+	case item == fileDialogParent:
+		d.chdir(filepath.Dir(d.dir))
+	case strings.HasSuffix(item, "/"):
+		d.chdir(filepath.Join(d.dir, strings.TrimSuffix(item, "/")))
+	case d.mode == FileDialogSave:
+		d.name.SetTitle(item)
+		d.accept()
+	default:
+		d.done(filepath.Join(d.dir, item), true)
+	}
+}
+
+// accept closes the dialog with the selected file. In save mode the
+// file name is taken from the name EditField
+func (d *fileDialog) accept() {
+	if d.mode == FileDialogSave {
+		if name := strings.TrimSpace(d.name.GetText()); name != "" {
+			d.done(filepath.Join(d.dir, name), true)
+		}
+		return
+	}
+
+	item := d.list.SelectedItemText()
+	if item == "" {
+		return
+	}
+	d.activate(item)
+}
+
+/*
+ShowFileDialog displays a modal dialog inside the Window to select a file
+and waits until a user closes it. The dialog takes up to 80% of the screen.
+The TreeView at the left side displays directories, and the ListBox at
+the right side displays the content of the selected directory. The filter
+at the top hides files which names do not match the glob pattern.
+
+Tab moves the focus between the dialog parts. Enter or a double click on
+a directory opens it, Enter or a double click on a file selects it. In
+save mode a user can type a name of a new file. The function returns the
+full path to the file and true, or an empty string and false if the user
+pressed Cancel or Escape.
+
+The function blocks, so it must not be called from the goroutine that
+processes events: e.g, call it inside a new goroutine from a Button
+OnClick callback. The dialog is created, displayed, and closed by the
+main loop.
+*/
+func ShowFileDialog(view *Window, mode FileDialogMode, startDir string) (string, bool) {
+	ch := make(chan bool, 1)
+	path, ok := "", false
+
+	view.runOnLoop(func() {
+		var d *fileDialog
+		d = createFileDialog(view, mode, startDir, func(s string, accepted bool) {
+			if view.ModalDialog() == d.frame {
+				path, ok = s, accepted
+				view.CloseModal()
+			}
+		})
+		d.frame.OnClose(func() {
+			ch <- ok
+		})
+		view.ShowModal(d.frame)
+	})
+
+	<-ch
+	return path, ok
+}
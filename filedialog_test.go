@@ -0,0 +1,131 @@
+package clui
+
+import (
+	term "github.com/nsf/termbox-go"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileDialog(t *testing.T) {
+	saved := canvas
+	canvas = newMemoryCanvas(80, 25)
+	defer func() { canvas = saved }()
+
+	dir, err := ioutil.TempDir("", "clui")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	os.Mkdir(filepath.Join(dir, "docs"), 0755)
+	for _, name := range []string{"b.go", "a.txt", filepath.Join("docs", "readme.md")} {
+		ioutil.WriteFile(filepath.Join(dir, name), nil, 0644)
+	}
+
+	wnd := CreateWindow(0, 0, 80, 25, "Test")
+	path, ok := "", false
+	d := createFileDialog(wnd, FileDialogOpen, dir, func(s string, accepted bool) {
+		path, ok = s, accepted
+	})
+
+	if w, h := d.frame.Size(); w != 64 || h != 20 {
+		t.Errorf("The dialog must take 80%% of the screen: %vx%v", w, h)
+	}
+	items := func() []string {
+		res := make([]string, 0)
+		for i := 0; i < d.list.ItemCount(); i++ {
+			res = append(res, d.list.itemText(i))
+		}
+		return res
+	}
+	if res := items(); len(res) != 4 || res[1] != "docs/" || res[2] != "a.txt" || res[3] != "b.go" {
+		t.Errorf("Invalid directory content: %v", res)
+	}
+
+	d.filter.SetTitle("*.go")
+	d.refreshList()
+	if res := items(); len(res) != 3 || res[2] != "b.go" {
+		t.Errorf("Files must be filtered: %v", res)
+	}
+
+	d.activate("docs/")
+	if d.dir != filepath.Join(dir, "docs") || d.paths[d.tree.SelectedNode()] != d.dir {
+		t.Errorf("Invalid directory after navigation: %v", d.dir)
+	}
+
+	d.filter.SetTitle("*")
+	d.refreshList()
+	d.list.SelectItem(1)
+	d.list.SetActive(true)
+	d.list.ProcessEvent(Event{Type: EventKey, Key: term.KeyEnter})
+	if !ok || path != filepath.Join(dir, "docs", "readme.md") {
+		t.Errorf("Enter must select the file: %v %v", path, ok)
+	}
+}
+
+func TestFileDialogCallbacks(t *testing.T) {
+	saved := canvas
+	canvas = newMemoryCanvas(80, 25)
+	defer func() { canvas = saved }()
+
+	dir, err := ioutil.TempDir("", "clui")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	os.Mkdir(filepath.Join(dir, "docs"), 0755)
+	for _, name := range []string{"b.go", "a.txt"} {
+		ioutil.WriteFile(filepath.Join(dir, name), nil, 0644)
+	}
+
+	wnd := CreateWindow(0, 0, 80, 25, "Test")
+	type result struct {
+		path string
+		ok   bool
+	}
+	ch := make(chan result, 1)
+	go func() {
+		path, ok := ShowFileDialog(wnd, FileDialogOpen, dir)
+		ch <- result{path, ok}
+	}()
+
+	deliverUntil(t, wnd, func() bool { return wnd.ModalDialog() != nil })
+	frame := wnd.ModalDialog().(*ModalFrame)
+	var filter *EditField
+	var list *ListBox
+	FindFirstControl(frame, func(ctrl Control) bool {
+		switch c := ctrl.(type) {
+		case *EditField:
+			filter = c
+		case *ListBox:
+			list = c
+		}
+		return false
+	})
+
+	// the filter callback refreshes the list on the main loop
+	filter.SetTitle("*.go")
+	deliverUntil(t, wnd, func() bool { return list.ItemCount() == 3 })
+	if item := list.itemText(2); item != "b.go" {
+		t.Errorf("Files must be filtered: %v", item)
+	}
+
+	// a double click on a file selects it
+	x, y := list.Pos()
+	click := Event{Type: EventMouse, Key: term.MouseLeft, X: x + 1, Y: y + 2}
+	wnd.ProcessEvent(click)
+	deliverUntil(t, wnd, func() bool { return list.SelectedItem() == 2 })
+	wnd.ProcessEvent(click)
+	deliverUntil(t, wnd, func() bool { return wnd.ModalDialog() == nil })
+
+	select {
+	case res := <-ch:
+		if !res.ok || res.path != filepath.Join(dir, "b.go") {
+			t.Errorf("Double click must select the file: %v %v", res.path, res.ok)
+		}
+	case <-time.After(2 * time.Second):
+		t.Error("ShowFileDialog did not return")
+	}
+}
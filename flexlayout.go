@@ -0,0 +1,371 @@
+package clui
+
+// FlexDirection is the main axis of FlexLayout
+type FlexDirection int
+
+// FlexLayout directions
+const (
+	// FlexRow arranges children from left to right
+	FlexRow FlexDirection = iota
+	// FlexColumn arranges children from top to bottom
+	FlexColumn
+)
+
+// FlexJustify defines how FlexLayout distributes free space along the
+// main axis when no child can grow
+type FlexJustify int
+
+// FlexLayout main axis alignments
+const (
+	// FlexJustifyStart packs children to the line start
+	FlexJustifyStart FlexJustify = iota
+	// FlexJustifyEnd packs children to the line end
+	FlexJustifyEnd
+	// FlexJustifyCenter packs children to the line center
+	FlexJustifyCenter
+	// FlexJustifySpaceBetween puts equal space between children
+	FlexJustifySpaceBetween
+	// FlexJustifySpaceAround puts equal space around every child
+	FlexJustifySpaceAround
+)
+
+// FlexAlign defines how FlexLayout aligns children along the cross axis
+type FlexAlign int
+
+// FlexLayout cross axis alignments
+const (
+	// FlexAlignStretch makes children fill the line height(or width
+	// for FlexColumn)
+	FlexAlignStretch FlexAlign = iota
+	// FlexAlignStart aligns children to the line start
+	FlexAlignStart
+	// FlexAlignEnd aligns children to the line end
+	FlexAlignEnd
+	// FlexAlignCenter aligns children to the line center
+	FlexAlignCenter
+)
+
+// flexItem keeps flex factors of a child
+type flexItem struct {
+	grow, shrink float64
+	basis        int
+}
+
+// flexLine is a set of children that are displayed in one line and
+// their sizes along both axes
+type flexLine struct {
+	items      []Control
+	main       []int
+	cross      []int
+	crossSize  int
+	totalBasis int
+}
+
+/*
+FlexLayout is a layout manager that arranges children using a simplified
+CSS flexbox algorithm. Every child has its basis - the size along the main
+axis before adjusting, grow factor, and shrink factor. If the sum of bases
+is less than the container size then the free space is distributed between
+children proportionally to their grow factors. If the sum is greater then
+children are shrunk proportionally to their shrink factors multiplied by
+bases. A child never becomes smaller than its minimal size.
+Container gaps are used as space between children and between lines.
+Children are arranged in the order they were added to the container.
+*/
+type FlexLayout struct {
+	direction  FlexDirection
+	wrap       bool
+	justify    FlexJustify
+	alignItems FlexAlign
+	items      map[Control]flexItem
+}
+
+// CreateFlexLayout creates a new FlexLayout with FlexRow direction.
+// Set it as the layout of a container with SetLayout
+func CreateFlexLayout() *FlexLayout {
+	f := new(FlexLayout)
+	f.items = make(map[Control]flexItem)
+	return f
+}
+
+// AddChild sets flex factors of the container child. basis is the child
+// size along the main axis, AutoSize means the child minimal size.
+// Children without flex factors neither grow nor shrink
+func (f *FlexLayout) AddChild(ctrl Control, grow, shrink float64, basis int) {
+	if grow < 0 {
+		grow = 0
+	}
+	if shrink < 0 {
+		shrink = 0
+	}
+	f.items[ctrl] = flexItem{grow: grow, shrink: shrink, basis: basis}
+}
+
+// Direction returns the main axis of the layout
+func (f *FlexLayout) Direction() FlexDirection {
+	return f.direction
+}
+
+// SetDirection changes the main axis of the layout
+func (f *FlexLayout) SetDirection(direction FlexDirection) {
+	f.direction = direction
+}
+
+// Wrap returns true if children that do not fit the container are
+// moved to the next line
+func (f *FlexLayout) Wrap() bool {
+	return f.wrap
+}
+
+// SetWrap turns on and off wrapping children to the next line
+func (f *FlexLayout) SetWrap(wrap bool) {
+	f.wrap = wrap
+}
+
+// Justify returns the main axis alignment
+func (f *FlexLayout) Justify() FlexJustify {
+	return f.justify
+}
+
+// SetJustify changes the main axis alignment
+func (f *FlexLayout) SetJustify(justify FlexJustify) {
+	f.justify = justify
+}
+
+// AlignItems returns the cross axis alignment
+func (f *FlexLayout) AlignItems() FlexAlign {
+	return f.alignItems
+}
+
+// SetAlignItems changes the cross axis alignment
+func (f *FlexLayout) SetAlignItems(align FlexAlign) {
+	f.alignItems = align
+}
+
+// axes converts width and height to main and cross axis values
+func (f *FlexLayout) axes(w, h int) (int, int) {
+	if f.direction == FlexColumn {
+		return h, w
+	}
+	return w, h
+}
+
+// gaps returns the space between children and the space between lines
+func (f *FlexLayout) gaps(parent Control) (int, int) {
+	gx, gy := parent.Gaps()
+	return f.axes(gx, gy)
+}
+
+// item returns flex factors of the child
+func (f *FlexLayout) item(ctrl Control) flexItem {
+	if it, ok := f.items[ctrl]; ok {
+		return it
+	}
+	return flexItem{basis: AutoSize}
+}
+
+// basis returns the initial size of the child along the main axis
+func (f *FlexLayout) basis(ctrl Control) int {
+	minMain, _ := f.axes(ctrl.MinimalSize())
+	b := f.item(ctrl).basis
+	if b < minMain {
+		b = minMain
+	}
+	return b
+}
+
+// MinimalSize returns the size of the container that fits all children
+// with their minimal sizes
+func (f *FlexLayout) MinimalSize(parent Control) (int, int) {
+	gap, lineGap := f.gaps(parent)
+	main, cross := 0, 0
+	for idx, ctrl := range visibleChildren(parent) {
+		cm, cc := f.axes(ctrl.MinimalSize())
+		switch {
+		case f.wrap:
+			if cm > main {
+				main = cm
+			}
+			if idx > 0 {
+				cross += lineGap
+			}
+			cross += cc
+		default:
+			if idx > 0 {
+				main += gap
+			}
+			main += cm
+			if cc > cross {
+				cross = cc
+			}
+		}
+	}
+
+	px, py := parent.Paddings()
+	w, h := f.axes(main, cross)
+	return w + 2*px, h + 2*py
+}
+
+// splitLines breaks children into lines that fit the main size
+func (f *FlexLayout) splitLines(children []Control, mainSize, gap int) []*flexLine {
+	var lines []*flexLine
+	var line *flexLine
+	for _, ctrl := range children {
+		b := f.basis(ctrl)
+		if line == nil || (f.wrap && line.totalBasis+gap+b > mainSize) {
+			line = new(flexLine)
+			lines = append(lines, line)
+		} else {
+			line.totalBasis += gap
+		}
+		line.items = append(line.items, ctrl)
+		line.main = append(line.main, b)
+		line.totalBasis += b
+	}
+	return lines
+}
+
+// flex grows or shrinks the children of the line to fill mainSize.
+// Returns the space that remains free
+func (f *FlexLayout) flex(line *flexLine, mainSize int) int {
+	free := mainSize - line.totalBasis
+	// children that reach their minimal size are frozen and the rest
+	// of the space is distributed again
+	frozen := make([]bool, len(line.items))
+	for free != 0 {
+		total := 0.0
+		for i, ctrl := range line.items {
+			if frozen[i] {
+				continue
+			}
+			it := f.item(ctrl)
+			if free > 0 {
+				total += it.grow
+			} else {
+				total += it.shrink * float64(line.main[i])
+			}
+		}
+		if total == 0 {
+			break
+		}
+
+		left, last := free, -1
+		for i, ctrl := range line.items {
+			it := f.item(ctrl)
+			if !frozen[i] && ((free > 0 && it.grow > 0) || (free < 0 && it.shrink > 0)) {
+				last = i
+			}
+		}
+		clamped := false
+		for i, ctrl := range line.items {
+			if frozen[i] {
+				continue
+			}
+			it := f.item(ctrl)
+			var d int
+			switch {
+			case i == last:
+				d = left
+			case free > 0:
+				d = int(float64(free) * it.grow / total)
+			default:
+				d = int(float64(free) * it.shrink * float64(line.main[i]) / total)
+			}
+			if d == 0 {
+				continue
+			}
+
+			minMain, _ := f.axes(ctrl.MinimalSize())
+			if line.main[i]+d < minMain {
+				d = minMain - line.main[i]
+				frozen[i] = true
+				clamped = true
+			}
+			line.main[i] += d
+			left -= d
+		}
+		free = left
+		if !clamped {
+			break
+		}
+	}
+	return free
+}
+
+// justifySpace returns the offset of the first child and the extra
+// space between children for the free space of the line
+func (f *FlexLayout) justifySpace(free, count int) (int, int, int) {
+	if free <= 0 {
+		return 0, 0, 0
+	}
+
+	switch f.justify {
+	case FlexJustifyEnd:
+		return free, 0, 0
+	case FlexJustifyCenter:
+		return free / 2, 0, 0
+	case FlexJustifySpaceBetween:
+		if count > 1 {
+			return 0, free / (count - 1), free % (count - 1)
+		}
+	case FlexJustifySpaceAround:
+		space := free / count
+		return space / 2, space, 0
+	}
+	return 0, 0, 0
+}
+
+// Arrange calculates sizes and positions of the container children
+func (f *FlexLayout) Arrange(parent Control) {
+	x, y, w, h := layoutArea(parent)
+	mainSize, crossSize := f.axes(w, h)
+	gap, lineGap := f.gaps(parent)
+
+	lines := f.splitLines(visibleChildren(parent), mainSize, gap)
+	for _, line := range lines {
+		for _, ctrl := range line.items {
+			_, cc := f.axes(ctrl.MinimalSize())
+			line.cross = append(line.cross, cc)
+			if cc > line.crossSize {
+				line.crossSize = cc
+			}
+		}
+	}
+	if len(lines) == 1 || !f.wrap {
+		for _, line := range lines {
+			line.crossSize = crossSize
+		}
+	}
+
+	crossPos := 0
+	for _, line := range lines {
+		free := f.flex(line, mainSize)
+		mainPos, space, extra := f.justifySpace(free, len(line.items))
+
+		for i, ctrl := range line.items {
+			cs, offset := line.cross[i], 0
+			switch f.alignItems {
+			case FlexAlignStretch:
+				cs = line.crossSize
+			case FlexAlignEnd:
+				offset = line.crossSize - cs
+			case FlexAlignCenter:
+				offset = (line.crossSize - cs) / 2
+			}
+			if offset < 0 {
+				offset = 0
+			}
+
+			cw, ch := f.axes(line.main[i], cs)
+			dx, dy := f.axes(mainPos, crossPos+offset)
+			ctrl.SetSize(cw, ch)
+			ctrl.SetPos(x+dx, y+dy)
+
+			mainPos += line.main[i] + gap + space
+			if i < extra {
+				mainPos++
+			}
+		}
+		crossPos += line.crossSize + lineGap
+	}
+}
@@ -0,0 +1,75 @@
+package clui
+
+import (
+	"fmt"
+	"testing"
+)
+
+func flexGeometry(ctrls ...Control) string {
+	var res []string
+	for _, ctrl := range ctrls {
+		x, y := ctrl.Pos()
+		w, h := ctrl.Size()
+		res = append(res, fmt.Sprintf("%v:%v %vx%v", x, y, w, h))
+	}
+	return fmt.Sprint(res)
+}
+
+func TestFlexLayout(t *testing.T) {
+	parent := CreateFrame(nil, 30, 5, BorderNone, Fixed)
+	a := CreateFrame(parent, 4, 1, BorderNone, Fixed)
+	b := CreateFrame(parent, 4, 1, BorderNone, Fixed)
+	c := CreateFrame(parent, 2, 1, BorderNone, Fixed)
+	parent.SetConstraints(1, 1)
+	parent.SetSize(30, 5)
+
+	flex := CreateFlexLayout()
+	flex.AddChild(a, 1, 0, AutoSize)
+	flex.AddChild(b, 2, 0, AutoSize)
+	flex.AddChild(c, 0, 0, 6)
+	parent.SetLayout(flex)
+
+	cases := []struct {
+		setup   func()
+		want    string
+		comment string
+	}{
+		{func() {}, "[0:0 9x5 9:0 15x5 24:0 6x5]", "grow"},
+		{func() {
+			flex.AddChild(a, 0, 0, AutoSize)
+			flex.AddChild(b, 0, 0, AutoSize)
+			flex.SetJustify(FlexJustifyCenter)
+			flex.SetAlignItems(FlexAlignCenter)
+		}, "[8:2 4x1 12:2 4x1 16:2 6x1]", "justify and align"},
+		{func() {
+			flex.SetJustify(FlexJustifySpaceBetween)
+			flex.SetAlignItems(FlexAlignEnd)
+		}, "[0:4 4x1 12:4 4x1 24:4 6x1]", "space between"},
+		{func() {
+			parent.SetSize(20, 5)
+			flex.SetJustify(FlexJustifyStart)
+			flex.SetAlignItems(FlexAlignStretch)
+			flex.AddChild(a, 0, 1, 10)
+			flex.AddChild(b, 0, 3, 10)
+		}, "[0:0 9x5 9:0 5x5 14:0 6x5]", "shrink"},
+		{func() {
+			parent.SetSize(10, 5)
+			flex.AddChild(a, 1, 0, AutoSize)
+			flex.AddChild(b, 2, 0, AutoSize)
+			flex.SetWrap(true)
+		}, "[0:0 4x1 4:0 6x1 0:1 6x1]", "wrap"},
+		{func() {
+			flex.SetWrap(false)
+			flex.SetDirection(FlexColumn)
+			parent.SetSize(6, 10)
+		}, "[0:0 6x1 0:1 6x3 0:4 6x6]", "column"},
+	}
+
+	for _, cs := range cases {
+		cs.setup()
+		parent.PlaceChildren()
+		if got := flexGeometry(a, b, c); got != cs.want {
+			t.Errorf("%v: got %v, want %v", cs.comment, got, cs.want)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package clui
+
+// focusGroup returns the focus group of the control. A control without
+// its own group belongs to the group of its closest parent that has one
+func focusGroup(ctrl Control) string {
+	for ctrl != nil {
+		if group := ctrl.FocusGroup(); group != "" {
+			return group
+		}
+		ctrl = ctrl.Parent()
+	}
+	return ""
+}
+
+// changeFocus deactivates the control that has focus and activates
+// the new one
+func changeFocus(from, to Control) {
+	if from == to {
+		return
+	}
+	if from != nil {
+		from.SetActive(false)
+		from.ProcessEvent(Event{Type: EventActivate, X: 0})
+	}
+	if to != nil {
+		to.SetActive(true)
+		to.ProcessEvent(Event{Type: EventActivate, X: 1})
+		announceFocus(to)
+	}
+}
+
+// SetGroupNavigation makes the key combination move the focus from any
+// control of the focus group from to the first control of the focus
+// group to. The same key can be used for different source groups, e.g,
+// CtrlN can move the focus from "left" to "right" and from "right"
+// to "left". Empty group name means controls without a group.
+// Set FocusGroup of controls or their containers with SetFocusGroup
+func (c *Window) SetGroupNavigation(from, to string, key KeyCombo) {
+	if c.groupNav == nil {
+		c.groupNav = make(map[KeyCombo]map[string]string)
+	}
+	if c.groupNav[key] == nil {
+		c.groupNav[key] = make(map[string]string)
+	}
+	c.groupNav[key][from] = to
+}
+
+// navigateGroups moves the focus to another focus group if the key is
+// registered with SetGroupNavigation for the group of the active
+// control. Returns true if the focus is moved
+func (c *Window) navigateGroups(ev Event) bool {
+	targets, ok := c.groupNav[comboFromEvent(ev)]
+	if !ok {
+		return false
+	}
+
+	aC := ActiveControl(c)
+	to, ok := targets[focusGroup(aC)]
+	if !ok {
+		return false
+	}
+	for _, ctrl := range focusControls(c) {
+		if focusGroup(ctrl) == to {
+			changeFocus(aC, ctrl)
+			return true
+		}
+	}
+	return false
+}
+
+// focusTrap is a part of the Window that keeps the focus inside and
+// the control that was active before the trap was pushed
+type focusTrap struct {
+	root      Control
+	prevFocus Control
+}
+
+// PushFocusTrap limits the focus to the descendants of root until
+// PopFocusTrap is called: TAB key cycles only among them, and clicking
+// controls outside root does nothing. If the active control is outside
+// root, the focus moves to the first control inside it. Traps can be
+// nested, only the last pushed one works. If root is a ModalFrame,
+// Escape pops the trap and calls the frame OnClose callback
+func (c *Window) PushFocusTrap(root Control) {
+	prev := ActiveControl(c)
+	c.traps = append(c.traps, focusTrap{root: root, prevFocus: prev})
+
+	if prev == nil || FindChild(root, prev) == nil {
+		if first := NextControl(root, nil, true); first != nil {
+			ActivateControl(c, first)
+		}
+	}
+}
+
+// PopFocusTrap removes the last focus trap pushed by PushFocusTrap and
+// restores the focus to the control that was active before the trap was
+// pushed. It does nothing if there is no trap
+func (c *Window) PopFocusTrap() {
+	if len(c.traps) == 0 {
+		return
+	}
+
+	trap := c.traps[len(c.traps)-1]
+	c.traps = c.traps[:len(c.traps)-1]
+	if trap.prevFocus != nil {
+		ActivateControl(c, trap.prevFocus)
+	}
+}
+
+// focusRoot returns the root of the active focus trap or the Window
+// itself if there is no trap
+func (c *Window) focusRoot() Control {
+	if len(c.traps) == 0 {
+		return c
+	}
+	return c.traps[len(c.traps)-1].root
+}
+
+// outsideTrap returns true if the mouse event is outside the active
+// focus trap
+func (c *Window) outsideTrap(ev Event) bool {
+	root := c.focusRoot()
+	return root != c && ChildAt(root, ev.X, ev.Y) == nil
+}
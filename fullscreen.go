@@ -0,0 +1,147 @@
+package clui
+
+import (
+	term "github.com/nsf/termbox-go"
+)
+
+// fullscreenState keeps the control that occupies the whole screen and
+// its geometry before it was expanded
+type fullscreenState struct {
+	ctrl Control
+	x, y int
+	w, h int
+}
+
+// baseOwner is implemented by every control that embeds BaseControl. It
+// finds the control that owns the BaseControl
+type baseOwner interface {
+	baseControl() *BaseControl
+}
+
+func (c *BaseControl) baseControl() *BaseControl {
+	return c
+}
+
+// owner returns the control that embeds the BaseControl. Only controls
+// with a parent can be found, for others the function returns nil
+func (c *BaseControl) owner() Control {
+	if c.parent == nil {
+		return nil
+	}
+	for _, ctrl := range c.parent.Children() {
+		if own, ok := ctrl.(baseOwner); ok && own.baseControl() == c {
+			return ctrl
+		}
+	}
+	return nil
+}
+
+// IsFullscreen returns true if the control temporarily occupies the
+// whole screen
+func (c *BaseControl) IsFullscreen() bool {
+	return c.fullscreen
+}
+
+// SetFullscreen expands the control to the whole screen or restores its
+// place in the Window. While the control is expanded, its Window displays
+// only the control and sends all events to it. Escape restores the
+// original layout. Only controls inside a Window can be expanded
+func (c *BaseControl) SetFullscreen(full bool) {
+	if c.parent == nil || full == c.fullscreen {
+		return
+	}
+
+	self := c.owner()
+	root := c.parent
+	for root.Parent() != nil {
+		root = root.Parent()
+	}
+	wnd, ok := root.(*Window)
+	if !ok || self == nil {
+		return
+	}
+
+	if full {
+		wnd.enterFullscreen(self)
+	} else {
+		wnd.leaveFullscreen()
+	}
+}
+
+// enterFullscreen expands the control to the whole screen. Another
+// expanded control of the Window is restored first
+func (wnd *Window) enterFullscreen(ctrl Control) {
+	wnd.leaveFullscreen()
+
+	x, y := ctrl.Pos()
+	w, h := ctrl.Size()
+	wnd.fullscreen = &fullscreenState{ctrl: ctrl, x: x, y: y, w: w, h: h}
+	ctrl.(baseOwner).baseControl().fullscreen = true
+	if ctrl.TabStop() && !ctrl.Active() {
+		ActivateControl(wnd, ctrl)
+	}
+	wnd.fitFullscreen()
+}
+
+// fitFullscreen sets the size of the expanded control to the screen size
+func (wnd *Window) fitFullscreen() {
+	ctrl := wnd.fullscreen.ctrl
+	ctrl.SetPos(0, 0)
+	ctrl.SetSize(ScreenSize())
+	ctrl.ResizeChildren()
+	ctrl.PlaceChildren()
+}
+
+// leaveFullscreen restores the original size and position of the
+// expanded control and rearranges the Window children
+func (wnd *Window) leaveFullscreen() {
+	fs := wnd.fullscreen
+	if fs == nil {
+		return
+	}
+
+	wnd.fullscreen = nil
+	fs.ctrl.(baseOwner).baseControl().fullscreen = false
+	fs.ctrl.SetPos(fs.x, fs.y)
+	fs.ctrl.SetSize(fs.w, fs.h)
+	wnd.ResizeChildren()
+	wnd.PlaceChildren()
+}
+
+// drawFullscreen draws the expanded control over the whole screen
+// instead of the Window content
+func (wnd *Window) drawFullscreen() {
+	PushAttributes()
+	defer PopAttributes()
+
+	w, h := ScreenSize()
+	SetBackColor(RealColor(wnd.bg, ColorViewBack))
+	FillRect(0, 0, w, h, ' ')
+
+	PushClipRect(0, 0, w, h)
+	defer PopClip()
+	wnd.fullscreen.ctrl.Draw()
+}
+
+// processFullscreenEvent sends keyboard and mouse events to the expanded
+// control. Escape restores the original layout
+func (wnd *Window) processFullscreenEvent(ev Event) bool {
+	ctrl := wnd.fullscreen.ctrl
+	switch ev.Type {
+	case EventKey:
+		switch ev.Key {
+		case term.KeyEsc:
+			wnd.leaveFullscreen()
+		case term.KeyTab:
+			tabFocus(ctrl)
+		default:
+			ctrl.ProcessEvent(ev)
+		}
+		return true
+	case EventMouse, EventClick:
+		ctrl.ProcessEvent(ev)
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,47 @@
+package clui
+
+import (
+	term "github.com/nsf/termbox-go"
+	"testing"
+)
+
+func TestFullscreen(t *testing.T) {
+	initThemeManager()
+	saved := canvas
+	canvas = newMemoryCanvas(40, 20)
+	defer func() { canvas = saved }()
+
+	wnd := CreateWindow(2, 2, 20, 8, "Test")
+	left := CreateFrame(wnd, 5, 3, BorderNone, 1)
+	right := CreateListBox(wnd, 5, 3, 1)
+	wnd.ResizeChildren()
+	wnd.PlaceChildren()
+	x, y := right.Pos()
+	w, h := right.Size()
+
+	right.SetFullscreen(true)
+	if !right.IsFullscreen() || left.IsFullscreen() {
+		t.Fatal("Only the expanded control must be fullscreen")
+	}
+	if nx, ny := right.Pos(); nx != 0 || ny != 0 {
+		t.Errorf("Fullscreen control must be at the screen corner: %v:%v", nx, ny)
+	}
+	if nw, nh := right.Size(); nw != 40 || nh != 20 {
+		t.Errorf("Fullscreen control must take the whole screen: %vx%v", nw, nh)
+	}
+	if wnd.HitTest(39, 19) != HitInside {
+		t.Error("Window must cover the whole screen")
+	}
+	wnd.Draw()
+
+	wnd.ProcessEvent(Event{Type: EventKey, Key: term.KeyEsc})
+	if right.IsFullscreen() {
+		t.Fatal("Escape must restore the layout")
+	}
+	if nx, ny := right.Pos(); nx != x || ny != y {
+		t.Errorf("Invalid restored position %v:%v", nx, ny)
+	}
+	if nw, nh := right.Size(); nw != w || nh != h {
+		t.Errorf("Invalid restored size %vx%v", nw, nh)
+	}
+}
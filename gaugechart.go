@@ -0,0 +1,291 @@
+package clui
+
+import (
+	"fmt"
+	term "github.com/nsf/termbox-go"
+	"math"
+)
+
+/*
+GaugeChart is a control that displays a single value as a part
+of the range between Min and Max. In bar mode(default one) it
+looks like a horizontal progress bar with the label and the
+percentage over it. The bar is drawn with block characters
+with 1/8 of a character cell precision. In arc mode the control
+draws a half of a ring with quadrant characters: the ring is
+filled clockwise from the left end, the label and the percentage
+are displayed in the bottom row.
+The filled part color depends on the value: it is normal color
+by default, warning color if the value reaches WarnAt, and
+critical color if the value reaches CritAt. Zero WarnAt or
+CritAt means that the level is not used
+*/
+type GaugeChart struct {
+	BaseControl
+	value    float64
+	min, max float64
+	label    string
+	style    GaugeStyle
+	warnAt   float64
+	critAt   float64
+}
+
+// partially filled character cells from 1/8 to 7/8
+var gaugeEighths = []rune("▏▎▍▌▋▊▉")
+
+// quadrant characters for every combination of quadrants: bit 0
+// is upper left, bit 1 is upper right, bit 2 is lower left, and
+// bit 3 is lower right quadrant
+var gaugeQuadrants = []rune(" ▘▝▀▖▌▞▛▗▚▐▜▄▙▟█")
+
+/*
+CreateGaugeChart creates a new gauge chart.
+parent - is container that keeps the control.
+w and h - are minimal size of the control.
+scale - the way of scaling the control when the parent is resized. Use DoNotScale constant if the
+control should keep its original size.
+*/
+func CreateGaugeChart(parent Control, w, h int, scale int) *GaugeChart {
+	c := new(GaugeChart)
+
+	if w == AutoSize {
+		w = 10
+	}
+	if h == AutoSize {
+		h = 1
+	}
+
+	c.parent = parent
+
+	c.SetSize(w, h)
+	c.SetConstraints(w, h)
+	c.tabSkip = true
+	c.max = 100
+	c.SetScale(scale)
+
+	if parent != nil {
+		parent.AddChild(c)
+	}
+
+	return c
+}
+
+// Draw repaints the control on its View surface
+func (g *GaugeChart) Draw() {
+	PushAttributes()
+	defer PopAttributes()
+
+	fg, bg := RealColor(g.fg, ColorGaugeText), RealColor(g.bg, ColorGaugeBack)
+	SetTextColor(fg)
+	SetBackColor(bg)
+	FillRect(g.x, g.y, g.width, g.height, ' ')
+
+	if g.max <= g.min {
+		return
+	}
+
+	if g.style == GaugeArc {
+		g.drawArc()
+	} else {
+		g.drawBar()
+	}
+}
+
+func (g *GaugeChart) drawBar() {
+	fg, bg := RealColor(g.fg, ColorGaugeText), RealColor(g.bg, ColorGaugeBack)
+	fill := g.fillColor()
+	parts := []rune(SysObject(ObjGauge))
+	chFilled, chEmpty := parts[0], parts[0]
+	if len(parts) > 1 {
+		chEmpty = parts[1]
+	}
+
+	eighths := int(g.percent() * float64(g.width*8))
+	filled := eighths / 8
+
+	SetTextColor(fill)
+	SetBackColor(bg)
+	FillRect(g.x, g.y, filled, g.height, chFilled)
+	if filled < g.width && eighths%8 != 0 {
+		FillRect(g.x+filled, g.y, 1, g.height, gaugeEighths[eighths%8-1])
+		filled++
+	}
+	SetTextColor(fg)
+	FillRect(g.x+filled, g.y, g.width-filled, g.height, chEmpty)
+
+	// the text over the filled part is displayed as inverted
+	shift, str := AlignText(g.text(), g.width, AlignCenter)
+	y := g.y + (g.height-1)/2
+	filled = eighths / 8
+	for idx, ch := range []rune(str) {
+		pos := shift + idx
+		if pos < filled {
+			SetBackColor(fill)
+		} else {
+			SetBackColor(bg)
+		}
+		PutChar(g.x+pos, y, ch)
+	}
+}
+
+func (g *GaugeChart) drawArc() {
+	w, h := g.width, g.height-1
+	if h < 1 {
+		g.drawBar()
+		return
+	}
+
+	bg := RealColor(g.bg, ColorGaugeBack)
+	fill := g.fillColor()
+	empty := RealColor(g.fg, ColorGaugeText)
+	prc := g.percent()
+
+	// the center of the ring is in the middle of the bottom
+	// edge of the arc area. Coordinates are in character cells
+	cx, cy := float64(w)/2, float64(h)
+	rx, ry := float64(w)/2, float64(h)
+	SetBackColor(bg)
+	for row := 0; row < h; row++ {
+		for col := 0; col < w; col++ {
+			ring, filled := 0, 0
+			for q := 0; q < 4; q++ {
+				px := float64(col) + 0.25 + 0.5*float64(q%2)
+				py := float64(row) + 0.25 + 0.5*float64(q/2)
+				dx, dy := (px-cx)/rx, (cy-py)/ry
+				r := math.Sqrt(dx*dx + dy*dy)
+				if r > 1 || r < 0.55 {
+					continue
+				}
+
+				ring |= 1 << uint(q)
+				if 1-math.Atan2(dy, dx)/math.Pi <= prc {
+					filled |= 1 << uint(q)
+				}
+			}
+
+			// a cell can have only one color, so a partially
+			// filled cell is displayed as filled one
+			if filled != 0 {
+				SetTextColor(fill)
+				PutChar(g.x+col, g.y+row, gaugeQuadrants[ring])
+			} else if ring != 0 {
+				SetTextColor(empty)
+				PutChar(g.x+col, g.y+row, gaugeQuadrants[ring])
+			}
+		}
+	}
+
+	SetTextColor(RealColor(g.fg, ColorGaugeText))
+	shift, str := AlignText(g.text(), g.width, AlignCenter)
+	DrawRawText(g.x+shift, g.y+g.height-1, str)
+}
+
+// percent returns the part of the range that the value takes:
+// a number between 0 and 1
+func (g *GaugeChart) percent() float64 {
+	if g.max <= g.min || g.value <= g.min {
+		return 0
+	}
+	if g.value >= g.max {
+		return 1
+	}
+	return (g.value - g.min) / (g.max - g.min)
+}
+
+// text returns the label and the percentage to display
+func (g *GaugeChart) text() string {
+	s := fmt.Sprintf("%v%%", int(math.Round(g.percent()*100)))
+	if g.label != "" {
+		s = g.label + " " + s
+	}
+	return CutText(s, g.width)
+}
+
+// fillColor returns the color of the filled part that depends
+// on the current value
+func (g *GaugeChart) fillColor() term.Attribute {
+	if g.critAt != 0 && g.value >= g.critAt {
+		return RealColor(ColorDefault, ColorGaugeCrit)
+	}
+	if g.warnAt != 0 && g.value >= g.warnAt {
+		return RealColor(ColorDefault, ColorGaugeWarn)
+	}
+	return RealColor(ColorDefault, ColorGaugeNormal)
+}
+
+// Value returns the current gauge value
+func (g *GaugeChart) Value() float64 {
+	return g.value
+}
+
+// SetValue changes the current gauge value. Values out of
+// the range between Min and Max are displayed as Min or Max
+func (g *GaugeChart) SetValue(v float64) {
+	g.value = v
+}
+
+// Min returns the lowest value of the gauge range
+func (g *GaugeChart) Min() float64 {
+	return g.min
+}
+
+// SetMin changes the lowest value of the gauge range
+func (g *GaugeChart) SetMin(v float64) {
+	g.min = v
+}
+
+// Max returns the highest value of the gauge range
+func (g *GaugeChart) Max() float64 {
+	return g.max
+}
+
+// SetMax changes the highest value of the gauge range
+func (g *GaugeChart) SetMax(v float64) {
+	g.max = v
+}
+
+// Label returns the text displayed before the percentage
+func (g *GaugeChart) Label() string {
+	return g.label
+}
+
+// SetLabel changes the text displayed before the percentage
+func (g *GaugeChart) SetLabel(s string) {
+	g.label = s
+}
+
+// GaugeStyle returns the way the gauge is drawn
+func (g *GaugeChart) GaugeStyle() GaugeStyle {
+	return g.style
+}
+
+// SetGaugeStyle changes the way the gauge is drawn: as a
+// horizontal bar or as an arc. Arc mode requires at least
+// 2 rows: the last row is used for the label
+func (g *GaugeChart) SetGaugeStyle(style GaugeStyle) {
+	g.style = style
+}
+
+// WarnAt returns the value starting from which the gauge
+// is filled with warning color
+func (g *GaugeChart) WarnAt() float64 {
+	return g.warnAt
+}
+
+// SetWarnAt sets the value starting from which the gauge
+// is filled with warning color. Set it to 0 to disable
+func (g *GaugeChart) SetWarnAt(v float64) {
+	g.warnAt = v
+}
+
+// CritAt returns the value starting from which the gauge
+// is filled with critical color
+func (g *GaugeChart) CritAt() float64 {
+	return g.critAt
+}
+
+// SetCritAt sets the value starting from which the gauge
+// is filled with critical color. Set it to 0 to disable
+func (g *GaugeChart) SetCritAt(v float64) {
+	g.critAt = v
+}
@@ -0,0 +1,90 @@
+package clui
+
+import (
+	"testing"
+)
+
+func TestGaugeChartBar(t *testing.T) {
+	cases := []struct {
+		value float64
+		want  string
+	}{
+		{0, "░░cpu 0%░░\n░░░░░░░░░░"},
+		// partially filled cell is drawn with 1/8 precision
+		{55, "█cpu 55%░░\n█████▌░░░░"},
+		{100, "█cpu 100%█\n██████████"},
+		// values out of range are displayed as Max
+		{150, "█cpu 100%█\n██████████"},
+	}
+
+	for _, c := range cases {
+		gauge := CreateGaugeChart(nil, 10, 2, Fixed)
+		gauge.SetLabel("cpu")
+		gauge.SetValue(c.value)
+		if got := renderToString(gauge); got != c.want {
+			t.Errorf("Gauge %v ==\n%v\nwant\n%v", c.value, got, c.want)
+		}
+	}
+}
+
+func TestGaugeChartColors(t *testing.T) {
+	mock := CreateMockCanvas(10, 1)
+	defer mock.Close()
+
+	gauge := CreateGaugeChart(nil, 10, 1, Fixed)
+	gauge.SetWarnAt(50)
+	gauge.SetCritAt(90)
+	cases := []struct {
+		value float64
+		color string
+	}{
+		{40, ColorGaugeNormal},
+		{50, ColorGaugeWarn},
+		{95, ColorGaugeCrit},
+	}
+	for _, c := range cases {
+		gauge.SetValue(c.value)
+		gauge.Draw()
+		if fg := mock.Cell(0, 0).Fg; fg != RealColor(ColorDefault, c.color) {
+			t.Errorf("Value %v must be drawn with %v color, got %v", c.value, c.color, fg)
+		}
+	}
+
+	// the text over the filled part is inverted
+	if bg := mock.Cell(3, 0).Bg; bg != RealColor(ColorDefault, ColorGaugeCrit) {
+		t.Errorf("Invalid text back color %v", bg)
+	}
+}
+
+func TestGaugeChartArc(t *testing.T) {
+	mock := CreateMockCanvas(8, 5)
+	defer mock.Close()
+
+	gauge := CreateGaugeChart(nil, 8, 5, Fixed)
+	gauge.SetGaugeStyle(GaugeArc)
+	gauge.SetValue(50)
+	gauge.Draw()
+
+	want := " ▗▟██▙▖ \n▗██████▖\n▟█▘  ▝█▙\n██    ██\n  50%   "
+	if got := mock.String(); got != want {
+		t.Errorf("Invalid arc:\n%v", got)
+	}
+
+	// the ring is filled clockwise from the left end
+	fill, empty := RealColor(ColorDefault, ColorGaugeNormal), RealColor(ColorDefault, ColorGaugeText)
+	cases := []struct {
+		x, y   int
+		filled bool
+	}{
+		{0, 3, true}, {3, 0, true}, {4, 0, false}, {7, 3, false},
+	}
+	for _, c := range cases {
+		clr := empty
+		if c.filled {
+			clr = fill
+		}
+		if fg := mock.Cell(c.x, c.y).Fg; fg != clr {
+			t.Errorf("Cell %v:%v filled must be %v, got color %v", c.x, c.y, c.filled, fg)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package clui
+
+// GridTrack is a size of a GridLayout column or row: either a fixed
+// number of cells or a fraction of the free space
+type GridTrack struct {
+	// Cells is the fixed size of the track. It is used if Fr is 0
+	Cells int
+	// Fr is the part of the free space the track takes: the space
+	// that remains after subtracting fixed tracks and gaps is divided
+	// between fraction tracks proportionally to their Fr values
+	Fr float64
+}
+
+// FixedTrack returns the track with the fixed size in cells
+func FixedTrack(cells int) GridTrack {
+	return GridTrack{Cells: cells}
+}
+
+// FrTrack returns the track that takes the fraction of the free space
+func FrTrack(fr float64) GridTrack {
+	return GridTrack{Fr: fr}
+}
+
+// gridCell is a place of a child in the grid
+type gridCell struct {
+	col, row         int
+	colSpan, rowSpan int
+}
+
+/*
+GridLayout is a layout manager that places children into the cells of
+a grid. Columns and rows are defined with a list of tracks, every track
+has either a fixed size or a fraction of the free space. A child can
+span several columns and rows. Children that are not added to the grid
+with AddChild are not arranged.
+*/
+type GridLayout struct {
+	columns []GridTrack
+	rows    []GridTrack
+	rowGap  int
+	colGap  int
+	cells   map[Control]gridCell
+}
+
+// CreateGridLayout creates a new GridLayout with one column and one row
+// that take all container space. Set it as the layout of a container
+// with SetLayout
+func CreateGridLayout() *GridLayout {
+	g := new(GridLayout)
+	g.columns = []GridTrack{FrTrack(1)}
+	g.rows = []GridTrack{FrTrack(1)}
+	g.cells = make(map[Control]gridCell)
+	return g
+}
+
+// SetColumns changes the grid columns. Empty list means one column that
+// takes all container width
+func (g *GridLayout) SetColumns(defs []GridTrack) {
+	if len(defs) == 0 {
+		defs = []GridTrack{FrTrack(1)}
+	}
+	g.columns = defs
+}
+
+// SetRows changes the grid rows. Empty list means one row that takes
+// all container height
+func (g *GridLayout) SetRows(defs []GridTrack) {
+	if len(defs) == 0 {
+		defs = []GridTrack{FrTrack(1)}
+	}
+	g.rows = defs
+}
+
+// Gap returns the space between rows and the space between columns
+func (g *GridLayout) Gap() (int, int) {
+	return g.rowGap, g.colGap
+}
+
+// SetGap changes the space between rows and the space between columns
+func (g *GridLayout) SetGap(rowGap, colGap int) {
+	if rowGap >= 0 {
+		g.rowGap = rowGap
+	}
+	if colGap >= 0 {
+		g.colGap = colGap
+	}
+}
+
+// AddChild places the container child to the grid cell. col and row
+// start from 0. The child spans colSpan columns and rowSpan rows, spans
+// less than 1 are treated as 1
+func (g *GridLayout) AddChild(ctrl Control, col, row, colSpan, rowSpan int) {
+	if colSpan < 1 {
+		colSpan = 1
+	}
+	if rowSpan < 1 {
+		rowSpan = 1
+	}
+	g.cells[ctrl] = gridCell{col: col, row: row, colSpan: colSpan, rowSpan: rowSpan}
+}
+
+// trackSizes calculates sizes of the tracks for the available space
+func trackSizes(tracks []GridTrack, total, gap int) []int {
+	sizes := make([]int, len(tracks))
+	free := total - gap*(len(tracks)-1)
+	totalFr := 0.0
+	last := -1
+	for i, t := range tracks {
+		if t.Fr > 0 {
+			totalFr += t.Fr
+			last = i
+		} else {
+			sizes[i] = t.Cells
+			free -= t.Cells
+		}
+	}
+	if free <= 0 || totalFr == 0 {
+		return sizes
+	}
+
+	left := free
+	for i, t := range tracks {
+		if t.Fr <= 0 {
+			continue
+		}
+		if i == last {
+			sizes[i] = left
+			break
+		}
+		sizes[i] = int(float64(free) * t.Fr / totalFr)
+		left -= sizes[i]
+	}
+	return sizes
+}
+
+// span returns the start and the length of the span of tracks
+func span(sizes []int, gap, from, count int) (int, int) {
+	start, length := 0, 0
+	for i := 0; i < len(sizes) && i < from+count; i++ {
+		if i < from {
+			start += sizes[i] + gap
+			continue
+		}
+		if i > from {
+			length += gap
+		}
+		length += sizes[i]
+	}
+	return start, length
+}
+
+// minTracks returns the minimal track sizes: fixed tracks keep their
+// sizes and fraction tracks fit the children that occupy only them
+func (g *GridLayout) minTracks(tracks []GridTrack, column bool) int {
+	sizes := make([]int, len(tracks))
+	for i, t := range tracks {
+		if t.Fr <= 0 {
+			sizes[i] = t.Cells
+		}
+	}
+	for ctrl, cell := range g.cells {
+		if !ctrl.Visible() {
+			continue
+		}
+		idx, cnt := cell.row, cell.rowSpan
+		w, h := ctrl.MinimalSize()
+		if column {
+			idx, cnt, h = cell.col, cell.colSpan, w
+		}
+		if cnt == 1 && idx >= 0 && idx < len(tracks) && tracks[idx].Fr > 0 && h > sizes[idx] {
+			sizes[idx] = h
+		}
+	}
+
+	total := 0
+	for _, s := range sizes {
+		total += s
+	}
+	return total
+}
+
+// MinimalSize returns the size of the container that fits fixed tracks,
+// gaps, and children minimal sizes
+func (g *GridLayout) MinimalSize(parent Control) (int, int) {
+	px, py := parent.Paddings()
+	w := g.minTracks(g.columns, true) + g.colGap*(len(g.columns)-1)
+	h := g.minTracks(g.rows, false) + g.rowGap*(len(g.rows)-1)
+	return w + 2*px, h + 2*py
+}
+
+// Arrange calculates sizes and positions of the container children
+func (g *GridLayout) Arrange(parent Control) {
+	x, y, w, h := layoutArea(parent)
+	cols := trackSizes(g.columns, w, g.colGap)
+	rows := trackSizes(g.rows, h, g.rowGap)
+
+	for _, ctrl := range visibleChildren(parent) {
+		cell, ok := g.cells[ctrl]
+		if !ok {
+			continue
+		}
+
+		cx, cw := span(cols, g.colGap, cell.col, cell.colSpan)
+		cy, ch := span(rows, g.rowGap, cell.row, cell.rowSpan)
+		ctrl.SetSize(cw, ch)
+		ctrl.SetPos(x+cx, y+cy)
+	}
+}
@@ -0,0 +1,37 @@
+package clui
+
+import (
+	"testing"
+)
+
+func TestGridLayout(t *testing.T) {
+	parent := CreateFrame(nil, 1, 1, BorderNone, Fixed)
+	header := CreateFrame(parent, 1, 1, BorderNone, Fixed)
+	sidebar := CreateFrame(parent, 1, 1, BorderNone, Fixed)
+	content := CreateFrame(parent, 1, 1, BorderNone, Fixed)
+	aside := CreateFrame(parent, 1, 1, BorderNone, Fixed)
+
+	grid := CreateGridLayout()
+	grid.SetColumns([]GridTrack{FixedTrack(8), FrTrack(2), FrTrack(1)})
+	grid.SetRows([]GridTrack{FixedTrack(1), FrTrack(1)})
+	grid.SetGap(1, 1)
+	grid.AddChild(header, 0, 0, 3, 1)
+	grid.AddChild(sidebar, 0, 1, 1, 1)
+	grid.AddChild(content, 1, 1, 1, 1)
+	grid.AddChild(aside, 2, 1, 1, 1)
+	parent.SetLayout(grid)
+
+	parent.SetSize(40, 10)
+	parent.SetPos(2, 3)
+	parent.PlaceChildren()
+
+	// 40 - 8 - 2 gaps = 30 cells for 3fr; 10 - 1 - 1 gap = 8 rows
+	want := "[2:3 40x1 2:5 8x8 11:5 20x8 32:5 10x8]"
+	if got := flexGeometry(header, sidebar, content, aside); got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if w, h := parent.MinimalSize(); w != 12 || h != 3 {
+		t.Errorf("Invalid minimal size %vx%v", w, h)
+	}
+}
@@ -0,0 +1,249 @@
+package clui
+
+import (
+	"fmt"
+	xs "github.com/huandu/xstrings"
+	term "github.com/nsf/termbox-go"
+)
+
+/*
+HeatMap is a control that displays two-dimensional data as a
+grid of colored cells. The range between the lowest and the
+highest values is divided into equal parts - one part for every
+color of the color scale - and every cell is filled with the
+color of the part that includes the cell value. The lowest value
+gets the first color of the scale, the highest one gets the last.
+All cells have the same size that is calculated to make the grid
+fit the control. A cell cannot be smaller than one character, so
+the cells that do not fit the control are not displayed.
+If ShowValues is true the chart displays cell values inside cells
+that are wide enough to display them.
+Please note that in default terminal mode only 8 colors are
+available, so built-in scales contain a few colors each
+*/
+type HeatMap struct {
+	BaseControl
+	rows, cols int
+	values     []float64
+	colors     []term.Attribute
+	showValues bool
+}
+
+// built-in color scales
+var heatMapScales = map[HeatMapColorScale][]term.Attribute{
+	HeatMapGrayscale: {ColorBlack, ColorWhite},
+	HeatMapRainbow:   {ColorBlue, ColorCyan, ColorGreen, ColorYellow, ColorRed, ColorMagenta},
+	HeatMapHeat:      {ColorBlack, ColorRed, ColorYellow, ColorWhite},
+}
+
+/*
+CreateHeatMap creates a new heat map.
+parent - is container that keeps the control.
+w and h - are minimal size of the control.
+scale - the way of scaling the control when the parent is resized. Use DoNotScale constant if the
+control should keep its original size.
+*/
+func CreateHeatMap(parent Control, w, h int, scale int) *HeatMap {
+	c := new(HeatMap)
+
+	if w == AutoSize {
+		w = 10
+	}
+	if h == AutoSize {
+		h = 5
+	}
+
+	c.parent = parent
+
+	c.SetSize(w, h)
+	c.SetConstraints(w, h)
+	c.tabSkip = true
+	c.SetColorScale(HeatMapHeat)
+	c.SetScale(scale)
+
+	if parent != nil {
+		parent.AddChild(c)
+	}
+
+	return c
+}
+
+// Draw repaints the control on its View surface
+func (m *HeatMap) Draw() {
+	PushAttributes()
+	defer PopAttributes()
+
+	fg, bg := RealColor(m.fg, ColorHeatMapText), RealColor(m.bg, ColorHeatMapBack)
+	SetTextColor(fg)
+	SetBackColor(bg)
+	FillRect(m.x, m.y, m.width, m.height, ' ')
+
+	if m.rows == 0 || m.cols == 0 || len(m.colors) == 0 {
+		return
+	}
+
+	cellW, cellH := m.width/m.cols, m.height/m.rows
+	if cellW == 0 {
+		cellW = 1
+	}
+	if cellH == 0 {
+		cellH = 1
+	}
+
+	min, max := m.limits()
+	for row := 0; row < m.rows; row++ {
+		y := row * cellH
+		if y+cellH > m.height {
+			break
+		}
+
+		for col := 0; col < m.cols; col++ {
+			x := col * cellW
+			if x+cellW > m.width {
+				break
+			}
+
+			v := m.values[row*m.cols+col]
+			clr := m.valueColor(v, min, max)
+			if clr == ColorDefault {
+				clr = SysColor(ColorHeatMapCellBack)
+			}
+			SetBackColor(RealColor(clr, ColorHeatMapCellBack))
+			FillRect(m.x+x, m.y+y, cellW, cellH, ' ')
+
+			if m.showValues {
+				s := fmt.Sprintf("%.2f", v)
+				if xs.Len(s) > cellW {
+					s = fmt.Sprintf("%.0f", v)
+				}
+				if xs.Len(s) <= cellW {
+					shift, str := AlignText(s, cellW, AlignCenter)
+					SetTextColor(RealColor(contrastColor(clr), ColorHeatMapText))
+					DrawRawText(m.x+x+shift, m.y+y+(cellH-1)/2, str)
+				}
+			}
+		}
+	}
+}
+
+// contrastColor returns the text color that is readable
+// over background color clr
+func contrastColor(clr term.Attribute) term.Attribute {
+	switch clr &^ term.AttrBold {
+	case ColorWhite, ColorYellow, ColorCyan, ColorGreen:
+		return ColorBlack
+	}
+	return ColorWhite
+}
+
+// limits returns the lowest and the highest values of the grid
+func (m *HeatMap) limits() (float64, float64) {
+	if len(m.values) == 0 {
+		return 0, 0
+	}
+
+	min, max := m.values[0], m.values[0]
+	for _, v := range m.values {
+		if v < min {
+			min = v
+		}
+		if v > max {
+			max = v
+		}
+	}
+	return min, max
+}
+
+// valueColor returns the color of the scale that corresponds to
+// the value. min and max are the lowest and the highest values
+func (m *HeatMap) valueColor(v, min, max float64) term.Attribute {
+	if max == min {
+		return m.colors[0]
+	}
+
+	idx := int((v - min) / (max - min) * float64(len(m.colors)))
+	if idx >= len(m.colors) {
+		idx = len(m.colors) - 1
+	}
+	if idx < 0 {
+		idx = 0
+	}
+	return m.colors[idx]
+}
+
+// Dimensions returns the number of rows and columns of the grid
+func (m *HeatMap) Dimensions() (int, int) {
+	return m.rows, m.cols
+}
+
+// SetDimensions changes the number of rows and columns of the
+// grid. All cell values are reset to 0
+func (m *HeatMap) SetDimensions(rows, cols int) {
+	if rows < 0 {
+		rows = 0
+	}
+	if cols < 0 {
+		cols = 0
+	}
+
+	m.rows, m.cols = rows, cols
+	m.values = make([]float64, rows*cols)
+}
+
+// Cell returns the value of the cell. It returns 0 if the
+// cell is outside the grid
+func (m *HeatMap) Cell(row, col int) float64 {
+	if row < 0 || row >= m.rows || col < 0 || col >= m.cols {
+		return 0
+	}
+
+	return m.values[row*m.cols+col]
+}
+
+// SetCell changes the value of the cell. The function does
+// nothing if the cell is outside the grid
+func (m *HeatMap) SetCell(row, col int, value float64) {
+	if row < 0 || row >= m.rows || col < 0 || col >= m.cols {
+		return
+	}
+
+	m.values[row*m.cols+col] = value
+}
+
+// ColorScale returns the list of color used to display values:
+// from the lowest to the highest
+func (m *HeatMap) ColorScale() []term.Attribute {
+	colors := make([]term.Attribute, len(m.colors))
+	copy(colors, m.colors)
+	return colors
+}
+
+// SetColorScale selects one of built-in color scales
+func (m *HeatMap) SetColorScale(scale HeatMapColorScale) {
+	colors, ok := heatMapScales[scale]
+	if !ok {
+		return
+	}
+
+	m.SetColors(colors)
+}
+
+// SetColors sets a custom color scale: the list of colors from
+// the color of the lowest value to the color of the highest one.
+// ColorDefault in the list is replaced with HeatMapCellBack color
+// of the current theme
+func (m *HeatMap) SetColors(colors []term.Attribute) {
+	m.colors = make([]term.Attribute, len(colors))
+	copy(m.colors, colors)
+}
+
+// ShowValues returns whether the chart displays cell values
+func (m *HeatMap) ShowValues() bool {
+	return m.showValues
+}
+
+// SetShowValues enables or disables displaying values inside cells.
+// A value is displayed only if the cell is wide enough for it
+func (m *HeatMap) SetShowValues(show bool) {
+	m.showValues = show
+}
@@ -0,0 +1,99 @@
+package clui
+
+import (
+	term "github.com/nsf/termbox-go"
+	"testing"
+)
+
+func TestHeatMapDraw(t *testing.T) {
+	mock := CreateMockCanvas(8, 4)
+	defer mock.Close()
+
+	m := CreateHeatMap(nil, 8, 4, Fixed)
+	m.SetDimensions(2, 2)
+	for idx := 0; idx < 4; idx++ {
+		m.SetCell(idx/2, idx%2, float64(idx))
+	}
+	m.SetCell(2, 0, 10)
+	if m.Cell(2, 0) != 0 || m.Cell(1, 1) != 3 {
+		t.Error("Cells outside the grid must be ignored")
+	}
+	m.SetShowValues(true)
+	m.Draw()
+
+	want := "0.001.00\n        \n2.003.00\n        "
+	if got := mock.String(); got != want {
+		t.Errorf("Invalid heat map:\n%v", got)
+	}
+
+	// heat scale: black, red, yellow, white. Values over light
+	// colors are drawn with black
+	cases := []struct {
+		x, y   int
+		fg, bg term.Attribute
+	}{
+		{0, 0, ColorWhite, ColorBlack},
+		{4, 0, ColorWhite, ColorRed},
+		{0, 2, ColorBlack, ColorYellow},
+		{4, 2, ColorBlack, ColorWhite},
+	}
+	for _, c := range cases {
+		if cell := mock.Cell(c.x, c.y); cell.Fg != c.fg || cell.Bg != c.bg {
+			t.Errorf("Cell %v:%v colors %v:%v, want %v:%v", c.x, c.y, cell.Fg, cell.Bg, c.fg, c.bg)
+		}
+		if bg := mock.Cell(c.x+3, c.y+1).Bg; bg != c.bg {
+			t.Errorf("The whole grid cell %v:%v must be filled, got %v", c.x, c.y, bg)
+		}
+	}
+}
+
+func TestHeatMapSmallCells(t *testing.T) {
+	mock := CreateMockCanvas(5, 2)
+	defer mock.Close()
+
+	// the grid does not fit the control: cells are one character,
+	// and the last row is not displayed
+	m := CreateHeatMap(nil, 5, 2, Fixed)
+	m.SetColors([]term.Attribute{ColorBlue, ColorGreen})
+	m.SetDimensions(3, 3)
+	for idx := 0; idx < 9; idx++ {
+		m.SetCell(idx/3, idx%3, float64(idx+1))
+	}
+	m.SetCell(1, 2, 10)
+	m.SetShowValues(true)
+	m.Draw()
+
+	// values wider than a cell are not displayed
+	want := "123  \n45   "
+	if got := mock.String(); got != want {
+		t.Errorf("Invalid heat map:\n%v", got)
+	}
+	if bg := mock.Cell(0, 0).Bg; bg != ColorBlue {
+		t.Errorf("The lowest value color %v", bg)
+	}
+	if bg := mock.Cell(2, 1).Bg; bg != ColorGreen {
+		t.Errorf("The highest value color %v", bg)
+	}
+	if bg := mock.Cell(3, 0).Bg; bg != RealColor(ColorDefault, ColorHeatMapBack) {
+		t.Errorf("The area outside the grid color %v", bg)
+	}
+}
+
+func TestHeatMapThemeColors(t *testing.T) {
+	mock := CreateMockCanvas(2, 1)
+	defer mock.Close()
+
+	// ColorDefault in the scale is a theme color
+	m := CreateHeatMap(nil, 2, 1, Fixed)
+	m.SetColors([]term.Attribute{ColorDefault, ColorGreen})
+	m.SetDimensions(1, 2)
+	m.SetCell(0, 1, 1)
+	m.Draw()
+
+	if bg := mock.Cell(0, 0).Bg; bg != RealColor(ColorDefault, ColorHeatMapCellBack) {
+		t.Errorf("The default cell color must be the theme one, got %v", bg)
+	}
+	if bg := mock.Cell(1, 0).Bg; bg != ColorGreen {
+		t.Errorf("The scale color %v", bg)
+	}
+}
@@ -0,0 +1,234 @@
+package clui
+
+import (
+	"bytes"
+	"fmt"
+	term "github.com/nsf/termbox-go"
+)
+
+// the number of bytes in a HexDump row
+const hexDumpRowSize = 16
+
+// columns of HexDump row parts: the offset takes 8 characters, every
+// byte takes 3 characters plus one extra space after the 8th byte
+const (
+	hexDumpHexColumn   = 10
+	hexDumpASCIIColumn = hexDumpHexColumn + hexDumpRowSize*3 + 2
+)
+
+/*
+HexDump is a control to inspect binary data. Every row displays 16
+bytes in three columns: the hexadecimal offset of the first byte, the
+bytes in hexadecimal, and the bytes as ASCII characters. Bytes that
+are not printable ASCII characters are displayed as '.'.
+A range of bytes can be highlighted with SetHighlight, e.g, to mark a
+field of a binary protocol packet or the found pattern.
+
+Keyboard navigation:
+
+	Arrow Up and Arrow Down - scroll one row
+	Page Up and Page Down - scroll one page
+	Home and End - go to the beginning and the end of the data
+
+Mouse wheel scrolls the data as well
+*/
+type HexDump struct {
+	BaseControl
+	data           []byte
+	topRow         int
+	hlStart, hlEnd int
+}
+
+/*
+CreateHexDump creates a new hex dump viewer.
+parent - is container that keeps the control.
+width and heigth - are minimal size of the control.
+scale - the way of scaling the control when the parent is resized. Use DoNotScale constant if the
+control should keep its original size.
+*/
+func CreateHexDump(parent Control, width, height int, scale int) *HexDump {
+	d := new(HexDump)
+
+	if height == AutoSize {
+		height = 5
+	}
+	if width == AutoSize {
+		width = hexDumpASCIIColumn + hexDumpRowSize
+	}
+
+	d.SetSize(width, height)
+	d.SetConstraints(width, height)
+	d.parent = parent
+	d.SetTabStop(true)
+	d.hlStart, d.hlEnd = -1, -1
+	d.SetScale(scale)
+	d.OnMouseScroll(func(delta int) {
+		d.scroll(delta * wheelScrollLines)
+	})
+
+	if parent != nil {
+		parent.AddChild(d)
+	}
+
+	return d
+}
+
+// Draw repaints the control on its View surface
+func (d *HexDump) Draw() {
+	PushAttributes()
+	defer PopAttributes()
+
+	fg, bg := RealColor(d.fg, ColorHexDumpText), RealColor(d.bg, ColorHexDumpBack)
+	offFg := RealColor(ColorDefault, ColorHexDumpOffset)
+	hlFg, hlBg := RealColor(ColorDefault, ColorHexDumpHighlightText), RealColor(ColorDefault, ColorHexDumpHighlightBack)
+	SetTextColor(fg)
+	SetBackColor(bg)
+	FillRect(d.x, d.y, d.width, d.height, ' ')
+
+	for dy := 0; dy < d.height; dy++ {
+		start := (d.topRow + dy) * hexDumpRowSize
+		if start >= len(d.data) {
+			break
+		}
+
+		SetTextColor(offFg)
+		SetBackColor(bg)
+		DrawRawText(d.x, d.y+dy, CutText(fmt.Sprintf("%08x", start), d.width))
+
+		for i := 0; i < hexDumpRowSize && start+i < len(d.data); i++ {
+			b := d.data[start+i]
+			if d.highlighted(start + i) {
+				SetTextColor(hlFg)
+				SetBackColor(hlBg)
+			} else {
+				SetTextColor(fg)
+				SetBackColor(bg)
+			}
+
+			col := hexDumpHexColumn + i*3
+			if i >= hexDumpRowSize/2 {
+				col++
+			}
+			DrawRawText(d.x+col, d.y+dy, fmt.Sprintf("%02x", b))
+			PutChar(d.x+hexDumpASCIIColumn+i, d.y+dy, hexDumpChar(b))
+		}
+	}
+}
+
+// hexDumpChar returns the character that displays the byte in the ASCII
+// column
+func hexDumpChar(b byte) rune {
+	if b < 0x20 || b > 0x7e {
+		return '.'
+	}
+	return rune(b)
+}
+
+// highlighted returns true if the byte is inside the highlighted range
+func (d *HexDump) highlighted(pos int) bool {
+	return pos >= d.hlStart && pos < d.hlEnd
+}
+
+// rowCount returns the number of data rows
+func (d *HexDump) rowCount() int {
+	return (len(d.data) + hexDumpRowSize - 1) / hexDumpRowSize
+}
+
+// scroll moves the view by delta rows. The last page is the furthest
+// the view can scroll to
+func (d *HexDump) scroll(delta int) {
+	d.SetOffset((d.topRow + delta) * hexDumpRowSize)
+}
+
+/*
+ProcessEvent processes all events come from the control parent. If a control
+processes an event it should return true. If the method returns false it means
+that the control do not want or cannot process the event and the caller sends
+the event to the control parent
+*/
+func (d *HexDump) ProcessEvent(event Event) bool {
+	if !d.Active() || !d.Enabled() || event.Type != EventKey {
+		return false
+	}
+
+	switch event.Key {
+	case term.KeyArrowUp:
+		d.scroll(-1)
+	case term.KeyArrowDown:
+		d.scroll(1)
+	case term.KeyPgup:
+		d.scroll(-d.height)
+	case term.KeyPgdn:
+		d.scroll(d.height)
+	case term.KeyHome:
+		d.SetOffset(0)
+	case term.KeyEnd:
+		d.SetOffset(len(d.data))
+	default:
+		return false
+	}
+	return true
+}
+
+// SetData replaces the displayed data. The view scrolls to the
+// beginning and the highlight is removed
+func (d *HexDump) SetData(data []byte) {
+	d.data = make([]byte, len(data))
+	copy(d.data, data)
+	d.topRow = 0
+	d.hlStart, d.hlEnd = -1, -1
+}
+
+// Data returns the displayed data
+func (d *HexDump) Data() []byte {
+	return d.data
+}
+
+// Offset returns the offset of the first displayed byte
+func (d *HexDump) Offset() int {
+	return d.topRow * hexDumpRowSize
+}
+
+// SetOffset scrolls the view to the row that contains the byte at the
+// offset. The view does not scroll further than the last page
+func (d *HexDump) SetOffset(offset int) {
+	row := offset / hexDumpRowSize
+	if max := d.rowCount() - d.height; row > max {
+		row = max
+	}
+	if row < 0 {
+		row = 0
+	}
+	d.topRow = row
+}
+
+// Search finds the first occurrence of the pattern in the data and
+// scrolls the view to it. Returns the offset of the found pattern or
+// -1 if the data does not contain it
+func (d *HexDump) Search(pattern []byte) int {
+	if len(pattern) == 0 {
+		return -1
+	}
+
+	pos := bytes.Index(d.data, pattern)
+	if pos != -1 {
+		d.SetOffset(pos)
+	}
+	return pos
+}
+
+// Highlight returns the highlighted range of bytes: the offset of its
+// first byte and the offset after its last byte. The values are -1 if
+// nothing is highlighted
+func (d *HexDump) Highlight() (int, int) {
+	return d.hlStart, d.hlEnd
+}
+
+// SetHighlight marks bytes from start up to but not including end
+// with highlight colors. Use -1 for both values to remove the highlight
+func (d *HexDump) SetHighlight(start, end int) {
+	if end < start {
+		start, end = end, start
+	}
+	d.hlStart, d.hlEnd = start, end
+}
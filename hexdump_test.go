@@ -0,0 +1,62 @@
+package clui
+
+import (
+	term "github.com/nsf/termbox-go"
+	"strings"
+	"testing"
+)
+
+func TestHexDumpDraw(t *testing.T) {
+	mock := CreateMockCanvas(76, 2)
+	defer mock.Close()
+
+	dump := CreateHexDump(nil, 76, 2, Fixed)
+	dump.SetData([]byte("ABCDEFGHIJKLMNOPQR\x00\xff"))
+	dump.SetHighlight(17, 19)
+	dump.Draw()
+
+	mock.AssertTextAt(t, 0, 0, "00000000  41 42 43 44 45 46 47 48  49 4a 4b 4c 4d 4e 4f 50  ABCDEFGHIJKLMNOP")
+	mock.AssertTextAt(t, 0, 1, "00000010  51 52 00 ff"+strings.Repeat(" ", 39)+"QR..")
+
+	hl := RealColor(ColorDefault, ColorHexDumpHighlightBack)
+	for _, x := range []int{13, 16, 61, 62} {
+		if bg := mock.Cell(x, 1).Bg; bg != hl {
+			t.Errorf("Cell %v is not highlighted", x)
+		}
+	}
+	for _, x := range []int{10, 19, 60, 63} {
+		if bg := mock.Cell(x, 1).Bg; bg == hl {
+			t.Errorf("Cell %v is highlighted", x)
+		}
+	}
+}
+
+func TestHexDumpScroll(t *testing.T) {
+	dump := CreateHexDump(nil, 76, 2, Fixed)
+	data := make([]byte, 100)
+	data[70], data[71] = 0xde, 0xad
+	dump.SetData(data)
+
+	if pos := dump.Search([]byte{0xde, 0xad}); pos != 70 || dump.Offset() != 64 {
+		t.Errorf("Search found %v, offset %v", pos, dump.Offset())
+	}
+	if pos := dump.Search([]byte{0xbe, 0xef}); pos != -1 || dump.Offset() != 64 {
+		t.Errorf("Search found %v, offset %v", pos, dump.Offset())
+	}
+
+	// 7 rows, 2 of them are displayed
+	dump.SetOffset(1000)
+	if dump.Offset() != 80 {
+		t.Errorf("Offset %v, want 80", dump.Offset())
+	}
+
+	dump.SetActive(true)
+	dump.ProcessEvent(Event{Type: EventKey, Key: term.KeyArrowUp})
+	if dump.Offset() != 64 {
+		t.Errorf("Offset %v after Arrow Up, want 64", dump.Offset())
+	}
+	dump.ProcessEvent(Event{Type: EventKey, Key: term.KeyHome})
+	if dump.Offset() != 0 {
+		t.Errorf("Offset %v after Home, want 0", dump.Offset())
+	}
+}
@@ -0,0 +1,356 @@
+package clui
+
+import (
+	"fmt"
+	"math"
+)
+
+// HistogramBucket is a range of values and the number of
+// samples in the range. A sample belongs to the bucket if it
+// is greater than or equal to Low and less than High. The last
+// bucket includes its High value as well
+type HistogramBucket struct {
+	Low   float64
+	High  float64
+	Count int
+}
+
+/*
+HistogramChart is a chart that displays frequency distribution
+of samples. The range between the lowest and the highest samples
+is divided into a number of buckets of the same size, and every
+bucket is displayed as a bar with height proportional to the
+number of samples in the bucket. A new sample inside the current
+range only increments its bucket. The buckets are recalculated
+before drawing if a sample is out of the range or the number of
+buckets is changed.
+All bars have the same width that is calculated to make all
+bars fit the control. If there are more buckets than the control
+width then the buckets that do not fit are not displayed.
+If CDF is enabled the chart draws a mark over every bar at the
+row that corresponds to the cumulative distribution function
+value at the bucket: the part of samples that are less than the
+bucket high value.
+If statistics is enabled the bottom row of the control displays
+mean and standard deviation of the samples
+*/
+type HistogramChart struct {
+	BaseControl
+	samples   []float64
+	buckets   []HistogramBucket
+	count     int
+	showCDF   bool
+	showStats bool
+
+	// the lowest and the highest samples of the buckets
+	min, max float64
+	// if true the buckets must be recalculated
+	dirty bool
+}
+
+/*
+CreateHistogramChart creates a new histogram chart.
+parent - is container that keeps the control.
+w and h - are minimal size of the control.
+scale - the way of scaling the control when the parent is resized. Use DoNotScale constant if the
+control should keep its original size.
+*/
+func CreateHistogramChart(parent Control, w, h int, scale int) *HistogramChart {
+	c := new(HistogramChart)
+
+	if w == AutoSize {
+		w = 10
+	}
+	if h == AutoSize {
+		h = 5
+	}
+
+	c.parent = parent
+
+	c.SetSize(w, h)
+	c.SetConstraints(w, h)
+	c.tabSkip = true
+	c.count = 10
+	c.samples = make([]float64, 0)
+	c.buckets = make([]HistogramBucket, 0)
+	c.SetScale(scale)
+
+	if parent != nil {
+		parent.AddChild(c)
+	}
+
+	return c
+}
+
+// Draw repaints the control on its View surface
+func (c *HistogramChart) Draw() {
+	PushAttributes()
+	defer PopAttributes()
+
+	fg, bg := RealColor(c.fg, ColorHistogramText), RealColor(c.bg, ColorHistogramBack)
+	SetTextColor(fg)
+	SetBackColor(bg)
+	FillRect(c.x, c.y, c.width, c.height, ' ')
+
+	if len(c.samples) == 0 {
+		return
+	}
+
+	c.updateBuckets()
+	c.drawBars()
+	c.drawCDF()
+	c.drawStats()
+}
+
+func (c *HistogramChart) drawBars() {
+	h := c.chartHeight()
+	barW := c.barWidth()
+	if h < 1 || barW == 0 {
+		return
+	}
+
+	max := 0
+	for _, b := range c.buckets {
+		if b.Count > max {
+			max = b.Count
+		}
+	}
+	if max == 0 {
+		return
+	}
+
+	PushAttributes()
+	defer PopAttributes()
+
+	SetTextColor(RealColor(c.fg, ColorHistogramBarText))
+	SetBackColor(RealColor(c.bg, ColorHistogramBarBack))
+	parts := []rune(SysObject(ObjHistogram))
+	for idx, b := range c.buckets {
+		pos := idx * barW
+		if pos+barW > c.width {
+			break
+		}
+
+		barH := b.Count * h / max
+		if barH == 0 && b.Count > 0 {
+			barH = 1
+		}
+		FillRect(c.x+pos, c.y+h-barH, barW, barH, parts[0])
+	}
+}
+
+func (c *HistogramChart) drawCDF() {
+	if !c.showCDF {
+		return
+	}
+
+	h := c.chartHeight()
+	barW := c.barWidth()
+	if h < 1 || barW == 0 {
+		return
+	}
+
+	PushAttributes()
+	defer PopAttributes()
+
+	SetTextColor(RealColor(c.fg, ColorHistogramCDFText))
+	SetBackColor(RealColor(c.bg, ColorHistogramCDFBack))
+	parts := []rune(SysObject(ObjHistogram))
+	ch := parts[0]
+	if len(parts) > 1 {
+		ch = parts[1]
+	}
+
+	total := 0
+	for idx, b := range c.buckets {
+		pos := idx * barW
+		if pos+barW > c.width {
+			break
+		}
+
+		total += b.Count
+		row := h - int(math.Ceil(float64(total*h)/float64(len(c.samples))))
+		if row < 0 {
+			row = 0
+		}
+		if row >= h {
+			row = h - 1
+		}
+		DrawHorizontalLine(c.x+pos, c.y+row, barW, ch)
+	}
+}
+
+// drawStats draws mean and standard deviation of the samples
+// at the bottom row of the control
+func (c *HistogramChart) drawStats() {
+	if !c.showStats {
+		return
+	}
+
+	PushAttributes()
+	defer PopAttributes()
+
+	mean, dev := c.Stats()
+	s := CutText(fmt.Sprintf("%.2f %.2f", mean, dev), c.width)
+	SetTextColor(RealColor(c.fg, ColorHistogramStatsText))
+	SetBackColor(RealColor(c.bg, ColorHistogramStatsBack))
+	FillRect(c.x, c.y+c.height-1, c.width, 1, ' ')
+	DrawRawText(c.x, c.y+c.height-1, s)
+}
+
+// chartHeight returns the height of the area used to draw bars
+func (c *HistogramChart) chartHeight() int {
+	if c.showStats {
+		return c.height - 1
+	}
+	return c.height
+}
+
+// barWidth returns the width of a bucket bar
+func (c *HistogramChart) barWidth() int {
+	if len(c.buckets) == 0 {
+		return 0
+	}
+
+	w := c.width / len(c.buckets)
+	if w == 0 {
+		w = 1
+	}
+	return w
+}
+
+// updateBuckets recalculates the buckets if they are outdated
+func (c *HistogramChart) updateBuckets() {
+	if c.dirty {
+		c.calculateBuckets()
+		c.dirty = false
+	}
+}
+
+// calculateBuckets divides the range of samples into buckets
+// and counts samples in every bucket
+func (c *HistogramChart) calculateBuckets() {
+	c.buckets = make([]HistogramBucket, 0, c.count)
+	if len(c.samples) == 0 || c.count < 1 {
+		return
+	}
+
+	min, max := c.samples[0], c.samples[0]
+	for _, s := range c.samples {
+		if s < min {
+			min = s
+		}
+		if s > max {
+			max = s
+		}
+	}
+	c.min, c.max = min, max
+	if min == max {
+		min, max = min-0.5, max+0.5
+	}
+
+	size := (max - min) / float64(c.count)
+	for i := 0; i < c.count; i++ {
+		low := min + float64(i)*size
+		c.buckets = append(c.buckets, HistogramBucket{Low: low, High: low + size})
+	}
+	c.buckets[c.count-1].High = max
+
+	for _, s := range c.samples {
+		c.buckets[c.bucketIndex(s)].Count++
+	}
+}
+
+// bucketIndex returns the index of the bucket the sample falls into
+func (c *HistogramChart) bucketIndex(v float64) int {
+	low, size := c.buckets[0].Low, c.buckets[0].High-c.buckets[0].Low
+	idx := int((v - low) / size)
+	if idx >= len(c.buckets) {
+		idx = len(c.buckets) - 1
+	}
+	return idx
+}
+
+// AddSample adds a new value to the histogram. If the value is
+// inside the range of the buckets, only its bucket is updated
+func (c *HistogramChart) AddSample(v float64) {
+	c.samples = append(c.samples, v)
+	if !c.dirty && len(c.buckets) != 0 && c.min < c.max && v >= c.min && v <= c.max {
+		c.buckets[c.bucketIndex(v)].Count++
+		return
+	}
+	c.dirty = true
+}
+
+// ClearSamples removes all samples from the histogram
+func (c *HistogramChart) ClearSamples() {
+	c.samples = make([]float64, 0)
+	c.dirty = true
+}
+
+// BucketCount returns the number of buckets
+func (c *HistogramChart) BucketCount() int {
+	return c.count
+}
+
+// SetBuckets changes the number of buckets. The number
+// cannot be less than 1
+func (c *HistogramChart) SetBuckets(n int) {
+	if n < 1 {
+		n = 1
+	}
+	c.count = n
+	c.dirty = true
+}
+
+// GetBuckets returns the current list of buckets
+func (c *HistogramChart) GetBuckets() []HistogramBucket {
+	c.updateBuckets()
+	buckets := make([]HistogramBucket, len(c.buckets))
+	copy(buckets, c.buckets)
+	return buckets
+}
+
+// Stats returns mean and standard deviation of the samples
+func (c *HistogramChart) Stats() (float64, float64) {
+	if len(c.samples) == 0 {
+		return 0, 0
+	}
+
+	sum := 0.0
+	for _, s := range c.samples {
+		sum += s
+	}
+	mean := sum / float64(len(c.samples))
+
+	sum = 0.0
+	for _, s := range c.samples {
+		sum += (s - mean) * (s - mean)
+	}
+
+	return mean, math.Sqrt(sum / float64(len(c.samples)))
+}
+
+// ShowCDF returns whether the chart displays cumulative
+// distribution function over bars
+func (c *HistogramChart) ShowCDF() bool {
+	return c.showCDF
+}
+
+// SetShowCDF enables or disables displaying cumulative
+// distribution function over bars
+func (c *HistogramChart) SetShowCDF(show bool) {
+	c.showCDF = show
+}
+
+// ShowStats returns whether the chart displays mean and
+// standard deviation of the samples at the bottom row
+func (c *HistogramChart) ShowStats() bool {
+	return c.showStats
+}
+
+// SetShowStats enables or disables displaying statistics
+// at the bottom row of the chart
+func (c *HistogramChart) SetShowStats(show bool) {
+	c.showStats = show
+}
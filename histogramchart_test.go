@@ -0,0 +1,70 @@
+package clui
+
+import (
+	"testing"
+)
+
+func TestHistogramBuckets(t *testing.T) {
+	cases := []struct {
+		samples []float64
+		buckets int
+		want    []int
+	}{
+		{[]float64{}, 3, []int{}},
+		{[]float64{1, 1, 1}, 2, []int{0, 3}},
+		{[]float64{0, 1, 2, 3, 4, 5}, 5, []int{1, 1, 1, 1, 2}},
+		{[]float64{0, 0, 10}, 2, []int{2, 1}},
+	}
+
+	for _, c := range cases {
+		chart := CreateHistogramChart(nil, 10, 5, Fixed)
+		chart.SetBuckets(c.buckets)
+		for _, s := range c.samples {
+			chart.AddSample(s)
+		}
+
+		got := chart.GetBuckets()
+		if len(got) != len(c.want) {
+			t.Errorf("Buckets(%v) count == %v, want %v", c.samples, len(got), len(c.want))
+			continue
+		}
+		for i, b := range got {
+			if b.Count != c.want[i] {
+				t.Errorf("Buckets(%v)[%v] == %v, want %v", c.samples, i, b.Count, c.want[i])
+			}
+		}
+	}
+}
+
+func TestHistogramAddSample(t *testing.T) {
+	chart := CreateHistogramChart(nil, 10, 5, Fixed)
+	chart.SetBuckets(2)
+
+	steps := []struct {
+		sample float64
+		want   []int
+	}{
+		{0, []int{0, 1}},
+		{10, []int{1, 1}},
+		// inside the range: only the bucket is incremented
+		{2, []int{2, 1}},
+		{5, []int{2, 2}},
+		// out of the range: the buckets are recalculated
+		{20, []int{3, 2}},
+	}
+	for _, step := range steps {
+		chart.AddSample(step.sample)
+		got := chart.GetBuckets()
+		if len(got) != len(step.want) {
+			t.Fatalf("AddSample(%v): %v buckets, want %v", step.sample, len(got), len(step.want))
+		}
+		for i, b := range got {
+			if b.Count != step.want[i] {
+				t.Errorf("AddSample(%v): bucket %v == %v, want %v", step.sample, i, b.Count, step.want[i])
+			}
+		}
+	}
+	if b := chart.GetBuckets(); b[0].Low != 0 || b[1].High != 20 {
+		t.Errorf("Invalid bucket range %v", b)
+	}
+}
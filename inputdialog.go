@@ -0,0 +1,109 @@
+package clui
+
+import (
+	xs "github.com/huandu/xstrings"
+	term "github.com/nsf/termbox-go"
+)
+
+// inputDialogWidth is the default width of the InputDialog EditField
+const inputDialogWidth = 40
+
+// createInputDialog builds the InputDialog. done is called when a user
+// presses OK (only if the entered value is valid) or Cancel
+func createInputDialog(title, prompt, defaultValue string, done func(string, bool)) (*ModalFrame, *EditField) {
+	w := inputDialogWidth
+	if l := xs.Len(prompt) + 1; l > w {
+		w = l
+	}
+	if l := xs.Len(title) + 4; l > w {
+		w = l
+	}
+
+	dlg := CreateModalFrame(w+4, 9, title)
+	dlg.SetPaddings(2, 1)
+
+	CreateLabel(dlg, w, 1, prompt, Fixed)
+	edit := CreateEditField(dlg, w, defaultValue, Fixed)
+	// the row below the field displays a validation error
+	CreateFrame(dlg, 1, 1, BorderNone, Fixed)
+
+	accept := func() {
+		if ValidateChildren(dlg) {
+			done(edit.GetText(), true)
+		}
+	}
+
+	row := CreateFrame(dlg, w, 4, BorderNone, Fixed)
+	row.SetGaps(1, 0)
+	CreateFrame(row, 1, 1, BorderNone, 1)
+	btnOK := CreateButton(row, 8, AutoSize, "OK", Fixed)
+	btnOK.OnClick(func(ev Event) {
+		accept()
+	})
+	btnCancel := CreateButton(row, 8, AutoSize, "Cancel", Fixed)
+	btnCancel.OnClick(func(ev Event) {
+		done("", false)
+	})
+	CreateFrame(row, 1, 1, BorderNone, 1)
+
+	dlg.OnKeyDown(func(ev Event) bool {
+		switch ev.Key {
+		case term.KeyCtrlM:
+			accept()
+		case term.KeyEsc:
+			done("", false)
+		default:
+			return false
+		}
+		return true
+	})
+
+	return dlg, edit
+}
+
+/*
+ShowInputDialog displays a modal dialog inside the Window to ask a user
+for a value and waits until the user closes it. The dialog contains the
+prompt, an EditField with the defaultValue, and OK and Cancel buttons.
+The EditField gets the focus. Returns the entered value and true if
+the user pressed OK or Enter, and an empty string and false if the user
+pressed Cancel or Escape.
+
+setup functions are called with the EditField before the dialog is
+displayed. Use them to set a validator, a placeholder, an input mask
+or other EditField properties. OK does not close the dialog if the
+value is invalid.
+
+The function blocks, so it must not be called from the goroutine that
+processes events: e.g, call it inside a new goroutine from a Button
+OnClick callback. The dialog is displayed and closed by the main loop.
+*/
+func ShowInputDialog(view *Window, title, prompt, defaultValue string, setup ...func(*EditField)) (string, bool) {
+	ch := make(chan bool, 1)
+	value, ok := "", false
+
+	// button callbacks are called in new goroutines, so the dialog
+	// is shown and closed by the main loop
+	var dlg *ModalFrame
+	dlg, edit := createInputDialog(title, prompt, defaultValue, func(s string, accepted bool) {
+		view.runOnLoop(func() {
+			if view.ModalDialog() == dlg {
+				value, ok = s, accepted
+				view.CloseModal()
+			}
+		})
+	})
+	for _, fn := range setup {
+		fn(edit)
+	}
+	dlg.OnClose(func() {
+		ch <- ok
+	})
+
+	view.runOnLoop(func() {
+		view.ShowModal(dlg)
+	})
+
+	<-ch
+	return value, ok
+}
@@ -0,0 +1,73 @@
+package clui
+
+import (
+	"errors"
+	term "github.com/nsf/termbox-go"
+	"testing"
+	"time"
+)
+
+func TestInputDialog(t *testing.T) {
+	wnd := CreateWindow(0, 0, 60, 16, "Test")
+	value, ok := "-", false
+	dlg, edit := createInputDialog("Rename", "New name:", "notes.txt", func(s string, accepted bool) {
+		value, ok = s, accepted
+		wnd.CloseModal()
+	})
+	edit.SetValidator(func(s string) error {
+		if s == "" {
+			return errors.New("empty name")
+		}
+		return nil
+	})
+	wnd.ShowModal(dlg)
+
+	if !edit.Active() || edit.Title() != "notes.txt" {
+		t.Fatal("EditField must get the focus and display the default value")
+	}
+
+	edit.Clear()
+	wnd.ProcessEvent(Event{Type: EventKey, Key: term.KeyEnter})
+	if value != "-" || wnd.ModalDialog() == nil {
+		t.Error("Invalid value must not close the dialog")
+	}
+
+	wnd.ProcessEvent(Event{Type: EventKey, Ch: 'a'})
+	wnd.ProcessEvent(Event{Type: EventKey, Key: term.KeyEnter})
+	if value != "a" || !ok || wnd.ModalDialog() != nil {
+		t.Errorf("Enter must accept the value: %q %v", value, ok)
+	}
+
+	wnd.ShowModal(dlg)
+	wnd.ProcessEvent(Event{Type: EventKey, Key: term.KeyEsc})
+	if value != "" || ok {
+		t.Errorf("Escape must cancel the dialog: %q %v", value, ok)
+	}
+}
+
+func TestShowInputDialog(t *testing.T) {
+	wnd := CreateWindow(0, 0, 60, 16, "Test")
+	type result struct {
+		value string
+		ok    bool
+	}
+	ch := make(chan result, 1)
+	go func() {
+		value, ok := ShowInputDialog(wnd, "Rename", "New name:", "notes")
+		ch <- result{value, ok}
+	}()
+
+	deliverUntil(t, wnd, func() bool { return wnd.ModalDialog() != nil })
+	wnd.ProcessEvent(Event{Type: EventKey, Ch: 'x'})
+	wnd.ProcessEvent(Event{Type: EventKey, Key: term.KeyEnter})
+	deliverUntil(t, wnd, func() bool { return wnd.ModalDialog() == nil })
+
+	select {
+	case res := <-ch:
+		if res.value != "notesx" || !res.ok {
+			t.Errorf("Enter must accept the value: %q %v", res.value, res.ok)
+		}
+	case <-time.After(2 * time.Second):
+		t.Error("ShowInputDialog did not return")
+	}
+}
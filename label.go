@@ -16,6 +16,8 @@ type Label struct {
 	BaseControl
 	direction Direction
 	multiline bool
+	// data source attached with Bind
+	bound binding
 }
 
 /*
@@ -115,8 +117,7 @@ func (l *Label) Draw() {
 		}
 	} else {
 		if l.direction == Horizontal {
-			shift, str := AlignColorizedText(l.title, l.width, l.align)
-			DrawText(l.x+shift, l.y, str)
+			DrawTextAligned(l.x, l.y, l.width, l.title, l.align)
 		} else {
 			shift, str := AlignColorizedText(l.title, l.height, l.align)
 			DrawTextVertical(l.x, l.y+shift, str)
@@ -0,0 +1,56 @@
+package clui
+
+/*
+LayoutManager arranges children of a container instead of the default
+layout that packs children in one direction by their scale. Set the
+manager of a container with SetLayout. The container calls the manager
+every time it resizes or moves its children.
+*/
+type LayoutManager interface {
+	// MinimalSize returns the minimal size of the container that is
+	// enough to display all its children
+	MinimalSize(parent Control) (int, int)
+	// Arrange sets sizes and positions of all children of the container
+	Arrange(parent Control)
+}
+
+// Layout returns the layout manager of the control or nil if the
+// control uses the default layout
+func (c *BaseControl) Layout() LayoutManager {
+	return c.layout
+}
+
+// SetLayout sets the layout manager that arranges the control children.
+// nil restores the default layout
+func (c *BaseControl) SetLayout(lm LayoutManager) {
+	c.layout = lm
+}
+
+// visibleChildren returns the children of the container that are not
+// hidden. Hidden children take no space in any layout
+func visibleChildren(parent Control) []Control {
+	var list []Control
+	for _, ctrl := range parent.Children() {
+		if ctrl.Visible() {
+			list = append(list, ctrl)
+		}
+	}
+	return list
+}
+
+// layoutArea returns the area of the container available for children:
+// the container rectangle without paddings
+func layoutArea(parent Control) (x, y, w, h int) {
+	x, y = parent.Pos()
+	w, h = parent.Size()
+	px, py := parent.Paddings()
+	x, y = x+px, y+py
+	w, h = w-2*px, h-2*py
+	if w < 0 {
+		w = 0
+	}
+	if h < 0 {
+		h = 0
+	}
+	return x, y, w, h
+}
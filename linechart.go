@@ -0,0 +1,146 @@
+package clui
+
+import (
+	term "github.com/nsf/termbox-go"
+	"math"
+)
+
+/*
+LineChart is a chart that displays one or more named series
+of data as lines. Every series has its own color. The lines
+are drawn with braille characters, so every character cell
+displays two points horizontally and four vertically.
+Point index is used as X coordinate: the first point of all
+series is at the left edge of the chart. By default the chart
+displays the last points that fit the chart area and scales
+the values automatically to make all displayed points fit
+the chart height. Use SetXRange and SetYRange to display the
+fixed part of the data.
+LineChart displays vertical axis with values on the chart left
+if ValueWidth greater than 0, a row of point indices at the
+bottom if ShowXLabels is true, and chart legend on the right if
+LegendWidth is greater than 3.
+If LegendWidth is greater than half of the chart it is not
+displayed. The same is applied to ValueWidth
+*/
+type LineChart struct {
+	seriesChart
+}
+
+/*
+CreateLineChart creates a new line chart.
+parent - is container that keeps the control.
+w and h - are minimal size of the control.
+scale - the way of scaling the control when the parent is resized. Use DoNotScale constant if the
+control should keep its original size.
+*/
+func CreateLineChart(parent Control, w, h int, scale int) *LineChart {
+	c := new(LineChart)
+	// braille characters display two points in a column
+	c.initSeriesChart(parent, w, h, scale, ColorLineChartText, ColorLineChartBack, ObjLineChart, 2)
+
+	if parent != nil {
+		parent.AddChild(c)
+	}
+
+	return c
+}
+
+// Draw repaints the control on its View surface
+func (l *LineChart) Draw() {
+	PushAttributes()
+	defer PopAttributes()
+
+	if !l.drawBackground() {
+		return
+	}
+
+	l.drawValues(l.rowValue)
+	l.drawXLabels()
+	l.drawLegend()
+	l.drawLines()
+}
+
+func (l *LineChart) drawLines() {
+	start, width := l.calculateChartArea()
+	h := l.chartHeight()
+	if width < 1 || h < 1 {
+		return
+	}
+
+	bottom, top := l.calculateRange()
+	if top == bottom {
+		return
+	}
+
+	PushAttributes()
+	defer PopAttributes()
+
+	// dots of the left and the right columns from top to bottom
+	dots := [2][4]rune{{0x01, 0x02, 0x04, 0x40}, {0x08, 0x10, 0x20, 0x80}}
+	cells := make([]rune, width*h)
+	colors := make([]term.Attribute, width*h)
+
+	first, count := l.xRange()
+	rows := h * 4
+	for _, s := range l.series {
+		clr := RealColor(s.color, ColorLineChartText)
+		prev := -1
+		for i := 0; i < count; i++ {
+			idx := first + i
+			if idx < 0 || idx >= len(s.data) {
+				prev = -1
+				continue
+			}
+
+			sub := valueToSubRow(s.data[idx], bottom, top, rows)
+			from, to := sub, sub
+			// connect the point with the previous one
+			if prev >= 0 && prev < sub {
+				from = prev + 1
+			} else if prev > sub {
+				to = prev - 1
+			}
+
+			col, side := i/2, i%2
+			for y := from; y <= to; y++ {
+				cell := (y/4)*width + col
+				cells[cell] |= dots[side][y%4]
+				colors[cell] = clr
+			}
+			prev = sub
+		}
+	}
+
+	SetBackColor(RealColor(l.bg, ColorLineChartBack))
+	for row := 0; row < h; row++ {
+		for col := 0; col < width; col++ {
+			cell := row*width + col
+			if cells[cell] == 0 {
+				continue
+			}
+
+			SetTextColor(colors[cell])
+			PutChar(l.x+start+col, l.y+row, 0x2800+cells[cell])
+		}
+	}
+}
+
+// valueToSubRow converts a value to the index of braille sub-row
+// counting from the chart top. rows is the total number of sub-rows
+func valueToSubRow(val, bottom, top float64, rows int) int {
+	sub := int(math.Round((top - val) / (top - bottom) * float64(rows-1)))
+	if sub < 0 {
+		sub = 0
+	}
+	if sub >= rows {
+		sub = rows - 1
+	}
+	return sub
+}
+
+// rowValue returns the value at the top sub-row of the row dy
+func (l *LineChart) rowValue(dy int, bottom, top float64) float64 {
+	rows := l.chartHeight() * 4
+	return top - float64(dy*4)/float64(rows-1)*(top-bottom)
+}
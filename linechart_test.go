@@ -0,0 +1,74 @@
+package clui
+
+import (
+	"testing"
+)
+
+func TestLineChartDraw(t *testing.T) {
+	cases := []struct {
+		data [][]float64
+		w, h int
+		want string
+	}{
+		{nil, 2, 1, "  "},
+		{[][]float64{{0, 1, 2, 3}}, 2, 1, "⡠⠊"},
+		// a steep segment connects the points with a vertical line
+		{[][]float64{{0, 3}}, 1, 1, "⡸"},
+		// only the last points that fit the chart are displayed
+		{[][]float64{{9, 9, 0, 1, 2, 3}}, 2, 1, "⡠⠊"},
+		{[][]float64{{0, 1, 2, 3}, {3, 3, 3, 3}}, 2, 1, "⡩⠋"},
+	}
+
+	for _, c := range cases {
+		chart := CreateLineChart(nil, c.w, c.h, Fixed)
+		for _, data := range c.data {
+			id := chart.AddSeries("s", ColorDefault)
+			for _, v := range data {
+				chart.AppendPoint(id, v)
+			}
+		}
+		if got := renderToString(chart); got != c.want {
+			t.Errorf("Line chart %v ==\n%v\nwant\n%v", c.data, got, c.want)
+		}
+	}
+}
+
+func TestLineChartAxes(t *testing.T) {
+	mock := CreateMockCanvas(20, 3)
+	defer mock.Close()
+
+	chart := CreateLineChart(nil, 20, 3, Fixed)
+	chart.SetValueWidth(4)
+	chart.SetLegendWidth(7)
+	chart.SetShowXLabels(true)
+	cpu := chart.AddSeries("cpu", ColorGreen)
+	mem := chart.AddSeries("mem", ColorRed)
+	for i := 0; i < 8; i++ {
+		chart.AppendPoint(cpu, float64(i))
+		chart.AppendPoint(mem, 2)
+	}
+	chart.Draw()
+
+	want := "7.00  ⡠⠊     █ - cpu\n    ⡲⠚⠒⠒     █ - mem\n    0  7            "
+	if got := mock.String(); got != want {
+		t.Errorf("Invalid line chart:\n%v", got)
+	}
+	if fg := mock.Cell(7, 0).Fg; fg != ColorGreen {
+		t.Errorf("Invalid series color %v", fg)
+	}
+	// the cell shared by series gets the color of the last one
+	if fg := mock.Cell(4, 1).Fg; fg != ColorRed {
+		t.Errorf("Invalid shared cell color %v", fg)
+	}
+	if fg := mock.Cell(13, 1).Fg; fg != ColorRed {
+		t.Errorf("Invalid legend mark color %v", fg)
+	}
+
+	chart.SetXRange(0, 3)
+	chart.SetYRange(0, 7)
+	chart.Draw()
+	want = "7.00         █ - cpu\n    ⡲⠚       █ - mem\n    0               "
+	if got := mock.String(); got != want {
+		t.Errorf("Invalid line chart with fixed ranges:\n%v", got)
+	}
+}
@@ -3,6 +3,7 @@ package clui
 import (
 	term "github.com/nsf/termbox-go"
 	"strings"
+	"unicode"
 )
 
 /*
@@ -15,17 +16,98 @@ selected item with mouse or using keyboard (extra case: the event is emitted
 when a user presses Enter - the case is used in ComboBox to select an item
 from drop down list). Event structure has 2 fields filled: Y - selected
 item number in list(-1 if nothing is selected), Msg - text of the selected item.
+
+By default ListBox keeps its items in a slice. An application can set
+its own item provider instead: ListBox requests from the provider only
+the items that are displayed, so the list may contain millions of
+items. Methods that change the item list(AddItem, RemoveItem, and
+Clear) work only with the default slice-backed list.
+
+A filterable ListBox(see SetFilterable) opens a filter bar at its
+bottom when a user types any printable character. Only items that
+contain all characters of the filter in the same order are displayed,
+and the matched characters are highlighted. Backspace deletes the last
+character of the filter, Escape clears the filter and shows all items.
+The filter does not change the item list: SelectItem, SelectedItem,
+and the event Y field use item numbers in the full list.
+
+A user can change the order of items in a reorderable ListBox(see
+SetReorderable): Alt+Arrow Up and Alt+Arrow Down move the selected
+item one position up and down, and an item can be dragged with mouse.
+Reordering works only with the default slice-backed list and only
+while the filter is empty and the list has no groups.
+
+Items can be combined into collapsible groups(see InsertGroup). A group
+header displays the group title and an indicator if the group is
+collapsed or expanded, and the group items are indented. Arrow Left and
+Arrow Right collapse and expand the group of the selected item, and
+clicking a group header toggles the group. Arrow keys skip headers of
+expanded groups, so only headers of collapsed groups can be selected.
 */
 type ListBox struct {
 	BaseControl
 	// own listbox members
 	items         []string
+	provider      ListItemProvider
 	currSelection int
 	topLine       int
 	buttonPos     int
 
+	// rows maps displayed rows to item numbers. A negative value
+	// -N is the header of group N-1. It is nil if the ListBox has
+	// neither groups nor filter and all items are displayed
+	rows   []int
+	groups []listGroup
+
+	filterable bool
+	filtering  bool
+	query      string
+
+	reorderable bool
+	dragging    bool
+	dragFrom    int
+
 	onSelectItem func(Event)
 	onKeyPress   func(term.Key) bool
+	onFilter     func(string, []string)
+	onReorder    func(int, int)
+}
+
+// GroupID is an identifier of a ListBox item group
+type GroupID int
+
+// listGroup is a range of ListBox items displayed under a header
+type listGroup struct {
+	title     string
+	first     int
+	count     int
+	collapsed bool
+}
+
+// ListItem is an item displayed by ListBox
+type ListItem struct {
+	Text string
+}
+
+// ListItemProvider is a source of ListBox items. Count returns the
+// total number of items, and Item returns the item by its index
+// from 0 to Count()-1. ListBox calls Item only for visible items
+type ListItemProvider interface {
+	Count() int
+	Item(index int) ListItem
+}
+
+// SliceItemProvider is a ListItemProvider that keeps items in a slice
+type SliceItemProvider []string
+
+// Count returns the number of items in the slice
+func (p SliceItemProvider) Count() int {
+	return len(p)
+}
+
+// Item returns the item by its index
+func (p SliceItemProvider) Item(index int) ListItem {
+	return ListItem{Text: p[index]}
 }
 
 /*
@@ -58,6 +140,9 @@ func CreateListBox(parent Control, width, height int, scale int) *ListBox {
 	l.SetScale(scale)
 
 	l.onSelectItem = nil
+	l.OnMouseScroll(func(delta int) {
+		l.scrollBy(delta * wheelScrollLines)
+	})
 
 	if parent != nil {
 		parent.AddChild(l)
@@ -70,20 +155,35 @@ func (l *ListBox) drawScroll() {
 	PushAttributes()
 	defer PopAttributes()
 
-	pos := ThumbPosition(l.currSelection, len(l.items), l.height)
+	pos := ThumbPosition(l.currSelection, l.viewCount(), l.listHeight())
 	l.buttonPos = pos
 
-	DrawScrollBar(l.x+l.width-1, l.y, 1, l.height, pos)
+	DrawScrollBar(l.x+l.width-1, l.y, 1, l.listHeight(), pos)
+}
+
+func (l *ListBox) drawFilter() {
+	if !l.filtering {
+		return
+	}
+
+	PushAttributes()
+	defer PopAttributes()
+
+	y := l.y + l.listHeight()
+	SetTextColor(RealColor(l.fg, ColorFilterBarText))
+	SetBackColor(RealColor(l.bg, ColorFilterBarBack))
+	FillRect(l.x, y, l.width, 1, ' ')
+	DrawRawText(l.x, y, CutText("/"+l.query, l.width))
 }
 
 func (l *ListBox) drawItems() {
 	PushAttributes()
 	defer PopAttributes()
 
-	maxCurr := len(l.items) - 1
+	maxCurr := l.viewCount() - 1
 	curr := l.topLine
 	dy := 0
-	maxDy := l.height - 1
+	maxDy := l.listHeight() - 1
 	maxWidth := l.width - 1
 
 	fg, bg := RealColor(l.fg, ColorEditText), RealColor(l.bg, ColorEditBack)
@@ -91,9 +191,23 @@ func (l *ListBox) drawItems() {
 		fg, bg = RealColor(l.fg, ColorEditActiveText), RealColor(l.bg, ColorEditActiveBack)
 	}
 	fgSel, bgSel := RealColor(l.fgActive, ColorSelectionText), RealColor(l.bgActive, ColorSelectionBack)
+	fgMatch := RealColor(l.fgActive, ColorFilterMatchText)
+	fgGroup, bgGroup := RealColor(l.fg, ColorListGroupText), RealColor(l.bg, ColorListGroupBack)
+	marks := []rune(SysObject(ObjListBox))
 
 	for curr <= maxCurr && dy <= maxDy {
+		id, indent := curr, 0
+		if l.rows != nil {
+			id = l.rows[curr]
+		}
+		if id >= 0 && l.groupOf(id) != -1 {
+			indent = 1
+		}
+
 		f, b := fg, bg
+		if id < 0 {
+			f, b = fgGroup, bgGroup
+		}
 		if curr == l.currSelection {
 			f, b = fgSel, bgSel
 		}
@@ -101,14 +215,45 @@ func (l *ListBox) drawItems() {
 		SetTextColor(f)
 		SetBackColor(b)
 		FillRect(l.x, l.y+dy, l.width-1, 1, ' ')
-		str := SliceColorized(l.items[curr], 0, maxWidth)
-		DrawText(l.x, l.y+dy, str)
+		switch {
+		case id < 0:
+			grp := l.groups[-id-1]
+			mark := marks[1]
+			if grp.collapsed {
+				mark = marks[0]
+			}
+			str := SliceColorized(string(mark)+" "+grp.title, 0, maxWidth)
+			DrawText(l.x, l.y+dy, str)
+		case l.query == "":
+			str := SliceColorized(l.itemText(id), 0, maxWidth-indent)
+			DrawText(l.x+indent, l.y+dy, str)
+		default:
+			l.drawMatch(l.x+indent, l.y+dy, l.itemText(id), maxWidth-indent, f, fgMatch)
+		}
 
 		curr++
 		dy++
 	}
 }
 
+// drawMatch draws a filtered item: characters that match
+// the filter are displayed with a different color
+func (l *ListBox) drawMatch(x, y int, text string, maxWidth int, fg, fgMatch term.Attribute) {
+	str := []rune(UnColorizeText(text))
+	matches := fuzzyMatch(str, []rune(l.query))
+
+	m := 0
+	for dx := 0; dx < len(str) && dx < maxWidth; dx++ {
+		if m < len(matches) && matches[m] == dx {
+			SetTextColor(fgMatch)
+			m++
+		} else {
+			SetTextColor(fg)
+		}
+		PutChar(x+dx, y, str[dx])
+	}
+}
+
 // Repaint draws the control on its View surface
 func (l *ListBox) Draw() {
 	PushAttributes()
@@ -126,25 +271,26 @@ func (l *ListBox) Draw() {
 	FillRect(x, y, w, h, ' ')
 	l.drawItems()
 	l.drawScroll()
+	l.drawFilter()
 }
 
 func (l *ListBox) home() {
-	if len(l.items) > 0 {
-		l.currSelection = 0
+	if l.viewCount() > 0 {
+		l.currSelection = l.skipHeader(0, 1)
 	}
 	l.topLine = 0
 }
 
 func (l *ListBox) end() {
-	length := len(l.items)
+	length := l.viewCount()
 
 	if length == 0 {
 		return
 	}
 
-	l.currSelection = length - 1
-	if length > l.height {
-		l.topLine = length - l.height
+	l.currSelection = l.skipHeader(length-1, -1)
+	if length > l.listHeight() {
+		l.topLine = length - l.listHeight()
 	}
 }
 
@@ -154,32 +300,32 @@ func (l *ListBox) moveUp(dy int) {
 	}
 
 	if l.currSelection == -1 {
-		if len(l.items) != 0 {
-			l.currSelection = 0
+		if l.viewCount() != 0 {
+			l.currSelection = l.skipHeader(0, 1)
 		}
 		return
 	}
 
 	if l.currSelection < dy {
-		l.currSelection = 0
+		l.currSelection = l.skipHeader(0, 1)
 	} else {
-		l.currSelection -= dy
+		l.currSelection = l.skipHeader(l.currSelection-dy, -1)
 	}
 
 	l.EnsureVisible()
 }
 
 func (l *ListBox) moveDown(dy int) {
-	length := len(l.items)
+	length := l.viewCount()
 
 	if length == 0 || l.currSelection == length-1 {
 		return
 	}
 
 	if l.currSelection+dy >= length {
-		l.currSelection = length - 1
+		l.currSelection = l.skipHeader(length-1, -1)
 	} else {
-		l.currSelection += dy
+		l.currSelection = l.skipHeader(l.currSelection+dy, 1)
 	}
 
 	l.EnsureVisible()
@@ -187,32 +333,37 @@ func (l *ListBox) moveDown(dy int) {
 
 // EnsureVisible makes the currently selected item visible and scrolls the item list if it is required
 func (l *ListBox) EnsureVisible() {
-	length := len(l.items)
+	length := l.viewCount()
+	height := l.listHeight()
 
-	if length <= l.height || l.currSelection == -1 {
+	if length <= height || l.currSelection == -1 {
 		return
 	}
 
 	diff := l.currSelection - l.topLine
-	if diff >= 0 && diff < l.height {
+	if diff >= 0 && diff < height {
 		return
 	}
 
 	if diff < 0 {
 		l.topLine = l.currSelection
 	} else {
-		top := l.currSelection - l.height + 1
-		if length-top > l.height {
+		top := l.currSelection - height + 1
+		if length-top > height {
 			l.topLine = top
 		} else {
-			l.topLine = length - l.height
+			l.topLine = length - height
 		}
 	}
 }
 
-// Clear deletes all ListBox items
+// Clear deletes all ListBox items. If the ListBox has a custom
+// item provider, the provider is removed
 func (l *ListBox) Clear() {
 	l.items = make([]string, 0)
+	l.provider = nil
+	l.groups = nil
+	l.resetFilter()
 	l.currSelection = -1
 	l.topLine = 0
 }
@@ -224,9 +375,15 @@ func (l *ListBox) processMouseClick(ev Event) bool {
 
 	dx := ev.X - l.x
 	dy := ev.Y - l.y
+	height := l.listHeight()
+
+	if dy >= height {
+		// the filter bar
+		return true
+	}
 
 	if dx == l.width-1 {
-		if dy < 0 || dy >= l.height || len(l.items) < 2 {
+		if dy < 0 || l.viewCount() < 2 {
 			return true
 		}
 
@@ -234,7 +391,7 @@ func (l *ListBox) processMouseClick(ev Event) bool {
 			l.moveUp(1)
 			return true
 		}
-		if dy == l.height-1 {
+		if dy == height-1 {
 			l.moveDown(1)
 			return true
 		}
@@ -248,13 +405,25 @@ func (l *ListBox) processMouseClick(ev Event) bool {
 		return true
 	}
 
-	if dy >= len(l.items) {
+	if l.topLine+dy >= l.viewCount() {
+		return true
+	}
+
+	if l.rows != nil && l.rows[l.topLine+dy] < 0 {
+		grp := -l.rows[l.topLine+dy] - 1
+		l.currSelection = l.topLine + dy
+		if l.groups[grp].collapsed {
+			l.ExpandGroup(GroupID(grp))
+		} else {
+			l.CollapseGroup(GroupID(grp))
+		}
 		return true
 	}
 
-	l.SelectItem(l.topLine + dy)
+	l.currSelection = l.topLine + dy
+	l.EnsureVisible()
 	if l.onSelectItem != nil {
-		ev := Event{Y: l.topLine + dy, Msg: l.SelectedItemText()}
+		ev := Event{Y: l.SelectedItem(), Msg: l.SelectedItemText()}
 		go l.onSelectItem(ev)
 	}
 
@@ -262,7 +431,7 @@ func (l *ListBox) processMouseClick(ev Event) bool {
 }
 
 func (l *ListBox) recalcPositionByScroll() {
-	newPos := ItemByThumbPosition(l.buttonPos, len(l.items), l.height)
+	newPos := ItemByThumbPosition(l.buttonPos, l.viewCount(), l.listHeight())
 	if newPos < 1 {
 		return
 	}
@@ -291,6 +460,21 @@ func (l *ListBox) ProcessEvent(event Event) bool {
 			}
 		}
 
+		if l.filterable && l.processFilterKey(event) {
+			return true
+		}
+
+		if event.Mod == term.ModAlt && l.canReorder() {
+			switch event.Key {
+			case term.KeyArrowUp:
+				l.moveSelected(-1)
+				return true
+			case term.KeyArrowDown:
+				l.moveSelected(1)
+				return true
+			}
+		}
+
 		switch event.Key {
 		case term.KeyHome:
 			l.home()
@@ -304,46 +488,438 @@ func (l *ListBox) ProcessEvent(event Event) bool {
 		case term.KeyArrowDown:
 			l.moveDown(1)
 			return true
+		case term.KeyArrowLeft, term.KeyArrowRight:
+			grp := l.selectedGroup()
+			if grp == -1 {
+				return false
+			}
+			if event.Key == term.KeyArrowLeft {
+				l.CollapseGroup(GroupID(grp))
+			} else {
+				l.ExpandGroup(GroupID(grp))
+			}
+			return true
 		case term.KeyPgdn:
-			l.moveDown(l.height)
+			l.moveDown(l.listHeight())
 			return true
 		case term.KeyPgup:
-			l.moveUp(l.height)
+			l.moveUp(l.listHeight())
 			return true
 		case term.KeyCtrlM:
 			if l.currSelection != -1 && l.onSelectItem != nil {
-				ev := Event{Y: l.currSelection, Msg: l.SelectedItemText()}
+				ev := Event{Y: l.SelectedItem(), Msg: l.SelectedItemText()}
 				go l.onSelectItem(ev)
 			}
 		default:
 			return false
 		}
 	case EventMouse:
+		if l.canReorder() && l.processDrag(event) {
+			return true
+		}
 		return l.processMouseClick(event)
 	}
 
 	return false
 }
 
+// processFilterKey edits the filter. It returns false if the key
+// does not change the filter
+func (l *ListBox) processFilterKey(event Event) bool {
+	switch {
+	case event.Ch != 0 && event.Mod == 0:
+		l.filtering = true
+		l.setQuery(l.query + string(event.Ch))
+	case !l.filtering:
+		return false
+	case event.Key == term.KeySpace:
+		l.setQuery(l.query + " ")
+	case event.Key == term.KeyBackspace || event.Key == term.KeyBackspace2:
+		runes := []rune(l.query)
+		if len(runes) > 0 {
+			runes = runes[:len(runes)-1]
+		}
+		if len(runes) == 0 {
+			l.filtering = false
+		}
+		l.setQuery(string(runes))
+	case event.Key == term.KeyEsc:
+		l.filtering = false
+		l.setQuery("")
+	default:
+		return false
+	}
+
+	return true
+}
+
+// fuzzyMatch returns positions of query characters in str if all
+// of them are found in str in the same order, and nil otherwise.
+// Characters are compared case insensitively
+func fuzzyMatch(str, query []rune) []int {
+	matches := make([]int, 0, len(query))
+	q := 0
+	for idx := 0; idx < len(str) && q < len(query); idx++ {
+		if unicode.ToLower(str[idx]) == unicode.ToLower(query[q]) {
+			matches = append(matches, idx)
+			q++
+		}
+	}
+
+	if q < len(query) {
+		return nil
+	}
+	return matches
+}
+
+// setQuery changes the filter and rebuilds the list of displayed
+// items. The selected item remains selected if it matches the filter
+func (l *ListBox) setQuery(query string) {
+	selected := l.SelectedItem()
+	l.query = query
+	l.buildRows()
+
+	l.topLine = 0
+	l.currSelection = l.itemRow(selected)
+	if l.currSelection == -1 && l.viewCount() > 0 {
+		l.currSelection = 0
+	}
+	l.EnsureVisible()
+
+	if l.onFilter != nil {
+		var matched []string
+		if l.rows != nil {
+			matched = make([]string, 0, len(l.rows))
+			for _, id := range l.rows {
+				if id >= 0 {
+					matched = append(matched, l.itemText(id))
+				}
+			}
+		}
+		go l.onFilter(l.query, matched)
+	}
+}
+
+// buildRows rebuilds the mapping between displayed rows and items.
+// Items of collapsed groups are hidden, and a group is hidden if
+// none of its items matches the filter
+func (l *ListBox) buildRows() {
+	if l.query == "" && len(l.groups) == 0 {
+		l.rows = nil
+		return
+	}
+
+	query := []rune(l.query)
+	matches := func(idx int) bool {
+		return len(query) == 0 || fuzzyMatch([]rune(UnColorizeText(l.itemText(idx))), query) != nil
+	}
+
+	l.rows = make([]int, 0)
+	grp := 0
+	for idx := 0; idx < l.ItemCount(); {
+		if grp >= len(l.groups) || l.groups[grp].first != idx {
+			if matches(idx) {
+				l.rows = append(l.rows, idx)
+			}
+			idx++
+			continue
+		}
+
+		g := l.groups[grp]
+		items := make([]int, 0, g.count)
+		for i := g.first; i < g.first+g.count; i++ {
+			if matches(i) {
+				items = append(items, i)
+			}
+		}
+		if len(query) == 0 || len(items) > 0 {
+			l.rows = append(l.rows, -grp-1)
+			if !g.collapsed {
+				l.rows = append(l.rows, items...)
+			}
+		}
+
+		idx += g.count
+		grp++
+	}
+
+	// empty groups
+	for ; grp < len(l.groups) && len(query) == 0; grp++ {
+		l.rows = append(l.rows, -grp-1)
+	}
+}
+
+// resetFilter closes the filter bar without calling the callback
+func (l *ListBox) resetFilter() {
+	l.filtering = false
+	l.query = ""
+	l.buildRows()
+}
+
+// groupOf returns the group of the item or -1 if the item
+// does not belong to any group
+func (l *ListBox) groupOf(id int) int {
+	for idx, g := range l.groups {
+		if id >= g.first && id < g.first+g.count {
+			return idx
+		}
+	}
+	return -1
+}
+
+// selectedGroup returns the group of the selected row: the
+// selected header or the group of the selected item
+func (l *ListBox) selectedGroup() int {
+	if l.currSelection == -1 || l.rows == nil {
+		return -1
+	}
+
+	id := l.rows[l.currSelection]
+	if id < 0 {
+		return -id - 1
+	}
+	return l.groupOf(id)
+}
+
+// selectable returns false for headers of expanded groups
+// that have visible items
+func (l *ListBox) selectable(row int) bool {
+	if l.rows == nil || l.rows[row] >= 0 {
+		return true
+	}
+
+	grp := -l.rows[row] - 1
+	next := row + 1
+	return next >= len(l.rows) || l.rows[next] < 0 || l.groupOf(l.rows[next]) != grp
+}
+
+// skipHeader returns the first selectable row starting from row
+// in the direction dir. If there is none in that direction it
+// looks in the opposite one
+func (l *ListBox) skipHeader(row, dir int) int {
+	for r := row; r >= 0 && r < l.viewCount(); r += dir {
+		if l.selectable(r) {
+			return r
+		}
+	}
+	for r := row - dir; r >= 0 && r < l.viewCount(); r -= dir {
+		if l.selectable(r) {
+			return r
+		}
+	}
+	return row
+}
+
+// setGroupCollapsed collapses or expands the group. The selected
+// item remains selected if it is visible, otherwise the group
+// header is selected
+func (l *ListBox) setGroupCollapsed(id GroupID, collapsed bool) {
+	if id < 0 || int(id) >= len(l.groups) {
+		return
+	}
+
+	selected, selGroup := l.SelectedItem(), l.selectedGroup()
+	l.groups[id].collapsed = collapsed
+	l.buildRows()
+
+	row := l.itemRow(selected)
+	switch {
+	case row != -1:
+	case selGroup == int(id):
+		row = l.headerRow(selGroup)
+		if row != -1 && !l.selectable(row) {
+			row++
+		}
+	case selected == -1 && selGroup != -1:
+		row = l.headerRow(selGroup)
+	}
+	l.currSelection = row
+
+	if max := l.viewCount() - l.listHeight(); l.topLine > max {
+		l.topLine = max
+	}
+	if l.topLine < 0 {
+		l.topLine = 0
+	}
+	l.EnsureVisible()
+}
+
+// viewCount returns the number of displayed items
+func (l *ListBox) viewCount() int {
+	if l.rows != nil {
+		return len(l.rows)
+	}
+	return l.ItemCount()
+}
+
+// itemRow returns the displayed row of the item or -1 if the
+// item is hidden by the filter
+func (l *ListBox) itemRow(id int) int {
+	if id < 0 || id >= l.ItemCount() {
+		return -1
+	}
+	if l.rows == nil {
+		return id
+	}
+
+	for row, itm := range l.rows {
+		if itm == id {
+			return row
+		}
+	}
+	return -1
+}
+
+// headerRow returns the displayed row of the group header or -1
+// if the group is hidden by the filter
+func (l *ListBox) headerRow(grp int) int {
+	for row, id := range l.rows {
+		if id == -grp-1 {
+			return row
+		}
+	}
+	return -1
+}
+
+// listHeight returns the number of rows for items: the filter
+// bar takes the bottom row
+func (l *ListBox) listHeight() int {
+	if l.filtering && l.height > 1 {
+		return l.height - 1
+	}
+	return l.height
+}
+
+// scrollBy moves the visible part of the list by dy lines. The
+// selection does not change
+func (l *ListBox) scrollBy(dy int) {
+	top := l.topLine + dy
+	if max := l.viewCount() - l.listHeight(); top > max {
+		top = max
+	}
+	if top < 0 {
+		top = 0
+	}
+	l.topLine = top
+}
+
+// canReorder returns true if the items can be moved now
+func (l *ListBox) canReorder() bool {
+	return l.reorderable && l.provider == nil && l.rows == nil && len(l.groups) == 0
+}
+
+// moveItem moves the item from one position to another
+// shifting the items between them
+func (l *ListBox) moveItem(from, to int) {
+	item := l.items[from]
+	if from < to {
+		copy(l.items[from:to], l.items[from+1:to+1])
+	} else {
+		copy(l.items[to+1:from+1], l.items[to:from])
+	}
+	l.items[to] = item
+}
+
+// moveSelected swaps the selected item with its neighbor
+func (l *ListBox) moveSelected(delta int) {
+	from := l.currSelection
+	to := from + delta
+	if from == -1 || to < 0 || to >= len(l.items) {
+		return
+	}
+
+	l.moveItem(from, to)
+	l.currSelection = to
+	l.EnsureVisible()
+
+	if l.onReorder != nil {
+		go l.onReorder(from, to)
+	}
+}
+
+// processDrag moves an item with mouse: a user presses the left
+// button over the item and drags it to the new position
+func (l *ListBox) processDrag(ev Event) bool {
+	switch {
+	case l.dragging && ev.Key == term.MouseLeft && ev.Mod == term.ModMotion:
+		to := l.topLine + ev.Y - l.y
+		if to < 0 {
+			to = 0
+		}
+		if to >= len(l.items) {
+			to = len(l.items) - 1
+		}
+		if to != l.currSelection {
+			l.moveItem(l.currSelection, to)
+			l.currSelection = to
+			l.EnsureVisible()
+		}
+		return true
+	case l.dragging && ev.Key == term.MouseRelease:
+		l.dragging = false
+		ReleaseEvents()
+		if l.onReorder != nil && l.dragFrom != l.currSelection {
+			go l.onReorder(l.dragFrom, l.currSelection)
+		}
+		return true
+	case !l.dragging && ev.Key == term.MouseLeft && ev.Mod == 0:
+		dx, dy := ev.X-l.x, ev.Y-l.y
+		if dx < 0 || dx >= l.width-1 || dy < 0 || dy >= l.listHeight() ||
+			l.topLine+dy >= len(l.items) {
+			return false
+		}
+
+		l.processMouseClick(ev)
+		l.dragging = true
+		l.dragFrom = l.currSelection
+		GrabEvents(l)
+		return true
+	}
+
+	return false
+}
+
 // own methods
 
+// itemText returns the text of the item by its index
+func (l *ListBox) itemText(id int) string {
+	if l.provider != nil {
+		return l.provider.Item(id).Text
+	}
+	return l.items[id]
+}
+
 // AddItem adds a new item to item list.
-// Returns true if the operation is successful
+// Returns true if the operation is successful. It fails if
+// the ListBox has a custom item provider
 func (l *ListBox) AddItem(item string) bool {
+	if l.provider != nil {
+		return false
+	}
+
 	l.items = append(l.items, item)
+	if l.rows != nil && (l.query == "" || fuzzyMatch([]rune(UnColorizeText(item)), []rune(l.query)) != nil) {
+		l.rows = append(l.rows, len(l.items)-1)
+	}
 	return true
 }
 
 // SelectItem slects item which number in the list equals
 // id. If the item exists the ListBox scrolls the list to
 // make the item visible.
-// Returns true if the item is selected successfully
+// Returns true if the item is selected successfully. It fails
+// if the item is hidden by the filter
 func (l *ListBox) SelectItem(id int) bool {
-	if len(l.items) <= id || id < 0 {
+	if l.ItemCount() <= id || id < 0 {
+		return false
+	}
+
+	row := l.itemRow(id)
+	if row == -1 {
 		return false
 	}
 
-	l.currSelection = id
+	l.currSelection = row
 	l.EnsureVisible()
 	return true
 }
@@ -352,7 +928,8 @@ func (l *ListBox) SelectItem(id int) bool {
 // to text, by default the search is casesensitive.
 // Returns item number in item list or -1 if nothing is found.
 func (l *ListBox) FindItem(text string, caseSensitive bool) int {
-	for idx, itm := range l.items {
+	for idx := 0; idx < l.ItemCount(); idx++ {
+		itm := l.itemText(idx)
 		if itm == text || (caseSensitive && strings.EqualFold(itm, text)) {
 			return idx
 		}
@@ -363,27 +940,49 @@ func (l *ListBox) FindItem(text string, caseSensitive bool) int {
 
 // SelectedItem returns currently selected item id
 func (l *ListBox) SelectedItem() int {
-	return l.currSelection
+	if l.currSelection == -1 || l.rows == nil {
+		return l.currSelection
+	}
+	if id := l.rows[l.currSelection]; id >= 0 {
+		return id
+	}
+	return -1
 }
 
 // SelectedItemText returns text of currently selected item or empty sting if nothing is
 // selected or ListBox is empty.
 func (l *ListBox) SelectedItemText() string {
-	if l.currSelection == -1 {
+	id := l.SelectedItem()
+	if id == -1 {
 		return ""
 	}
 
-	return l.items[l.currSelection]
+	return l.itemText(id)
 }
 
 // RemoveItem deletes an item which number is id in item list
-// Returns true if item is deleted
+// Returns true if item is deleted. It fails if the ListBox has
+// a custom item provider
 func (l *ListBox) RemoveItem(id int) bool {
-	if id < 0 || id >= len(l.items) {
+	if l.provider != nil || id < 0 || id >= l.ItemCount() {
 		return false
 	}
 
 	l.items = append(l.items[:id], l.items[id+1:]...)
+	for idx := range l.groups {
+		g := &l.groups[idx]
+		if id < g.first {
+			g.first--
+		} else if id < g.first+g.count {
+			g.count--
+		}
+	}
+	if l.rows != nil {
+		l.buildRows()
+		if l.currSelection >= l.viewCount() {
+			l.currSelection = l.viewCount() - 1
+		}
+	}
 	return true
 }
 
@@ -403,5 +1002,128 @@ func (l *ListBox) OnKeyPress(fn func(term.Key) bool) {
 
 // ItemCount returns the number of items in the ListBox
 func (l *ListBox) ItemCount() int {
+	if l.provider != nil {
+		return l.provider.Count()
+	}
 	return len(l.items)
 }
+
+// ItemProvider returns the source of ListBox items. If no custom
+// provider is set it returns the default slice-backed provider
+func (l *ListBox) ItemProvider() ListItemProvider {
+	if l.provider != nil {
+		return l.provider
+	}
+	return SliceItemProvider(l.items)
+}
+
+// SetItemProvider sets a custom source of ListBox items. The
+// selection is reset. Pass nil to return to the default
+// slice-backed item list
+func (l *ListBox) SetItemProvider(provider ListItemProvider) {
+	l.provider = provider
+	l.groups = nil
+	l.resetFilter()
+	l.currSelection = -1
+	l.topLine = 0
+}
+
+// SetFilterable enables or disables the item filter. Disabling
+// the filter shows all items
+func (l *ListBox) SetFilterable(filterable bool) {
+	l.filterable = filterable
+	if !filterable && l.query != "" {
+		l.filtering = false
+		l.setQuery("")
+	}
+}
+
+// Filterable returns true if a user can filter ListBox items
+func (l *ListBox) Filterable() bool {
+	return l.filterable
+}
+
+// Filter returns the current filter text
+func (l *ListBox) Filter() string {
+	return l.query
+}
+
+// OnFilter sets a callback that is called every time the filter
+// is changed. The callback gets the filter text and the texts of
+// matched items. If the filter is cleared matched is nil
+func (l *ListBox) OnFilter(fn func(query string, matched []string)) {
+	l.onFilter = fn
+}
+
+// SetReorderable enables or disables moving items by a user
+func (l *ListBox) SetReorderable(reorderable bool) {
+	l.reorderable = reorderable
+}
+
+// Reorderable returns true if a user can change the order of items
+func (l *ListBox) Reorderable() bool {
+	return l.reorderable
+}
+
+// OnReorder sets a callback that is called after a user moves
+// an item. The callback gets the old and the new item positions.
+// Dragging an item with mouse calls the callback once when the
+// mouse button is released
+func (l *ListBox) OnReorder(fn func(oldIndex, newIndex int)) {
+	l.onReorder = fn
+}
+
+// InsertGroup adds a new collapsible group of items to the end of
+// the item list. Group items get item numbers like ordinary items.
+// Returns the group identifier or -1 if the ListBox has a custom
+// item provider
+func (l *ListBox) InsertGroup(title string, items []string) GroupID {
+	if l.provider != nil {
+		return -1
+	}
+
+	l.groups = append(l.groups, listGroup{title: title, first: len(l.items), count: len(items)})
+	l.items = append(l.items, items...)
+
+	selected := l.SelectedItem()
+	l.buildRows()
+	if selected != -1 {
+		l.currSelection = l.itemRow(selected)
+	}
+	return GroupID(len(l.groups) - 1)
+}
+
+// CollapseGroup hides items of the group
+func (l *ListBox) CollapseGroup(id GroupID) {
+	l.setGroupCollapsed(id, true)
+}
+
+// ExpandGroup shows items of the group
+func (l *ListBox) ExpandGroup(id GroupID) {
+	l.setGroupCollapsed(id, false)
+}
+
+// GroupCollapsed returns true if the group items are hidden
+func (l *ListBox) GroupCollapsed(id GroupID) bool {
+	if id < 0 || int(id) >= len(l.groups) {
+		return false
+	}
+	return l.groups[id].collapsed
+}
+
+// SelectedGroupItem returns the group of the selected item and the
+// item index inside the group. The index is -1 if a group header
+// is selected, and the group is -1 if the item does not belong to
+// any group or nothing is selected
+func (l *ListBox) SelectedGroupItem() (GroupID, int) {
+	grp := l.selectedGroup()
+	if grp == -1 {
+		return -1, l.SelectedItem()
+	}
+
+	id := l.SelectedItem()
+	if id == -1 {
+		return GroupID(grp), -1
+	}
+	return GroupID(grp), id - l.groups[grp].first
+}
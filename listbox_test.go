@@ -1,6 +1,9 @@
 package clui
 
 import (
+	"fmt"
+	term "github.com/nsf/termbox-go"
+	"strings"
 	"testing"
 )
 
@@ -62,3 +65,191 @@ func TestListBox(t *testing.T) {
 		t.Errorf("Clear failed")
 	}
 }
+
+// countingProvider is a huge virtual list that remembers requested items
+type countingProvider struct {
+	requested map[int]bool
+}
+
+func (p *countingProvider) Count() int {
+	return 5000000
+}
+
+func (p *countingProvider) Item(index int) ListItem {
+	p.requested[index] = true
+	return ListItem{Text: fmt.Sprintf("Item %v", index)}
+}
+
+func TestListBoxItemProvider(t *testing.T) {
+	lbox := CreateListBox(nil, 10, 5, Fixed)
+	p := &countingProvider{requested: make(map[int]bool)}
+	lbox.SetItemProvider(p)
+
+	if lbox.ItemCount() != 5000000 {
+		t.Errorf("Invalid item count %v", lbox.ItemCount())
+	}
+	if lbox.AddItem("new") {
+		t.Error("AddItem must fail for custom provider")
+	}
+
+	lbox.SelectItem(1000000)
+	renderToString(lbox)
+	if len(p.requested) != 5 {
+		t.Errorf("Only visible items must be requested, got %v", len(p.requested))
+	}
+	for idx := range p.requested {
+		if idx < 999996 || idx > 1000000 {
+			t.Errorf("Item %v is not visible", idx)
+		}
+	}
+	if s := lbox.SelectedItemText(); s != "Item 1000000" {
+		t.Errorf("Invalid selected item %q", s)
+	}
+
+	lbox.SetItemProvider(nil)
+	lbox.AddItem("first")
+	if lbox.ItemProvider().Count() != 1 || lbox.ItemProvider().Item(0).Text != "first" {
+		t.Error("Default provider must contain added items")
+	}
+}
+
+func TestListBoxFilter(t *testing.T) {
+	lbox := CreateListBox(nil, 10, 5, Fixed)
+	lbox.SetActive(true)
+	for _, s := range []string{"apple", "banana", "grape", "pineapple"} {
+		lbox.AddItem(s)
+	}
+
+	lbox.ProcessEvent(Event{Type: EventKey, Ch: 'a'})
+	if lbox.Filter() != "" {
+		t.Error("Filter must be disabled by default")
+	}
+
+	lbox.SetFilterable(true)
+	lbox.ProcessEvent(Event{Type: EventKey, Ch: 'a'})
+	lbox.ProcessEvent(Event{Type: EventKey, Ch: 'P'})
+	lbox.ProcessEvent(Event{Type: EventKey, Ch: 'e'})
+	if lbox.viewCount() != 3 {
+		t.Errorf("Invalid number of matched items: %v", lbox.viewCount())
+	}
+
+	lbox.ProcessEvent(Event{Type: EventKey, Key: term.KeyArrowDown})
+	if lbox.SelectedItem() != 2 || lbox.SelectedItemText() != "grape" {
+		t.Errorf("Invalid selected item %v(%v)", lbox.SelectedItem(), lbox.SelectedItemText())
+	}
+	if lbox.SelectItem(1) {
+		t.Error("Hidden item must not be selected")
+	}
+	if lbox.ItemCount() != 4 {
+		t.Error("Filter must not change the item list")
+	}
+
+	lbox.ProcessEvent(Event{Type: EventKey, Key: term.KeyEsc})
+	if lbox.Filter() != "" || lbox.viewCount() != 4 {
+		t.Errorf("Escape must clear the filter: %q", lbox.Filter())
+	}
+	if lbox.SelectedItem() != 2 {
+		t.Errorf("Selection must be kept after clearing the filter: %v", lbox.SelectedItem())
+	}
+}
+
+func TestFuzzyMatch(t *testing.T) {
+	cases := []struct {
+		str, query string
+		matches    []int
+	}{
+		{"pineapple", "pal", []int{0, 4, 7}},
+		{"Apple", "ap", []int{0, 1}},
+		{"apple", "pa", nil},
+		{"abc", "abcd", nil},
+	}
+
+	for _, c := range cases {
+		res := fuzzyMatch([]rune(c.str), []rune(c.query))
+		if fmt.Sprint(res) != fmt.Sprint(c.matches) || (res == nil) != (c.matches == nil) {
+			t.Errorf("fuzzyMatch(%q, %q) = %v, expected %v", c.str, c.query, res, c.matches)
+		}
+	}
+}
+
+func TestListBoxReorder(t *testing.T) {
+	lbox := CreateListBox(nil, 10, 5, Fixed)
+	lbox.SetActive(true)
+	for _, s := range []string{"one", "two", "three", "four"} {
+		lbox.AddItem(s)
+	}
+	lbox.SelectItem(1)
+
+	altUp := Event{Type: EventKey, Key: term.KeyArrowUp, Mod: term.ModAlt}
+	altDown := Event{Type: EventKey, Key: term.KeyArrowDown, Mod: term.ModAlt}
+
+	lbox.ProcessEvent(altDown)
+	if lbox.items[1] != "two" || lbox.SelectedItem() != 2 {
+		t.Errorf("Items must not move by default: %v", lbox.items)
+	}
+
+	lbox.SetReorderable(true)
+	lbox.ProcessEvent(altDown)
+	if fmt.Sprint(lbox.items) != "[one two four three]" || lbox.SelectedItem() != 3 {
+		t.Errorf("Invalid order after moving down: %v", lbox.items)
+	}
+	lbox.ProcessEvent(altDown)
+	if fmt.Sprint(lbox.items) != "[one two four three]" {
+		t.Errorf("The last item must not move down: %v", lbox.items)
+	}
+
+	lbox.SelectItem(2)
+	lbox.ProcessEvent(altUp)
+	lbox.ProcessEvent(altUp)
+	if fmt.Sprint(lbox.items) != "[four one two three]" || lbox.SelectedItemText() != "four" {
+		t.Errorf("Invalid order after moving up: %v", lbox.items)
+	}
+
+	lbox.moveItem(0, 3)
+	if fmt.Sprint(lbox.items) != "[one two three four]" {
+		t.Errorf("Invalid order after moving item: %v", lbox.items)
+	}
+}
+
+func TestListBoxGroups(t *testing.T) {
+	lbox := CreateListBox(nil, 12, 10, Fixed)
+	lbox.SetActive(true)
+	lbox.AddItem("plain")
+	fruits := lbox.InsertGroup("Fruits", []string{"apple", "pear"})
+	veggies := lbox.InsertGroup("Veggies", []string{"carrot"})
+
+	if lbox.ItemCount() != 4 || lbox.viewCount() != 6 {
+		t.Errorf("Invalid item count %v and row count %v", lbox.ItemCount(), lbox.viewCount())
+	}
+
+	lbox.SelectItem(0)
+	lbox.ProcessEvent(Event{Type: EventKey, Key: term.KeyArrowDown})
+	if grp, idx := lbox.SelectedGroupItem(); grp != fruits || idx != 0 || lbox.SelectedItemText() != "apple" {
+		t.Errorf("Header must be skipped: group %v item %v", grp, idx)
+	}
+
+	lbox.ProcessEvent(Event{Type: EventKey, Key: term.KeyArrowLeft})
+	if !lbox.GroupCollapsed(fruits) || lbox.viewCount() != 4 {
+		t.Errorf("Group must be collapsed: %v rows", lbox.viewCount())
+	}
+	if grp, idx := lbox.SelectedGroupItem(); grp != fruits || idx != -1 || lbox.SelectedItem() != -1 {
+		t.Errorf("Collapsed group header must be selected: group %v item %v", grp, idx)
+	}
+
+	lbox.ProcessEvent(Event{Type: EventKey, Key: term.KeyArrowDown})
+	if grp, idx := lbox.SelectedGroupItem(); grp != veggies || idx != 0 {
+		t.Errorf("Invalid selection after collapsed header: group %v item %v", grp, idx)
+	}
+
+	lbox.ProcessEvent(Event{Type: EventKey, Key: term.KeyArrowUp})
+	lbox.ProcessEvent(Event{Type: EventKey, Key: term.KeyArrowRight})
+	if lbox.GroupCollapsed(fruits) || lbox.SelectedItemText() != "apple" {
+		t.Errorf("Group must be expanded with the first item selected: %q", lbox.SelectedItemText())
+	}
+
+	mark := []rune(SysObject(ObjListBox))[1]
+	out := renderToString(lbox)
+	if !strings.Contains(out, string(mark)+" Fruits") || !strings.Contains(out, " apple") {
+		t.Errorf("Invalid group output:\n%v", out)
+	}
+}
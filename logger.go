@@ -1,24 +1,116 @@
 package clui
 
 import (
+	"context"
 	"log"
+	"log/slog"
 	"os"
+	"sync"
 )
 
 var (
 	logger *log.Logger
+	// handler that writes structured records to the same file as logger
+	logBase  slog.Handler
+	logLevel slog.LevelVar
+	logMtx   sync.Mutex
 )
 
+func init() {
+	logLevel.Set(slog.LevelInfo)
+}
+
 func InitLogger() {
+	logMtx.Lock()
+	defer logMtx.Unlock()
+	initLogger()
+}
+
+// initLogger opens the log file. It is called with logMtx locked
+func initLogger() {
 	file, _ := os.OpenFile("debug.log", os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
 	logger = log.New(file, "", log.Ldate|log.Ltime|log.Lshortfile)
 	logger.Printf("----------------------------------")
+	logBase = slog.NewTextHandler(file, &slog.HandlerOptions{Level: &logLevel})
 }
 
 func Logger() *log.Logger {
+	logMtx.Lock()
+	defer logMtx.Unlock()
 	if logger == nil {
-		InitLogger()
+		initLogger()
 	}
 
 	return logger
 }
+
+// SetLogLevel changes the minimal level of records written by structured
+// loggers. The default level is slog.LevelInfo, so debug records of
+// controls are skipped
+func SetLogLevel(level slog.Level) {
+	logLevel.Set(level)
+}
+
+// logEnabled returns true if records of the level are written. Use it to
+// avoid preparing records that are skipped anyway
+func logEnabled(level slog.Level) bool {
+	return level >= logLevel.Level()
+}
+
+// lazyHandler opens the log file only when the first record is written,
+// so applications that do not enable logging do not create the file
+type lazyHandler struct {
+	wrap func(slog.Handler) slog.Handler
+}
+
+func (h *lazyHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return logEnabled(level)
+}
+
+func (h *lazyHandler) Handle(ctx context.Context, r slog.Record) error {
+	logMtx.Lock()
+	if logBase == nil {
+		initLogger()
+	}
+	base := logBase
+	logMtx.Unlock()
+
+	if h.wrap != nil {
+		base = h.wrap(base)
+	}
+	return base.Handle(ctx, r)
+}
+
+func (h *lazyHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return h.with(func(b slog.Handler) slog.Handler { return b.WithAttrs(attrs) })
+}
+
+func (h *lazyHandler) WithGroup(name string) slog.Handler {
+	return h.with(func(b slog.Handler) slog.Handler { return b.WithGroup(name) })
+}
+
+func (h *lazyHandler) with(fn func(slog.Handler) slog.Handler) slog.Handler {
+	prev := h.wrap
+	return &lazyHandler{wrap: func(b slog.Handler) slog.Handler {
+		if prev != nil {
+			b = prev(b)
+		}
+		return fn(b)
+	}}
+}
+
+// StructuredLogger returns a logger that adds the control type, ID, and
+// its parent ID to every record. The type is known only for controls
+// inside a container. Records are written to the same file as Logger
+func (c *BaseControl) StructuredLogger() *slog.Logger {
+	ctype, parentID := "", ""
+	if self := c.owner(); self != nil {
+		ctype = typeName(self)
+	}
+	if c.parent != nil {
+		parentID = c.parent.ID()
+	}
+
+	return slog.New(&lazyHandler{}).With(
+		"control_type", ctype, "control_id", c.id, "parent_id", parentID)
+}
@@ -0,0 +1,86 @@
+package clui
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestStructuredLogger(t *testing.T) {
+	var buf bytes.Buffer
+	saved := logBase
+	logBase = slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: &logLevel})
+	defer func() {
+		logBase = saved
+		SetLogLevel(slog.LevelInfo)
+	}()
+
+	frame := CreateFrame(nil, 10, 5, BorderNone, Fixed)
+	frame.SetID("panel")
+	chart := CreateSparkChart(frame, 10, 5, Fixed)
+	chart.SetID("cpu")
+
+	chart.AddData(1)
+	if buf.Len() != 0 {
+		t.Errorf("Debug records must be skipped by default: %q", buf.String())
+	}
+
+	SetLogLevel(slog.LevelDebug)
+	chart.AddData(2)
+	s := buf.String()
+	for _, field := range []string{"control_type=SparkChart", "control_id=cpu", "parent_id=panel", "value=2"} {
+		if !strings.Contains(s, field) {
+			t.Errorf("Record must contain %v: %q", field, s)
+		}
+	}
+}
+
+func TestLoggerConcurrentInit(t *testing.T) {
+	dir, err := ioutil.TempDir("", "clui")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	wd, _ := os.Getwd()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	savedLogger, savedBase := logger, logBase
+	logger, logBase = nil, nil
+	defer func() {
+		logger, logBase = savedLogger, savedBase
+	}()
+
+	// the first use from many goroutines opens the log file once
+	loggers := make([]*log.Logger, 8)
+	var wg sync.WaitGroup
+	for i := range loggers {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			slog.New(&lazyHandler{}).Info("started")
+			loggers[i] = Logger()
+		}(i)
+	}
+	wg.Wait()
+
+	for _, l := range loggers {
+		if l != loggers[0] {
+			t.Fatal("All goroutines must get the same logger")
+		}
+	}
+	data, err := ioutil.ReadFile("debug.log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(string(data), "----------------------------------"); n != 1 {
+		t.Errorf("The log file must be opened once, got %v headers", n)
+	}
+}
@@ -0,0 +1,603 @@
+package clui
+
+import (
+	xs "github.com/huandu/xstrings"
+	term "github.com/nsf/termbox-go"
+	"strings"
+	"unicode"
+)
+
+// MenuRef is a reference to a MenuBar menu
+type MenuRef int
+
+// ItemRef is a reference to an item inside a menu
+type ItemRef int
+
+// menuItem is a menu command or a separator
+type menuItem struct {
+	label     string
+	shortcut  string
+	action    func()
+	disabled  bool
+	separator bool
+}
+
+// barMenu is a menu of MenuBar
+type barMenu struct {
+	title string
+	items []menuItem
+}
+
+// menuPanel is a floating framed list of menu items. It is drawn on
+// the overlay layer and grabs all events while it is open
+type menuPanel struct {
+	BaseControl
+	items   []menuItem
+	current int
+
+	// onEvent is called before the panel processes an event. If it
+	// returns true the panel skips the event
+	onEvent func(Event) bool
+	onClose func()
+}
+
+func newMenuPanel(items []menuItem) *menuPanel {
+	p := new(menuPanel)
+	p.items = items
+	p.current = -1
+	p.tabSkip = true
+
+	labelW, shortW := 0, 0
+	for _, item := range items {
+		if l := xs.Len(item.label); l > labelW {
+			labelW = l
+		}
+		if l := xs.Len(item.shortcut); l > shortW {
+			shortW = l
+		}
+	}
+
+	width := labelW + 4
+	if shortW > 0 {
+		width += shortW + 2
+	}
+	p.SetSize(width, len(items)+2)
+
+	return p
+}
+
+// selectable returns true if the item can be selected
+func (p *menuPanel) selectable(idx int) bool {
+	return idx >= 0 && idx < len(p.items) && !p.items[idx].separator && !p.items[idx].disabled
+}
+
+// moveSelection selects the next or previous selectable item. The
+// selection wraps around
+func (p *menuPanel) moveSelection(dir int) {
+	cnt := len(p.items)
+	idx := p.current
+	for i := 0; i < cnt; i++ {
+		idx = (idx + dir + cnt) % cnt
+		if p.selectable(idx) {
+			p.current = idx
+			return
+		}
+	}
+}
+
+// open shows the panel at screen position x, y. The panel is moved
+// if it does not fit the screen
+func (p *menuPanel) open(x, y int) {
+	sw, sh := ScreenSize()
+	if x+p.width > sw {
+		x = sw - p.width
+	}
+	if y+p.height > sh {
+		y = sh - p.height
+	}
+	if x < 0 {
+		x = 0
+	}
+	if y < 0 {
+		y = 0
+	}
+	p.SetPos(x, y)
+
+	p.current = -1
+	p.moveSelection(1)
+	showOverlay(p)
+	GrabEvents(p)
+}
+
+// close hides the panel and returns event processing to normal
+func (p *menuPanel) close() {
+	hideOverlay(p)
+	ReleaseEvents()
+	if p.onClose != nil {
+		p.onClose()
+	}
+}
+
+// fire closes the panel and runs the action of the selected item
+func (p *menuPanel) fire() {
+	if !p.selectable(p.current) {
+		return
+	}
+
+	action := p.items[p.current].action
+	p.close()
+	if action != nil {
+		go action()
+	}
+}
+
+// Draw repaints the panel on the overlay layer
+func (p *menuPanel) Draw() {
+	PushAttributes()
+	defer PopAttributes()
+
+	fg, bg := RealColor(p.fg, ColorMenuText), RealColor(p.bg, ColorMenuBack)
+	fgActive, bgActive := RealColor(p.fgActive, ColorMenuActiveText), RealColor(p.bgActive, ColorMenuActiveBack)
+	fgDisabled := RealColor(p.fg, ColorMenuDisabledText)
+
+	SetTextColor(fg)
+	SetBackColor(bg)
+	FillRect(p.x, p.y, p.width, p.height, ' ')
+	DrawFrame(p.x, p.y, p.width, p.height, BorderThin)
+
+	line := []rune(SysObject(ObjSingleBorder))[0]
+	inner := p.width - 2
+	for idx, item := range p.items {
+		y := p.y + idx + 1
+		if item.separator {
+			SetTextColor(fg)
+			SetBackColor(bg)
+			DrawHorizontalLine(p.x+1, y, inner, line)
+			continue
+		}
+
+		switch {
+		case item.disabled:
+			SetTextColor(fgDisabled)
+			SetBackColor(bg)
+		case idx == p.current:
+			SetTextColor(fgActive)
+			SetBackColor(bgActive)
+		default:
+			SetTextColor(fg)
+			SetBackColor(bg)
+		}
+
+		gap := inner - 2 - xs.Len(item.label) - xs.Len(item.shortcut)
+		if gap < 0 {
+			gap = 0
+		}
+		DrawRawText(p.x+1, y, " "+item.label+strings.Repeat(" ", gap)+item.shortcut+" ")
+	}
+}
+
+// ProcessEvent processes keyboard and mouse events while the panel is open
+func (p *menuPanel) ProcessEvent(event Event) bool {
+	if p.onEvent != nil && p.onEvent(event) {
+		return true
+	}
+
+	switch event.Type {
+	case EventKey:
+		switch event.Key {
+		case term.KeyArrowUp:
+			p.moveSelection(-1)
+		case term.KeyArrowDown:
+			p.moveSelection(1)
+		case term.KeyHome:
+			p.current = -1
+			p.moveSelection(1)
+		case term.KeyEnd:
+			p.current = 0
+			p.moveSelection(-1)
+		case term.KeyCtrlM:
+			p.fire()
+		case term.KeyEsc:
+			p.close()
+		}
+		return true
+	case EventMouse:
+		idx := event.Y - p.y - 1
+		inside := event.X > p.x && event.X < p.x+p.width-1 && idx >= 0 && idx < len(p.items)
+		switch event.Key {
+		case term.MouseLeft:
+			if inside {
+				if p.selectable(idx) {
+					p.current = idx
+				}
+			} else if event.Mod != term.ModMotion {
+				p.close()
+			}
+		case term.MouseRelease:
+			if inside && idx == p.current {
+				p.fire()
+			}
+		}
+		return true
+	}
+
+	return false
+}
+
+/*
+MenuBar is a one row strip with menu titles. Clicking a title or
+pressing Alt and the first letter of a title opens the menu dropdown
+that is displayed above all other controls.
+
+Inside an open menu Arrow Up and Arrow Down select an item, Enter runs
+the item action, Escape closes the menu. Arrow Left and Arrow Right
+switch to the neighbour menu. Items can display a shortcut text(e.g,
+"Ctrl+S") at the right side - it is only a hint, the MenuBar does not
+process shortcuts itself.
+*/
+type MenuBar struct {
+	BaseControl
+	menus   []barMenu
+	current int
+	panel   *menuPanel
+}
+
+/*
+CreateMenuBar creates a new MenuBar.
+parent - is container that keeps the control.
+width - is minimal width of the control.
+scale - the way of scaling the control when the parent is resized. Use DoNotScale constant if the
+control should keep its original size.
+*/
+func CreateMenuBar(parent Control, width int, scale int) *MenuBar {
+	m := new(MenuBar)
+
+	if width == AutoSize {
+		width = 10
+	}
+
+	m.SetSize(width, 1)
+	m.SetConstraints(width, 1)
+	m.parent = parent
+	m.current = -1
+	m.menus = make([]barMenu, 0)
+	m.tabSkip = true
+	m.SetScale(scale)
+
+	if parent != nil {
+		parent.AddChild(m)
+	}
+
+	return m
+}
+
+// titleX returns the position of the menu title relative to the
+// MenuBar left edge
+func (m *MenuBar) titleX(idx int) int {
+	x := 0
+	for i := 0; i < idx; i++ {
+		x += xs.Len(m.menus[i].title) + 2
+	}
+	return x
+}
+
+// menuAt returns the menu which title is at a screen column x or -1
+func (m *MenuBar) menuAt(x int) int {
+	dx := x - m.x
+	for idx := range m.menus {
+		tx := m.titleX(idx)
+		if dx >= tx && dx < tx+xs.Len(m.menus[idx].title)+2 {
+			return idx
+		}
+	}
+	return -1
+}
+
+// Draw repaints the control on its View surface
+func (m *MenuBar) Draw() {
+	PushAttributes()
+	defer PopAttributes()
+
+	fg, bg := RealColor(m.fg, ColorMenuText), RealColor(m.bg, ColorMenuBack)
+	fgActive, bgActive := RealColor(m.fgActive, ColorMenuActiveText), RealColor(m.bgActive, ColorMenuActiveBack)
+	if !m.Enabled() {
+		fg = RealColor(m.fg, ColorMenuDisabledText)
+	}
+
+	SetTextColor(fg)
+	SetBackColor(bg)
+	FillRect(m.x, m.y, m.width, 1, ' ')
+
+	for idx, menu := range m.menus {
+		x := m.titleX(idx)
+		if x >= m.width {
+			break
+		}
+
+		if idx == m.current {
+			SetTextColor(fgActive)
+			SetBackColor(bgActive)
+		} else {
+			SetTextColor(fg)
+			SetBackColor(bg)
+		}
+		DrawRawText(m.x+x, m.y, CutText(" "+menu.title+" ", m.width-x))
+	}
+}
+
+// openMenu closes the open dropdown and opens the menu dropdown
+func (m *MenuBar) openMenu(idx int) {
+	if m.panel != nil {
+		m.panel.close()
+	}
+
+	p := newMenuPanel(m.menus[idx].items)
+	p.onEvent = m.panelEvent
+	p.onClose = func() {
+		if m.panel == p {
+			m.panel = nil
+			m.current = -1
+		}
+	}
+
+	m.current = idx
+	m.panel = p
+	p.open(m.x+m.titleX(idx), m.y+1)
+}
+
+// closeMenu closes the open dropdown
+func (m *MenuBar) closeMenu() {
+	if m.panel != nil {
+		m.panel.close()
+	}
+}
+
+// panelEvent processes events of the open dropdown that belong
+// to the MenuBar: switching menus with arrows, hotkeys, and clicks
+// on menu titles
+func (m *MenuBar) panelEvent(event Event) bool {
+	switch event.Type {
+	case EventKey:
+		cnt := len(m.menus)
+		switch {
+		case event.Key == term.KeyArrowLeft:
+			m.openMenu((m.current - 1 + cnt) % cnt)
+			return true
+		case event.Key == term.KeyArrowRight:
+			m.openMenu((m.current + 1) % cnt)
+			return true
+		case event.Mod == term.ModAlt && event.Ch != 0:
+			return m.processHotKey(event)
+		}
+	case EventMouse:
+		if event.Key != term.MouseLeft || event.Mod == term.ModMotion || event.Y != m.y {
+			return false
+		}
+		if idx := m.menuAt(event.X); idx != -1 {
+			if idx == m.current {
+				m.closeMenu()
+			} else {
+				m.openMenu(idx)
+			}
+			return true
+		}
+	}
+
+	return false
+}
+
+// processHotKey opens the menu which title starts with the pressed
+// character. Window calls the method for all Alt+character keys.
+// Returns false if no menu has such title
+func (m *MenuBar) processHotKey(event Event) bool {
+	if !m.Enabled() || event.Mod != term.ModAlt || event.Ch == 0 {
+		return false
+	}
+
+	ch := unicode.ToLower(event.Ch)
+	for idx, menu := range m.menus {
+		title := []rune(menu.title)
+		if len(title) > 0 && unicode.ToLower(title[0]) == ch {
+			m.openMenu(idx)
+			return true
+		}
+	}
+	return false
+}
+
+/*
+ProcessEvent processes all events come from the control parent. If a control
+processes an event it should return true. If the method returns false it means
+that the control do not want or cannot process the event and the caller sends
+the event to the control parent
+*/
+func (m *MenuBar) ProcessEvent(event Event) bool {
+	if !m.Enabled() || event.Type != EventMouse || event.Key != term.MouseLeft {
+		return false
+	}
+
+	if idx := m.menuAt(event.X); idx != -1 {
+		m.openMenu(idx)
+	}
+	return true
+}
+
+// own methods
+
+// AddMenu appends a new menu. Returns the reference to the menu
+func (m *MenuBar) AddMenu(title string) MenuRef {
+	m.menus = append(m.menus, barMenu{title: title, items: make([]menuItem, 0)})
+	return MenuRef(len(m.menus) - 1)
+}
+
+// AddMenuItem appends a new item to the menu. shortcut is a text
+// displayed at the right side of the item and it can be empty.
+// Returns the reference to the item or -1 if the menu does not exist
+func (m *MenuBar) AddMenuItem(menu MenuRef, label string, shortcut string, action func()) ItemRef {
+	if menu < 0 || int(menu) >= len(m.menus) {
+		return -1
+	}
+
+	items := append(m.menus[menu].items, menuItem{label: label, shortcut: shortcut, action: action})
+	m.menus[menu].items = items
+	return ItemRef(len(items) - 1)
+}
+
+// AddMenuSeparator appends a horizontal line to the menu to divide
+// groups of items
+func (m *MenuBar) AddMenuSeparator(menu MenuRef) {
+	if menu < 0 || int(menu) >= len(m.menus) {
+		return
+	}
+
+	m.menus[menu].items = append(m.menus[menu].items, menuItem{separator: true})
+}
+
+// SetItemEnabled enables or disables the menu item. Disabled items
+// are grayed out and cannot be selected
+func (m *MenuBar) SetItemEnabled(menu MenuRef, item ItemRef, enabled bool) {
+	if menu < 0 || int(menu) >= len(m.menus) {
+		return
+	}
+	items := m.menus[menu].items
+	if item < 0 || int(item) >= len(items) || items[item].separator {
+		return
+	}
+
+	items[item].disabled = !enabled
+}
+
+// ItemEnabled returns true if the menu item is enabled
+func (m *MenuBar) ItemEnabled(menu MenuRef, item ItemRef) bool {
+	if menu < 0 || int(menu) >= len(m.menus) {
+		return false
+	}
+	items := m.menus[menu].items
+	if item < 0 || int(item) >= len(items) || items[item].separator {
+		return false
+	}
+
+	return !items[item].disabled
+}
+
+// IsOpen returns true if a menu dropdown is displayed
+func (m *MenuBar) IsOpen() bool {
+	return m.panel != nil
+}
+
+/*
+ContextMenu is a popup menu that is not a part of the control tree. It is
+displayed on demand with Show or automatically: attach it to a control
+with SetContextMenu, and the Window opens the menu when a user clicks the
+control with the right mouse button or presses Alt+F10 while the control
+is active(terminals do not report Shift+F10, so Alt is used instead).
+
+While the menu is visible it receives all keyboard and mouse events.
+Escape or a click outside the menu closes it.
+*/
+type ContextMenu struct {
+	items []menuItem
+	panel *menuPanel
+}
+
+var contextMenus = make(map[Control]*ContextMenu)
+
+// CreateContextMenu creates a new empty ContextMenu
+func CreateContextMenu() *ContextMenu {
+	c := new(ContextMenu)
+	c.items = make([]menuItem, 0)
+	return c
+}
+
+// SetContextMenu attaches the menu to the control. If the control does
+// not have a menu, the menu of the closest parent is used. Pass nil to
+// detach the menu
+func SetContextMenu(ctrl Control, menu *ContextMenu) {
+	if menu == nil {
+		delete(contextMenus, ctrl)
+		return
+	}
+	contextMenus[ctrl] = menu
+}
+
+// contextMenuFor returns the menu attached to the control or to its
+// closest parent. Returns nil if there is no menu
+func contextMenuFor(ctrl Control) *ContextMenu {
+	for ctrl != nil {
+		if menu, ok := contextMenus[ctrl]; ok {
+			return menu
+		}
+		ctrl = ctrl.Parent()
+	}
+	return nil
+}
+
+// AddItem appends a new item to the menu. shortcut is a text displayed
+// at the right side of the item and it can be empty. Returns the
+// reference to the item
+func (c *ContextMenu) AddItem(label string, shortcut string, action func()) ItemRef {
+	c.items = append(c.items, menuItem{label: label, shortcut: shortcut, action: action})
+	return ItemRef(len(c.items) - 1)
+}
+
+// AddSeparator appends a horizontal line to divide groups of items
+func (c *ContextMenu) AddSeparator() {
+	c.items = append(c.items, menuItem{separator: true})
+}
+
+// SetItemEnabled enables or disables the item. Disabled items
+// are grayed out and cannot be selected
+func (c *ContextMenu) SetItemEnabled(item ItemRef, enabled bool) {
+	if item < 0 || int(item) >= len(c.items) || c.items[item].separator {
+		return
+	}
+	c.items[item].disabled = !enabled
+}
+
+// ItemEnabled returns true if the item is enabled
+func (c *ContextMenu) ItemEnabled(item ItemRef) bool {
+	if item < 0 || int(item) >= len(c.items) || c.items[item].separator {
+		return false
+	}
+	return !c.items[item].disabled
+}
+
+// Show displays the menu with its top left corner at screen position
+// x, y. If the menu does not fit the screen, it is flipped to the left
+// or upwards of the position
+func (c *ContextMenu) Show(x, y int) {
+	if len(c.items) == 0 {
+		return
+	}
+	c.Hide()
+
+	p := newMenuPanel(c.items)
+	p.onClose = func() {
+		if c.panel == p {
+			c.panel = nil
+		}
+	}
+
+	sw, sh := ScreenSize()
+	if x+p.width > sw && x-p.width+1 >= 0 {
+		x = x - p.width + 1
+	}
+	if y+p.height > sh && y-p.height+1 >= 0 {
+		y = y - p.height + 1
+	}
+
+	c.panel = p
+	p.open(x, y)
+}
+
+// Hide closes the menu if it is visible
+func (c *ContextMenu) Hide() {
+	if c.panel != nil {
+		c.panel.close()
+	}
+}
+
+// IsVisible returns true if the menu is displayed
+func (c *ContextMenu) IsVisible() bool {
+	return c.panel != nil
+}
@@ -0,0 +1,96 @@
+package clui
+
+import (
+	term "github.com/nsf/termbox-go"
+	"testing"
+)
+
+func TestMenuBar(t *testing.T) {
+	initComposer()
+	saved := canvas
+	canvas = newMemoryCanvas(80, 25)
+	defer func() { canvas = saved }()
+
+	fired := make(chan string, 1)
+	mb := CreateMenuBar(nil, 30, Fixed)
+	file := mb.AddMenu("File")
+	mb.AddMenuItem(file, "Open", "", func() { fired <- "open" })
+	mb.AddMenuSeparator(file)
+	save := mb.AddMenuItem(file, "Save", "Ctrl+S", func() { fired <- "save" })
+	mb.AddMenuItem(file, "Quit", "", func() { fired <- "quit" })
+	edit := mb.AddMenu("Edit")
+	mb.AddMenuItem(edit, "Copy", "", nil)
+
+	if save != 2 || mb.AddMenuItem(5, "Bad", "", nil) != -1 {
+		t.Errorf("Invalid item references: %v", save)
+	}
+	if out := renderToString(mb); out != " File  Edit                   " {
+		t.Errorf("Invalid output %q", out)
+	}
+
+	if !mb.processHotKey(Event{Type: EventKey, Mod: term.ModAlt, Ch: 'f'}) || !mb.IsOpen() {
+		t.Fatal("Alt+F must open File menu")
+	}
+	if comp.consumer != mb.panel || len(comp.overlays) != 1 {
+		t.Error("The open menu must grab events and be on the overlay layer")
+	}
+	if out := renderToString(mb.panel); out != "┌──────────────┐\n│ Open         │\n│──────────────│\n│ Save  Ctrl+S │\n│ Quit         │\n└──────────────┘" {
+		t.Errorf("Invalid dropdown output %q", out)
+	}
+
+	mb.SetItemEnabled(file, save, false)
+	mb.panel.ProcessEvent(Event{Type: EventKey, Key: term.KeyArrowDown})
+	if mb.panel.current != 3 {
+		t.Errorf("Separators and disabled items must be skipped: %v", mb.panel.current)
+	}
+
+	mb.panel.ProcessEvent(Event{Type: EventKey, Key: term.KeyArrowRight})
+	if mb.current != int(edit) || len(comp.overlays) != 1 {
+		t.Errorf("Arrow Right must switch to Edit menu: %v", mb.current)
+	}
+	mb.panel.ProcessEvent(Event{Type: EventKey, Key: term.KeyArrowLeft})
+	mb.panel.ProcessEvent(Event{Type: EventKey, Key: term.KeyArrowUp})
+	mb.panel.ProcessEvent(Event{Type: EventKey, Key: term.KeyCtrlM})
+	if res := <-fired; res != "quit" || mb.IsOpen() {
+		t.Errorf("Enter must close the menu and run the item action: %v", res)
+	}
+
+	mb.processHotKey(Event{Type: EventKey, Mod: term.ModAlt, Ch: 'E'})
+	mb.panel.ProcessEvent(Event{Type: EventKey, Key: term.KeyEsc})
+	if mb.IsOpen() || comp.consumer != nil || len(comp.overlays) != 0 {
+		t.Error("Escape must close the menu")
+	}
+}
+
+func TestContextMenu(t *testing.T) {
+	initComposer()
+	saved := canvas
+	canvas = newMemoryCanvas(40, 10)
+	defer func() { canvas = saved }()
+
+	cm := CreateContextMenu()
+	cm.AddItem("Cut", "Ctrl+X", nil)
+	cm.AddSeparator()
+	cm.AddItem("Paste", "Ctrl+V", nil)
+
+	frame := CreateFrame(nil, 10, 5, BorderNone, Fixed)
+	btn := CreateButton(frame, 8, 2, "Ok", Fixed)
+	SetContextMenu(frame, cm)
+	defer SetContextMenu(frame, nil)
+	if contextMenuFor(btn) != cm {
+		t.Error("The menu of the parent must be used")
+	}
+
+	cm.Show(35, 8)
+	if !cm.IsVisible() || comp.consumer != cm.panel {
+		t.Fatal("The menu must be visible and grab events")
+	}
+	if x, y := cm.panel.Pos(); x != 19 || y != 4 {
+		t.Errorf("The menu must flip to stay on the screen: %v:%v", x, y)
+	}
+
+	cm.panel.ProcessEvent(Event{Type: EventMouse, Key: term.MouseLeft, X: 0, Y: 0})
+	if cm.IsVisible() || len(comp.overlays) != 0 {
+		t.Error("Click outside must close the menu")
+	}
+}
@@ -0,0 +1,157 @@
+package clui
+
+import (
+	term "github.com/nsf/termbox-go"
+)
+
+// TestReporter is the part of testing.TB that MockCanvas uses to
+// report failed assertions. *testing.T implements it
+type TestReporter interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// DrawCall is a call of a drawing function recorded by MockCanvas
+type DrawCall struct {
+	// Func is the name of the function: FillRect, DrawText,
+	// DrawRawText, or PutChar
+	Func string
+	X, Y int
+	// W and H are the size of the rectangle filled by FillRect
+	W, H int
+	// Text is the drawn text or character
+	Text string
+}
+
+// MockCanvas replaces the terminal with a memory screen, so widgets can
+// be created and drawn in unit tests without termbox. It also replaces
+// the current theme with the built-in default one and starts a new
+// composer, so windows added with AddWindow or CreateMockView do not
+// need a terminal either. All drawing function calls are recorded to
+// Calls, including the calls made by other drawing functions.
+// Call Close to restore the previous state
+type MockCanvas struct {
+	// Calls is the log of drawing function calls
+	Calls []DrawCall
+
+	screen      *Canvas
+	savedCanvas *Canvas
+	savedComp   *Composer
+	savedTheme  *ThemeManager
+}
+
+// CreateMockCanvas creates a memory screen of the given size and makes
+// it the current one
+func CreateMockCanvas(width, height int) *MockCanvas {
+	m := new(MockCanvas)
+	m.savedCanvas, m.savedComp, m.savedTheme = canvas, comp, themeManager
+
+	m.screen = newMemoryCanvas(width, height)
+	m.screen.mock = m
+	canvas = m.screen
+	initComposer()
+	initThemeManager()
+
+	return m
+}
+
+// Close restores the screen, theme, and composer that were used
+// before the MockCanvas was created
+func (m *MockCanvas) Close() {
+	canvas, comp, themeManager = m.savedCanvas, m.savedComp, m.savedTheme
+}
+
+// CreateMockView adds a Window that covers the whole memory screen of the
+// current MockCanvas
+func CreateMockView(title string) *Window {
+	w, h := ScreenSize()
+	return AddWindow(0, 0, w, h, title)
+}
+
+// Snapshot draws the Window with all its controls to memory and returns
+// the result as newline separated rows in the same format as
+// MockCanvas.String. Colors are ignored. It is useful for golden-file
+// tests. The method must not be called at the same time when the screen
+// is being redrawn
+func (c *Window) Snapshot() string {
+	if themeManager == nil {
+		initThemeManager()
+	}
+
+	x, y := c.Pos()
+	w, h := c.Size()
+	if w <= 0 || h <= 0 {
+		return ""
+	}
+
+	// children positions are screen coordinates, so unlike renderToString
+	// the Window is not moved and the canvas is shifted instead
+	saved := canvas
+	canvas = newMemoryCanvas(w, h)
+	canvas.originX, canvas.originY = -x, -y
+	canvas.clipX, canvas.clipY = x, y
+	defer func() { canvas = saved }()
+
+	c.Draw()
+	return cellsToString(canvas)
+}
+
+// recordCall adds the call to the log of the current MockCanvas
+func recordCall(call DrawCall) {
+	if canvas != nil && canvas.mock != nil {
+		canvas.mock.Calls = append(canvas.mock.Calls, call)
+	}
+}
+
+// ClearCalls empties the log of drawing function calls
+func (m *MockCanvas) ClearCalls() {
+	m.Calls = nil
+}
+
+// Size returns the size of the memory screen
+func (m *MockCanvas) Size() (int, int) {
+	return m.screen.width, m.screen.height
+}
+
+// Cell returns the screen cell. Cells outside the screen are empty
+func (m *MockCanvas) Cell(x, y int) term.Cell {
+	if x < 0 || y < 0 || x >= m.screen.width || y >= m.screen.height {
+		return term.Cell{}
+	}
+	return m.screen.cells[y*m.screen.width+x]
+}
+
+// Text returns length characters of the screen row y starting from
+// the column x
+func (m *MockCanvas) Text(x, y, length int) string {
+	s := make([]rune, 0, length)
+	for xx := x; xx < x+length && xx < m.screen.width; xx++ {
+		s = append(s, m.Cell(xx, y).Ch)
+	}
+	return string(s)
+}
+
+// String returns the screen characters as newline separated rows.
+// Colors are ignored
+func (m *MockCanvas) String() string {
+	return cellsToString(m.screen)
+}
+
+// AssertCellAt reports an error if the screen cell differs from the
+// expected one: both character and colors are compared
+func (m *MockCanvas) AssertCellAt(t TestReporter, x, y int, expected term.Cell) {
+	t.Helper()
+	if c := m.Cell(x, y); c != expected {
+		t.Errorf("Cell %v:%v is %q(%v on %v), expected %q(%v on %v)",
+			x, y, c.Ch, c.Fg, c.Bg, expected.Ch, expected.Fg, expected.Bg)
+	}
+}
+
+// AssertTextAt reports an error if the screen row y starting from the
+// column x does not display the expected text. Colors are ignored
+func (m *MockCanvas) AssertTextAt(t TestReporter, x, y int, expected string) {
+	t.Helper()
+	if s := m.Text(x, y, len([]rune(expected))); s != expected {
+		t.Errorf("Text at %v:%v is %q, expected %q", x, y, s, expected)
+	}
+}
@@ -0,0 +1,46 @@
+package clui
+
+import (
+	term "github.com/nsf/termbox-go"
+	"testing"
+)
+
+func TestMockCanvas(t *testing.T) {
+	m := CreateMockCanvas(30, 8)
+	defer m.Close()
+
+	view := CreateMockView("Mock")
+	lbl := CreateLabel(view, 10, 1, "Hello", Fixed)
+	lbl.SetTextColor(ColorYellow)
+	view.ResizeChildren()
+	view.PlaceChildren()
+
+	m.ClearCalls()
+	RefreshScreen()
+	x, y := lbl.Pos()
+	m.AssertTextAt(t, x, y, "Hello")
+	m.AssertCellAt(t, x, y, term.Cell{Ch: 'H', Fg: ColorYellow, Bg: RealColor(ColorDefault, ColorViewBack)})
+
+	found := false
+	for _, c := range m.Calls {
+		if c.Func == "FillRect" && c.X == 0 && c.Y == 0 && c.W == 30 && c.H == 8 {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Window background fill must be recorded: %v", m.Calls)
+	}
+}
+
+func TestWindowSnapshot(t *testing.T) {
+	initThemeManager()
+	wnd := CreateWindow(5, 3, 9, 3, "")
+	CreateLabel(wnd, 5, 1, "Hi", Fixed)
+	wnd.ResizeChildren()
+	wnd.PlaceChildren()
+
+	expected := "╔═══[↓^○]\n║Hi     ║\n╚═══════╝"
+	if s := wnd.Snapshot(); s != expected {
+		t.Errorf("Invalid snapshot:\n%v", s)
+	}
+}
@@ -0,0 +1,240 @@
+package clui
+
+import (
+	xs "github.com/huandu/xstrings"
+	term "github.com/nsf/termbox-go"
+)
+
+// Dialog is a control that can be displayed by a Window as a modal
+// dialog. Any control can be a dialog, the dialog gets EventDialogClose
+// event when the Window closes it
+type Dialog interface {
+	Control
+}
+
+// ShowModal displays the dialog in the center of the Window above
+// its content. The Window content is covered with a backdrop, and all
+// keyboard and mouse events go only to the dialog until CloseModal is
+// called. Tab moves the focus only between the dialog controls
+func (c *Window) ShowModal(dlg Dialog) {
+	if c.dialog != nil {
+		c.CloseModal()
+	}
+
+	c.prevFocus = ActiveControl(c)
+	DeactivateControls(c)
+
+	c.dialog = dlg
+	dlg.SetParent(c)
+	c.placeModal()
+
+	first := FindFirstControl(dlg, func(ctrl Control) bool {
+		return ctrl.TabStop() && ctrl.Enabled()
+	})
+	if first != nil {
+		ActivateControl(dlg, first)
+	} else {
+		dlg.SetActive(true)
+	}
+}
+
+// CloseModal hides the modal dialog and restores the focus to the
+// control that was active before the dialog was shown
+func (c *Window) CloseModal() {
+	dlg := c.dialog
+	if dlg == nil {
+		return
+	}
+
+	c.dialog = nil
+	DeactivateControls(dlg)
+	dlg.SetActive(false)
+	if c.prevFocus != nil {
+		ActivateControl(c, c.prevFocus)
+		c.prevFocus = nil
+	}
+
+	dlg.ProcessEvent(Event{Type: EventDialogClose})
+}
+
+// ModalDialog returns the displayed modal dialog or nil
+func (c *Window) ModalDialog() Dialog {
+	return c.dialog
+}
+
+// placeModal moves the dialog to the center of the Window
+func (c *Window) placeModal() {
+	w, h := c.dialog.Size()
+	x, y := c.x+(c.width-w)/2, c.y+(c.height-h)/2
+	if x < c.x {
+		x = c.x
+	}
+	if y < c.y {
+		y = c.y
+	}
+
+	c.dialog.SetPos(x, y)
+	c.dialog.ResizeChildren()
+	c.dialog.PlaceChildren()
+}
+
+// processModalEvent sends keyboard and mouse events to the dialog.
+// Mouse events outside the dialog are skipped
+func (c *Window) processModalEvent(ev Event) bool {
+	dlg := c.dialog
+	if ev.Type == EventKey {
+		if ev.Key == term.KeyTab {
+			tabFocus(dlg)
+			return true
+		}
+	} else if ChildAt(dlg, ev.X, ev.Y) == nil {
+		return true
+	}
+
+	if SendEventToChild(dlg, ev) {
+		return true
+	}
+	dlg.ProcessEvent(ev)
+	return true
+}
+
+// drawModal covers the Window content with the backdrop and draws
+// the dialog above it
+func (wnd *Window) drawModal() {
+	PushAttributes()
+	defer PopAttributes()
+
+	SetBackColor(RealColor(ColorDefault, ColorModalBackdrop))
+	FillRect(wnd.x+1, wnd.y+1, wnd.width-2, wnd.height-2, ' ')
+
+	wnd.dialog.Draw()
+}
+
+/*
+ModalFrame is a container for modal dialogs: it draws a border with
+the title and a shadow. Put the dialog controls into the ModalFrame
+and display it with Window.ShowModal. Escape closes the dialog.
+A ModalFrame can be a part of the Window content as well: make it the
+root of the Window focus trap with PushFocusTrap, and Escape pops the
+trap.
+
+Events:
+
+	OnKeyDown - called when no dialog control processes a key. If the
+	    callback returns true, Escape is not processed by the frame
+	OnClose - called after the Window closes the dialog or pops the
+	    focus trap
+*/
+type ModalFrame struct {
+	BaseControl
+	onKeyDown func(Event) bool
+	onClose   func()
+}
+
+/*
+CreateModalFrame creates a new ModalFrame. The frame does not have a
+parent until it is displayed with Window.ShowModal.
+width and heigth - are minimal size of the control.
+title - text displayed in the top border.
+*/
+func CreateModalFrame(width, height int, title string) *ModalFrame {
+	f := new(ModalFrame)
+
+	if width == AutoSize {
+		width = 20
+	}
+	if height == AutoSize {
+		height = 5
+	}
+
+	f.SetSize(width, height)
+	f.SetConstraints(width, height)
+	f.SetTitle(title)
+	f.SetPaddings(1, 1)
+	f.SetPack(Vertical)
+	f.tabSkip = true
+	f.SetScale(Fixed)
+
+	return f
+}
+
+// Draw repaints the frame, its shadow, and all its children
+func (f *ModalFrame) Draw() {
+	PushAttributes()
+	defer PopAttributes()
+
+	x, y := f.Pos()
+	w, h := f.Size()
+
+	SetBackColor(RealColor(ColorDefault, ColorControlShadow))
+	FillRect(x+1, y+h, w, 1, ' ')
+	FillRect(x+w, y+1, 1, h, ' ')
+
+	SetTextColor(RealColor(f.fg, ColorViewText))
+	SetBackColor(RealColor(f.bg, ColorViewBack))
+	FillRect(x, y, w, h, ' ')
+	DrawFrame(x, y, w, h, BorderThick)
+
+	if f.title != "" && w > 4 {
+		str := " " + f.title + " "
+		if xs.Len(UnColorizeText(str)) > w-2 {
+			str = SliceColorized(str, 0, w-2-3) + "..."
+		}
+		DrawTextAligned(x+1, y, w-2, str, AlignCenter)
+	}
+
+	f.DrawChildren()
+}
+
+// close asks the Window that displays the frame to close it. If the
+// frame is the root of the Window focus trap, the trap is popped
+func (f *ModalFrame) close() {
+	for p := f.Parent(); p != nil; p = p.Parent() {
+		if wnd, ok := p.(*Window); ok {
+			if wnd.dialog == nil && wnd.focusRoot() == f {
+				wnd.PopFocusTrap()
+				f.ProcessEvent(Event{Type: EventDialogClose})
+				return
+			}
+			wnd.CloseModal()
+			return
+		}
+	}
+}
+
+/*
+ProcessEvent processes all events come from the control parent. If a control
+processes an event it should return true. If the method returns false it means
+that the control do not want or cannot process the event and the caller sends
+the event to the control parent
+*/
+func (f *ModalFrame) ProcessEvent(event Event) bool {
+	switch event.Type {
+	case EventKey:
+		if f.onKeyDown != nil && f.onKeyDown(event) {
+			return true
+		}
+		if event.Key == term.KeyEsc {
+			f.close()
+			return true
+		}
+	case EventDialogClose:
+		if f.onClose != nil {
+			go f.onClose()
+		}
+		return true
+	}
+
+	return false
+}
+
+// OnKeyDown sets the callback that is called when no dialog control
+// processes a key. The callback returns true if it processes the key
+func (f *ModalFrame) OnKeyDown(fn func(Event) bool) {
+	f.onKeyDown = fn
+}
+
+// OnClose sets the callback that is called after the dialog is closed
+func (f *ModalFrame) OnClose(fn func()) {
+	f.onClose = fn
+}
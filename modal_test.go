@@ -0,0 +1,48 @@
+package clui
+
+import (
+	term "github.com/nsf/termbox-go"
+	"testing"
+)
+
+func TestModalFrame(t *testing.T) {
+	wnd := CreateWindow(0, 0, 40, 12, "Test")
+	btn := CreateButton(wnd, 8, 2, "Main", Fixed)
+	wnd.ResizeChildren()
+	wnd.PlaceChildren()
+	ActivateControl(wnd, btn)
+
+	closed := make(chan bool, 1)
+	dlg := CreateModalFrame(20, 6, "Confirm")
+	dlg.SetPack(Horizontal)
+	ok := CreateButton(dlg, 6, 2, "Ok", Fixed)
+	cancel := CreateButton(dlg, 6, 2, "No", Fixed)
+	dlg.OnClose(func() { closed <- true })
+
+	wnd.ShowModal(dlg)
+	if x, y := dlg.Pos(); x != 10 || y != 3 {
+		t.Errorf("The dialog must be centered: %v:%v", x, y)
+	}
+	if btn.Active() || !ok.Active() {
+		t.Error("The first dialog control must get the focus")
+	}
+
+	wnd.ProcessEvent(Event{Type: EventKey, Key: term.KeyTab})
+	wnd.ProcessEvent(Event{Type: EventKey, Key: term.KeyTab})
+	if !ok.Active() || cancel.Active() || btn.Active() {
+		t.Error("Tab must move the focus only inside the dialog")
+	}
+
+	wnd.ProcessEvent(Event{Type: EventMouse, Key: term.MouseLeft, X: 2, Y: 2})
+	if btn.Active() || wnd.ModalDialog() == nil {
+		t.Error("Clicks outside the dialog must be skipped")
+	}
+
+	wnd.ProcessEvent(Event{Type: EventKey, Key: term.KeyEsc})
+	if wnd.ModalDialog() != nil || !btn.Active() || ok.Active() {
+		t.Error("Escape must close the dialog and restore the focus")
+	}
+	if !<-closed {
+		t.Error("OnClose must be called")
+	}
+}
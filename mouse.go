@@ -0,0 +1,229 @@
+package clui
+
+import (
+	term "github.com/nsf/termbox-go"
+)
+
+// hoverHandler is a control that tracks whether the mouse cursor is
+// over it. BaseControl implements it, so all controls do
+type hoverHandler interface {
+	mouseEnter()
+	mouseLeave()
+}
+
+// OnMouseEnter sets the callback that is called when the mouse cursor
+// moves over the control
+func (c *BaseControl) OnMouseEnter(fn func()) {
+	c.onMouseEnter = fn
+}
+
+// OnMouseLeave sets the callback that is called when the mouse cursor
+// leaves the control
+func (c *BaseControl) OnMouseLeave(fn func()) {
+	c.onMouseLeave = fn
+}
+
+// IsHovered returns true if the mouse cursor is over the control and
+// not over any of its children. Controls may use it in Draw to
+// highlight themselves
+func (c *BaseControl) IsHovered() bool {
+	return c.hovered
+}
+
+func (c *BaseControl) mouseEnter() {
+	c.hovered = true
+	if c.onMouseEnter != nil {
+		c.onMouseEnter()
+	}
+}
+
+func (c *BaseControl) mouseLeave() {
+	c.hovered = false
+	if c.onMouseLeave != nil {
+		c.onMouseLeave()
+	}
+}
+
+// updateMouseOver finds the innermost control under the mouse cursor
+// and sends leave and enter notifications if the control changes
+func (c *Composer) updateMouseOver(x, y int) {
+	var ctrl Control
+	if wnd, _ := c.checkWindowUnderMouse(x, y); wnd != nil {
+		ctrl = ChildAt(wnd, x, y)
+	}
+	if ctrl == c.mouseOver {
+		return
+	}
+
+	if h, ok := c.mouseOver.(hoverHandler); ok {
+		h.mouseLeave()
+	}
+	c.mouseOver = ctrl
+	if h, ok := ctrl.(hoverHandler); ok {
+		h.mouseEnter()
+	}
+}
+
+// dragHandler is a control that can be dragged with mouse. BaseControl
+// implements it, so all controls do
+type dragHandler interface {
+	draggable() bool
+	dragStart(x, y int)
+	dragMove(dx, dy int)
+	dragEnd(x, y int)
+}
+
+// OnDragStart sets the callback that is called when a user presses the
+// left mouse button over the control and starts moving the mouse. The
+// callback gets the screen coordinates where the button was pressed
+func (c *BaseControl) OnDragStart(fn func(x, y int)) {
+	c.onDragStart = fn
+}
+
+// OnDragMove sets the callback that is called every time the mouse
+// moves while the control is dragged. The callback gets the distance
+// the cursor has moved since the previous call
+func (c *BaseControl) OnDragMove(fn func(dx, dy int)) {
+	c.onDragMove = fn
+}
+
+// OnDragEnd sets the callback that is called when a user releases the
+// mouse button after dragging the control. The callback gets the screen
+// coordinates where the button was released
+func (c *BaseControl) OnDragEnd(fn func(x, y int)) {
+	c.onDragEnd = fn
+}
+
+func (c *BaseControl) draggable() bool {
+	return c.onDragStart != nil || c.onDragMove != nil || c.onDragEnd != nil
+}
+
+func (c *BaseControl) dragStart(x, y int) {
+	if c.onDragStart != nil {
+		c.onDragStart(x, y)
+	}
+}
+
+func (c *BaseControl) dragMove(dx, dy int) {
+	if c.onDragMove != nil {
+		c.onDragMove(dx, dy)
+	}
+}
+
+func (c *BaseControl) dragEnd(x, y int) {
+	if c.onDragEnd != nil {
+		c.onDragEnd(x, y)
+	}
+}
+
+// dragOwner returns the control or its closest parent that has drag
+// callbacks. Returns nil if there is no such control
+func dragOwner(ctrl Control) Control {
+	for ctrl != nil {
+		if h, ok := ctrl.(dragHandler); ok && h.draggable() {
+			return ctrl
+		}
+		ctrl = ctrl.Parent()
+	}
+	return nil
+}
+
+// processControlDrag turns mouse events into drag events: pressing the
+// left button selects the control to drag, the first move with the
+// button down starts dragging, and releasing the button ends it.
+// Returns true if the event is consumed by dragging
+func (c *Composer) processControlDrag(ev Event, hit HitResult) bool {
+	switch {
+	case ev.Key == term.MouseLeft && ev.Mod == 0:
+		c.dragCtrl, c.dragStarted = nil, false
+		if hit == HitInside && c.dragType == DragNone {
+			c.dragCtrl = dragOwner(ChildAt(c.topWindow(), ev.X, ev.Y))
+			c.dragLastX, c.dragLastY = ev.X, ev.Y
+		}
+		return false
+	case c.dragCtrl == nil:
+		return false
+	case ev.Key == term.MouseLeft && ev.Mod == term.ModMotion:
+		h := c.dragCtrl.(dragHandler)
+		if !c.dragStarted {
+			c.dragStarted = true
+			h.dragStart(c.dragLastX, c.dragLastY)
+		}
+		dx, dy := ev.X-c.dragLastX, ev.Y-c.dragLastY
+		if dx != 0 || dy != 0 {
+			c.dragLastX, c.dragLastY = ev.X, ev.Y
+			h.dragMove(dx, dy)
+		}
+		return true
+	case ev.Key == term.MouseRelease:
+		h, started := c.dragCtrl.(dragHandler), c.dragStarted
+		c.dragCtrl, c.dragStarted = nil, false
+		if !started {
+			return false
+		}
+		h.dragEnd(ev.X, ev.Y)
+		// the control gets the button release but not a click
+		c.sendEventToActiveWindow(ev)
+		return true
+	}
+
+	return false
+}
+
+// wheelScrollLines is the number of lines standard controls scroll for
+// one mouse wheel tick
+const wheelScrollLines = 3
+
+// scrollHandler is a control that processes mouse wheel. BaseControl
+// implements it, so all controls do
+type scrollHandler interface {
+	scrollable() bool
+	mouseScroll(delta int)
+}
+
+// OnMouseScroll sets the callback that is called when a user rotates
+// the mouse wheel over the control. delta is 1 if the wheel scrolls
+// down and -1 if it scrolls up. If the control does not have the
+// callback, the event goes to the closest parent that has it.
+// ListBox, TableView, DataGrid and ScrollableTextView set the callback
+// that scrolls their content, so setting a new callback replaces the
+// default behavior
+func (c *BaseControl) OnMouseScroll(fn func(delta int)) {
+	c.onMouseScroll = fn
+}
+
+func (c *BaseControl) scrollable() bool {
+	return c.onMouseScroll != nil
+}
+
+func (c *BaseControl) mouseScroll(delta int) {
+	if c.onMouseScroll != nil {
+		c.onMouseScroll(delta)
+	}
+}
+
+// processWheel sends the mouse wheel event to the control under the
+// cursor or to its closest parent that has the callback. Returns true
+// if the event is processed
+func (c *Composer) processWheel(ev Event, hit HitResult) bool {
+	delta := 0
+	switch ev.Key {
+	case term.MouseWheelUp:
+		delta = -1
+	case term.MouseWheelDown:
+		delta = 1
+	default:
+		return false
+	}
+
+	if hit != HitInside {
+		return false
+	}
+	for ctrl := ChildAt(c.topWindow(), ev.X, ev.Y); ctrl != nil; ctrl = ctrl.Parent() {
+		if h, ok := ctrl.(scrollHandler); ok && h.scrollable() {
+			h.mouseScroll(delta)
+			return true
+		}
+	}
+	return false
+}
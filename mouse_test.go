@@ -0,0 +1,121 @@
+package clui
+
+import (
+	"fmt"
+	term "github.com/nsf/termbox-go"
+	"testing"
+)
+
+func TestMouseHover(t *testing.T) {
+	initComposer()
+	saved := canvas
+	canvas = newMemoryCanvas(40, 20)
+	defer func() { canvas = saved }()
+
+	wnd := CreateWindow(0, 0, 30, 10, "Test")
+	comp.windows = append(comp.windows, wnd)
+	btn := CreateButton(wnd, 8, 2, "Ok", Fixed)
+	wnd.PlaceChildren()
+
+	var got []string
+	btn.OnMouseEnter(func() { got = append(got, "enter") })
+	btn.OnMouseLeave(func() { got = append(got, "leave") })
+
+	bx, by := btn.Pos()
+	move := Event{Type: EventMouse, Key: term.MouseRelease, Mod: term.ModMotion, X: bx, Y: by}
+	comp.processMouse(move)
+	move.X++
+	comp.processMouse(move)
+	if len(got) != 1 || !btn.IsHovered() {
+		t.Errorf("Moving over the button must enter it once: %v", got)
+	}
+
+	move.X, move.Y = 35, 15
+	comp.processMouse(move)
+	if len(got) != 2 || got[1] != "leave" || btn.IsHovered() {
+		t.Errorf("Moving outside must leave the button: %v", got)
+	}
+	if comp.mouseOver != nil {
+		t.Error("No control must be hovered outside windows")
+	}
+}
+
+func TestMouseDrag(t *testing.T) {
+	initComposer()
+	saved := canvas
+	canvas = newMemoryCanvas(40, 20)
+	defer func() { canvas = saved }()
+
+	wnd := CreateWindow(0, 0, 30, 10, "Test")
+	comp.windows = append(comp.windows, wnd)
+	frame := CreateFrame(wnd, 10, 4, BorderNone, Fixed)
+	wnd.PlaceChildren()
+
+	var got []string
+	frame.OnDragStart(func(x, y int) { got = append(got, fmt.Sprintf("start %v:%v", x, y)) })
+	frame.OnDragMove(func(dx, dy int) { got = append(got, fmt.Sprintf("move %v:%v", dx, dy)) })
+	frame.OnDragEnd(func(x, y int) { got = append(got, fmt.Sprintf("end %v:%v", x, y)) })
+
+	fx, fy := frame.Pos()
+	comp.processMouse(Event{Type: EventMouse, Key: term.MouseLeft, X: fx, Y: fy})
+	if len(got) != 0 {
+		t.Errorf("Pressing the button must not start dragging: %v", got)
+	}
+	comp.processMouse(Event{Type: EventMouse, Key: term.MouseLeft, Mod: term.ModMotion, X: fx + 2, Y: fy})
+	comp.processMouse(Event{Type: EventMouse, Key: term.MouseLeft, Mod: term.ModMotion, X: fx + 3, Y: fy + 1})
+	// moving outside the window must not stop dragging
+	comp.processMouse(Event{Type: EventMouse, Key: term.MouseLeft, Mod: term.ModMotion, X: 35, Y: fy + 1})
+	comp.processMouse(Event{Type: EventMouse, Key: term.MouseRelease, X: 35, Y: fy + 1})
+
+	want := []string{
+		fmt.Sprintf("start %v:%v", fx, fy), "move 2:0", "move 1:1",
+		fmt.Sprintf("move %v:0", 35-fx-3), fmt.Sprintf("end 35:%v", fy+1),
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("Invalid drag events:\n%v\nwant:\n%v", got, want)
+	}
+	if comp.dragCtrl != nil {
+		t.Error("Releasing the button must end dragging")
+	}
+}
+
+func TestMouseScroll(t *testing.T) {
+	initComposer()
+	saved := canvas
+	canvas = newMemoryCanvas(40, 20)
+	defer func() { canvas = saved }()
+
+	wnd := CreateWindow(0, 0, 30, 12, "Test")
+	comp.windows = append(comp.windows, wnd)
+	frame := CreateFrame(wnd, 20, 8, BorderNone, Fixed)
+	lbox := CreateListBox(frame, 10, 4, Fixed)
+	for i := 0; i < 10; i++ {
+		lbox.AddItem(fmt.Sprintf("item %v", i))
+	}
+	label := CreateLabel(frame, 6, 1, "label", Fixed)
+	wnd.PlaceChildren()
+	lbox.SetSize(10, 4)
+
+	lx, ly := lbox.Pos()
+	wheel := Event{Type: EventMouse, Key: term.MouseWheelDown, X: lx, Y: ly}
+	comp.processMouse(wheel)
+	comp.processMouse(wheel)
+	comp.processMouse(wheel)
+	if lbox.topLine != 6 {
+		t.Errorf("ListBox must scroll by 3 lines up to the last page: %v", lbox.topLine)
+	}
+	wheel.Key = term.MouseWheelUp
+	comp.processMouse(wheel)
+	if lbox.topLine != 3 || lbox.SelectedItem() != -1 {
+		t.Errorf("ListBox must scroll up without changing selection: %v", lbox.topLine)
+	}
+
+	// the label does not process wheel, so its parent gets the event
+	total := 0
+	frame.OnMouseScroll(func(delta int) { total += delta })
+	wheel.X, wheel.Y = label.Pos()
+	comp.processMouse(wheel)
+	if total != -1 {
+		t.Errorf("Unprocessed event must bubble to the parent: %v", total)
+	}
+}
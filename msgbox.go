@@ -0,0 +1,161 @@
+package clui
+
+import (
+	xs "github.com/huandu/xstrings"
+	term "github.com/nsf/termbox-go"
+	"strings"
+)
+
+// MsgBoxButtons is a set of buttons displayed by ShowMessageBox
+type MsgBoxButtons int
+
+// MessageBox buttons. Combine them with bitwise OR
+const (
+	MsgBoxOK MsgBoxButtons = 1 << iota
+	MsgBoxCancel
+	MsgBoxYes
+	MsgBoxNo
+)
+
+// MsgBoxResult is a button a user pressed to close a MessageBox
+type MsgBoxResult int
+
+// MessageBox results
+const (
+	// MsgBoxResultNone - the MessageBox was closed without pressing a button
+	MsgBoxResultNone MsgBoxResult = iota
+	MsgBoxResultOK
+	MsgBoxResultCancel
+	MsgBoxResultYes
+	MsgBoxResultNo
+)
+
+// msgBoxMaxWidth is the maximal width of a MessageBox text. Longer
+// lines are wrapped at word boundaries
+const msgBoxMaxWidth = 50
+
+// msgBoxButtons is the order of MessageBox buttons from left to right
+var msgBoxButtons = []struct {
+	flag   MsgBoxButtons
+	title  string
+	result MsgBoxResult
+}{
+	{MsgBoxOK, "OK", MsgBoxResultOK},
+	{MsgBoxYes, "Yes", MsgBoxResultYes},
+	{MsgBoxNo, "No", MsgBoxResultNo},
+	{MsgBoxCancel, "Cancel", MsgBoxResultCancel},
+}
+
+// createMessageBox builds the MessageBox dialog. done is called with
+// the result when a user presses a button, Enter, or Escape
+func createMessageBox(title, message string, buttons MsgBoxButtons, done func(MsgBoxResult)) *ModalFrame {
+	if buttons&(MsgBoxOK|MsgBoxCancel|MsgBoxYes|MsgBoxNo) == 0 {
+		buttons = MsgBoxOK
+	}
+
+	// multiline Label moves to the next row when a row is filled, so
+	// it gets an extra column to avoid an empty row after full lines
+	lines := wrapText(message, msgBoxMaxWidth)
+	textW := 1
+	for _, line := range lines {
+		if l := xs.Len(line) + 1; l > textW {
+			textW = l
+		}
+	}
+
+	// the default button is the leftmost one, Escape selects
+	// Cancel or No
+	btnW := -1
+	defResult, escResult := MsgBoxResultNone, MsgBoxResultNone
+	for _, b := range msgBoxButtons {
+		if buttons&b.flag == 0 {
+			continue
+		}
+		if defResult == MsgBoxResultNone {
+			defResult = b.result
+		}
+		if b.flag == MsgBoxCancel || (b.flag == MsgBoxNo && escResult == MsgBoxResultNone) {
+			escResult = b.result
+		}
+		btnW += xs.Len(b.title) + 3 + 1
+	}
+
+	w := textW
+	if btnW > w {
+		w = btnW
+	}
+	if l := xs.Len(title) + 4; l > w {
+		w = l
+	}
+
+	dlg := CreateModalFrame(w+4, len(lines)+7, title)
+	dlg.SetPaddings(2, 1)
+
+	lbl := CreateLabel(dlg, textW, len(lines), strings.Join(lines, "\n"), Fixed)
+	lbl.SetMultiline(true)
+	CreateFrame(dlg, 1, 1, BorderNone, Fixed)
+
+	row := CreateFrame(dlg, btnW, 4, BorderNone, Fixed)
+	row.SetGaps(1, 0)
+	CreateFrame(row, 1, 1, BorderNone, 1)
+	for _, b := range msgBoxButtons {
+		if buttons&b.flag == 0 {
+			continue
+		}
+		res := b.result
+		btn := CreateButton(row, AutoSize, AutoSize, b.title, Fixed)
+		btn.OnClick(func(ev Event) {
+			done(res)
+		})
+	}
+	CreateFrame(row, 1, 1, BorderNone, 1)
+
+	dlg.OnKeyDown(func(ev Event) bool {
+		switch ev.Key {
+		case term.KeyCtrlM:
+			done(defResult)
+		case term.KeyEsc:
+			done(escResult)
+		default:
+			return false
+		}
+		return true
+	})
+
+	return dlg
+}
+
+/*
+ShowMessageBox displays a modal MessageBox inside the Window and waits
+until a user closes it. Returns the button the user pressed. Enter
+presses the leftmost button, Escape presses Cancel or No button.
+
+The function blocks, so it must not be called from the goroutine that
+processes events: e.g, call it inside a new goroutine from a Button
+OnClick callback. The dialog is displayed and closed by the main loop.
+*/
+func ShowMessageBox(view *Window, title, message string, buttons MsgBoxButtons) MsgBoxResult {
+	ch := make(chan MsgBoxResult, 1)
+	result := MsgBoxResultNone
+
+	// button callbacks are called in new goroutines, so the dialog
+	// is shown and closed by the main loop
+	var dlg *ModalFrame
+	dlg = createMessageBox(title, message, buttons, func(res MsgBoxResult) {
+		view.runOnLoop(func() {
+			if view.ModalDialog() == dlg {
+				result = res
+				view.CloseModal()
+			}
+		})
+	})
+	dlg.OnClose(func() {
+		ch <- result
+	})
+
+	view.runOnLoop(func() {
+		view.ShowModal(dlg)
+	})
+
+	return <-ch
+}
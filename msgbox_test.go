@@ -0,0 +1,83 @@
+package clui
+
+import (
+	term "github.com/nsf/termbox-go"
+	"testing"
+	"time"
+)
+
+// deliverUntil plays the main loop role for the Window: it calls the
+// functions queued with runOnLoop until cond returns true
+func deliverUntil(t *testing.T, wnd *Window, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("Timeout waiting for the main loop")
+		}
+		wnd.bus.deliver()
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestMessageBox(t *testing.T) {
+	cases := []struct {
+		buttons    MsgBoxButtons
+		key        term.Key
+		result     MsgBoxResult
+		numButtons int
+	}{
+		{MsgBoxOK, term.KeyCtrlM, MsgBoxResultOK, 1},
+		{MsgBoxOK, term.KeyEsc, MsgBoxResultNone, 1},
+		{MsgBoxYes | MsgBoxNo, term.KeyCtrlM, MsgBoxResultYes, 2},
+		{MsgBoxYes | MsgBoxNo, term.KeyEsc, MsgBoxResultNo, 2},
+		{MsgBoxYes | MsgBoxNo | MsgBoxCancel, term.KeyEsc, MsgBoxResultCancel, 3},
+		{MsgBoxCancel | MsgBoxOK, term.KeyCtrlM, MsgBoxResultOK, 2},
+	}
+
+	for idx, c := range cases {
+		wnd := CreateWindow(0, 0, 60, 16, "Test")
+		result := MsgBoxResult(-1)
+		dlg := createMessageBox("Title", "Message", c.buttons, func(res MsgBoxResult) {
+			result = res
+			wnd.CloseModal()
+		})
+		wnd.ShowModal(dlg)
+
+		btns := 0
+		for _, ch := range dlg.Children()[2].Children() {
+			if _, ok := ch.(*Button); ok {
+				btns++
+			}
+		}
+		if btns != c.numButtons {
+			t.Errorf("%v. Invalid number of buttons: %v", idx, btns)
+		}
+
+		wnd.ProcessEvent(Event{Type: EventKey, Key: c.key})
+		if result != c.result || wnd.ModalDialog() != nil {
+			t.Errorf("%v. Result %v expected %v", idx, result, c.result)
+		}
+	}
+}
+
+func TestShowMessageBox(t *testing.T) {
+	wnd := CreateWindow(0, 0, 60, 16, "Test")
+	ch := make(chan MsgBoxResult, 1)
+	go func() {
+		ch <- ShowMessageBox(wnd, "Title", "Message", MsgBoxYes|MsgBoxNo)
+	}()
+
+	deliverUntil(t, wnd, func() bool { return wnd.ModalDialog() != nil })
+	wnd.ProcessEvent(Event{Type: EventKey, Key: term.KeyEsc})
+	deliverUntil(t, wnd, func() bool { return wnd.ModalDialog() == nil })
+
+	select {
+	case res := <-ch:
+		if res != MsgBoxResultNo {
+			t.Errorf("Escape must press No: %v", res)
+		}
+	case <-time.After(2 * time.Second):
+		t.Error("ShowMessageBox did not return")
+	}
+}
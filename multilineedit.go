@@ -0,0 +1,781 @@
+package clui
+
+import (
+	"github.com/atotto/clipboard"
+	term "github.com/nsf/termbox-go"
+	"strings"
+)
+
+// editPos is a position in MultiLineEdit text: line and character
+type editPos struct {
+	row, col int
+}
+
+func (p editPos) before(o editPos) bool {
+	return p.row < o.row || (p.row == o.row && p.col < o.col)
+}
+
+// editUndo is a single change of MultiLineEdit text: text deleted
+// starting from position from was replaced with inserted text
+// that ends at position end. cursor is the cursor position
+// before the change
+type editUndo struct {
+	from, end editPos
+	deleted   string
+	inserted  string
+	cursor    editPos
+}
+
+// editRow is a part of a line displayed in one row of the control
+type editRow struct {
+	line       int
+	start, end int
+	last       bool
+}
+
+/*
+MultiLineEdit is a multi-line text edit control. The text is kept
+as a list of lines, so typing a character changes only one line
+regardless of the text size.
+Hotkeys:
+
+	Arrows, Home, End, PgUp, PgDn - move the cursor
+	Alt+Home and Alt+End - move the cursor to the start and the end
+	    of the text
+	Alt+Arrows - select text. Please note that terminals do not report
+	    Shift and Ctrl modifiers for arrow keys and Home/End, so Alt
+	    is used instead
+	Ctrl+C, Ctrl+X, Ctrl+V - copy, cut, and paste selected text
+	Ctrl+Z - undo the last change. Only the last UndoLimit changes
+	    can be undone
+
+In read-only mode all editing hotkeys are disabled, but the text
+can be scrolled, selected, and copied.
+If the number of lines is limited(see SetMaxLines) the changes that
+make the text longer than the limit are ignored.
+The vertical scrollbar is displayed only if the text does not fit
+the control. In wrap mode long lines are split at word boundaries
+and the cursor moves up and down by text lines.
+*/
+type MultiLineEdit struct {
+	BaseControl
+	lines  [][]rune
+	cursor editPos
+	// selection is the text between anchor and cursor
+	anchor    editPos
+	selecting bool
+
+	topLine, topSub int
+	leftCol         int
+	wrap            bool
+	readonly        bool
+	maxLines        int
+
+	undo       []editUndo
+	undoLimit  int
+	onKeyPress func(term.Key) bool
+}
+
+/*
+CreateMultiLineEdit creates a new multi-line edit control.
+parent - is container that keeps the control.
+width and heigth - are minimal size of the control.
+scale - the way of scaling the control when the parent is resized. Use DoNotScale constant if the
+control should keep its original size.
+*/
+func CreateMultiLineEdit(parent Control, width, height int, scale int) *MultiLineEdit {
+	e := new(MultiLineEdit)
+
+	if height == AutoSize {
+		height = 3
+	}
+	if width == AutoSize {
+		width = 10
+	}
+
+	e.SetSize(width, height)
+	e.SetConstraints(width, height)
+	e.parent = parent
+	e.lines = [][]rune{{}}
+	e.undo = make([]editUndo, 0)
+	e.undoLimit = 50
+
+	e.SetTabStop(true)
+	e.SetScale(scale)
+
+	if parent != nil {
+		parent.AddChild(e)
+	}
+
+	return e
+}
+
+// OnKeyPress sets the callback that is called when a user presses a Key while
+// the controls is active. If a handler processes the key it should return
+// true. If handler returns false it means that the default handler will
+// process the key
+func (e *MultiLineEdit) OnKeyPress(fn func(term.Key) bool) {
+	e.onKeyPress = fn
+}
+
+// needScroll returns true if the text does not fit the control
+func (e *MultiLineEdit) needScroll() bool {
+	if !e.wrap {
+		return len(e.lines) > e.height
+	}
+
+	rows := 0
+	for _, line := range e.lines {
+		rows += len(wrapLine(line, e.width))
+		if rows > e.height {
+			return true
+		}
+	}
+	return false
+}
+
+// textWidth returns the width of the area used to display text
+func (e *MultiLineEdit) textWidth() int {
+	w := e.width
+	if e.needScroll() {
+		w--
+	}
+	if w < 1 {
+		w = 1
+	}
+	return w
+}
+
+// visibleRows returns the parts of lines displayed in the control
+func (e *MultiLineEdit) visibleRows() []editRow {
+	tw := e.textWidth()
+	rows := make([]editRow, 0, e.height)
+
+	if !e.wrap {
+		for y := 0; y < e.height && e.topLine+y < len(e.lines); y++ {
+			line := e.lines[e.topLine+y]
+			start, end := e.leftCol, e.leftCol+tw
+			if start > len(line) {
+				start = len(line)
+			}
+			if end > len(line) {
+				end = len(line)
+			}
+			rows = append(rows, editRow{line: e.topLine + y, start: start, end: end, last: true})
+		}
+		return rows
+	}
+
+	sub := e.topSub
+	for lineID := e.topLine; lineID < len(e.lines) && len(rows) < e.height; lineID++ {
+		line := e.lines[lineID]
+		starts := wrapLine(line, tw)
+		for ; sub < len(starts) && len(rows) < e.height; sub++ {
+			end := len(line)
+			if sub < len(starts)-1 {
+				end = starts[sub+1]
+			}
+			if end > starts[sub]+tw {
+				end = starts[sub] + tw
+			}
+			rows = append(rows, editRow{line: lineID, start: starts[sub], end: end, last: sub == len(starts)-1})
+		}
+		sub = 0
+	}
+	return rows
+}
+
+// Draw repaints the control on its View surface
+func (e *MultiLineEdit) Draw() {
+	PushAttributes()
+	defer PopAttributes()
+
+	fg, bg := RealColor(e.fg, ColorEditText), RealColor(e.bg, ColorEditBack)
+	if !e.Enabled() {
+		fg, bg = RealColor(e.fg, ColorDisabledText), RealColor(e.fg, ColorDisabledBack)
+	} else if e.Active() {
+		fg, bg = RealColor(e.fg, ColorEditActiveText), RealColor(e.bg, ColorEditActiveBack)
+	}
+	fgSel, bgSel := RealColor(e.fgActive, ColorSelectionText), RealColor(e.bgActive, ColorSelectionBack)
+
+	SetTextColor(fg)
+	SetBackColor(bg)
+	FillRect(e.x, e.y, e.width, e.height, ' ')
+
+	selFrom, selTo, selected := e.selection()
+	for y, r := range e.visibleRows() {
+		line := e.lines[r.line]
+		for i := r.start; i < r.end; i++ {
+			pos := editPos{r.line, i}
+			if selected && !pos.before(selFrom) && pos.before(selTo) {
+				SetTextColor(fgSel)
+				SetBackColor(bgSel)
+			} else {
+				SetTextColor(fg)
+				SetBackColor(bg)
+			}
+			PutChar(e.x+i-r.start, e.y+y, line[i])
+		}
+	}
+
+	if e.needScroll() {
+		pos := ThumbPosition(e.topLine, len(e.lines)-1, e.height)
+		DrawScrollBar(e.x+e.width-1, e.y, 1, e.height, pos)
+	}
+
+	if e.Active() {
+		if x, y, ok := e.cursorScreenPos(); ok {
+			SetCursorPos(e.x+x, e.y+y)
+		}
+	}
+}
+
+// cursorScreenPos returns the cursor position inside the control.
+// It returns false if the cursor is not visible
+func (e *MultiLineEdit) cursorScreenPos() (int, int, bool) {
+	tw := e.textWidth()
+	for y, r := range e.visibleRows() {
+		if r.line != e.cursor.row || e.cursor.col < r.start {
+			continue
+		}
+		if e.cursor.col < r.end || r.last || (e.wrap && r.end == r.start) {
+			x := e.cursor.col - r.start
+			if x >= tw {
+				x = tw - 1
+			}
+			return x, y, true
+		}
+	}
+	return 0, 0, false
+}
+
+// cursorSub returns the index of the wrapped row of the text
+// line that contains the cursor
+func (e *MultiLineEdit) cursorSub(tw int) int {
+	starts := wrapLine(e.lines[e.cursor.row], tw)
+	sub := 0
+	for idx, s := range starts {
+		if s <= e.cursor.col {
+			sub = idx
+		}
+	}
+	return sub
+}
+
+// EnsureCursorVisible scrolls the text to make the cursor visible
+func (e *MultiLineEdit) EnsureCursorVisible() {
+	tw := e.textWidth()
+
+	if !e.wrap {
+		e.topSub = 0
+		if e.cursor.row < e.topLine {
+			e.topLine = e.cursor.row
+		} else if e.cursor.row >= e.topLine+e.height {
+			e.topLine = e.cursor.row - e.height + 1
+		}
+		if e.cursor.col < e.leftCol {
+			e.leftCol = e.cursor.col
+		} else if e.cursor.col >= e.leftCol+tw {
+			e.leftCol = e.cursor.col - tw + 1
+		}
+		return
+	}
+
+	e.leftCol = 0
+	curr := editPos{e.cursor.row, e.cursorSub(tw)}
+	top := editPos{e.topLine, e.topSub}
+	if curr.before(top) {
+		e.topLine, e.topSub = curr.row, curr.col
+		return
+	}
+
+	// the top row if the cursor is at the bottom row of the control
+	for i := 0; i < e.height-1; i++ {
+		if curr.col > 0 {
+			curr.col--
+		} else if curr.row > 0 {
+			curr.row--
+			curr.col = len(wrapLine(e.lines[curr.row], tw)) - 1
+		} else {
+			break
+		}
+	}
+	if top.before(curr) {
+		e.topLine, e.topSub = curr.row, curr.col
+	}
+}
+
+// selection returns the start and the end of selected text
+func (e *MultiLineEdit) selection() (editPos, editPos, bool) {
+	if !e.selecting || e.anchor == e.cursor {
+		return e.cursor, e.cursor, false
+	}
+
+	if e.anchor.before(e.cursor) {
+		return e.anchor, e.cursor, true
+	}
+	return e.cursor, e.anchor, true
+}
+
+// textRange returns the text between two positions
+func (e *MultiLineEdit) textRange(from, to editPos) string {
+	if from.row == to.row {
+		return string(e.lines[from.row][from.col:to.col])
+	}
+
+	parts := make([]string, 0, to.row-from.row+1)
+	parts = append(parts, string(e.lines[from.row][from.col:]))
+	for row := from.row + 1; row < to.row; row++ {
+		parts = append(parts, string(e.lines[row]))
+	}
+	parts = append(parts, string(e.lines[to.row][:to.col]))
+	return strings.Join(parts, "\n")
+}
+
+// deleteRange removes the text between two positions
+func (e *MultiLineEdit) deleteRange(from, to editPos) {
+	line := make([]rune, 0, from.col+len(e.lines[to.row])-to.col)
+	line = append(line, e.lines[from.row][:from.col]...)
+	line = append(line, e.lines[to.row][to.col:]...)
+
+	e.lines[from.row] = line
+	if to.row > from.row {
+		e.lines = append(e.lines[:from.row+1], e.lines[to.row+1:]...)
+	}
+}
+
+// insertAt inserts the text at the position and returns the
+// position of the end of inserted text
+func (e *MultiLineEdit) insertAt(p editPos, s string) editPos {
+	parts := strings.Split(s, "\n")
+	line := e.lines[p.row]
+	tail := line[p.col:]
+
+	if len(parts) == 1 {
+		newLine := make([]rune, 0, len(line)+len(s))
+		newLine = append(newLine, line[:p.col]...)
+		newLine = append(newLine, []rune(parts[0])...)
+		newLine = append(newLine, tail...)
+		e.lines[p.row] = newLine
+		return editPos{p.row, p.col + len([]rune(parts[0]))}
+	}
+
+	newLines := make([][]rune, 0, len(e.lines)+len(parts)-1)
+	newLines = append(newLines, e.lines[:p.row]...)
+
+	first := make([]rune, 0, p.col+len(parts[0]))
+	first = append(first, line[:p.col]...)
+	newLines = append(newLines, append(first, []rune(parts[0])...))
+	for _, part := range parts[1 : len(parts)-1] {
+		newLines = append(newLines, []rune(part))
+	}
+	last := []rune(parts[len(parts)-1])
+	end := editPos{p.row + len(parts) - 1, len(last)}
+	newLines = append(newLines, append(last, tail...))
+
+	e.lines = append(newLines, e.lines[p.row+1:]...)
+	return end
+}
+
+// replace replaces the text between two positions with a new
+// text, saves the change to undo list, and moves the cursor to
+// the end of inserted text
+func (e *MultiLineEdit) replace(from, to editPos, s string) {
+	if e.readonly {
+		return
+	}
+
+	s = strings.Replace(s, "\r", "", -1)
+	count := len(e.lines) - (to.row - from.row) + strings.Count(s, "\n")
+	if e.maxLines > 0 && count > e.maxLines && count > len(e.lines) {
+		return
+	}
+
+	u := editUndo{from: from, inserted: s, cursor: e.cursor}
+	if from != to {
+		u.deleted = e.textRange(from, to)
+		e.deleteRange(from, to)
+	}
+	u.end = e.insertAt(from, s)
+
+	// typing a word creates one undo item instead of an item
+	// per character
+	last := len(e.undo) - 1
+	if last >= 0 && u.deleted == "" && e.undo[last].inserted != "" && e.undo[last].end == from &&
+		len([]rune(s)) == 1 && s != "\n" && s != " " {
+		e.undo[last].inserted += s
+		e.undo[last].end = u.end
+	} else if e.undoLimit > 0 {
+		e.undo = append(e.undo, u)
+		if len(e.undo) > e.undoLimit {
+			e.undo = e.undo[len(e.undo)-e.undoLimit:]
+		}
+	}
+
+	e.cursor = u.end
+	e.selecting = false
+	e.EnsureCursorVisible()
+}
+
+func (e *MultiLineEdit) insertText(s string) {
+	from, to, _ := e.selection()
+	e.replace(from, to, s)
+}
+
+func (e *MultiLineEdit) backspace() {
+	if from, to, ok := e.selection(); ok {
+		e.replace(from, to, "")
+	} else if e.cursor.col > 0 {
+		e.replace(editPos{e.cursor.row, e.cursor.col - 1}, e.cursor, "")
+	} else if e.cursor.row > 0 {
+		e.replace(editPos{e.cursor.row - 1, len(e.lines[e.cursor.row-1])}, e.cursor, "")
+	}
+}
+
+func (e *MultiLineEdit) del() {
+	if from, to, ok := e.selection(); ok {
+		e.replace(from, to, "")
+	} else if e.cursor.col < len(e.lines[e.cursor.row]) {
+		e.replace(e.cursor, editPos{e.cursor.row, e.cursor.col + 1}, "")
+	} else if e.cursor.row < len(e.lines)-1 {
+		e.replace(e.cursor, editPos{e.cursor.row + 1, 0}, "")
+	}
+}
+
+// Undo reverts the last change of the text
+func (e *MultiLineEdit) Undo() {
+	if e.readonly || len(e.undo) == 0 {
+		return
+	}
+
+	u := e.undo[len(e.undo)-1]
+	e.undo = e.undo[:len(e.undo)-1]
+
+	e.deleteRange(u.from, u.end)
+	e.insertAt(u.from, u.deleted)
+	e.cursor = u.cursor
+	e.selecting = false
+	e.EnsureCursorVisible()
+}
+
+// UndoLimit returns the maximum number of changes that can be undone
+func (e *MultiLineEdit) UndoLimit() int {
+	return e.undoLimit
+}
+
+// SetUndoLimit sets the maximum number of changes that can be
+// undone. The oldest changes are dropped. Zero disables undo
+func (e *MultiLineEdit) SetUndoLimit(n int) {
+	if n < 0 {
+		n = 0
+	}
+	e.undoLimit = n
+
+	if len(e.undo) > n {
+		e.undo = e.undo[len(e.undo)-n:]
+	}
+}
+
+func (e *MultiLineEdit) copySelection() {
+	if from, to, ok := e.selection(); ok {
+		clipboard.WriteAll(e.textRange(from, to))
+	}
+}
+
+// moveTo moves the cursor. If sel is true the text between the
+// old and new position is selected
+func (e *MultiLineEdit) moveTo(p editPos, sel bool) {
+	if sel && !e.selecting {
+		e.anchor = e.cursor
+		e.selecting = true
+	} else if !sel {
+		e.selecting = false
+	}
+
+	e.cursor = p
+	e.EnsureCursorVisible()
+}
+
+func (e *MultiLineEdit) charLeft(sel bool) {
+	p := e.cursor
+	if p.col > 0 {
+		p.col--
+	} else if p.row > 0 {
+		p.row--
+		p.col = len(e.lines[p.row])
+	}
+	e.moveTo(p, sel)
+}
+
+func (e *MultiLineEdit) charRight(sel bool) {
+	p := e.cursor
+	if p.col < len(e.lines[p.row]) {
+		p.col++
+	} else if p.row < len(e.lines)-1 {
+		p.row++
+		p.col = 0
+	}
+	e.moveTo(p, sel)
+}
+
+// lineMove moves the cursor dy lines up(negative dy) or down
+func (e *MultiLineEdit) lineMove(dy int, sel bool) {
+	p := e.cursor
+	p.row += dy
+	if p.row < 0 {
+		p.row = 0
+	}
+	if p.row >= len(e.lines) {
+		p.row = len(e.lines) - 1
+	}
+	if p.col > len(e.lines[p.row]) {
+		p.col = len(e.lines[p.row])
+	}
+	e.moveTo(p, sel)
+}
+
+func (e *MultiLineEdit) processMouseClick(ev Event) bool {
+	if ev.Key != term.MouseLeft {
+		return false
+	}
+
+	dx := ev.X - e.x
+	dy := ev.Y - e.y
+
+	if e.needScroll() && dx == e.width-1 {
+		if dy == 0 {
+			e.scrollLines(-1)
+		} else if dy == e.height-1 {
+			e.scrollLines(1)
+		} else {
+			newPos := ItemByThumbPosition(dy, len(e.lines), e.height)
+			if newPos >= 0 {
+				e.topLine, e.topSub = newPos, 0
+			}
+		}
+		return true
+	}
+
+	rows := e.visibleRows()
+	if len(rows) == 0 {
+		return true
+	}
+
+	p := editPos{len(e.lines) - 1, len(e.lines[len(e.lines)-1])}
+	if dy < len(rows) {
+		r := rows[dy]
+		p = editPos{r.line, r.start + dx}
+		if p.col > r.end {
+			p.col = r.end
+		}
+		if !r.last && p.col == r.end && r.end > r.start {
+			p.col--
+		}
+	}
+	e.moveTo(p, false)
+	return true
+}
+
+// scrollLines scrolls the text without moving the cursor
+func (e *MultiLineEdit) scrollLines(dy int) {
+	e.topLine += dy
+	if e.topLine >= len(e.lines) {
+		e.topLine = len(e.lines) - 1
+	}
+	if e.topLine < 0 {
+		e.topLine = 0
+	}
+	e.topSub = 0
+}
+
+/*
+ProcessEvent processes all events come from the control parent. If a control
+processes an event it should return true. If the method returns false it means
+that the control do not want or cannot process the event and the caller sends
+the event to the control parent
+*/
+func (e *MultiLineEdit) ProcessEvent(event Event) bool {
+	if !e.Active() || !e.Enabled() {
+		return false
+	}
+
+	if event.Type == EventActivate && event.X == 0 {
+		term.HideCursor()
+	}
+
+	if event.Type == EventMouse {
+		return e.processMouseClick(event)
+	}
+
+	if event.Type != EventKey || event.Key == term.KeyTab {
+		return false
+	}
+
+	if e.onKeyPress != nil && e.onKeyPress(event.Key) {
+		return true
+	}
+
+	sel := event.Mod == term.ModAlt
+	switch event.Key {
+	case term.KeyEnter:
+		e.insertText("\n")
+	case term.KeySpace:
+		e.insertText(" ")
+	case term.KeyBackspace, term.KeyBackspace2:
+		e.backspace()
+	case term.KeyDelete:
+		e.del()
+	case term.KeyArrowLeft:
+		e.charLeft(sel)
+	case term.KeyArrowRight:
+		e.charRight(sel)
+	case term.KeyArrowUp:
+		e.lineMove(-1, sel)
+	case term.KeyArrowDown:
+		e.lineMove(1, sel)
+	case term.KeyPgup:
+		e.lineMove(-e.height, false)
+	case term.KeyPgdn:
+		e.lineMove(e.height, false)
+	case term.KeyHome:
+		if sel {
+			e.moveTo(editPos{0, 0}, false)
+		} else {
+			e.moveTo(editPos{e.cursor.row, 0}, false)
+		}
+	case term.KeyEnd:
+		row := e.cursor.row
+		if sel {
+			row = len(e.lines) - 1
+		}
+		e.moveTo(editPos{row, len(e.lines[row])}, false)
+	case term.KeyCtrlC:
+		e.copySelection()
+	case term.KeyCtrlX:
+		if !e.readonly {
+			e.copySelection()
+			if from, to, ok := e.selection(); ok {
+				e.replace(from, to, "")
+			}
+		}
+	case term.KeyCtrlV:
+		if !e.readonly {
+			s, _ := clipboard.ReadAll()
+			e.insertText(s)
+		}
+	case term.KeyCtrlZ:
+		e.Undo()
+	default:
+		if event.Ch == 0 {
+			return false
+		}
+		e.insertText(string(event.Ch))
+	}
+
+	return true
+}
+
+// own methods
+
+// GetText returns the text of the control. Lines are separated
+// with new line characters
+func (e *MultiLineEdit) GetText() string {
+	parts := make([]string, len(e.lines))
+	for idx, line := range e.lines {
+		parts[idx] = string(line)
+	}
+	return strings.Join(parts, "\n")
+}
+
+// SetText replaces the text of the control. The cursor moves to
+// the start of the text and the undo list is cleared. If the
+// number of lines is limited the extra lines are removed
+func (e *MultiLineEdit) SetText(s string) {
+	s = strings.Replace(s, "\r", "", -1)
+	parts := strings.Split(s, "\n")
+	e.lines = make([][]rune, len(parts))
+	for idx, part := range parts {
+		e.lines[idx] = []rune(part)
+	}
+	e.applyLimit()
+
+	e.cursor = editPos{}
+	e.selecting = false
+	e.topLine, e.topSub, e.leftCol = 0, 0, 0
+	e.undo = make([]editUndo, 0)
+}
+
+func (e *MultiLineEdit) applyLimit() {
+	if e.maxLines > 0 && len(e.lines) > e.maxLines {
+		e.lines = e.lines[:e.maxLines]
+	}
+}
+
+// LineCount returns the number of lines of the text
+func (e *MultiLineEdit) LineCount() int {
+	return len(e.lines)
+}
+
+// MaxLines returns the maximum number of lines. Zero means no limit
+func (e *MultiLineEdit) MaxLines() int {
+	return e.maxLines
+}
+
+// SetMaxLines sets the maximum number of lines. If the current
+// text is longer it is truncated and the undo list is cleared
+func (e *MultiLineEdit) SetMaxLines(n int) {
+	if n < 0 {
+		n = 0
+	}
+	e.maxLines = n
+
+	if n > 0 && len(e.lines) > n {
+		e.applyLimit()
+		e.undo = make([]editUndo, 0)
+		e.selecting = false
+		if e.cursor.row >= n {
+			e.cursor = editPos{n - 1, len(e.lines[n-1])}
+		}
+		e.EnsureCursorVisible()
+	}
+}
+
+// CursorPos returns the line and the character of the cursor
+func (e *MultiLineEdit) CursorPos() (int, int) {
+	return e.cursor.row, e.cursor.col
+}
+
+// SelectedText returns the selected text or empty string
+// if nothing is selected
+func (e *MultiLineEdit) SelectedText() string {
+	from, to, ok := e.selection()
+	if !ok {
+		return ""
+	}
+	return e.textRange(from, to)
+}
+
+// ReadOnly returns if the text can be changed by a user
+func (e *MultiLineEdit) ReadOnly() bool {
+	return e.readonly
+}
+
+// SetReadOnly enables or disables read-only mode. In read-only
+// mode a user can move the cursor and select text but cannot
+// change it
+func (e *MultiLineEdit) SetReadOnly(ro bool) {
+	e.readonly = ro
+}
+
+// WordWrap returns if the wordwrap is enabled
+func (e *MultiLineEdit) WordWrap() bool {
+	return e.wrap
+}
+
+// SetWordWrap enables or disables wordwrap mode. In wordwrap
+// mode long lines are displayed on a few control rows
+func (e *MultiLineEdit) SetWordWrap(wrap bool) {
+	e.wrap = wrap
+	e.topSub, e.leftCol = 0, 0
+	e.EnsureCursorVisible()
+}
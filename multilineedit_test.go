@@ -0,0 +1,118 @@
+package clui
+
+import (
+	term "github.com/nsf/termbox-go"
+	"testing"
+)
+
+func typeText(e *MultiLineEdit, s string) {
+	for _, ch := range s {
+		e.insertText(string(ch))
+	}
+}
+
+func TestMultiLineEditInsert(t *testing.T) {
+	e := CreateMultiLineEdit(nil, 10, 3, Fixed)
+	typeText(e, "hello\nworld")
+	if s := e.GetText(); s != "hello\nworld" {
+		t.Errorf("Expected 'hello\\nworld', got %q", s)
+	}
+	if row, col := e.CursorPos(); row != 1 || col != 5 {
+		t.Errorf("Expected cursor at 1:5, got %v:%v", row, col)
+	}
+
+	// join lines
+	e.moveTo(editPos{1, 0}, false)
+	e.backspace()
+	if s := e.GetText(); s != "helloworld" {
+		t.Errorf("Expected 'helloworld', got %q", s)
+	}
+
+	// replace selection
+	e.moveTo(editPos{0, 2}, false)
+	e.moveTo(editPos{0, 8}, true)
+	if s := e.SelectedText(); s != "llowor" {
+		t.Errorf("Expected selected 'llowor', got %q", s)
+	}
+	e.insertText("a\nb")
+	if s := e.GetText(); s != "hea\nbld" {
+		t.Errorf("Expected 'hea\\nbld', got %q", s)
+	}
+}
+
+func TestMultiLineEditUndo(t *testing.T) {
+	e := CreateMultiLineEdit(nil, 10, 3, Fixed)
+	e.SetText("one\ntwo\nthree")
+	e.moveTo(editPos{1, 3}, false)
+	typeText(e, " more")
+	e.moveTo(editPos{0, 1}, false)
+	e.moveTo(editPos{2, 2}, true)
+	e.del()
+	if s := e.GetText(); s != "oree" {
+		t.Errorf("Expected 'oree', got %q", s)
+	}
+
+	e.Undo()
+	if s := e.GetText(); s != "one\ntwo more\nthree" {
+		t.Errorf("Expected text restored, got %q", s)
+	}
+	// " more" is a space and a word: two undo items
+	e.Undo()
+	e.Undo()
+	if s := e.GetText(); s != "one\ntwo\nthree" {
+		t.Errorf("Expected original text, got %q", s)
+	}
+	if row, col := e.CursorPos(); row != 1 || col != 3 {
+		t.Errorf("Expected cursor at 1:3, got %v:%v", row, col)
+	}
+}
+
+func TestMultiLineEditUndoLimit(t *testing.T) {
+	e := CreateMultiLineEdit(nil, 10, 3, Fixed)
+	if e.UndoLimit() != 50 {
+		t.Errorf("Default undo limit must be 50, got %v", e.UndoLimit())
+	}
+
+	// every line break starts a new undo item: "a", "\nb", and "\nc"
+	e.SetUndoLimit(2)
+	typeText(e, "a\nb\nc")
+	if len(e.undo) != 2 {
+		t.Errorf("The history must keep 2 items, got %v", len(e.undo))
+	}
+	for i := 0; i < 5; i++ {
+		e.Undo()
+	}
+	if s := e.GetText(); s != "a" {
+		t.Errorf("Only the last changes must be undone, got %q", s)
+	}
+
+	e.SetUndoLimit(0)
+	typeText(e, "x")
+	e.Undo()
+	if s := e.GetText(); s != "ax" {
+		t.Errorf("Zero limit must disable undo, got %q", s)
+	}
+}
+
+func TestMultiLineEditLimits(t *testing.T) {
+	e := CreateMultiLineEdit(nil, 10, 3, Fixed)
+	e.SetText("1\n2\n3\n4")
+	e.SetMaxLines(3)
+	if e.LineCount() != 3 {
+		t.Errorf("Expected 3 lines, got %v", e.LineCount())
+	}
+
+	e.moveTo(editPos{2, 1}, false)
+	e.insertText("\n")
+	if e.LineCount() != 3 {
+		t.Errorf("New line must be ignored, got %v lines", e.LineCount())
+	}
+
+	e.SetReadOnly(true)
+	e.SetActive(true)
+	e.ProcessEvent(Event{Type: EventKey, Ch: 'x'})
+	e.ProcessEvent(Event{Type: EventKey, Key: term.KeyBackspace})
+	if s := e.GetText(); s != "1\n2\n3" {
+		t.Errorf("Read-only text must not change, got %q", s)
+	}
+}
@@ -0,0 +1,209 @@
+package clui
+
+import (
+	term "github.com/nsf/termbox-go"
+	"sync"
+	"time"
+)
+
+// NotificationLevel defines the importance of a notification and
+// its colors
+type NotificationLevel int
+
+// Notification levels
+const (
+	// NotificationInfo - a regular message, blue by default
+	NotificationInfo NotificationLevel = iota
+	// NotificationWarning - a warning, yellow by default
+	NotificationWarning
+	// NotificationError - an error message, red by default
+	NotificationError
+)
+
+// NotificationPosition is a Window edge where notifications appear
+type NotificationPosition int
+
+// Notification positions
+const (
+	NotificationTop NotificationPosition = iota
+	NotificationBottom
+)
+
+// notificationHeight is a number of rows of a notification banner
+const notificationHeight = 3
+
+// notificationStep is a delay between animation frames when a
+// banner slides in and out
+var notificationStep = 40 * time.Millisecond
+
+type notification struct {
+	msg      string
+	level    NotificationLevel
+	duration time.Duration
+}
+
+// notifier displays Window notifications one by one. It is used
+// by the goroutine that animates banners, so all fields are guarded
+// with the mutex
+type notifier struct {
+	mtx      sync.Mutex
+	queue    []notification
+	current  *notification
+	visible  int
+	position NotificationPosition
+	running  bool
+	dismiss  chan struct{}
+}
+
+func newNotifier() *notifier {
+	return &notifier{dismiss: make(chan struct{}, 1)}
+}
+
+/*
+ShowNotification displays a banner with the message at the top of the
+Window(see Window.SetNotificationPosition to display it at the bottom).
+The banner slides in, stays visible for the duration, and slides out.
+If another notification is visible, the new one waits in the queue.
+
+Unlike MessageBox, notifications do not block and do not require any user
+action. The function can be called from any goroutine.
+*/
+func ShowNotification(view *Window, msg string, level NotificationLevel, duration time.Duration) {
+	n := view.notices
+
+	n.mtx.Lock()
+	n.queue = append(n.queue, notification{msg: msg, level: level, duration: duration})
+	start := !n.running
+	n.running = true
+	n.mtx.Unlock()
+
+	if start {
+		go n.run()
+	}
+}
+
+// DismissNotification hides the visible notifications of all Windows
+// before their time is over. Queued notifications are displayed after
+// that
+func DismissNotification() {
+	if comp == nil {
+		return
+	}
+
+	for _, wnd := range comp.windows {
+		n := wnd.(*Window).notices
+		n.mtx.Lock()
+		if n.current != nil {
+			select {
+			case n.dismiss <- struct{}{}:
+			default:
+			}
+		}
+		n.mtx.Unlock()
+	}
+}
+
+// redraw asks the main loop to repaint the screen
+func (n *notifier) redraw() {
+	if loop != nil {
+		PutEvent(Event{Type: EventRedraw})
+	}
+}
+
+// slide shows or hides the banner one row at a time
+func (n *notifier) slide(show bool) {
+	for i := 1; i <= notificationHeight; i++ {
+		n.mtx.Lock()
+		if show {
+			n.visible = i
+		} else {
+			n.visible = notificationHeight - i
+		}
+		n.mtx.Unlock()
+
+		n.redraw()
+		time.Sleep(notificationStep)
+	}
+}
+
+// run displays queued notifications until the queue is empty
+func (n *notifier) run() {
+	for {
+		n.mtx.Lock()
+		if len(n.queue) == 0 {
+			n.running = false
+			n.mtx.Unlock()
+			return
+		}
+		current := n.queue[0]
+		n.queue = n.queue[1:]
+		n.current = &current
+		// forget dismissals that came when nothing was displayed
+		select {
+		case <-n.dismiss:
+		default:
+		}
+		n.mtx.Unlock()
+
+		n.slide(true)
+		select {
+		case <-time.After(current.duration):
+		case <-n.dismiss:
+		}
+		n.slide(false)
+
+		n.mtx.Lock()
+		n.current = nil
+		n.mtx.Unlock()
+	}
+}
+
+// draw paints the visible part of the banner inside the Window
+func (n *notifier) draw(wnd *Window) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+
+	if n.current == nil || n.visible == 0 {
+		return
+	}
+
+	PushAttributes()
+	defer PopAttributes()
+
+	var fg, bg term.Attribute
+	switch n.current.level {
+	case NotificationWarning:
+		fg, bg = RealColor(ColorDefault, ColorNotificationWarningText), RealColor(ColorDefault, ColorNotificationWarningBack)
+	case NotificationError:
+		fg, bg = RealColor(ColorDefault, ColorNotificationErrorText), RealColor(ColorDefault, ColorNotificationErrorBack)
+	default:
+		fg, bg = RealColor(ColorDefault, ColorNotificationInfoText), RealColor(ColorDefault, ColorNotificationInfoBack)
+	}
+
+	x, w := wnd.x+1, wnd.width-2
+	if w < 1 {
+		return
+	}
+
+	// the banner slides from the Window edge: the row with the
+	// message is the middle one
+	y, first := wnd.y+1, notificationHeight-n.visible
+	if n.position == NotificationBottom {
+		y, first = wnd.y+wnd.height-1-n.visible, 0
+	}
+
+	SetTextColor(fg)
+	SetBackColor(bg)
+	FillRect(x, y, w, n.visible, ' ')
+	if row := notificationHeight/2 - first; row >= 0 && row < n.visible {
+		DrawRawText(x+1, y+row, CutText(n.current.msg, w-2))
+	}
+}
+
+// SetNotificationPosition sets the Window edge where notifications
+// appear: at the top(default) or at the bottom
+func (c *Window) SetNotificationPosition(pos NotificationPosition) {
+	c.notices.mtx.Lock()
+	c.notices.position = pos
+	c.notices.mtx.Unlock()
+}
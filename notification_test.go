@@ -0,0 +1,67 @@
+package clui
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNotificationQueue(t *testing.T) {
+	step := notificationStep
+	notificationStep = time.Millisecond
+	defer func() { notificationStep = step }()
+
+	wnd := CreateWindow(0, 0, 30, 10, "Test")
+	n := wnd.notices
+
+	current := func() string {
+		n.mtx.Lock()
+		defer n.mtx.Unlock()
+		if n.current == nil {
+			return ""
+		}
+		return n.current.msg
+	}
+	waitFor := func(msg string) bool {
+		for i := 0; i < 200; i++ {
+			if current() == msg {
+				return true
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+		return false
+	}
+
+	ShowNotification(wnd, "first", NotificationInfo, 50*time.Millisecond)
+	ShowNotification(wnd, "second", NotificationError, time.Hour)
+	if !waitFor("first") {
+		t.Fatal("The first notification must be displayed")
+	}
+	if !waitFor("second") {
+		t.Fatal("Queued notification must be displayed after the first one")
+	}
+
+	initComposer()
+	comp.windows = append(comp.windows, wnd)
+	DismissNotification()
+	if !waitFor("") {
+		t.Error("DismissNotification must hide the notification")
+	}
+}
+
+func TestNotificationDraw(t *testing.T) {
+	wnd := CreateWindow(0, 0, 20, 8, "Test")
+	wnd.notices.current = &notification{msg: "Saved", level: NotificationInfo}
+	wnd.notices.visible = notificationHeight
+
+	lines := strings.Split(renderToString(wnd), "\n")
+	if !strings.HasPrefix(lines[2], "║ Saved") {
+		t.Errorf("The message must be displayed in the middle row: %q", lines[2])
+	}
+
+	wnd.SetNotificationPosition(NotificationBottom)
+	lines = strings.Split(renderToString(wnd), "\n")
+	if !strings.HasPrefix(lines[5], "║ Saved") {
+		t.Errorf("The message must be displayed at the bottom: %q", lines[5])
+	}
+}
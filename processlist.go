@@ -0,0 +1,300 @@
+package clui
+
+import (
+	"fmt"
+	term "github.com/nsf/termbox-go"
+	"sort"
+	"strings"
+)
+
+// Process is a row of ProcessList
+type Process struct {
+	PID int
+	// CPU and Mem are usage in percents
+	CPU  float64
+	Mem  float64
+	Name string
+}
+
+/*
+ProcessList is a TableView that displays a list of processes like
+htop does: PID, CPU%, MEM%, and Name columns. All TableView
+navigation hotkeys and mouse scrolling work in ProcessList as well.
+
+The list is sorted by CPU usage by default. Clicking a column
+header sorts the list by the column. SortBy sorts it from the
+code: CPU and memory usage in descending order, PID and name in
+ascending one. SetData keeps the sort order and the selected process.
+
+Rows are colored by CPU usage: the usage below WarnAt is displayed
+with ColorProcessListNormal color, from WarnAt to CritAt with
+ColorProcessListWarn color, and higher with ColorProcessListCrit
+color. Zero WarnAt or CritAt means that the level is not used.
+
+Events:
+
+	OnSelect - called every time the selected row is changed.
+	    The argument is the selected process
+*/
+type ProcessList struct {
+	TableView
+	all      []Process
+	rows     []Process
+	filter   func(Process) bool
+	sortCol  int
+	sortDesc bool
+	warnAt   float64
+	critAt   float64
+
+	onSelect func(Process)
+}
+
+/*
+CreateProcessList creates a new process list.
+parent - is container that keeps the control.
+width and heigth - are minimal size of the control.
+scale - the way of scaling the control when the parent is resized. Use DoNotScale constant if the
+control should keep its original size.
+*/
+func CreateProcessList(parent Control, width, height int, scale int) *ProcessList {
+	p := new(ProcessList)
+
+	if height == AutoSize {
+		height = 5
+	}
+	if width == AutoSize {
+		width = 40
+	}
+
+	p.SetSize(width, height)
+	p.SetConstraints(width, height)
+	p.selectedCol = 0
+	p.selectedRow = 0
+	p.parent = parent
+	p.columns = []Column{
+		{Title: "PID", Width: 7, Alignment: AlignRight},
+		{Title: "CPU%", Width: 6, Alignment: AlignRight},
+		{Title: "MEM%", Width: 6, Alignment: AlignRight},
+		{Title: "Name", Width: 20, Alignment: AlignLeft},
+	}
+	p.fullRowSelect = true
+	p.warnAt, p.critAt = 50, 90
+	p.SetScale(scale)
+
+	p.SetTabStop(true)
+
+	p.lastEventCol = -1
+	p.lastEventRow = -1
+	p.TableView.OnDrawCell(p.drawCell)
+	p.TableView.OnAction(p.processAction)
+	p.TableView.OnSelectCell(p.selectCell)
+	p.OnMouseScroll(func(delta int) {
+		p.scrollRows(delta * wheelScrollLines)
+	})
+	p.SortBy(ProcessColumnCPU)
+
+	if parent != nil {
+		parent.AddChild(p)
+	}
+
+	return p
+}
+
+func (p *ProcessList) drawCell(info *ColumnDrawInfo) {
+	if info.Row < 0 || info.Row >= len(p.rows) {
+		return
+	}
+
+	proc := p.rows[info.Row]
+	switch info.Col {
+	case ProcessColumnPID:
+		info.Text = fmt.Sprintf("%d", proc.PID)
+	case ProcessColumnCPU:
+		info.Text = fmt.Sprintf("%.1f", proc.CPU)
+	case ProcessColumnMem:
+		info.Text = fmt.Sprintf("%.1f", proc.Mem)
+	case ProcessColumnName:
+		info.Text = proc.Name
+	}
+
+	if !info.RowSelected && !info.CellSelected {
+		info.Fg = p.usageColor(proc.CPU)
+	}
+}
+
+// usageColor returns the color of the zone the CPU usage belongs to
+func (p *ProcessList) usageColor(cpu float64) term.Attribute {
+	switch {
+	case p.critAt > 0 && cpu >= p.critAt:
+		return RealColor(ColorDefault, ColorProcessListCrit)
+	case p.warnAt > 0 && cpu >= p.warnAt:
+		return RealColor(ColorDefault, ColorProcessListWarn)
+	}
+	return RealColor(p.fg, ColorProcessListNormal)
+}
+
+func (p *ProcessList) processAction(ev TableEvent) {
+	if ev.Action != TableActionSort || ev.Col < 0 {
+		return
+	}
+
+	switch ev.Sort {
+	case SortNone:
+		p.sortRows(ProcessColumnPID, false)
+	default:
+		p.sortRows(ev.Col, ev.Sort == SortDesc)
+	}
+}
+
+func (p *ProcessList) selectCell(col, row int) {
+	if p.onSelect != nil && row >= 0 && row < len(p.rows) {
+		go p.onSelect(p.rows[row])
+	}
+}
+
+// sortRows changes the sort order and sorts the list
+func (p *ProcessList) sortRows(col int, desc bool) {
+	if col < ProcessColumnPID || col > ProcessColumnName {
+		return
+	}
+
+	p.sortCol, p.sortDesc = col, desc
+	for idx := range p.columns {
+		p.columns[idx].Sort = SortNone
+	}
+	if desc {
+		p.columns[col].Sort = SortDesc
+	} else {
+		p.columns[col].Sort = SortAsc
+	}
+	p.refresh()
+}
+
+// less compares two processes by the sort column. Processes with equal
+// values are ordered by PID
+func (p *ProcessList) less(a, b Process) bool {
+	if p.sortDesc {
+		a, b = b, a
+	}
+
+	switch p.sortCol {
+	case ProcessColumnCPU:
+		if a.CPU != b.CPU {
+			return a.CPU < b.CPU
+		}
+	case ProcessColumnMem:
+		if a.Mem != b.Mem {
+			return a.Mem < b.Mem
+		}
+	case ProcessColumnName:
+		if c := strings.Compare(a.Name, b.Name); c != 0 {
+			return c < 0
+		}
+	}
+	return a.PID < b.PID
+}
+
+// refresh applies the filter and the sort order to the process list.
+// The selected process stays selected if it passes the filter
+func (p *ProcessList) refresh() {
+	pid, selected := -1, false
+	if p.selectedRow >= 0 && p.selectedRow < len(p.rows) {
+		pid, selected = p.rows[p.selectedRow].PID, true
+	}
+
+	p.rows = make([]Process, 0, len(p.all))
+	for _, proc := range p.all {
+		if p.filter == nil || p.filter(proc) {
+			p.rows = append(p.rows, proc)
+		}
+	}
+	sort.SliceStable(p.rows, func(i, j int) bool {
+		return p.less(p.rows[i], p.rows[j])
+	})
+	p.rowCount = len(p.rows)
+
+	if selected {
+		for idx, proc := range p.rows {
+			if proc.PID == pid {
+				p.selectedRow = idx
+				p.lastEventRow = idx
+				p.EnsureRowVisible()
+				return
+			}
+		}
+	}
+	if p.selectedRow >= p.rowCount {
+		p.selectedRow = p.rowCount - 1
+	}
+	if p.selectedRow < 0 && p.rowCount > 0 {
+		p.selectedRow = 0
+	}
+	p.lastEventRow = -1
+	p.emitSelectionChange()
+}
+
+// SetData replaces the list of processes
+func (p *ProcessList) SetData(procs []Process) {
+	p.all = make([]Process, len(procs))
+	copy(p.all, procs)
+	p.refresh()
+}
+
+// Processes returns the displayed processes in the displayed order
+func (p *ProcessList) Processes() []Process {
+	return append([]Process(nil), p.rows...)
+}
+
+// SortBy sorts the list by the column: ProcessColumnCPU and
+// ProcessColumnMem in descending order, ProcessColumnPID and
+// ProcessColumnName in ascending one
+func (p *ProcessList) SortBy(column int) {
+	p.sortRows(column, column == ProcessColumnCPU || column == ProcessColumnMem)
+}
+
+// SetFilter sets the function that decides which processes are
+// displayed: only the processes for which fn returns true are in
+// the list. Use nil to display all processes
+func (p *ProcessList) SetFilter(fn func(Process) bool) {
+	p.filter = fn
+	p.refresh()
+}
+
+// SelectedProcess returns the selected process. The second value is
+// false if no process is selected
+func (p *ProcessList) SelectedProcess() (Process, bool) {
+	if p.selectedRow < 0 || p.selectedRow >= len(p.rows) {
+		return Process{}, false
+	}
+	return p.rows[p.selectedRow], true
+}
+
+// WarnAt returns the CPU usage from which rows are displayed with
+// warning color
+func (p *ProcessList) WarnAt() float64 {
+	return p.warnAt
+}
+
+// SetWarnAt changes the CPU usage from which rows are displayed with
+// warning color. The default is 50
+func (p *ProcessList) SetWarnAt(cpu float64) {
+	p.warnAt = cpu
+}
+
+// CritAt returns the CPU usage from which rows are displayed with
+// critical color
+func (p *ProcessList) CritAt() float64 {
+	return p.critAt
+}
+
+// SetCritAt changes the CPU usage from which rows are displayed with
+// critical color. The default is 90
+func (p *ProcessList) SetCritAt(cpu float64) {
+	p.critAt = cpu
+}
+
+// OnSelect sets a callback that is called every time the selected
+// row is changed
+func (p *ProcessList) OnSelect(fn func(Process)) {
+	p.onSelect = fn
+}
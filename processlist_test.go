@@ -0,0 +1,88 @@
+package clui
+
+import (
+	term "github.com/nsf/termbox-go"
+	"testing"
+	"time"
+)
+
+func TestProcessListSort(t *testing.T) {
+	initThemeManager()
+	list := CreateProcessList(nil, 40, 6, Fixed)
+	list.SetData([]Process{
+		{PID: 10, CPU: 5, Mem: 30, Name: "bash"},
+		{PID: 2, CPU: 95, Mem: 1, Name: "yes"},
+		{PID: 7, CPU: 60, Mem: 10, Name: "go"},
+	})
+
+	pids := func() []int {
+		var res []int
+		for _, p := range list.Processes() {
+			res = append(res, p.PID)
+		}
+		return res
+	}
+	check := func(name string, want ...int) {
+		t.Helper()
+		got := pids()
+		if len(got) != len(want) {
+			t.Errorf("%v: %v, want %v", name, got, want)
+			return
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("%v: %v, want %v", name, got, want)
+				return
+			}
+		}
+	}
+
+	check("CPU", 2, 7, 10)
+	list.SortBy(ProcessColumnMem)
+	check("Mem", 10, 7, 2)
+	list.SortBy(ProcessColumnName)
+	check("Name", 10, 7, 2)
+	list.SortBy(ProcessColumnPID)
+	check("PID", 2, 7, 10)
+
+	list.SetActive(true)
+	list.ProcessEvent(Event{Type: EventKey, Key: term.KeyArrowDown})
+	list.SetFilter(func(p Process) bool { return p.CPU > 10 })
+	check("Filter", 2, 7)
+	if p, ok := list.SelectedProcess(); !ok || p.PID != 7 {
+		t.Errorf("Selected process %v, want 7", p.PID)
+	}
+}
+
+func TestProcessListColors(t *testing.T) {
+	initThemeManager()
+	list := CreateProcessList(nil, 40, 6, Fixed)
+	list.SetData([]Process{{PID: 1, CPU: 95}, {PID: 2, CPU: 60}, {PID: 3, CPU: 5}})
+
+	for row, clr := range []string{ColorProcessListCrit, ColorProcessListWarn, ColorProcessListNormal} {
+		info := ColumnDrawInfo{Row: row, Col: ProcessColumnName}
+		list.drawCell(&info)
+		if info.Fg != RealColor(ColorDefault, clr) {
+			t.Errorf("Row %v color %v, want %v", row, info.Fg, clr)
+		}
+	}
+}
+
+func TestProcessListSelect(t *testing.T) {
+	list := CreateProcessList(nil, 40, 6, Fixed)
+	list.SetData([]Process{{PID: 1, CPU: 20}, {PID: 2, CPU: 10}})
+
+	selected := make(chan Process, 1)
+	list.OnSelect(func(p Process) { selected <- p })
+	list.SetActive(true)
+	list.ProcessEvent(Event{Type: EventKey, Key: term.KeyArrowDown})
+
+	select {
+	case p := <-selected:
+		if p.PID != 2 {
+			t.Errorf("Selected process %v, want 2", p.PID)
+		}
+	case <-time.After(time.Second):
+		t.Error("OnSelect was not called")
+	}
+}
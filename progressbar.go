@@ -3,22 +3,54 @@ package clui
 import (
 	xs "github.com/huandu/xstrings"
 	term "github.com/nsf/termbox-go"
+	"fmt"
 	"strconv"
 	"strings"
+	"time"
 )
 
+// etaSamples is the number of recent progress changes used to
+// calculate the progress rate for ETA
+const etaSamples = 10
+
+// progressSample is a ProgressBar value and the time it was set
+type progressSample struct {
+	at    time.Time
+	value int
+}
+
 /*
 ProgressBar control visualizes the progression of extended operation.
 
 The control has two sets of colors(almost all other controls have only
 one set: foreground and background colors): for filled part and for
 empty one. By default colors are the same.
+
+ProgressBar can display a secondary progress(see SetBuffer), e.g, how
+much data is downloaded ahead of playback. The buffered part is drawn
+after the filled part with a separate color.
+
+Horizontal ProgressBar can display the estimated time remaining(see
+SetShowETA). The estimation uses the average rate of the last few
+value changes, so it adapts when the operation speeds up or slows down.
+
+The characters for filled and empty parts are taken from theme objects
+ProgressBarFill and ProgressBarEmpty. If a theme does not define them
+then the characters of ProgressBar object are used. SetFillChar and
+SetEmptyChar override the theme for a single ProgressBar.
 */
 type ProgressBar struct {
 	BaseControl
+	// data source attached with Bind
+	bound            binding
 	direction        Direction
 	min, max         int
 	value            int
+	buffer           float64
+	showETA          bool
+	fillChar         rune
+	emptyChar        rune
+	samples          []progressSample
 	emptyFg, emptyBg term.Attribute
 	titleFg          term.Attribute
 }
@@ -67,6 +99,9 @@ func CreateProgressBar(parent Control, width, height int, scale int) *ProgressBa
 // value - raw ProgressBar value
 // min - lower ProgressBar limit
 // max - upper ProgressBar limit
+// eta - estimated time remaining, e.g "~2m30s"
+// If ETA is enabled and the title does not contain eta variable
+// the estimation is appended to the title if it fits the bar.
 // Examples:
 //      pb.SetTitle("{{value}} of {{max}}")
 //      pb.SetTitle("{{percent}}%")
@@ -80,41 +115,53 @@ func (b *ProgressBar) Draw() {
 
 	fgOff, fgOn := RealColor(b.fg, ColorProgressText), RealColor(b.fgActive, ColorProgressActiveText)
 	bgOff, bgOn := RealColor(b.bg, ColorProgressBack), RealColor(b.bgActive, ColorProgressActiveBack)
+	fgBuf := RealColor(ColorDefault, ColorProgressBuffer)
 
-	parts := []rune(SysObject(ObjProgressBar))
-	cFilled, cEmpty := parts[0], parts[1]
+	cFilled, cEmpty := b.chars()
 
-	prc := 0
-	if b.value >= b.max {
-		prc = 100
-	} else if b.value < b.max && b.value > b.min {
-		prc = (100 * (b.value - b.min)) / (b.max - b.min)
+	prc := b.percent(float64(b.value))
+	bufPrc := b.percent(b.buffer)
+	if bufPrc < prc {
+		bufPrc = prc
 	}
 
+	x, y := b.Pos()
+	w, h := b.Size()
+
 	var title string
-	if b.direction == Horizontal && b.Title() != "" {
+	if b.direction == Horizontal && (b.Title() != "" || b.showETA) {
+		eta := b.ETA()
 		title = b.Title()
+		if b.showETA && eta != "" && !strings.Contains(title, "{{eta}}") {
+			if title == "" {
+				title = eta
+			} else if xs.Len(title)+xs.Len(eta)+1 <= w {
+				title += " " + eta
+			}
+		}
 		title = strings.Replace(title, "{{percent}}", strconv.Itoa(prc), -1)
 		title = strings.Replace(title, "{{value}}", strconv.Itoa(b.value), -1)
 		title = strings.Replace(title, "{{min}}", strconv.Itoa(b.min), -1)
 		title = strings.Replace(title, "{{max}}", strconv.Itoa(b.max), -1)
+		title = strings.Replace(title, "{{eta}}", eta, -1)
 	}
 
-	x, y := b.Pos()
-	w, h := b.Size()
-
 	if b.direction == Horizontal {
 		filled := prc * w / 100
+		buffered := bufPrc*w/100 - filled
 		sFilled := strings.Repeat(string(cFilled), filled)
-		sEmpty := strings.Repeat(string(cEmpty), w-filled)
+		sBuffer := strings.Repeat(string(cFilled), buffered)
+		sEmpty := strings.Repeat(string(cEmpty), w-filled-buffered)
 
 		for yy := y; yy < y+h; yy++ {
 			SetTextColor(fgOn)
 			SetBackColor(bgOn)
 			DrawRawText(x, yy, sFilled)
-			SetTextColor(fgOff)
+			SetTextColor(fgBuf)
 			SetBackColor(bgOff)
-			DrawRawText(x+filled, yy, sEmpty)
+			DrawRawText(x+filled, yy, sBuffer)
+			SetTextColor(fgOff)
+			DrawRawText(x+filled+buffered, yy, sEmpty)
 		}
 
 		if title != "" {
@@ -142,13 +189,19 @@ func (b *ProgressBar) Draw() {
 		}
 	} else {
 		filled := prc * h / 100
+		buffered := bufPrc*h/100 - filled
 		sFilled := strings.Repeat(string(cFilled), w)
 		sEmpty := strings.Repeat(string(cEmpty), w)
-		for yy := y; yy < y+h-filled; yy++ {
+		for yy := y; yy < y+h-filled-buffered; yy++ {
 			SetTextColor(fgOff)
 			SetBackColor(bgOff)
 			DrawRawText(x, yy, sEmpty)
 		}
+		for yy := y + h - filled - buffered; yy < y+h-filled; yy++ {
+			SetTextColor(fgBuf)
+			SetBackColor(bgOff)
+			DrawRawText(x, yy, sFilled)
+		}
 		for yy := y + h - filled; yy < y+h; yy++ {
 			SetTextColor(fgOff)
 			SetBackColor(bgOff)
@@ -157,6 +210,39 @@ func (b *ProgressBar) Draw() {
 	}
 }
 
+// chars returns characters to draw filled and empty parts
+func (b *ProgressBar) chars() (rune, rune) {
+	parts := []rune(SysObject(ObjProgressBar))
+	cFilled, cEmpty := parts[0], parts[1]
+
+	if fill := []rune(SysObject(ObjProgressBarFill)); len(fill) > 0 {
+		cFilled = fill[0]
+	}
+	if empty := []rune(SysObject(ObjProgressBarEmpty)); len(empty) > 0 {
+		cEmpty = empty[0]
+	}
+	if b.fillChar != 0 {
+		cFilled = b.fillChar
+	}
+	if b.emptyChar != 0 {
+		cEmpty = b.emptyChar
+	}
+
+	return cFilled, cEmpty
+}
+
+// percent returns how far the value is from the lower limit
+// in percents
+func (b *ProgressBar) percent(v float64) int {
+	if v >= float64(b.max) {
+		return 100
+	}
+	if v <= float64(b.min) {
+		return 0
+	}
+	return int(100 * (v - float64(b.min)) / float64(b.max-b.min))
+}
+
 //----------------- own methods -------------------------
 
 // SetValue sets new progress value. If value exceeds ProgressBar
@@ -169,6 +255,7 @@ func (b *ProgressBar) SetValue(pos int) {
 	} else {
 		b.value = pos
 	}
+	b.addSample(time.Now())
 }
 
 // Value returns the current ProgressBar value
@@ -193,6 +280,7 @@ func (b *ProgressBar) SetLimits(min, max int) {
 	if b.value > b.max {
 		b.value = max
 	}
+	b.SetBuffer(b.buffer)
 }
 
 // Step increases ProgressBar value by 1 if the value is less
@@ -203,10 +291,130 @@ func (b *ProgressBar) Step() int {
 	if b.value > b.max {
 		b.value = b.max
 	}
+	b.addSample(time.Now())
 
 	return b.value
 }
 
+// addSample remembers the current value for ETA calculation
+func (b *ProgressBar) addSample(at time.Time) {
+	if !b.showETA {
+		return
+	}
+
+	b.samples = append(b.samples, progressSample{at: at, value: b.value})
+	if len(b.samples) > etaSamples {
+		b.samples = b.samples[len(b.samples)-etaSamples:]
+	}
+}
+
+// remaining returns the estimated time to reach the upper limit.
+// It returns false if there is not enough data for the estimation
+func (b *ProgressBar) remaining() (time.Duration, bool) {
+	if len(b.samples) < 2 {
+		return 0, false
+	}
+
+	first, last := b.samples[0], b.samples[len(b.samples)-1]
+	done := last.value - first.value
+	elapsed := last.at.Sub(first.at)
+	if done <= 0 || elapsed <= 0 {
+		return 0, false
+	}
+
+	left := b.max - last.value
+	return time.Duration(float64(elapsed) * float64(left) / float64(done)), true
+}
+
+// formatETA converts the duration to a short string like "~2m30s"
+func formatETA(d time.Duration) string {
+	secs := int((d + time.Second/2) / time.Second)
+	switch {
+	case secs >= 3600:
+		return fmt.Sprintf("~%dh%02dm", secs/3600, secs%3600/60)
+	case secs >= 60:
+		return fmt.Sprintf("~%dm%02ds", secs/60, secs%60)
+	}
+	return fmt.Sprintf("~%ds", secs)
+}
+
+// ETA returns the estimated time remaining, e.g "~2m30s". It
+// returns empty string if ETA is disabled or there is not enough
+// data for the estimation
+func (b *ProgressBar) ETA() string {
+	if !b.showETA {
+		return ""
+	}
+
+	d, ok := b.remaining()
+	if !ok {
+		return ""
+	}
+	return formatETA(d)
+}
+
+// ShowETA returns true if ProgressBar displays the estimated
+// time remaining
+func (b *ProgressBar) ShowETA() bool {
+	return b.showETA
+}
+
+// SetShowETA enables or disables displaying the estimated time
+// remaining. Enabling starts a new measurement
+func (b *ProgressBar) SetShowETA(show bool) {
+	if show && !b.showETA {
+		b.samples = nil
+	}
+	b.showETA = show
+}
+
+// ResetETA restarts the time measurement. It should be called,
+// e.g, when a new operation starts with the same ProgressBar
+func (b *ProgressBar) ResetETA() {
+	b.samples = nil
+}
+
+// Buffer returns the current secondary progress value
+func (b *ProgressBar) Buffer() float64 {
+	return b.buffer
+}
+
+// SetBuffer sets the secondary progress value. If the value exceeds
+// ProgressBar limits then the limit value is used. The buffered part
+// is visible only if the value is greater than the primary one
+func (b *ProgressBar) SetBuffer(v float64) {
+	if v < float64(b.min) {
+		v = float64(b.min)
+	} else if v > float64(b.max) {
+		v = float64(b.max)
+	}
+	b.buffer = v
+}
+
+// FillChar returns the custom character for filled part or 0
+// if the theme character is used
+func (b *ProgressBar) FillChar() rune {
+	return b.fillChar
+}
+
+// SetFillChar sets the character for filled part. Use 0 to
+// return to the theme character
+func (b *ProgressBar) SetFillChar(ch rune) {
+	b.fillChar = ch
+}
+
+// EmptyChar returns the custom character for empty part or 0
+// if the theme character is used
+func (b *ProgressBar) EmptyChar() rune {
+	return b.emptyChar
+}
+
+// SetEmptyChar sets the character for empty part. Use 0 to
+// return to the theme character
+func (b *ProgressBar) SetEmptyChar(ch rune) {
+	b.emptyChar = ch
+}
+
 // SecondaryColors returns text and background colors for empty
 // part of the ProgressBar
 func (b *ProgressBar) SecondaryColors() (term.Attribute, term.Attribute) {
@@ -0,0 +1,112 @@
+package clui
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProgressBarBuffer(t *testing.T) {
+	pb := CreateProgressBar(nil, 10, 1, Fixed)
+	pb.SetLimits(0, 20)
+	pb.SetValue(5)
+
+	pb.SetBuffer(30)
+	if pb.Buffer() != 20 {
+		t.Errorf("Buffer must be limited by max: %v", pb.Buffer())
+	}
+	pb.SetBuffer(-1)
+	if pb.Buffer() != 0 {
+		t.Errorf("Buffer must be limited by min: %v", pb.Buffer())
+	}
+
+	pb.SetBuffer(12.5)
+	if prc := pb.percent(pb.Buffer()); prc != 62 {
+		t.Errorf("Invalid buffer percent %v", prc)
+	}
+
+	parts := []rune(SysObject(ObjProgressBar))
+	expected := string([]rune{parts[0], parts[0], parts[0], parts[0], parts[0], parts[0],
+		parts[1], parts[1], parts[1], parts[1]})
+	if out := renderToString(pb); out != expected {
+		t.Errorf("Invalid output %q", out)
+	}
+
+	pb.SetLimits(0, 10)
+	if pb.Buffer() != 10 {
+		t.Errorf("Buffer must be adjusted to new limits: %v", pb.Buffer())
+	}
+}
+
+func TestProgressBarETA(t *testing.T) {
+	pb := CreateProgressBar(nil, 20, 1, Fixed)
+	pb.SetLimits(0, 100)
+	pb.SetShowETA(true)
+
+	start := time.Now()
+	pb.value = 0
+	pb.addSample(start)
+	if pb.ETA() != "" {
+		t.Errorf("ETA requires at least two values: %q", pb.ETA())
+	}
+
+	// the first half is slow, then the rate increases
+	for i := 1; i <= 10; i++ {
+		pb.value = i
+		pb.addSample(start.Add(time.Duration(i) * 10 * time.Second))
+	}
+	for i := 1; i <= 10; i++ {
+		pb.value = 10 + 5*i
+		pb.addSample(start.Add(100*time.Second + time.Duration(i)*time.Second))
+	}
+
+	// the last samples: 5 units per second, 40 units left
+	if eta := pb.ETA(); eta != "~8s" {
+		t.Errorf("Invalid ETA %q", eta)
+	}
+
+	pb.SetTitle("{{percent}}%")
+	if out := renderToString(pb); !strings.Contains(out, "60% ~8s") {
+		t.Errorf("ETA must be appended to the title: %q", out)
+	}
+
+	pb.ResetETA()
+	if pb.ETA() != "" {
+		t.Errorf("ETA must be reset: %q", pb.ETA())
+	}
+}
+
+func TestFormatETA(t *testing.T) {
+	cases := map[time.Duration]string{
+		150 * time.Second:              "~2m30s",
+		45 * time.Second:               "~45s",
+		time.Hour + 5*time.Minute + 20: "~1h05m",
+	}
+	for d, expected := range cases {
+		if s := formatETA(d); s != expected {
+			t.Errorf("formatETA(%v) = %q, expected %q", d, s, expected)
+		}
+	}
+}
+
+func TestProgressBarChars(t *testing.T) {
+	pb := CreateProgressBar(nil, 4, 1, Fixed)
+	pb.SetLimits(0, 4)
+	pb.SetValue(2)
+
+	parts := []rune(SysObject(ObjProgressBar))
+	if fill, empty := pb.chars(); fill != parts[0] || empty != parts[1] {
+		t.Errorf("Theme characters must be used by default: %q %q", fill, empty)
+	}
+
+	pb.SetFillChar('=')
+	pb.SetEmptyChar('-')
+	if out := renderToString(pb); out != "==--" {
+		t.Errorf("Invalid output %q", out)
+	}
+
+	pb.SetFillChar(0)
+	if fill, _ := pb.chars(); fill != parts[0] {
+		t.Errorf("Zero must restore the theme character: %q", fill)
+	}
+}
@@ -83,8 +83,7 @@ func (c *Radio) Draw() {
 		return
 	}
 
-	shift, text := AlignColorizedText(c.title, w-4, c.align)
-	DrawText(x+4+shift, y, text)
+	DrawTextAligned(x+4, y, w-4, c.title, c.align)
 }
 
 /*
@@ -0,0 +1,43 @@
+package clui
+
+import (
+	term "github.com/nsf/termbox-go"
+	"os"
+	"runtime"
+	"strings"
+)
+
+// rgbFlag is the bit termbox sets in all RGB attributes
+var rgbFlag = term.RGBToAttribute(0, 0, 0)
+
+// attrMask keeps only modifiers of an attribute like bold or underline
+const attrMask = (term.AttrReverse<<1 - 1) &^ colorMask
+
+// RGBColor returns the attribute for the 24-bit color. RGB colors are
+// displayed as is only if the terminal supports true color. Otherwise,
+// RealColor replaces them with the closest color from 256-color palette
+// or with the closest basic color. Combine the result with modifiers
+// as usual, e.g: RGBColor(255, 128, 0) | term.AttrBold
+func RGBColor(r, g, b uint8) term.Attribute {
+	return term.RGBToAttribute(r, g, b)
+}
+
+// isRGBColor returns true if the attribute is created with RGBColor
+func isRGBColor(clr term.Attribute) bool {
+	return clr&rgbFlag != 0
+}
+
+// TermSupportsRGB returns true if the terminal can display 24-bit
+// colors. The check is based on COLORTERM environment variable
+func TermSupportsRGB() bool {
+	if runtime.GOOS == "windows" {
+		return false
+	}
+	ct := strings.ToLower(os.Getenv("COLORTERM"))
+	return ct == "truecolor" || ct == "24bit"
+}
+
+// SetRGBColor sets the theme color to the 24-bit color
+func (t *Theme) SetRGBColor(id string, r, g, b uint8) {
+	t.SetColor(id, RGBColor(r, g, b))
+}
@@ -0,0 +1,42 @@
+package clui
+
+import (
+	term "github.com/nsf/termbox-go"
+	"testing"
+)
+
+func TestRGBColor(t *testing.T) {
+	clr := RGBColor(255, 128, 0) | term.AttrBold
+	if !isRGBColor(clr) || isRGBColor(ColorBlack) || isRGBColor(Color256(255)) {
+		t.Error("Only RGBColor results must be RGB colors")
+	}
+	if s := ColorToString(clr); s != "#FF8000 bold" {
+		t.Errorf("Invalid string representation: %v", s)
+	}
+	if c := StringToColor("#ff8000 bold"); c != clr {
+		t.Errorf("Failed to parse RGB color: %v", c)
+	}
+
+	defer func(mode term.OutputMode) { colorMode = mode }(colorMode)
+
+	colorMode = term.OutputRGB
+	if c := adjustColor(clr); c != clr {
+		t.Errorf("RGB color must not change in true color mode: %v", c)
+	}
+	if c := adjustColor(ColorRed | term.AttrUnderline); c != RGBColor(128, 0, 0)|term.AttrUnderline {
+		t.Errorf("Basic color must be converted to RGB: %v", c)
+	}
+	if c := adjustColor(ColorWhiteBold); c != RGBColor(255, 255, 255)|term.AttrBold {
+		t.Errorf("Bold basic color must be bright: %v", c)
+	}
+
+	colorMode = term.Output256
+	if c := adjustColor(clr); c != Color256(208)|term.AttrBold {
+		t.Errorf("RGB color must be converted to palette: %v", c)
+	}
+
+	colorMode = term.OutputNormal
+	if c := adjustColor(RGBColor(250, 10, 10)); c != term.ColorLightRed {
+		t.Errorf("RGB color must be converted to basic color: %v", c)
+	}
+}
@@ -0,0 +1,335 @@
+package clui
+
+import (
+	xs "github.com/huandu/xstrings"
+	term "github.com/nsf/termbox-go"
+	"math"
+)
+
+// scatterPoint is a point of ScatterPlot with its label
+type scatterPoint struct {
+	x, y  float64
+	label string
+}
+
+// scatterSeries is a named list of points drawn with the same
+// character and color
+type scatterSeries struct {
+	name   string
+	ch     rune
+	color  term.Attribute
+	points []scatterPoint
+}
+
+/*
+ScatterPlot is a chart that displays two-dimensional points. Every
+point is drawn with a marker character in the character cell that
+is the nearest to the point coordinates. Points added with AddPoint
+belong to the default series that is drawn with PointChar and
+ColorScatterPlotPoint color. AddSeries creates a named series with
+its own marker and color.
+By default the plot scales automatically to make all points fit
+the chart. SetXRange and SetYRange set the fixed ranges, points
+outside them are not displayed.
+If ShowAxes is true, the chart displays the Y axis on the left and
+the X axis at the bottom with tick marks at regular intervals. If
+ShowLabels is true, the point label is drawn to the right from the
+marker if there is enough free space for it
+*/
+type ScatterPlot struct {
+	BaseControl
+	series     []scatterSeries
+	autoRange  bool
+	xMin, xMax float64
+	yMin, yMax float64
+	showAxes   bool
+	showLabels bool
+}
+
+// distance between tick marks on axes
+const (
+	scatterTickX = 4
+	scatterTickY = 2
+)
+
+/*
+CreateScatterPlot creates a new scatter plot.
+parent - is container that keeps the control.
+w and h - are minimal size of the control.
+scale - the way of scaling the control when the parent is resized. Use DoNotScale constant if the
+control should keep its original size.
+*/
+func CreateScatterPlot(parent Control, w, h int, scale int) *ScatterPlot {
+	c := new(ScatterPlot)
+
+	if w == AutoSize {
+		w = 20
+	}
+	if h == AutoSize {
+		h = 10
+	}
+
+	c.parent = parent
+
+	c.SetSize(w, h)
+	c.SetConstraints(w, h)
+	c.tabSkip = true
+	c.autoRange = true
+	// the default series for AddPoint
+	c.series = []scatterSeries{{ch: '•', color: ColorDefault}}
+	c.SetScale(scale)
+
+	if parent != nil {
+		parent.AddChild(c)
+	}
+
+	return c
+}
+
+// Draw repaints the control on its View surface
+func (p *ScatterPlot) Draw() {
+	PushAttributes()
+	defer PopAttributes()
+
+	fg, bg := RealColor(p.fg, ColorScatterPlotText), RealColor(p.bg, ColorScatterPlotBack)
+	SetTextColor(fg)
+	SetBackColor(bg)
+	FillRect(p.x, p.y, p.width, p.height, ' ')
+
+	left, width, height := p.plotArea()
+	if width < 1 || height < 1 {
+		return
+	}
+	if p.showAxes {
+		p.drawAxes(left, width, height)
+	}
+
+	xMin, xMax, yMin, yMax := p.ranges()
+	used := make([]bool, width*height)
+	type label struct {
+		col, row int
+		text     string
+	}
+	var labels []label
+
+	defClr := RealColor(ColorDefault, ColorScatterPlotPoint)
+	for _, s := range p.series {
+		clr := s.color
+		if clr == ColorDefault {
+			clr = defClr
+		}
+		SetTextColor(clr)
+
+		for _, pt := range s.points {
+			if pt.x < xMin || pt.x > xMax || pt.y < yMin || pt.y > yMax {
+				continue
+			}
+			col := scatterCell(pt.x-xMin, xMax-xMin, width)
+			row := height - 1 - scatterCell(pt.y-yMin, yMax-yMin, height)
+			used[row*width+col] = true
+			PutChar(p.x+left+col, p.y+row, s.ch)
+			if pt.label != "" {
+				labels = append(labels, label{col: col + 1, row: row, text: pt.label})
+			}
+		}
+	}
+
+	if !p.showLabels {
+		return
+	}
+
+	SetTextColor(fg)
+	for _, l := range labels {
+		length := xs.Len(l.text)
+		if l.col+length > width {
+			continue
+		}
+		free := true
+		for dx := 0; dx < length && free; dx++ {
+			free = !used[l.row*width+l.col+dx]
+		}
+		if !free {
+			continue
+		}
+		for dx := 0; dx < length; dx++ {
+			used[l.row*width+l.col+dx] = true
+		}
+		DrawRawText(p.x+left+l.col, p.y+l.row, l.text)
+	}
+}
+
+// scatterCell returns the index of the nearest of count cells for
+// the offset from the range start
+func scatterCell(offset, length float64, count int) int {
+	return int(math.Round(offset / length * float64(count-1)))
+}
+
+// plotArea returns the first column, width, and height of the area
+// where points are drawn. Axes are outside the area
+func (p *ScatterPlot) plotArea() (int, int, int) {
+	if p.showAxes {
+		return 1, p.width - 1, p.height - 1
+	}
+	return 0, p.width, p.height
+}
+
+// drawAxes draws the Y axis to the left of the plot area and the X
+// axis under it. The first tick marks are at the range start
+func (p *ScatterPlot) drawAxes(left, width, height int) {
+	parts := []rune(SysObject(ObjBarChart))
+	cH, cV, cC := parts[1], parts[2], parts[5]
+	tickX, tickY := parts[7], parts[10]
+
+	for dy := 0; dy < height; dy++ {
+		c := cV
+		if (height-1-dy)%scatterTickY == 0 {
+			c = tickY
+		}
+		PutChar(p.x+left-1, p.y+dy, c)
+	}
+	for dx := 0; dx < width; dx++ {
+		c := cH
+		if dx%scatterTickX == 0 {
+			c = tickX
+		}
+		PutChar(p.x+left+dx, p.y+height, c)
+	}
+	PutChar(p.x+left-1, p.y+height, cC)
+}
+
+// ranges returns the displayed X and Y ranges
+func (p *ScatterPlot) ranges() (float64, float64, float64, float64) {
+	if !p.autoRange {
+		return p.xMin, p.xMax, p.yMin, p.yMax
+	}
+
+	found := false
+	var xMin, xMax, yMin, yMax float64
+	for _, s := range p.series {
+		for _, pt := range s.points {
+			if !found {
+				xMin, xMax, yMin, yMax = pt.x, pt.x, pt.y, pt.y
+				found = true
+				continue
+			}
+			xMin, xMax = math.Min(xMin, pt.x), math.Max(xMax, pt.x)
+			yMin, yMax = math.Min(yMin, pt.y), math.Max(yMax, pt.y)
+		}
+	}
+
+	if xMin == xMax {
+		xMin, xMax = xMin-1, xMax+1
+	}
+	if yMin == yMax {
+		yMin, yMax = yMin-1, yMax+1
+	}
+	return xMin, xMax, yMin, yMax
+}
+
+// AddPoint adds a point to the default series. The label is displayed
+// if ShowLabels is true
+func (p *ScatterPlot) AddPoint(x, y float64, label string) {
+	p.AddSeriesPoint(0, x, y, label)
+}
+
+// AddSeries adds a new empty series to the chart. Points of the
+// series are drawn with ch and color. Use ColorDefault to draw
+// them with ColorScatterPlotPoint color. The function returns the
+// identifier that is used to add points to the series
+func (p *ScatterPlot) AddSeries(name string, ch rune, color term.Attribute) SeriesID {
+	p.series = append(p.series, scatterSeries{name: name, ch: ch, color: color})
+	return SeriesID(len(p.series) - 1)
+}
+
+// AddSeriesPoint adds a point to the series. The function does
+// nothing if the series does not exist
+func (p *ScatterPlot) AddSeriesPoint(id SeriesID, x, y float64, label string) {
+	if id < 0 || int(id) >= len(p.series) {
+		return
+	}
+
+	p.series[id].points = append(p.series[id].points, scatterPoint{x: x, y: y, label: label})
+}
+
+// ClearPoints removes points of all series. Series are kept
+func (p *ScatterPlot) ClearPoints() {
+	for i := range p.series {
+		p.series[i].points = nil
+	}
+}
+
+// PointChar returns the marker of the default series points
+func (p *ScatterPlot) PointChar() rune {
+	return p.series[0].ch
+}
+
+// SetPointChar changes the marker of the default series points
+func (p *ScatterPlot) SetPointChar(ch rune) {
+	p.series[0].ch = ch
+}
+
+// AutoRange returns if the plot scales automatically to display
+// all points
+func (p *ScatterPlot) AutoRange() bool {
+	return p.autoRange
+}
+
+// SetAutoRange turns on and off automatic scaling. If it is off, the
+// plot uses ranges set by SetXRange and SetYRange
+func (p *ScatterPlot) SetAutoRange(auto bool) {
+	p.autoRange = auto
+}
+
+// XRange returns the values at the left and the right edges of the
+// plot when automatic scaling is off
+func (p *ScatterPlot) XRange() (float64, float64) {
+	return p.xMin, p.xMax
+}
+
+// SetXRange sets the values at the left and the right edges of the
+// plot and turns automatic scaling off. The call is ignored if min
+// is not less than max
+func (p *ScatterPlot) SetXRange(min, max float64) {
+	if min >= max {
+		return
+	}
+	p.xMin, p.xMax = min, max
+	p.autoRange = false
+}
+
+// YRange returns the values at the bottom and the top of the plot
+// when automatic scaling is off
+func (p *ScatterPlot) YRange() (float64, float64) {
+	return p.yMin, p.yMax
+}
+
+// SetYRange sets the values at the bottom and the top of the plot
+// and turns automatic scaling off. The call is ignored if min is not
+// less than max
+func (p *ScatterPlot) SetYRange(min, max float64) {
+	if min >= max {
+		return
+	}
+	p.yMin, p.yMax = min, max
+	p.autoRange = false
+}
+
+// ShowLabels returns if point labels are displayed
+func (p *ScatterPlot) ShowLabels() bool {
+	return p.showLabels
+}
+
+// SetShowLabels turns on and off point labels
+func (p *ScatterPlot) SetShowLabels(show bool) {
+	p.showLabels = show
+}
+
+// ShowAxes returns if the plot displays X and Y axes
+func (p *ScatterPlot) ShowAxes() bool {
+	return p.showAxes
+}
+
+// SetShowAxes turns on and off X and Y axes with tick marks
+func (p *ScatterPlot) SetShowAxes(show bool) {
+	p.showAxes = show
+}
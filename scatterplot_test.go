@@ -0,0 +1,36 @@
+package clui
+
+import (
+	"testing"
+)
+
+func TestScatterPlotDraw(t *testing.T) {
+	initThemeManager()
+	plot := CreateScatterPlot(nil, 7, 4, Fixed)
+	plot.SetShowAxes(true)
+	plot.SetShowLabels(true)
+	plot.AddPoint(0, 0, "a")
+	plot.AddPoint(10, 10, "b")
+	id := plot.AddSeries("other", 'x', ColorRed)
+	plot.AddSeriesPoint(id, 5, 5, "c")
+
+	// the label "b" does not fit the plot
+	want := "┤     •\n│   xc \n┤•a    \n└┬───┬─"
+	if got := renderToString(plot); got != want {
+		t.Errorf("Invalid scatter plot:\n%v", got)
+	}
+
+	plot.SetXRange(0, 5)
+	plot.SetYRange(0, 5)
+	plot.SetShowLabels(false)
+	want = "┤     x\n│      \n┤•     \n└┬───┬─"
+	if got := renderToString(plot); got != want {
+		t.Errorf("Invalid scatter plot with fixed range:\n%v", got)
+	}
+
+	plot.ClearPoints()
+	plot.SetAutoRange(true)
+	if got := renderToString(plot); got != "┤      \n│      \n┤      \n└┬───┬─" {
+		t.Errorf("Points are not cleared:\n%v", got)
+	}
+}
@@ -0,0 +1,527 @@
+package clui
+
+import (
+	term "github.com/nsf/termbox-go"
+	"sort"
+	"strings"
+)
+
+// StyledSpan is a part of a line that is displayed with its own
+// colors. ColorDefault means the control text or back color
+type StyledSpan struct {
+	Text string
+	Fg   term.Attribute
+	Bg   term.Attribute
+}
+
+/*
+ScrollableTextView is a control to display a read-only text that
+may contain thousands of lines, e.g. a log. The control draws only
+the lines that are visible, and appending a line does not make the
+control recalculate the whole text.
+If wrap mode is off long lines are clipped and the control can be
+scrolled horizontally. In wrap mode long lines are split at word
+boundaries - a word that is longer than the control width is split
+at the control edge.
+If follow mode is on the control scrolls to the last line every
+time a line is appended, so it is possible to tail a log.
+An optional syntax highlighter is called for every visible line
+and returns the list of colored spans the line consists of.
+Content is scrollable with arrow keys or by clicking the
+scrollbars.
+*/
+type ScrollableTextView struct {
+	BaseControl
+	lines   []string
+	lengths []int
+	// the first visual row of every line in wrap mode
+	rowStarts []int
+	totalRows int
+	// the text width that was used to calculate rowStarts
+	wrapWidth int
+	maxLength int
+
+	// the first visible line or the first visible row in wrap mode
+	topRow    int
+	leftShift int
+	wrap      bool
+	follow    bool
+
+	highlighter func(string) []StyledSpan
+}
+
+/*
+CreateScrollableTextView creates a new read-only text view.
+parent - is container that keeps the control.
+width and heigth - are minimal size of the control.
+scale - the way of scaling the control when the parent is resized. Use DoNotScale constant if the
+control should keep its original size.
+*/
+func CreateScrollableTextView(parent Control, width, height int, scale int) *ScrollableTextView {
+	l := new(ScrollableTextView)
+
+	if height == AutoSize {
+		height = 3
+	}
+	if width == AutoSize {
+		width = 5
+	}
+
+	l.SetSize(width, height)
+	l.SetConstraints(width, height)
+	l.parent = parent
+	l.lines = make([]string, 0)
+	l.lengths = make([]int, 0)
+
+	l.SetTabStop(true)
+	l.SetScale(scale)
+	l.OnMouseScroll(func(delta int) {
+		l.SetScrollOffset(l.topRow + delta*wheelScrollLines)
+	})
+
+	if parent != nil {
+		parent.AddChild(l)
+	}
+
+	return l
+}
+
+// wrapLine returns the positions where the rows of the wrapped
+// line start. An empty line takes one row
+func wrapLine(line []rune, width int) []int {
+	starts := []int{0}
+	if width < 1 {
+		return starts
+	}
+
+	start := 0
+	for len(line)-start > width {
+		// a space right after the last character that fits is
+		// a word boundary as well, so the loop starts from end
+		end := start + width
+		brk := end
+		for i := end; i > start; i-- {
+			if line[i] == ' ' {
+				brk = i + 1
+				break
+			}
+		}
+
+		starts = append(starts, brk)
+		start = brk
+	}
+
+	return starts
+}
+
+// textWidth returns the width of the area used to display text
+func (l *ScrollableTextView) textWidth() int {
+	return l.width - 1
+}
+
+func (l *ScrollableTextView) outputHeight() int {
+	h := l.height
+	if !l.wrap {
+		h--
+	}
+	return h
+}
+
+// calculateRows recalculates the number of rows of every line
+// if the text width has changed since the last calculation
+func (l *ScrollableTextView) calculateRows() {
+	w := l.textWidth()
+	if !l.wrap || w == l.wrapWidth {
+		return
+	}
+
+	topLine := l.lineByRow(l.topRow)
+	l.wrapWidth = w
+	l.rowStarts = make([]int, 0, len(l.lines))
+	l.totalRows = 0
+	for _, line := range l.lines {
+		l.addRows(line)
+	}
+	if topLine < len(l.rowStarts) {
+		l.topRow = l.rowStarts[topLine]
+	}
+}
+
+// addRows appends the rows of a new line in wrap mode
+func (l *ScrollableTextView) addRows(line string) {
+	l.rowStarts = append(l.rowStarts, l.totalRows)
+	l.totalRows += len(wrapLine([]rune(UnColorizeText(line)), l.wrapWidth))
+}
+
+// lineByRow returns the line that contains the visual row
+func (l *ScrollableTextView) lineByRow(row int) int {
+	if !l.wrap || len(l.rowStarts) == 0 {
+		return row
+	}
+
+	idx := sort.Search(len(l.rowStarts), func(i int) bool {
+		return l.rowStarts[i] > row
+	})
+	if idx > 0 {
+		idx--
+	}
+	return idx
+}
+
+// virtualHeight returns the total number of rows of the text
+func (l *ScrollableTextView) virtualHeight() int {
+	if l.wrap {
+		l.calculateRows()
+		return l.totalRows
+	}
+	return len(l.lines)
+}
+
+func (l *ScrollableTextView) maxOffset() int {
+	max := l.virtualHeight() - l.outputHeight()
+	if max < 0 {
+		max = 0
+	}
+	return max
+}
+
+// styledLine returns the characters of the line and their colors.
+// Colors are nil if highlighter is not set
+func (l *ScrollableTextView) styledLine(idx int) ([]rune, []term.Attribute, []term.Attribute) {
+	line := UnColorizeText(l.lines[idx])
+	if l.highlighter == nil {
+		return []rune(line), nil, nil
+	}
+
+	runes := make([]rune, 0, l.lengths[idx])
+	fgs := make([]term.Attribute, 0, l.lengths[idx])
+	bgs := make([]term.Attribute, 0, l.lengths[idx])
+	for _, span := range l.highlighter(line) {
+		for _, ch := range span.Text {
+			runes = append(runes, ch)
+			fgs = append(fgs, span.Fg)
+			bgs = append(bgs, span.Bg)
+		}
+	}
+	return runes, fgs, bgs
+}
+
+// drawRunes draws a part of a styled line starting from
+// character start. fg and bg are the default colors
+func (l *ScrollableTextView) drawRunes(x, y int, runes []rune, fgs, bgs []term.Attribute, start, end int, fg, bg term.Attribute) {
+	for i := start; i < end && i < len(runes); i++ {
+		f, b := fg, bg
+		if fgs != nil && fgs[i] != ColorDefault {
+			f = fgs[i]
+		}
+		if bgs != nil && bgs[i] != ColorDefault {
+			b = bgs[i]
+		}
+		SetTextColor(f)
+		SetBackColor(b)
+		PutChar(x+i-start, y, runes[i])
+	}
+}
+
+func (l *ScrollableTextView) drawText(fg, bg term.Attribute) {
+	PushAttributes()
+	defer PopAttributes()
+
+	maxWidth := l.textWidth()
+	maxHeight := l.outputHeight()
+	if maxWidth < 1 {
+		return
+	}
+
+	if !l.wrap {
+		for y := 0; y < maxHeight && l.topRow+y < len(l.lines); y++ {
+			runes, fgs, bgs := l.styledLine(l.topRow + y)
+			l.drawRunes(l.x, l.y+y, runes, fgs, bgs, l.leftShift, l.leftShift+maxWidth, fg, bg)
+		}
+		return
+	}
+
+	l.calculateRows()
+	lineID := l.lineByRow(l.topRow)
+	y := 0
+	for y < maxHeight && lineID < len(l.lines) {
+		runes, fgs, bgs := l.styledLine(lineID)
+		starts := wrapLine(runes, maxWidth)
+		for idx, start := range starts {
+			if l.rowStarts[lineID]+idx < l.topRow {
+				continue
+			}
+			if y >= maxHeight {
+				break
+			}
+
+			end := len(runes)
+			if idx < len(starts)-1 {
+				end = starts[idx+1]
+			}
+			if end > start+maxWidth {
+				end = start + maxWidth
+			}
+			l.drawRunes(l.x, l.y+y, runes, fgs, bgs, start, end, fg, bg)
+			y++
+		}
+		lineID++
+	}
+}
+
+func (l *ScrollableTextView) drawScrolls() {
+	height := l.outputHeight()
+	pos := ThumbPosition(l.topRow, l.virtualHeight()-height, height)
+	DrawScrollBar(l.x+l.width-1, l.y, 1, height, pos)
+
+	if !l.wrap {
+		pos = ThumbPosition(l.leftShift, l.maxLength-l.textWidth(), l.width-1)
+		DrawScrollBar(l.x, l.y+l.height-1, l.width-1, 1, pos)
+	}
+}
+
+// Draw repaints the control on its View surface
+func (l *ScrollableTextView) Draw() {
+	PushAttributes()
+	defer PopAttributes()
+
+	bg, fg := RealColor(l.bg, ColorEditBack), RealColor(l.fg, ColorEditText)
+	if l.Active() {
+		bg, fg = RealColor(l.bg, ColorEditActiveBack), RealColor(l.fg, ColorEditActiveText)
+	}
+
+	SetTextColor(fg)
+	SetBackColor(bg)
+	FillRect(l.x, l.y, l.width, l.height, ' ')
+	l.drawText(fg, bg)
+	l.drawScrolls()
+}
+
+func (l *ScrollableTextView) moveUp(dy int) {
+	l.SetScrollOffset(l.topRow - dy)
+}
+
+func (l *ScrollableTextView) moveDown(dy int) {
+	l.SetScrollOffset(l.topRow + dy)
+}
+
+func (l *ScrollableTextView) moveLeft() {
+	if l.wrap || l.leftShift == 0 {
+		return
+	}
+
+	l.leftShift--
+}
+
+func (l *ScrollableTextView) moveRight() {
+	if l.wrap || l.leftShift+l.textWidth() >= l.maxLength {
+		return
+	}
+
+	l.leftShift++
+}
+
+func (l *ScrollableTextView) processMouseClick(ev Event) bool {
+	if ev.Key != term.MouseLeft {
+		return false
+	}
+
+	dx := ev.X - l.x
+	dy := ev.Y - l.y
+	yy := l.outputHeight()
+
+	// vertical scroll bar
+	if dx == l.width-1 && dy < yy {
+		if dy == 0 {
+			l.moveUp(1)
+		} else if dy == yy-1 {
+			l.moveDown(1)
+		} else {
+			newPos := ItemByThumbPosition(dy, l.virtualHeight()-yy+1, yy)
+			if newPos >= 0 {
+				l.SetScrollOffset(newPos)
+			}
+		}
+		return true
+	}
+
+	// horizontal scrollbar
+	if !l.wrap && dy == l.height-1 && dx < l.width-1 {
+		if dx == 0 {
+			l.moveLeft()
+		} else if dx == l.width-2 {
+			l.moveRight()
+		} else {
+			newPos := ItemByThumbPosition(dx, l.maxLength-l.width+2, l.width-1)
+			if newPos >= 0 {
+				l.leftShift = newPos
+			}
+		}
+		return true
+	}
+
+	return false
+}
+
+/*
+ProcessEvent processes all events come from the control parent. If a control
+processes an event it should return true. If the method returns false it means
+that the control do not want or cannot process the event and the caller sends
+the event to the control parent
+*/
+func (l *ScrollableTextView) ProcessEvent(event Event) bool {
+	if !l.Active() || !l.Enabled() {
+		return false
+	}
+
+	switch event.Type {
+	case EventKey:
+		switch event.Key {
+		case term.KeyHome:
+			l.SetScrollOffset(0)
+		case term.KeyEnd:
+			l.SetScrollOffset(l.maxOffset())
+		case term.KeyArrowUp:
+			l.moveUp(1)
+		case term.KeyArrowDown:
+			l.moveDown(1)
+		case term.KeyArrowLeft:
+			l.moveLeft()
+		case term.KeyArrowRight:
+			l.moveRight()
+		case term.KeyPgup:
+			l.moveUp(l.outputHeight())
+		case term.KeyPgdn:
+			l.moveDown(l.outputHeight())
+		default:
+			return false
+		}
+		return true
+	case EventMouse:
+		return l.processMouseClick(event)
+	}
+
+	return false
+}
+
+// own methods
+
+// appendLines adds lines to the end of the text without
+// recalculating the existing lines
+func (l *ScrollableTextView) appendLines(lines []string) {
+	for _, line := range lines {
+		line = strings.TrimRight(line, "\r")
+		length := len([]rune(UnColorizeText(line)))
+		l.lines = append(l.lines, line)
+		l.lengths = append(l.lengths, length)
+		if length > l.maxLength {
+			l.maxLength = length
+		}
+		if l.wrap && l.wrapWidth > 0 {
+			l.addRows(line)
+		}
+	}
+}
+
+// SetText replaces existing content of the control. Lines
+// of the text are separated with new line characters
+func (l *ScrollableTextView) SetText(s string) {
+	l.lines = make([]string, 0)
+	l.lengths = make([]int, 0)
+	l.rowStarts = make([]int, 0)
+	l.totalRows = 0
+	l.maxLength = 0
+	l.topRow, l.leftShift = 0, 0
+
+	if s != "" {
+		l.appendLines(strings.Split(s, "\n"))
+	}
+
+	if l.follow {
+		l.SetScrollOffset(l.maxOffset())
+	}
+}
+
+// AppendLine adds a line to the end of the text. If follow
+// mode is on the control scrolls to the end of the text
+func (l *ScrollableTextView) AppendLine(s string) {
+	l.appendLines(strings.Split(s, "\n"))
+
+	if l.follow {
+		l.SetScrollOffset(l.maxOffset())
+	}
+}
+
+// LineCount returns the number of lines in the text
+func (l *ScrollableTextView) LineCount() int {
+	return len(l.lines)
+}
+
+// Follow returns if follow mode is enabled
+func (l *ScrollableTextView) Follow() bool {
+	return l.follow
+}
+
+// SetFollow enables or disables follow mode. If follow mode
+// is on the control scrolls to the end of the text every
+// time a line is appended
+func (l *ScrollableTextView) SetFollow(follow bool) {
+	l.follow = follow
+	if follow {
+		l.SetScrollOffset(l.maxOffset())
+	}
+}
+
+// GetScrollOffset returns the first visible line. In wrap mode
+// it returns the first visible row of wrapped text
+func (l *ScrollableTextView) GetScrollOffset() int {
+	return l.topRow
+}
+
+// SetScrollOffset scrolls the text so the line becomes the first
+// visible one. In wrap mode offset is the row of wrapped text
+func (l *ScrollableTextView) SetScrollOffset(offset int) {
+	if max := l.maxOffset(); offset > max {
+		offset = max
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	l.topRow = offset
+}
+
+// WrapMode returns if the wrap mode is enabled
+func (l *ScrollableTextView) WrapMode() bool {
+	return l.wrap
+}
+
+// SetWrapMode enables or disables wrap mode. If wrap mode is
+// enabled the control hides horizontal scrollbar and draws long
+// lines on a few rows. The first visible line stays the same
+func (l *ScrollableTextView) SetWrapMode(wrap bool) {
+	if wrap == l.wrap {
+		return
+	}
+
+	if wrap {
+		// topRow is a line number yet, so calculateRows converts
+		// it into a row when there are no rows calculated
+		l.wrap = true
+		l.wrapWidth = 0
+		l.rowStarts = nil
+		l.calculateRows()
+		l.leftShift = 0
+	} else {
+		l.calculateRows()
+		l.topRow = l.lineByRow(l.topRow)
+		l.wrap = false
+	}
+	l.SetScrollOffset(l.topRow)
+}
+
+// SetSyntaxHighlighter sets a function that splits a line into
+// colored spans. The function is called only for visible lines.
+// Pass nil to display all text with the control colors
+func (l *ScrollableTextView) SetSyntaxHighlighter(fn func(line string) []StyledSpan) {
+	l.highlighter = fn
+}
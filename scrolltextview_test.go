@@ -0,0 +1,79 @@
+package clui
+
+import (
+	"testing"
+)
+
+func TestWrapLine(t *testing.T) {
+	cases := []struct {
+		line     string
+		width    int
+		expected []int
+	}{
+		{"", 5, []int{0}},
+		{"short", 5, []int{0}},
+		{"hello world", 5, []int{0, 6}},
+		{"hello world", 8, []int{0, 6}},
+		{"abcdefghij", 4, []int{0, 4, 8}},
+		{"ab cdefghij", 4, []int{0, 3, 7}},
+	}
+
+	for _, c := range cases {
+		got := wrapLine([]rune(c.line), c.width)
+		if len(got) != len(c.expected) {
+			t.Errorf("%q at %v: expected %v, got %v", c.line, c.width, c.expected, got)
+			continue
+		}
+		for idx := range got {
+			if got[idx] != c.expected[idx] {
+				t.Errorf("%q at %v: expected %v, got %v", c.line, c.width, c.expected, got)
+				break
+			}
+		}
+	}
+}
+
+func TestScrollableTextViewFollow(t *testing.T) {
+	l := CreateScrollableTextView(nil, 11, 4, Fixed)
+	l.SetText("1\n2\n3")
+	if l.GetScrollOffset() != 0 {
+		t.Errorf("Expected offset 0, got %v", l.GetScrollOffset())
+	}
+
+	// 3 rows are visible: the last one is used by the scrollbar
+	l.SetFollow(true)
+	for i := 0; i < 5; i++ {
+		l.AppendLine("line")
+	}
+	if l.LineCount() != 8 {
+		t.Errorf("Expected 8 lines, got %v", l.LineCount())
+	}
+	if l.GetScrollOffset() != 5 {
+		t.Errorf("Expected offset 5, got %v", l.GetScrollOffset())
+	}
+
+	l.SetScrollOffset(100)
+	if l.GetScrollOffset() != 5 {
+		t.Errorf("Offset must be limited: expected 5, got %v", l.GetScrollOffset())
+	}
+}
+
+func TestScrollableTextViewWrap(t *testing.T) {
+	l := CreateScrollableTextView(nil, 6, 3, Fixed)
+	l.SetText("one two three\nfour\nfive six")
+	l.SetScrollOffset(1)
+	l.SetWrapMode(true)
+
+	// "one two three" takes 3 rows, so the line "four" is row 3
+	if l.GetScrollOffset() != 3 {
+		t.Errorf("Expected offset 3, got %v", l.GetScrollOffset())
+	}
+	if l.virtualHeight() != 6 {
+		t.Errorf("Expected 6 rows, got %v", l.virtualHeight())
+	}
+
+	l.SetWrapMode(false)
+	if l.GetScrollOffset() != 1 {
+		t.Errorf("Expected offset 1, got %v", l.GetScrollOffset())
+	}
+}
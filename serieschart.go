@@ -0,0 +1,344 @@
+package clui
+
+import (
+	"fmt"
+	xs "github.com/huandu/xstrings"
+	term "github.com/nsf/termbox-go"
+)
+
+// SeriesID is an identifier of a data series in a chart. It is
+// returned by AddSeries and used to add points to the series
+type SeriesID int
+
+// chartSeries is a named list of values displayed with
+// its own color. Fill character, fill color, and z-order
+// are used only by charts that fill the area under the line
+type chartSeries struct {
+	name      string
+	color     term.Attribute
+	data      []float64
+	fill      rune
+	fillColor term.Attribute
+	z         int
+}
+
+// seriesChart keeps the data series, the value axis, the row of
+// point indices, and the legend shared by LineChart and AreaChart.
+// A chart that embeds it draws only the series itself
+type seriesChart struct {
+	BaseControl
+	series      []chartSeries
+	valueWidth  int
+	legendWidth int
+	showXLabels bool
+	xMin, xMax  int
+	yMin, yMax  float64
+
+	// theme ids of the chart text and back colors and of the
+	// object that keeps the legend character
+	textColor, backColor string
+	legendObj            string
+	// the number of points displayed in one character column
+	colPoints int
+	// if true the automatic value range always includes zero
+	withZero bool
+}
+
+// initSeriesChart sets the parent, the size, and the theme ids of
+// the chart
+func (c *seriesChart) initSeriesChart(parent Control, w, h, scale int, text, back, legend string, colPoints int) {
+	if w == AutoSize {
+		w = 10
+	}
+	if h == AutoSize {
+		h = 5
+	}
+
+	c.parent = parent
+
+	c.SetSize(w, h)
+	c.SetConstraints(w, h)
+	c.tabSkip = true
+	c.series = make([]chartSeries, 0)
+	c.textColor, c.backColor, c.legendObj = text, back, legend
+	c.colPoints = colPoints
+	c.SetScale(scale)
+}
+
+// drawBackground clears the control. Returns false if there is
+// nothing to draw
+func (c *seriesChart) drawBackground() bool {
+	SetTextColor(RealColor(c.fg, c.textColor))
+	SetBackColor(RealColor(c.bg, c.backColor))
+	FillRect(c.x, c.y, c.width, c.height, ' ')
+
+	return len(c.series) != 0
+}
+
+// drawValues draws the value axis. rowValue returns the value
+// displayed in the row dy of the chart
+func (c *seriesChart) drawValues(rowValue func(dy int, bottom, top float64) float64) {
+	if c.valueWidth <= 0 {
+		return
+	}
+
+	pos, _ := c.calculateChartArea()
+	if pos == 0 {
+		return
+	}
+
+	bottom, top := c.calculateRange()
+	if top == bottom {
+		return
+	}
+
+	h := c.chartHeight()
+	format := fmt.Sprintf("%%%v.2f", c.valueWidth)
+	for dy := 0; dy < h; dy += 2 {
+		s := CutText(fmt.Sprintf(format, rowValue(dy, bottom, top)), c.valueWidth)
+		DrawRawText(c.x, c.y+dy, s)
+	}
+}
+
+func (c *seriesChart) drawXLabels() {
+	if !c.showXLabels || c.height < 2 {
+		return
+	}
+
+	start, width := c.calculateChartArea()
+	if width < 1 {
+		return
+	}
+
+	first, count := c.xRange()
+	last := first + count - 1
+	if max := c.maxLength() - 1; last > max {
+		last = max
+	}
+
+	y := c.y + c.height - 1
+	left := fmt.Sprintf("%v", first)
+	DrawRawText(c.x+start, y, CutText(left, width))
+
+	right := fmt.Sprintf("%v", last)
+	// the label of the last point ends in the column of the point
+	pos := (last-first)/c.colPoints - xs.Len(right) + 1
+	if last > first && pos > xs.Len(left) {
+		DrawRawText(c.x+start+pos, y, right)
+	}
+}
+
+func (c *seriesChart) drawLegend() {
+	pos, width := c.calculateChartArea()
+	if pos+width >= c.width-3 {
+		return
+	}
+
+	PushAttributes()
+	defer PopAttributes()
+	fg, bg := RealColor(c.fg, c.textColor), RealColor(c.bg, c.backColor)
+
+	parts := []rune(SysObject(c.legendObj))
+	for idx, s := range c.series {
+		if idx >= c.height {
+			break
+		}
+
+		SetTextColor(RealColor(s.color, c.textColor))
+		SetBackColor(bg)
+		PutChar(c.x+pos+width, c.y+idx, parts[0])
+		str := CutText(fmt.Sprintf(" - %v", s.name), c.legendWidth-1)
+		SetTextColor(fg)
+		DrawRawText(c.x+pos+width+1, c.y+idx, str)
+	}
+}
+
+// calculateChartArea returns the position of the area used to
+// draw series relative to the control left and its width
+func (c *seriesChart) calculateChartArea() (int, int) {
+	w := c.width
+	pos := 0
+
+	if c.valueWidth < w/2 {
+		w = w - c.valueWidth
+		pos = c.valueWidth
+	}
+
+	if c.legendWidth > 3 && c.legendWidth < w/2 {
+		w -= c.legendWidth
+	}
+
+	return pos, w
+}
+
+// chartHeight returns the height of the area used to draw series
+func (c *seriesChart) chartHeight() int {
+	if c.showXLabels {
+		return c.height - 1
+	}
+	return c.height
+}
+
+// maxLength returns the number of points in the longest series
+func (c *seriesChart) maxLength() int {
+	length := 0
+	for _, s := range c.series {
+		if len(s.data) > length {
+			length = len(s.data)
+		}
+	}
+	return length
+}
+
+// xRange returns the index of the first displayed point
+// and the number of points that fit the chart area
+func (c *seriesChart) xRange() (int, int) {
+	_, width := c.calculateChartArea()
+	count := width * c.colPoints
+
+	if c.xMax > c.xMin {
+		if c.xMax-c.xMin+1 < count {
+			count = c.xMax - c.xMin + 1
+		}
+		return c.xMin, count
+	}
+
+	first := c.maxLength() - count
+	if first < 0 {
+		first = 0
+	}
+	return first, count
+}
+
+// calculateRange returns the lowest and the highest values
+// that fit the chart area
+func (c *seriesChart) calculateRange() (float64, float64) {
+	if c.yMax > c.yMin {
+		return c.yMin, c.yMax
+	}
+
+	first, count := c.xRange()
+	found := false
+	var bottom, top float64
+	for _, s := range c.series {
+		for i := first; i < first+count && i < len(s.data); i++ {
+			v := s.data[i]
+			if !found || v < bottom {
+				bottom = v
+			}
+			if !found || v > top {
+				top = v
+			}
+			found = true
+		}
+	}
+
+	if found && c.withZero && bottom > 0 {
+		bottom = 0
+	}
+	if found && top == bottom {
+		bottom, top = bottom-1, top+1
+	}
+
+	return bottom, top
+}
+
+// AddSeries adds a new empty series to the chart. The series
+// is drawn with color. Use ColorDefault to draw the series with
+// the chart text color. The function returns the identifier
+// that is used to add points to the series
+func (c *seriesChart) AddSeries(name string, color term.Attribute) SeriesID {
+	c.series = append(c.series, chartSeries{name: name, color: color, data: make([]float64, 0)})
+	return SeriesID(len(c.series) - 1)
+}
+
+// AppendPoint adds a new value to the end of the series.
+// The function does nothing if the series does not exist
+func (c *seriesChart) AppendPoint(id SeriesID, v float64) {
+	if id < 0 || int(id) >= len(c.series) {
+		return
+	}
+
+	c.series[id].data = append(c.series[id].data, v)
+}
+
+// ClearSeries removes all points from the series
+func (c *seriesChart) ClearSeries(id SeriesID) {
+	if id < 0 || int(id) >= len(c.series) {
+		return
+	}
+
+	c.series[id].data = make([]float64, 0)
+}
+
+// SeriesCount returns the number of series in the chart
+func (c *seriesChart) SeriesCount() int {
+	return len(c.series)
+}
+
+// XRange returns indices of the first and the last displayed
+// points. If max is not greater than min then the chart displays
+// the last points
+func (c *seriesChart) XRange() (int, int) {
+	return c.xMin, c.xMax
+}
+
+// SetXRange sets indices of the first and the last points to
+// display. Points that do not fit the chart area are not
+// displayed. Set max less than or equal to min to display
+// the last points of the series
+func (c *seriesChart) SetXRange(min, max int) {
+	if min < 0 {
+		min = 0
+	}
+	c.xMin, c.xMax = min, max
+}
+
+// YRange returns the values at the bottom and the top of the
+// chart. If max is not greater than min then the chart
+// scales automatically
+func (c *seriesChart) YRange() (float64, float64) {
+	return c.yMin, c.yMax
+}
+
+// SetYRange sets the values at the bottom and the top of the
+// chart. Set max less than or equal to min to scale the chart
+// automatically
+func (c *seriesChart) SetYRange(min, max float64) {
+	c.yMin, c.yMax = min, max
+}
+
+// ValueWidth returns the width of the area at the left of
+// chart used to draw values. Set it to 0 to turn off the
+// value panel
+func (c *seriesChart) ValueWidth() int {
+	return c.valueWidth
+}
+
+// SetValueWidth changes width of the value panel on the left
+func (c *seriesChart) SetValueWidth(width int) {
+	c.valueWidth = width
+}
+
+// LegendWidth returns width of chart legend displayed at the
+// right side of the chart. Set it to 0 to disable legend
+func (c *seriesChart) LegendWidth() int {
+	return c.legendWidth
+}
+
+// SetLegendWidth sets new legend panel width
+func (c *seriesChart) SetLegendWidth(width int) {
+	c.legendWidth = width
+}
+
+// ShowXLabels returns if chart displays indices of the first
+// and the last points under the chart
+func (c *seriesChart) ShowXLabels() bool {
+	return c.showXLabels
+}
+
+// SetShowXLabels turns on and off the row of point indices
+// under the chart
+func (c *seriesChart) SetShowXLabels(show bool) {
+	c.showXLabels = show
+}
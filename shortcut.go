@@ -0,0 +1,81 @@
+package clui
+
+import (
+	"fmt"
+	term "github.com/nsf/termbox-go"
+)
+
+// KeyCombo is a key combination of a global shortcut. Use Key for
+// special keys and Ctrl combinations, e.g, KeyCombo{Key: term.KeyCtrlS},
+// and Ch with Mod for Alt combinations, e.g,
+// KeyCombo{Ch: 'x', Mod: term.ModAlt}
+type KeyCombo struct {
+	Key term.Key
+	Ch  rune
+	Mod term.Modifier
+}
+
+// ShortcutToken identifies a registered shortcut. Use it to unregister
+// the shortcut
+type ShortcutToken int
+
+type shortcut struct {
+	token  ShortcutToken
+	action func()
+}
+
+// shortcutList is a set of global shortcuts of a Window
+type shortcutList struct {
+	actions   map[KeyCombo]shortcut
+	lastToken ShortcutToken
+}
+
+func newShortcutList() *shortcutList {
+	return &shortcutList{actions: make(map[KeyCombo]shortcut)}
+}
+
+// comboFromEvent returns the key combination of the keyboard event
+func comboFromEvent(ev Event) KeyCombo {
+	return KeyCombo{Key: ev.Key, Ch: ev.Ch, Mod: ev.Mod}
+}
+
+// RegisterShortcut adds the global shortcut to the Window: the action
+// is called when a user presses the key combination, regardless of what
+// control is active. Shortcuts are checked before the keyboard event is
+// sent to the active control and they take precedence over the
+// Composer hotkey sequences that start with the same key, e.g, CtrlS.
+// Shortcuts do not work while the Window displays a modal dialog.
+// Returns an error if the combination is already registered
+func (c *Window) RegisterShortcut(key KeyCombo, action func()) (ShortcutToken, error) {
+	if sc, ok := c.shortcuts.actions[key]; ok {
+		return 0, fmt.Errorf("key combination %v is already registered by shortcut %v", key, sc.token)
+	}
+
+	c.shortcuts.lastToken++
+	c.shortcuts.actions[key] = shortcut{token: c.shortcuts.lastToken, action: action}
+	return c.shortcuts.lastToken, nil
+}
+
+// UnregisterShortcut removes the shortcut added with RegisterShortcut.
+// It does nothing if the token is unknown
+func (c *Window) UnregisterShortcut(token ShortcutToken) {
+	for key, sc := range c.shortcuts.actions {
+		if sc.token == token {
+			delete(c.shortcuts.actions, key)
+			return
+		}
+	}
+}
+
+// shortcutFor returns the action of the shortcut for the keyboard
+// event or nil if there is no such shortcut
+func (c *Window) shortcutFor(ev Event) func() {
+	if ev.Type != EventKey || c.dialog != nil {
+		return nil
+	}
+
+	if sc, ok := c.shortcuts.actions[comboFromEvent(ev)]; ok {
+		return sc.action
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package clui
+
+import (
+	term "github.com/nsf/termbox-go"
+	"testing"
+)
+
+func TestShortcuts(t *testing.T) {
+	wnd := CreateWindow(0, 0, 20, 8, "Test")
+	edit := CreateEditField(wnd, 10, "", Fixed)
+	ActivateControl(wnd, edit)
+
+	saved := 0
+	token, err := wnd.RegisterShortcut(KeyCombo{Key: term.KeyCtrlS}, func() { saved++ })
+	if err != nil {
+		t.Fatalf("Failed to register shortcut: %v", err)
+	}
+	if _, err := wnd.RegisterShortcut(KeyCombo{Key: term.KeyCtrlS}, func() {}); err == nil {
+		t.Error("Registering the same combination must fail")
+	}
+
+	if !wnd.ProcessEvent(Event{Type: EventKey, Key: term.KeyCtrlS}) || saved != 1 {
+		t.Errorf("Shortcut must be called: %v", saved)
+	}
+	wnd.ProcessEvent(Event{Type: EventKey, Ch: 's'})
+	if saved != 1 || edit.Title() != "s" {
+		t.Errorf("Other keys must go to the active control: %v '%v'", saved, edit.Title())
+	}
+
+	wnd.UnregisterShortcut(token)
+	wnd.ProcessEvent(Event{Type: EventKey, Key: term.KeyCtrlS})
+	if saved != 1 {
+		t.Error("Unregistered shortcut must not be called")
+	}
+	if _, err := wnd.RegisterShortcut(KeyCombo{Key: term.KeyCtrlS}, func() {}); err != nil {
+		t.Errorf("Combination must be free after UnregisterShortcut: %v", err)
+	}
+}
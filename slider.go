@@ -0,0 +1,260 @@
+package clui
+
+import (
+	term "github.com/nsf/termbox-go"
+	"math"
+)
+
+/*
+Slider control allows a user to select a value from a range. The
+filled part of the track shows the current value, and the thumb marks
+the current position.
+
+Arrow keys change the value by one step. Alt+Arrow and PgUp/PgDn change
+the value by 10 steps(terminals do not report Ctrl modifier for arrow
+keys, so Alt is used instead). Home and End select the minimal and the
+maximal values. Clicking the track moves the thumb to the mouse cursor.
+
+Horizontal Slider grows from left to right, vertical one grows from
+bottom to top.
+*/
+type Slider struct {
+	BaseControl
+	direction Direction
+	min, max  float64
+	step      float64
+	value     float64
+
+	onChange func(float64)
+}
+
+/*
+CreateSlider creates a new Slider. By default the range is from 0 to
+100 with step 1.
+parent - is container that keeps the control.
+width and heigth - are minimal size of the control.
+scale - the way of scaling the control when the parent is resized. Use DoNotScale constant if the
+control should keep its original size.
+*/
+func CreateSlider(parent Control, width, height int, scale int) *Slider {
+	s := new(Slider)
+
+	if height == AutoSize {
+		height = 1
+	}
+	if width == AutoSize {
+		width = 10
+	}
+
+	s.SetSize(width, height)
+	s.SetConstraints(width, height)
+	s.SetTabStop(true)
+	s.SetScale(scale)
+	s.min = 0
+	s.max = 100
+	s.step = 1
+	s.direction = Horizontal
+	s.parent = parent
+
+	if parent != nil {
+		parent.AddChild(s)
+	}
+
+	return s
+}
+
+// thumbPos returns the thumb position along the track of length
+func (s *Slider) thumbPos(length int) int {
+	if s.max <= s.min || length < 2 {
+		return 0
+	}
+
+	return int(math.Floor((s.value-s.min)/(s.max-s.min)*float64(length-1) + 0.5))
+}
+
+// Draw repaints the control on its View surface
+func (s *Slider) Draw() {
+	PushAttributes()
+	defer PopAttributes()
+
+	fg, bg := RealColor(s.fg, ColorSliderText), RealColor(s.bg, ColorSliderBack)
+	if s.Active() {
+		fg, bg = RealColor(s.fg, ColorSliderActiveText), RealColor(s.bg, ColorSliderActiveBack)
+	}
+	fgThumb := RealColor(s.fgActive, ColorSliderThumb)
+
+	parts := []rune(SysObject(ObjSlider))
+	cFilled, cEmpty, cThumb := parts[0], parts[1], parts[2]
+
+	x, y := s.Pos()
+	w, h := s.Size()
+
+	SetBackColor(bg)
+	if s.direction == Horizontal {
+		pos := s.thumbPos(w)
+		for dx := 0; dx < w; dx++ {
+			ch := cEmpty
+			SetTextColor(fg)
+			if dx < pos {
+				ch = cFilled
+			} else if dx == pos {
+				ch = cThumb
+				SetTextColor(fgThumb)
+			}
+			FillRect(x+dx, y, 1, h, ch)
+		}
+		return
+	}
+
+	pos := s.thumbPos(h)
+	for dy := 0; dy < h; dy++ {
+		ch := cEmpty
+		SetTextColor(fg)
+		if dy < pos {
+			ch = cFilled
+		} else if dy == pos {
+			ch = cThumb
+			SetTextColor(fgThumb)
+		}
+		FillRect(x, y+h-1-dy, w, 1, ch)
+	}
+}
+
+/*
+ProcessEvent processes all events come from the control parent. If a control
+processes an event it should return true. If the method returns false it means
+that the control do not want or cannot process the event and the caller sends
+the event to the control parent
+*/
+func (s *Slider) ProcessEvent(event Event) bool {
+	if !s.Active() || !s.Enabled() {
+		return false
+	}
+
+	switch event.Type {
+	case EventKey:
+		steps := 1.0
+		if event.Mod == term.ModAlt {
+			steps = 10
+		}
+
+		switch event.Key {
+		case term.KeyArrowLeft, term.KeyArrowDown:
+			s.SetValue(s.value - steps*s.step)
+		case term.KeyArrowRight, term.KeyArrowUp:
+			s.SetValue(s.value + steps*s.step)
+		case term.KeyPgdn:
+			s.SetValue(s.value - 10*s.step)
+		case term.KeyPgup:
+			s.SetValue(s.value + 10*s.step)
+		case term.KeyHome:
+			s.SetValue(s.min)
+		case term.KeyEnd:
+			s.SetValue(s.max)
+		default:
+			return false
+		}
+		return true
+	case EventMouse:
+		if event.Key != term.MouseLeft {
+			return false
+		}
+
+		pos, length := event.X-s.x, s.width
+		if s.direction != Horizontal {
+			pos, length = s.y+s.height-1-event.Y, s.height
+		}
+		if pos < 0 || pos >= length || length < 2 {
+			return true
+		}
+		s.SetValue(s.min + (s.max-s.min)*float64(pos)/float64(length-1))
+		return true
+	}
+
+	return false
+}
+
+//----------------- own methods -------------------------
+
+// Value returns the current Slider value
+func (s *Slider) Value() float64 {
+	return s.value
+}
+
+// SetValue sets the new Slider value. The value is rounded to
+// the closest step and it is adjusted if it exceeds the limits
+func (s *Slider) SetValue(v float64) {
+	if s.step > 0 {
+		v = s.min + math.Floor((v-s.min)/s.step+0.5)*s.step
+	}
+	if v > s.max {
+		v = s.max
+	}
+	if v < s.min {
+		v = s.min
+	}
+
+	if v == s.value {
+		return
+	}
+
+	s.value = v
+	if s.onChange != nil {
+		go s.onChange(v)
+	}
+}
+
+// Min returns the minimal Slider value
+func (s *Slider) Min() float64 {
+	return s.min
+}
+
+// SetMin changes the minimal Slider value. The current value
+// is adjusted if it exceeds the new limit
+func (s *Slider) SetMin(v float64) {
+	s.min = v
+	s.SetValue(s.value)
+}
+
+// Max returns the maximal Slider value
+func (s *Slider) Max() float64 {
+	return s.max
+}
+
+// SetMax changes the maximal Slider value. The current value
+// is adjusted if it exceeds the new limit
+func (s *Slider) SetMax(v float64) {
+	s.max = v
+	s.SetValue(s.value)
+}
+
+// Step returns the value change for one arrow key press
+func (s *Slider) Step() float64 {
+	return s.step
+}
+
+// SetStep changes the value change for one arrow key press.
+// Zero step means that the value is not rounded
+func (s *Slider) SetStep(v float64) {
+	if v < 0 {
+		return
+	}
+	s.step = v
+}
+
+// Orientation returns the direction in which the Slider grows
+func (s *Slider) Orientation() Direction {
+	return s.direction
+}
+
+// SetOrientation sets the direction in which the Slider grows:
+// Horizontal or Vertical
+func (s *Slider) SetOrientation(dir Direction) {
+	s.direction = dir
+}
+
+// OnChange sets the callback that is called every time the
+// Slider value is changed. The argument is the new value
+func (s *Slider) OnChange(fn func(float64)) {
+	s.onChange = fn
+}
@@ -0,0 +1,45 @@
+package clui
+
+import (
+	term "github.com/nsf/termbox-go"
+	"testing"
+)
+
+func TestSlider(t *testing.T) {
+	s := CreateSlider(nil, 11, 1, Fixed)
+	s.SetActive(true)
+	s.SetMax(10)
+	s.SetStep(0.5)
+
+	s.ProcessEvent(Event{Type: EventKey, Key: term.KeyArrowRight})
+	if s.Value() != 0.5 {
+		t.Errorf("Arrow must change value by step: %v", s.Value())
+	}
+	s.ProcessEvent(Event{Type: EventKey, Key: term.KeyArrowRight, Mod: term.ModAlt})
+	if s.Value() != 5.5 {
+		t.Errorf("Alt+Arrow must change value by 10 steps: %v", s.Value())
+	}
+	s.ProcessEvent(Event{Type: EventKey, Key: term.KeyEnd})
+	if s.Value() != 10 {
+		t.Errorf("End must select maximum: %v", s.Value())
+	}
+
+	s.SetValue(3.3)
+	if s.Value() != 3.5 {
+		t.Errorf("Value must be rounded to step: %v", s.Value())
+	}
+	s.SetMax(2)
+	if s.Value() != 2 {
+		t.Errorf("Value must be adjusted to new limit: %v", s.Value())
+	}
+
+	s.SetMax(10)
+	s.SetValue(5)
+	if pos := s.thumbPos(11); pos != 5 {
+		t.Errorf("Invalid thumb position %v", pos)
+	}
+	s.ProcessEvent(Event{Type: EventMouse, Key: term.MouseLeft, X: 8})
+	if s.Value() != 8 {
+		t.Errorf("Click must move the thumb: %v", s.Value())
+	}
+}
@@ -4,8 +4,22 @@ import (
 	"fmt"
 	// xs "github.com/huandu/xstrings"
 	term "github.com/nsf/termbox-go"
+	"log/slog"
+	"math"
+	"strings"
 )
 
+// SparkZone is a range of values that are drawn with its
+// own colors. A value belongs to the zone if it is between
+// Low and High inclusive. Use ColorDefault for Fg and Bg
+// to draw with SparkChart defaults
+type SparkZone struct {
+	Low  float64
+	High float64
+	Fg   term.Attribute
+	Bg   term.Attribute
+}
+
 /*
 SparkChart is a chart that represents a live data that
 is continuously added to the chart. Or it can be static
@@ -14,25 +28,69 @@ case it looks like BarChart. At a moment SparkChart
 keeps only th enumber of last data that is enough to
 fill the control area. So, if you enlarge the control,
 it will show partially filled area until it gets new data.
+Set Capacity to keep more data than the control can display:
+the chart displays only the last data that fit the control
+but it does not lose data when the control is made narrower.
+Viewport makes the chart display any part of the kept data
+instead of the last data. Autoscale uses only the displayed
+data to calculate the chart scale.
 SparkChart displays vertical axis with values on the chart left
-if ValueWidth greater than 0, horizontal axis with bar titles.
+(or right, see SetValueAxisSide) if ValueWidth greater than 0,
+horizontal axis with bar titles.
 Maximum peaks(maximum of the the data that control keeps)
-can be hilited with different color.
+can be hilited with different color. The same is true for
+minimum values. If a value is both maximum and minimum(e.g,
+all data values are equal) then it is hilited as maximum.
 By default the data is autoscaled to make the highest bar
 fit the full height of the control. But it maybe useful
 to disable autoscale and set the Top value to have more
 handy diagram. E.g, for CPU load in % you can set
 AutoScale to false and Top value to 100.
-Note: negative and zero values are displayed as empty bar
+In braille render mode every character cell displays two
+bars and four sub-rows, so the chart fits twice more data
+and looks smoother even if the control is low.
+Negative values are drawn below the baseline - a horizontal line
+that marks zero value. The baseline is displayed only if the data
+contains negative values or Top is negative. Zero values are
+displayed as empty bar.
+Non-zero threshold draws a horizontal line at the row that
+corresponds to the threshold value. All values that are greater
+than or equal to the threshold are drawn with separate colors
+that have priority over hilite colors. The line is hidden if the
+threshold is out of the range of the displayed values.
+Zones make the chart color bars depending on their values
+instead of using the same color for all bars.
+If statistics is enabled the bottom row of the control
+displays minimum, average, and maximum of the data.
+Moving average is drawn over bars as a mark at the row that
+corresponds to the average of the last values
 */
 type SparkChart struct {
 	BaseControl
+	// data source attached with Bind
+	bound binding
+	// reader of values started with SetDataSource
+	source       *sparkSource
 	data         []float64
 	valueWidth   int
+	valueSide    AxisSide
+	valueFormat  string
+	formatter    func(float64) string
 	hiliteMax    bool
+	hiliteMin    bool
 	maxFg, maxBg term.Attribute
+	minFg, minBg term.Attribute
 	topValue     float64
 	autosize     bool
+	renderMode   SparkRenderMode
+	barWidth     int
+	threshold    float64
+	zones        []SparkZone
+	showStats    bool
+	capacity     int
+	viewOffset   int
+	viewCount    int
+	maWindow     int
 }
 
 /*
@@ -60,6 +118,8 @@ func CreateSparkChart(parent Control, w, h int, scale int) *SparkChart {
 	c.tabSkip = true
 	c.hiliteMax = true
 	c.autosize = true
+	c.barWidth = 1
+	c.viewOffset = -1
 	c.data = make([]float64, 0)
 	c.SetScale(scale)
 
@@ -85,7 +145,13 @@ func (b *SparkChart) Draw() {
 	}
 
 	b.drawValues()
-	b.drawBars()
+	b.drawStats()
+	if b.renderMode == SparkRenderBraille {
+		b.drawBrailleBars()
+	} else {
+		b.drawBars()
+	}
+	b.drawMovingAverage()
 }
 
 func (b *SparkChart) drawBars() {
@@ -98,7 +164,7 @@ func (b *SparkChart) drawBars() {
 		return
 	}
 
-	coeff, max := b.calculateMultiplier()
+	coeff, base := b.calculateMultiplier()
 	if coeff == 0.0 {
 		return
 	}
@@ -106,37 +172,247 @@ func (b *SparkChart) drawBars() {
 	PushAttributes()
 	defer PopAttributes()
 
-	h := b.height
+	h := b.chartHeight()
 	pos := b.x + start
 
 	mxFg, mxBg := RealColor(b.maxFg, ColorSparkChartMaxText), RealColor(b.maxBg, ColorSparkChartMaxBack)
 	brFg, brBg := RealColor(b.fg, ColorSparkChartBarText), RealColor(b.bg, ColorSparkChartBarBack)
+	ngFg, ngBg := RealColor(b.fg, ColorSparkChartNegText), RealColor(b.bg, ColorSparkChartNegBack)
+	mnFg, mnBg := RealColor(b.minFg, ColorSparkChartMinText), RealColor(b.minBg, ColorSparkChartMinBack)
+	ovFg, ovBg := RealColor(b.fg, ColorSparkChartOverText), RealColor(b.bg, ColorSparkChartOverBack)
 	parts := []rune(SysObject(ObjSparkChart))
+	chBar, chNeg, chBase := parts[0], parts[0], parts[0]
+	if len(parts) > 2 {
+		chNeg, chBase = parts[1], parts[2]
+	}
 
-	var dt []float64
-	if len(b.data) > width {
-		dt = b.data[len(b.data)-width:]
-	} else {
-		dt = b.data
+	if base < h {
+		SetTextColor(RealColor(b.fg, ColorSparkChartText))
+		SetBackColor(RealColor(b.bg, ColorSparkChartBack))
+		DrawHorizontalLine(pos, b.y+base, width, chBase)
+	}
+
+	if row, ok := b.thresholdRow(coeff, base); ok {
+		SetTextColor(ovFg)
+		SetBackColor(ovBg)
+		DrawHorizontalLine(pos, b.y+row, width, b.thresholdChar())
 	}
 
-	for _, d := range dt {
+	min, max := dataLimits(b.visibleData())
+	for _, d := range b.visibleData() {
+		if d < 0 {
+			barH := int(-d * coeff)
+			if barH > h-base-1 {
+				barH = h - base - 1
+			}
+			if barH > 0 {
+				f, g := b.zoneColors(d, ngFg, ngBg)
+				switch b.hilite(d, min, max) {
+				case hiliteOver:
+					f, g = ovFg, ovBg
+				case hiliteMinimum:
+					f, g = mnFg, mnBg
+				}
+				SetTextColor(f)
+				SetBackColor(g)
+				FillRect(pos, b.y+base+1, b.barWidth, barH, chNeg)
+			}
+
+			pos += b.barWidth
+			continue
+		}
+
 		barH := int(d * coeff)
+		if barH > base {
+			barH = base
+		}
 
 		if barH <= 0 {
-			pos++
+			pos += b.barWidth
 			continue
 		}
 
-		f, g := brFg, brBg
-		if b.hiliteMax && max == d {
+		f, g := b.zoneColors(d, brFg, brBg)
+		switch b.hilite(d, min, max) {
+		case hiliteOver:
+			f, g = ovFg, ovBg
+		case hiliteMaximum:
 			f, g = mxFg, mxBg
+		case hiliteMinimum:
+			f, g = mnFg, mnBg
 		}
 		SetTextColor(f)
 		SetBackColor(g)
-		FillRect(pos, b.y+h-barH, 1, barH, parts[0])
+		FillRect(pos, b.y+base-barH, b.barWidth, barH, chBar)
+
+		pos += b.barWidth
+	}
+}
+
+// drawBrailleBars draws bars using braille characters. Every
+// character is a grid of 2x4 dots: the left column displays one
+// bar and the right column displays the next one
+func (b *SparkChart) drawBrailleBars() {
+	if len(b.data) == 0 {
+		return
+	}
+
+	start, width := b.calculateBarArea()
+	if width < 2 {
+		return
+	}
+
+	coeff, base := b.calculateMultiplier()
+	if coeff == 0.0 {
+		return
+	}
+
+	PushAttributes()
+	defer PopAttributes()
 
-		pos++
+	h := b.chartHeight()
+	mxFg, mxBg := RealColor(b.maxFg, ColorSparkChartMaxText), RealColor(b.maxBg, ColorSparkChartMaxBack)
+	brFg, brBg := RealColor(b.fg, ColorSparkChartBarText), RealColor(b.bg, ColorSparkChartBarBack)
+	ngFg, ngBg := RealColor(b.fg, ColorSparkChartNegText), RealColor(b.bg, ColorSparkChartNegBack)
+	mnFg, mnBg := RealColor(b.minFg, ColorSparkChartMinText), RealColor(b.minBg, ColorSparkChartMinBack)
+	ovFg, ovBg := RealColor(b.fg, ColorSparkChartOverText), RealColor(b.bg, ColorSparkChartOverBack)
+
+	if sub, ok := b.thresholdRow(coeff, base); ok {
+		SetTextColor(ovFg)
+		SetBackColor(ovBg)
+		DrawHorizontalLine(b.x+start, b.y+sub/4, width, b.thresholdChar())
+	}
+
+	// dots of the left and the right columns from top to bottom
+	dots := [2][4]rune{{0x01, 0x02, 0x04, 0x40}, {0x08, 0x10, 0x20, 0x80}}
+	cells := make([]rune, width*h)
+	peaks := make([]sparkHilite, width*h)
+	values := make([]float64, width*h)
+
+	min, max := dataLimits(b.visibleData())
+	for idx, d := range b.visibleData() {
+		col, side := idx/2, idx%2
+		if col >= width {
+			break
+		}
+
+		from, to := base-int(d*coeff), base
+		if d < 0 {
+			from, to = base, base+int(-d*coeff)
+		}
+		if from < 0 {
+			from = 0
+		}
+		if to > h*4 {
+			to = h * 4
+		}
+
+		hl := b.hilite(d, min, max)
+		for sub := from; sub < to; sub++ {
+			cell := (sub/4)*width + col
+			cells[cell] |= dots[side][sub%4]
+			// a cell shared by two bars is colored by the right one
+			values[cell] = d
+			// a cell shared by differently hilited bars gets the highest priority one
+			if hl > peaks[cell] {
+				peaks[cell] = hl
+			}
+		}
+	}
+
+	for row := 0; row < h; row++ {
+		for col := 0; col < width; col++ {
+			cell := row*width + col
+			if cells[cell] == 0 {
+				continue
+			}
+
+			f, g := b.zoneColors(values[cell], brFg, brBg)
+			if row*4 >= base {
+				f, g = b.zoneColors(values[cell], ngFg, ngBg)
+			}
+			if peaks[cell] == hiliteOver {
+				f, g = ovFg, ovBg
+			} else if peaks[cell] == hiliteMaximum {
+				f, g = mxFg, mxBg
+			} else if peaks[cell] == hiliteMinimum {
+				f, g = mnFg, mnBg
+			}
+			SetTextColor(f)
+			SetBackColor(g)
+			PutChar(b.x+start+col, b.y+row, 0x2800+cells[cell])
+		}
+	}
+}
+
+// drawMovingAverage draws a mark over every bar at the row that
+// corresponds to the average of the bar value and the previous
+// values. In braille mode the mark is drawn for every second bar
+func (b *SparkChart) drawMovingAverage() {
+	if b.maWindow < 2 || len(b.data) == 0 {
+		return
+	}
+
+	start, width := b.calculateBarArea()
+	if width < 2 {
+		return
+	}
+
+	coeff, base := b.calculateMultiplier()
+	if coeff == 0.0 {
+		return
+	}
+
+	PushAttributes()
+	defer PopAttributes()
+
+	SetTextColor(RealColor(b.fg, ColorSparkChartMAText))
+	SetBackColor(RealColor(b.bg, ColorSparkChartMABack))
+	parts := []rune(SysObject(ObjSparkChartMA))
+	ch := '*'
+	if len(parts) > 0 {
+		ch = parts[0]
+	}
+
+	h := b.chartHeight()
+	res := b.rowResolution()
+	data := b.visibleData()
+	sum := 0.0
+	for idx, d := range data {
+		sum += d
+		if idx >= b.maWindow {
+			sum -= data[idx-b.maWindow]
+		}
+		if b.renderMode == SparkRenderBraille && idx%2 == 0 && idx != len(data)-1 {
+			continue
+		}
+
+		cnt := b.maWindow
+		if idx+1 < cnt {
+			cnt = idx + 1
+		}
+		avg := sum / float64(cnt)
+
+		// the mark replaces the farthest from the baseline cell of the bar
+		barH := int(math.Abs(avg) * coeff)
+		if barH == 0 {
+			barH = 1
+		}
+		row := (base - barH) / res
+		if avg < 0 && res == 1 {
+			row = base + barH
+		} else if avg < 0 {
+			row = (base + barH - 1) / res
+		}
+		if row < 0 || row >= h {
+			continue
+		}
+
+		if b.renderMode == SparkRenderBraille {
+			PutChar(b.x+start+idx/2, b.y+row, ch)
+		} else {
+			DrawHorizontalLine(b.x+start+idx*b.barWidth, b.y+row, b.barWidth, ch)
+		}
 	}
 }
 
@@ -145,78 +421,332 @@ func (b *SparkChart) drawValues() {
 		return
 	}
 
-	pos, _ := b.calculateBarArea()
-	if pos == 0 {
+	if _, width := b.calculateBarArea(); width == b.width {
 		return
 	}
+	pos := b.x
+	if b.valueSide == AxisRight {
+		pos = b.x + b.width - b.valueWidth
+	}
 
-	h := b.height
-	coeff, max := b.calculateMultiplier()
-	if max == coeff {
+	h := b.chartHeight()
+	coeff, base := b.calculateMultiplier()
+	if coeff == 0.0 {
 		return
 	}
-	if !b.autosize || b.topValue == 0 {
-		max = b.topValue
-	}
 
 	dy := 0
-	format := fmt.Sprintf("%%%v.2f", b.valueWidth)
+	res := b.rowResolution()
 	for dy < h-1 {
-		v := float64(h-dy) / float64(h) * max
-		s := fmt.Sprintf(format, v)
+		v := float64(base-dy*res) / coeff
+		s := fmt.Sprintf("%*s", b.valueWidth, b.formatValue(v))
 		s = CutText(s, b.valueWidth)
-		DrawRawText(b.x, b.y+dy, s)
+		DrawRawText(pos, b.y+dy, s)
 
 		dy += 2
 	}
 }
 
+// drawStats draws minimum, average, and maximum of the data
+// at the bottom row of the control
+func (b *SparkChart) drawStats() {
+	if !b.showStats || len(b.data) == 0 {
+		return
+	}
+
+	PushAttributes()
+	defer PopAttributes()
+
+	min, max := dataLimits(b.data)
+	sum := 0.0
+	for _, d := range b.data {
+		sum += d
+	}
+	avg := sum / float64(len(b.data))
+
+	s := fmt.Sprintf("%.2f %.2f %.2f", min, avg, max)
+	s = CutText(s, b.width)
+	SetTextColor(RealColor(b.fg, ColorSparkChartStatsText))
+	SetBackColor(RealColor(b.bg, ColorSparkChartStatsBack))
+	FillRect(b.x, b.y+b.height-1, b.width, 1, ' ')
+	DrawRawText(b.x, b.y+b.height-1, s)
+}
+
+// chartHeight returns the height of the area used to draw bars
+func (b *SparkChart) chartHeight() int {
+	if b.showStats {
+		return b.height - 1
+	}
+	return b.height
+}
+
 func (b *SparkChart) calculateBarArea() (int, int) {
 	w := b.width
 	pos := 0
 
 	if b.valueWidth < w/2 {
 		w = w - b.valueWidth
-		pos = b.valueWidth
+		if b.valueSide == AxisLeft {
+			pos = b.valueWidth
+		}
 	}
 
 	return pos, w
 }
 
-func (b *SparkChart) calculateMultiplier() (float64, float64) {
-	if len(b.data) == 0 {
-		return 0, 0
+// barCount returns the maximum number of bars that fit the chart.
+// A bar that fits the chart only partially is not counted
+func (b *SparkChart) barCount() int {
+	_, width := b.calculateBarArea()
+	if b.renderMode == SparkRenderBraille {
+		return width * 2
 	}
+	return width / b.barWidth
+}
 
-	h := b.height
-	if h <= 1 {
+// rowResolution returns how many vertical units one chart row
+// contains in the current render mode
+func (b *SparkChart) rowResolution() int {
+	if b.renderMode == SparkRenderBraille {
+		return 4
+	}
+	return 1
+}
+
+// visibleCount returns the number of data the chart displays
+func (b *SparkChart) visibleCount() int {
+	count := b.barCount()
+	if b.viewCount > 0 && b.viewCount < count {
+		count = b.viewCount
+	}
+	return count
+}
+
+// visibleData returns the part of the data that fits the chart
+func (b *SparkChart) visibleData() []float64 {
+	count := b.visibleCount()
+	if b.viewOffset < 0 {
+		if len(b.data) > count {
+			return b.data[len(b.data)-count:]
+		}
+		return b.data
+	}
+
+	start := b.viewOffset
+	if start > len(b.data) {
+		start = len(b.data)
+	}
+	end := start + count
+	if end > len(b.data) {
+		end = len(b.data)
+	}
+	return b.data[start:end]
+}
+
+// sparkHilite is a way of hiliting a bar. Higher value has
+// higher priority
+type sparkHilite int
+
+const (
+	hiliteNone sparkHilite = iota
+	hiliteMinimum
+	hiliteMaximum
+	hiliteOver
+)
+
+// hilite returns how a bar with value val must be hilited.
+// min and max are the lowest and the highest values of the data
+func (b *SparkChart) hilite(val, min, max float64) sparkHilite {
+	if b.threshold != 0 && val >= b.threshold {
+		return hiliteOver
+	}
+	if b.hiliteMax && val == max {
+		return hiliteMaximum
+	}
+	if b.hiliteMin && val == min {
+		return hiliteMinimum
+	}
+	return hiliteNone
+}
+
+// zoneColors returns colors of the first zone that contains
+// the value. If no zone contains the value or the zone color
+// is ColorDefault, then fg and bg are used
+func (b *SparkChart) zoneColors(val float64, fg, bg term.Attribute) (term.Attribute, term.Attribute) {
+	for _, z := range b.zones {
+		if val < z.Low || val > z.High {
+			continue
+		}
+
+		if z.Fg != ColorDefault {
+			fg = z.Fg
+		}
+		if z.Bg != ColorDefault {
+			bg = z.Bg
+		}
+		break
+	}
+
+	return fg, bg
+}
+
+// thresholdRow returns the row of the threshold line relative to
+// the chart top. The row is in the same units as base(sub-rows
+// in braille mode). The second value is false if the threshold
+// is not set or is out of the displayed range
+func (b *SparkChart) thresholdRow(coeff float64, base int) (int, bool) {
+	if b.threshold == 0 || coeff == 0.0 {
+		return 0, false
+	}
+
+	bottom, top := b.calculateRange()
+	if b.threshold > top || b.threshold < bottom {
+		return 0, false
+	}
+
+	row := base - int(b.threshold*coeff)
+	if b.threshold < 0 {
+		row = base + int(-b.threshold*coeff)
+	}
+	if row < 0 || row >= b.chartHeight()*b.rowResolution() {
+		return 0, false
+	}
+
+	return row, true
+}
+
+// thresholdChar returns the character to draw the threshold line
+func (b *SparkChart) thresholdChar() rune {
+	parts := []rune(SysObject(ObjSparkChartThreshold))
+	if len(parts) == 0 {
+		return '-'
+	}
+	return parts[0]
+}
+
+// dataLimits returns the lowest and the highest values of the data
+func dataLimits(data []float64) (float64, float64) {
+	if len(data) == 0 {
 		return 0, 0
 	}
 
-	max := b.data[0]
-	for _, val := range b.data {
+	min, max := data[0], data[0]
+	for _, val := range data {
 		if val > max {
 			max = val
 		}
+		if val < min {
+			min = val
+		}
+	}
+
+	return min, max
+}
+
+// calculateRange returns the lowest and the highest values that
+// fit the chart area. Only the displayed data is used. The range
+// always includes zero. If AutoScale is off then positive Top
+// value is used as the highest value and negative Top value is
+// used as the lowest one
+func (b *SparkChart) calculateRange() (float64, float64) {
+	min, max := dataLimits(b.visibleData())
+	bottom, top := math.Min(min, 0), math.Max(max, 0)
+
+	if !b.autosize && b.topValue > 0 {
+		top = b.topValue
+	} else if !b.autosize && b.topValue < 0 {
+		bottom = b.topValue
 	}
 
-	if max == 0 {
+	return bottom, top
+}
+
+// calculateMultiplier returns the number of rows that corresponds
+// to one unit of value and the row of the baseline(the row that
+// displays zero) relative to the chart top. If the chart does not
+// show negative values then the baseline is right below the chart
+// and the row equals the chart height.
+// In braille mode both values are in sub-rows - a quarter of a row
+// each - and the baseline does not occupy a separate row
+func (b *SparkChart) calculateMultiplier() (float64, int) {
+	if len(b.data) == 0 {
+		return 0, 0
+	}
+
+	h := b.chartHeight()
+	if h <= 1 {
 		return 0, 0
 	}
 
-	if b.autosize || b.topValue == 0 {
-		return float64(h) / max, max
+	bottom, top := b.calculateRange()
+	if top == bottom {
+		return 0, 0
 	}
-	return float64(h) / b.topValue, max
+
+	h *= b.rowResolution()
+	if bottom == 0 {
+		return float64(h) / top, h
+	}
+
+	// one row is occupied by the baseline
+	if b.renderMode == SparkRenderBlock {
+		h--
+	}
+	coeff := float64(h) / (top - bottom)
+	return coeff, int(top * coeff)
 }
 
-// AddData appends a new bar to a chart
+// AddData appends a new bar to a chart. If the bar does not change
+// the other bars, only its column is marked dirty, otherwise the
+// whole chart is marked. Use RefreshDirty to display the change
 func (b *SparkChart) AddData(val float64) {
+	bottom, top := b.calculateRange()
+	min, max := dataLimits(b.visibleData())
+	count := len(b.visibleData())
+
 	b.data = append(b.data, val)
+	b.trimData()
+	if logEnabled(slog.LevelDebug) {
+		b.StructuredLogger().Debug("data added", "value", val, "count", len(b.data))
+	}
 
-	_, width := b.calculateBarArea()
-	if len(b.data) > width {
-		b.data = b.data[len(b.data)-width:]
+	nb, nt := b.calculateRange()
+	if b.viewOffset >= 0 || count == 0 || len(b.visibleData()) != count+1 ||
+		bottom != nb || top != nt || val >= max || val <= min {
+		MarkDirty(b.x, b.y, b.width, b.height)
+		return
+	}
+
+	start, _ := b.calculateBarArea()
+	if b.renderMode == SparkRenderBraille {
+		MarkDirty(b.x+start+count/2, b.y, 1, b.chartHeight())
+	} else {
+		MarkDirty(b.x+start+count*b.barWidth, b.y, b.barWidth, b.chartHeight())
+	}
+	if b.showStats {
+		MarkDirty(b.x, b.y+b.height-1, b.width, 1)
+	}
+}
+
+// trimData removes the oldest data that exceed the chart
+// capacity. If capacity is not set then the chart keeps only
+// the data that fits the control
+func (b *SparkChart) trimData() {
+	limit := b.capacity
+	if limit <= 0 {
+		limit = b.barCount()
+	}
+	if len(b.data) > limit {
+		removed := len(b.data) - limit
+		b.data = b.data[removed:]
+
+		// keep the viewport displaying the same data if possible
+		if b.viewOffset > 0 {
+			b.viewOffset -= removed
+			if b.viewOffset < 0 {
+				b.viewOffset = 0
+			}
+		}
 	}
 }
 
@@ -229,25 +759,169 @@ func (b *SparkChart) ClearData() {
 func (b *SparkChart) SetData(data []float64) {
 	b.data = make([]float64, len(data))
 	copy(b.data, data)
+	b.trimData()
+}
 
-	_, width := b.calculateBarArea()
-	if len(b.data) > width {
-		b.data = b.data[len(b.data)-width:]
+// Capacity returns the maximum number of data the chart keeps.
+// Zero means that the chart keeps only the data that fits the
+// control
+func (b *SparkChart) Capacity() int {
+	return b.capacity
+}
+
+// SetCapacity changes the maximum number of data the chart
+// keeps. The chart displays only the last data that fits the
+// control. Set capacity to 0 to keep only the data that fits
+// the control
+func (b *SparkChart) SetCapacity(capacity int) {
+	if capacity < 0 {
+		capacity = 0
+	}
+	b.capacity = capacity
+	b.trimData()
+}
+
+// Viewport returns the index of the first displayed data and
+// the number of data to display. Negative offset means that the
+// chart displays the last data. Zero count means that the chart
+// displays as many data as fit the control
+func (b *SparkChart) Viewport() (int, int) {
+	return b.viewOffset, b.viewCount
+}
+
+// SetViewport changes the part of kept data the chart displays.
+// offset is the index of the first displayed data, use negative
+// value to display the last data(default behavior). count is the
+// number of data to display, use 0 to fill the whole control.
+// Viewport is useful only if the chart Capacity is greater
+// than the control width
+func (b *SparkChart) SetViewport(offset, count int) {
+	if offset < 0 {
+		offset = -1
+	}
+	if count < 0 {
+		count = 0
+	}
+	b.viewOffset, b.viewCount = offset, count
+}
+
+// ScrollLeft moves the viewport n data back to the older data
+func (b *SparkChart) ScrollLeft(n int) {
+	offset := b.viewOffset
+	if offset < 0 {
+		offset = len(b.data) - b.visibleCount()
 	}
+	offset -= n
+	if offset < 0 {
+		offset = 0
+	}
+	b.viewOffset = offset
+}
+
+// ScrollRight moves the viewport n data forward to the newer
+// data. When the viewport reaches the last data, the chart
+// starts displaying the last data again, including new ones
+func (b *SparkChart) ScrollRight(n int) {
+	if b.viewOffset < 0 {
+		return
+	}
+	b.viewOffset += n
+	if b.viewOffset >= len(b.data)-b.visibleCount() {
+		b.viewOffset = -1
+	}
+}
+
+// RenderToString draws the chart to memory and returns the result
+// as newline separated rows. Theme characters are used but colors
+// are ignored. It is useful for logging the chart state and testing
+// without a terminal. The method must not be called at the same time
+// when the screen is being redrawn
+func (b *SparkChart) RenderToString() string {
+	return renderToString(b)
 }
 
-// ValueWidth returns the width of the area at the left of
-// chart used to draw values. Set it to 0 to turn off the
+// ValueWidth returns the width of the area at the left(or
+// right) of chart used to draw values. Set it to 0 to turn off the
 // value panel
 func (b *SparkChart) ValueWidth() int {
 	return b.valueWidth
 }
 
-// SetValueWidth changes width of the value panel on the left
+// SetValueWidth changes width of the value panel
 func (b *SparkChart) SetValueWidth(width int) {
 	b.valueWidth = width
 }
 
+// ValueFormat returns the format string of the value axis labels.
+// Empty string means the default format with two decimal places
+func (b *SparkChart) ValueFormat() string {
+	return b.valueFormat
+}
+
+// SetValueFormat changes the format string of the value axis labels in
+// fmt package format, e.g. "%.2e" or "%.0f%%". Integer verbs like "%d"
+// display the value without fractional part. Use empty string to restore
+// the default format
+func (b *SparkChart) SetValueFormat(format string) {
+	b.valueFormat = format
+}
+
+// SetValueFormatter sets the function that converts values to the value
+// axis labels, e.g. to display "1.4MB" instead of "1400000.00". The
+// formatter takes priority over the format string. Use nil to restore
+// formatting with the format string
+func (b *SparkChart) SetValueFormatter(fn func(v float64) string) {
+	b.formatter = fn
+}
+
+// formatValue returns the value axis label for the value
+func (b *SparkChart) formatValue(v float64) string {
+	if b.formatter != nil {
+		return b.formatter(v)
+	}
+	if b.valueFormat == "" {
+		return fmt.Sprintf("%.2f", v)
+	}
+	if intVerb(b.valueFormat) {
+		return fmt.Sprintf(b.valueFormat, int64(math.Round(v)))
+	}
+	return fmt.Sprintf(b.valueFormat, v)
+}
+
+// intVerb returns true if the first verb of the format string expects
+// an integer value
+func intVerb(format string) bool {
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		i++
+		for i < len(format) && strings.ContainsRune("+-# 0123456789.", rune(format[i])) {
+			i++
+		}
+		if i >= len(format) {
+			return false
+		}
+		if format[i] == '%' {
+			continue
+		}
+		return strings.ContainsRune("dboxXc", rune(format[i]))
+	}
+	return false
+}
+
+// ValueAxisSide returns the side of the chart that displays values
+func (b *SparkChart) ValueAxisSide() AxisSide {
+	return b.valueSide
+}
+
+// SetValueAxisSide changes the side of the chart that displays values.
+// The right side is useful if the chart is next to a split pane divider
+// or another control on its left
+func (b *SparkChart) SetValueAxisSide(side AxisSide) {
+	b.valueSide = side
+}
+
 // Top returns the value of the top of a chart. The value is
 // used only if autosize is off to scale all the data
 func (b *SparkChart) Top() float64 {
@@ -255,7 +929,9 @@ func (b *SparkChart) Top() float64 {
 }
 
 // SetTop sets the theoretical highest value of data flow
-// to scale the chart
+// to scale the chart. Negative value sets the theoretical
+// lowest value of data flow instead: the chart bottom is
+// fixed and the top is calculated from the data
 func (b *SparkChart) SetTop(top float64) {
 	b.topValue = top
 }
@@ -282,3 +958,100 @@ func (b *SparkChart) HilitePeaks() bool {
 func (b *SparkChart) SetHilitePeaks(hilite bool) {
 	b.hiliteMax = hilite
 }
+
+// HiliteMin returns whether chart draws minimum values
+// with different color
+func (b *SparkChart) HiliteMin() bool {
+	return b.hiliteMin
+}
+
+// SetHiliteMin enables or disables hiliting minimum
+// values with different colors
+func (b *SparkChart) SetHiliteMin(hilite bool) {
+	b.hiliteMin = hilite
+}
+
+// RenderMode returns the way the chart draws bars: with block
+// characters or with braille ones
+func (b *SparkChart) RenderMode() SparkRenderMode {
+	return b.renderMode
+}
+
+// SetRenderMode changes the way the chart draws bars. Braille
+// mode doubles both horizontal and vertical resolution of the
+// chart but requires a terminal font that includes braille
+// characters
+func (b *SparkChart) SetRenderMode(mode SparkRenderMode) {
+	b.renderMode = mode
+}
+
+// Threshold returns the value at which the threshold line
+// is drawn. Zero means the threshold is not set
+func (b *SparkChart) Threshold() float64 {
+	return b.threshold
+}
+
+// SetThreshold sets the value at which the threshold line is
+// drawn. All values that are greater than or equal to the
+// threshold are drawn with separate colors. Set it to 0 to
+// turn off the threshold
+func (b *SparkChart) SetThreshold(v float64) {
+	b.threshold = v
+}
+
+// Zones returns the list of value ranges that have their own colors
+func (b *SparkChart) Zones() []SparkZone {
+	zones := make([]SparkZone, len(b.zones))
+	copy(zones, b.zones)
+	return zones
+}
+
+// SetZones sets the list of value ranges that have their own
+// colors. The zones are checked in order and the first zone that
+// contains a value defines the colors of the value bar. Empty
+// list makes the chart draw all bars with the same colors
+func (b *SparkChart) SetZones(zones []SparkZone) {
+	b.zones = make([]SparkZone, len(zones))
+	copy(b.zones, zones)
+}
+
+// ShowStats returns whether the chart displays minimum,
+// average, and maximum of the data at the bottom row
+func (b *SparkChart) ShowStats() bool {
+	return b.showStats
+}
+
+// SetShowStats enables or disables displaying statistics
+// at the bottom row of the chart
+func (b *SparkChart) SetShowStats(show bool) {
+	b.showStats = show
+}
+
+// ShowMovingAverage returns the number of values used to
+// calculate the moving average. Values less than 2 mean that
+// moving average is not displayed
+func (b *SparkChart) ShowMovingAverage() int {
+	return b.maWindow
+}
+
+// SetShowMovingAverage sets the number of last values used to
+// calculate the moving average. Set it to 0 or 1 to hide moving
+// average
+func (b *SparkChart) SetShowMovingAverage(window int) {
+	b.maWindow = window
+}
+
+// BarWidth returns the width of a bar in character cells
+func (b *SparkChart) BarWidth() int {
+	return b.barWidth
+}
+
+// SetBarWidth changes the width of a bar. Width cannot be less
+// than 1. The value is not used in braille render mode: in this
+// mode a bar is always half of a character cell wide
+func (b *SparkChart) SetBarWidth(width int) {
+	if width < 1 {
+		width = 1
+	}
+	b.barWidth = width
+}
@@ -0,0 +1,389 @@
+package clui
+
+import (
+	"fmt"
+	term "github.com/nsf/termbox-go"
+	"testing"
+)
+
+func TestSparkChartRenderToString(t *testing.T) {
+	cases := []struct {
+		data []float64
+		want string
+	}{
+		{[]float64{}, "    \n    \n    "},
+		{[]float64{1, 2, 3, 0}, "  █ \n ██ \n███ "},
+		{[]float64{1, -1}, "█   \n────\n ▓  "},
+	}
+
+	for _, c := range cases {
+		chart := CreateSparkChart(nil, 4, 3, Fixed)
+		chart.SetData(c.data)
+		got := chart.RenderToString()
+		if got != c.want {
+			t.Errorf("RenderToString(%v) == \n%v\nwant\n%v", c.data, got, c.want)
+		}
+	}
+}
+
+func TestSparkChartValueAxisSide(t *testing.T) {
+	chart := CreateSparkChart(nil, 10, 3, Fixed)
+	chart.SetValueWidth(4)
+	chart.SetData([]float64{1, 2, 3})
+
+	if got, want := chart.RenderToString(), "3.00  █   \n     ██   \n    ███   "; got != want {
+		t.Errorf("Invalid left axis:\n%v", got)
+	}
+	chart.SetValueAxisSide(AxisRight)
+	if got, want := chart.RenderToString(), "  █   3.00\n ██       \n███       "; got != want {
+		t.Errorf("Invalid right axis:\n%v", got)
+	}
+}
+
+func TestSparkChartValueFormat(t *testing.T) {
+	chart := CreateSparkChart(nil, 4, 3, Fixed)
+	cases := []struct {
+		format string
+		want   string
+	}{
+		{"", "1400.50"},
+		{"%d", "1401"},
+		{"%.1e", "1.4e+03"},
+		{"%6.1f%%", "1400.5%"},
+		{"%%%d", "%1401"},
+	}
+	for _, c := range cases {
+		chart.SetValueFormat(c.format)
+		if got := chart.formatValue(1400.5); got != c.want {
+			t.Errorf("Format %q: got %q, want %q", c.format, got, c.want)
+		}
+	}
+
+	chart.SetValueFormatter(func(v float64) string { return fmt.Sprintf("%.1fK", v/1000) })
+	if got := chart.formatValue(1400.5); got != "1.4K" {
+		t.Errorf("Formatter must take priority: %q", got)
+	}
+}
+
+func TestSparkChartBraille(t *testing.T) {
+	cases := []struct {
+		data []float64
+		w, h int
+		want string
+	}{
+		{[]float64{}, 2, 2, "  \n  "},
+		// two bars share every character cell
+		{[]float64{1, 2, 3, 4}, 2, 2, " ⣼\n⣼⣿"},
+		// one bar in the last cell
+		{[]float64{4, 2, 1}, 2, 2, "⡇ \n⣿⡄"},
+		// only the last bars that fit the chart are displayed
+		{[]float64{4, 4, 1, 2, 3, 4}, 2, 2, " ⣼\n⣼⣿"},
+		{[]float64{1, 2, 3, 4, 5, 6, 7, 8}, 4, 2, "  ⣠⣾\n⣠⣾⣿⣿"},
+		// negative values are drawn below zero without a baseline row
+		{[]float64{2, -2}, 2, 2, "⡇ \n⢸ "},
+		// the chart one row high does not display anything
+		{[]float64{1, 2}, 2, 1, "  "},
+	}
+
+	for _, c := range cases {
+		chart := CreateSparkChart(nil, c.w, c.h, Fixed)
+		chart.SetRenderMode(SparkRenderBraille)
+		chart.SetData(c.data)
+		if got := chart.RenderToString(); got != c.want {
+			t.Errorf("Braille chart %v ==\n%v\nwant\n%v", c.data, got, c.want)
+		}
+	}
+}
+
+func TestSparkChartBrailleColors(t *testing.T) {
+	mock := CreateMockCanvas(3, 2)
+	defer mock.Close()
+
+	chart := CreateSparkChart(nil, 3, 2, Fixed)
+	chart.SetRenderMode(SparkRenderBraille)
+	chart.SetHiliteMin(true)
+	chart.SetData([]float64{1, -1, 2, 2, 4, 4})
+	chart.Draw()
+
+	cases := []struct {
+		x, y  int
+		color string
+	}{
+		// the cell shared by the minimum and a plain bar is hilited
+		{0, 1, ColorSparkChartMinText},
+		{1, 1, ColorSparkChartBarText},
+		{2, 0, ColorSparkChartMaxText},
+		{2, 1, ColorSparkChartMaxText},
+	}
+	for _, c := range cases {
+		if fg := mock.Cell(c.x, c.y).Fg; fg != RealColor(ColorDefault, c.color) {
+			t.Errorf("Cell %v:%v must have %v color, got %v", c.x, c.y, c.color, fg)
+		}
+	}
+}
+
+func TestSparkChartThreshold(t *testing.T) {
+	cases := []struct {
+		data      []float64
+		threshold float64
+		h         int
+		want      string
+	}{
+		{[]float64{1, 2, 3, 4}, 0, 4, "   █\n  ██\n ███\n████"},
+		// bars are drawn over the line
+		{[]float64{1, 2, 3, 4}, 2, 4, "   █\n  ██\n┄███\n████"},
+		// the threshold out of the displayed range is hidden
+		{[]float64{1, 2, 3, 4}, 10, 4, "   █\n  ██\n ███\n████"},
+		{[]float64{2, -2}, -2, 3, "█   \n────\n┄▓┄┄"},
+		{[]float64{}, 2, 4, "    \n    \n    \n    "},
+	}
+
+	for _, c := range cases {
+		chart := CreateSparkChart(nil, 4, c.h, Fixed)
+		chart.SetThreshold(c.threshold)
+		chart.SetData(c.data)
+		if got := chart.RenderToString(); got != c.want {
+			t.Errorf("Threshold %v for %v ==\n%v\nwant\n%v", c.threshold, c.data, got, c.want)
+		}
+	}
+
+	mock := CreateMockCanvas(4, 4)
+	defer mock.Close()
+	chart := CreateSparkChart(nil, 4, 4, Fixed)
+	chart.SetThreshold(2)
+	chart.SetData([]float64{1, 2, 3, 4})
+	chart.Draw()
+
+	over := RealColor(ColorDefault, ColorSparkChartOverText)
+	if fg := mock.Cell(0, 3).Fg; fg == over {
+		t.Error("The value below the threshold must not be hilited")
+	}
+	for x := 1; x < 4; x++ {
+		if fg := mock.Cell(x, 3).Fg; fg != over {
+			t.Errorf("The value in column %v must be hilited, got %v", x, fg)
+		}
+	}
+	if fg := mock.Cell(0, 2).Fg; fg != over {
+		t.Errorf("Invalid threshold line color %v", fg)
+	}
+}
+
+func TestSparkChartZones(t *testing.T) {
+	mock := CreateMockCanvas(4, 5)
+	defer mock.Close()
+
+	zones := []SparkZone{
+		{Low: 0, High: 1.5, Fg: ColorGreen, Bg: ColorDefault},
+		{Low: 1.5, High: 3, Fg: ColorYellow, Bg: ColorBlue},
+		{Low: 2.5, High: 4, Fg: ColorRed, Bg: ColorDefault},
+	}
+	chart := CreateSparkChart(nil, 4, 5, Fixed)
+	chart.SetHilitePeaks(false)
+	chart.SetZones(zones)
+	zones[0].Fg = ColorCyan
+	if z := chart.Zones(); len(z) != 3 || z[0].Fg != ColorGreen {
+		t.Errorf("SetZones must copy zones: %v", z)
+	}
+	chart.SetData([]float64{1, 2, 3, 5})
+	chart.Draw()
+
+	barBg := RealColor(ColorDefault, ColorSparkChartBarBack)
+	cases := []struct {
+		x      int
+		fg, bg term.Attribute
+	}{
+		{0, ColorGreen, barBg},
+		{1, ColorYellow, ColorBlue},
+		// the first zone that contains the value is used
+		{2, ColorYellow, ColorBlue},
+		// the value out of all zones gets default colors
+		{3, RealColor(ColorDefault, ColorSparkChartBarText), barBg},
+	}
+	for _, c := range cases {
+		if cell := mock.Cell(c.x, 4); cell.Fg != c.fg || cell.Bg != c.bg {
+			t.Errorf("Bar %v colors %v:%v, want %v:%v", c.x, cell.Fg, cell.Bg, c.fg, c.bg)
+		}
+	}
+
+	// the threshold has priority over zones
+	chart.SetThreshold(2)
+	chart.Draw()
+	if fg := mock.Cell(1, 4).Fg; fg != RealColor(ColorDefault, ColorSparkChartOverText) {
+		t.Errorf("Threshold color must override the zone, got %v", fg)
+	}
+	if fg := mock.Cell(0, 4).Fg; fg != ColorGreen {
+		t.Errorf("Zone color expected, got %v", fg)
+	}
+}
+
+// addSparkData adds values from 1 to n to the chart
+func addSparkData(chart *SparkChart, n int) {
+	for i := 1; i <= n; i++ {
+		chart.AddData(float64(i))
+	}
+}
+
+func equalFloats(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSparkChartCapacity(t *testing.T) {
+	cases := []struct {
+		name    string
+		fill    func(*SparkChart)
+		data    []float64
+		visible []float64
+	}{
+		{"no capacity", func(c *SparkChart) { addSparkData(c, 6) },
+			[]float64{3, 4, 5, 6}, []float64{3, 4, 5, 6}},
+		{"wraparound", func(c *SparkChart) {
+			c.SetCapacity(6)
+			addSparkData(c, 8)
+		}, []float64{3, 4, 5, 6, 7, 8}, []float64{5, 6, 7, 8}},
+		{"capacity less than width", func(c *SparkChart) {
+			c.SetCapacity(2)
+			addSparkData(c, 5)
+		}, []float64{4, 5}, []float64{4, 5}},
+		{"shrink capacity", func(c *SparkChart) {
+			c.SetCapacity(6)
+			addSparkData(c, 8)
+			c.SetCapacity(3)
+		}, []float64{6, 7, 8}, []float64{6, 7, 8}},
+		{"reset capacity", func(c *SparkChart) {
+			c.SetCapacity(6)
+			addSparkData(c, 8)
+			c.SetCapacity(-1)
+		}, []float64{5, 6, 7, 8}, []float64{5, 6, 7, 8}},
+		{"grow capacity", func(c *SparkChart) {
+			addSparkData(c, 6)
+			c.SetCapacity(6)
+			c.AddData(7)
+		}, []float64{3, 4, 5, 6, 7}, []float64{4, 5, 6, 7}},
+		{"set data", func(c *SparkChart) {
+			c.SetCapacity(6)
+			c.SetData([]float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10})
+		}, []float64{5, 6, 7, 8, 9, 10}, []float64{7, 8, 9, 10}},
+		{"viewport keeps data", func(c *SparkChart) {
+			c.SetCapacity(6)
+			addSparkData(c, 6)
+			c.SetViewport(2, 0)
+			c.AddData(7)
+		}, []float64{2, 3, 4, 5, 6, 7}, []float64{3, 4, 5, 6}},
+	}
+
+	for _, c := range cases {
+		chart := CreateSparkChart(nil, 4, 3, Fixed)
+		c.fill(chart)
+		if !equalFloats(chart.data, c.data) {
+			t.Errorf("%v: data %v, want %v", c.name, chart.data, c.data)
+		}
+		if got := chart.visibleData(); !equalFloats(got, c.visible) {
+			t.Errorf("%v: visible data %v, want %v", c.name, got, c.visible)
+		}
+	}
+
+	chart := CreateSparkChart(nil, 4, 3, Fixed)
+	chart.SetCapacity(6)
+	addSparkData(chart, 8)
+	if got, want := chart.RenderToString(), "   █\n ███\n████"; got != want {
+		t.Errorf("Only the last data must be displayed:\n%v", got)
+	}
+}
+
+func TestSparkChartViewport(t *testing.T) {
+	cases := []struct {
+		name    string
+		scroll  func(*SparkChart)
+		offset  int
+		visible []float64
+	}{
+		{"default", func(c *SparkChart) {}, -1, []float64{7, 8, 9, 10}},
+		{"offset", func(c *SparkChart) { c.SetViewport(2, 0) }, 2, []float64{3, 4, 5, 6}},
+		{"offset and count", func(c *SparkChart) { c.SetViewport(2, 2) }, 2, []float64{3, 4}},
+		{"offset near the end", func(c *SparkChart) { c.SetViewport(8, 0) }, 8, []float64{9, 10}},
+		{"offset past the end", func(c *SparkChart) { c.SetViewport(20, 0) }, 20, []float64{}},
+		{"negative values", func(c *SparkChart) { c.SetViewport(-5, -3) }, -1, []float64{7, 8, 9, 10}},
+		{"scroll left", func(c *SparkChart) { c.ScrollLeft(3) }, 3, []float64{4, 5, 6, 7}},
+		{"scroll left past the start", func(c *SparkChart) { c.ScrollLeft(100) }, 0, []float64{1, 2, 3, 4}},
+		{"scroll left and right", func(c *SparkChart) {
+			c.ScrollLeft(2)
+			c.ScrollRight(1)
+		}, 5, []float64{6, 7, 8, 9}},
+		{"scroll right past the end", func(c *SparkChart) {
+			c.ScrollLeft(2)
+			c.ScrollRight(5)
+		}, -1, []float64{7, 8, 9, 10}},
+		{"scroll right at the end", func(c *SparkChart) { c.ScrollRight(1) }, -1, []float64{7, 8, 9, 10}},
+		{"scroll with count", func(c *SparkChart) {
+			c.SetViewport(-1, 2)
+			c.ScrollLeft(1)
+		}, 7, []float64{8, 9}},
+		{"new data after scrolling back", func(c *SparkChart) {
+			c.ScrollLeft(2)
+			c.ScrollRight(2)
+			c.AddData(11)
+		}, -1, []float64{8, 9, 10, 11}},
+		{"new data while scrolled", func(c *SparkChart) {
+			c.ScrollLeft(2)
+			c.AddData(11)
+		}, 3, []float64{5, 6, 7, 8}},
+	}
+
+	for _, c := range cases {
+		chart := CreateSparkChart(nil, 4, 3, Fixed)
+		chart.SetCapacity(10)
+		addSparkData(chart, 10)
+		c.scroll(chart)
+		if offset, _ := chart.Viewport(); offset != c.offset {
+			t.Errorf("%v: offset %v, want %v", c.name, offset, c.offset)
+		}
+		if got := chart.visibleData(); !equalFloats(got, c.visible) {
+			t.Errorf("%v: visible data %v, want %v", c.name, got, c.visible)
+		}
+	}
+
+	chart := CreateSparkChart(nil, 4, 3, Fixed)
+	chart.SetCapacity(10)
+	addSparkData(chart, 10)
+	chart.SetViewport(0, 3)
+	if got, want := chart.RenderToString(), "  █ \n ██ \n███ "; got != want {
+		t.Errorf("Autoscale must use only the displayed data:\n%v", got)
+	}
+}
+
+func TestSparkChartMovingAverage(t *testing.T) {
+	cases := []struct {
+		data   []float64
+		window int
+		mode   SparkRenderMode
+		w, h   int
+		want   string
+	}{
+		{[]float64{}, 3, SparkRenderBlock, 4, 4, "    \n    \n    \n    "},
+		{[]float64{2, 4}, 1, SparkRenderBlock, 4, 4, " █  \n █  \n██  \n██  "},
+		// the window is larger than the data: the first averages use
+		// all available values
+		{[]float64{2, 4}, 5, SparkRenderBlock, 4, 4, " █  \n •  \n•█  \n██  "},
+		{[]float64{4, 0, 4, 0}, 2, SparkRenderBlock, 4, 4, "• █ \n█ █ \n█•••\n█ █ "},
+		// in braille mode the mark is drawn for every second bar
+		{[]float64{1, 2, 3, 4}, 4, SparkRenderBraille, 2, 2, " •\n•⣿"},
+	}
+
+	for _, c := range cases {
+		chart := CreateSparkChart(nil, c.w, c.h, Fixed)
+		chart.SetRenderMode(c.mode)
+		chart.SetShowMovingAverage(c.window)
+		chart.SetData(c.data)
+		if got := chart.RenderToString(); got != c.want {
+			t.Errorf("Moving average %v of %v ==\n%v\nwant\n%v", c.window, c.data, got, c.want)
+		}
+	}
+}
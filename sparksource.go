@@ -0,0 +1,103 @@
+package clui
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// sparkDataEvent is the custom event that delivers a value read by
+// the data source to the chart in the main loop
+const sparkDataEvent = "SparkChartData"
+
+// sparkSource is a goroutine that reads values for a SparkChart
+type sparkSource struct {
+	r     io.Reader
+	wnd   *Window
+	token SubscribeToken
+	stop  chan struct{}
+	once  sync.Once
+}
+
+// sparkData is the payload of sparkDataEvent
+type sparkData struct {
+	src   *sparkSource
+	value float64
+}
+
+// SetDataSource starts a goroutine that reads values from r and appends
+// them to the chart. Values are separated with sep, usually '\n'. Every
+// value must be a number, spaces around it are ignored, and other text
+// (e.g, column headers) is skipped. Values are added in the main loop, so
+// the chart is never changed at the same time when it is drawn. The
+// previous data source is stopped, see StopDataSource about readers
+// that are not io.Closer. Returns false if the chart is not inside a
+// Window: the Window event bus delivers the values
+func (b *SparkChart) SetDataSource(r io.Reader, sep byte) bool {
+	b.StopDataSource()
+
+	var wnd *Window
+	for p := Control(b); p != nil; p = p.Parent() {
+		if w, ok := p.(*Window); ok {
+			wnd = w
+		}
+	}
+	if wnd == nil || r == nil {
+		return false
+	}
+
+	src := &sparkSource{r: r, wnd: wnd, stop: make(chan struct{})}
+	src.token = wnd.Subscribe(sparkDataEvent, func(ev CustomEvent) {
+		if data, ok := ev.Payload.(sparkData); ok && data.src == b.source {
+			b.AddData(data.value)
+		}
+	})
+	b.source = src
+	go src.read(sep)
+	return true
+}
+
+// StopDataSource stops reading the data source set with SetDataSource.
+// If the reader is an io.Closer, it is closed, and that unblocks the
+// reading goroutine. A reader that is not an io.Closer cannot be
+// interrupted: the goroutine exits only when the pending read returns,
+// e.g, when the next value arrives, so pass an io.ReadCloser if the
+// source may stay silent. Values that have been read but not added yet
+// are dropped
+func (b *SparkChart) StopDataSource() {
+	src := b.source
+	if src == nil {
+		return
+	}
+
+	b.source = nil
+	src.wnd.Unsubscribe(src.token)
+	src.once.Do(func() {
+		close(src.stop)
+		if c, ok := src.r.(io.Closer); ok {
+			c.Close()
+		}
+	})
+}
+
+// read posts all values of the reader to the Window until the reader
+// ends or the source is stopped
+func (s *sparkSource) read(sep byte) {
+	br := bufio.NewReader(s.r)
+	for {
+		chunk, err := br.ReadString(sep)
+		if v, perr := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(chunk, string(sep))), 64); perr == nil {
+			select {
+			case <-s.stop:
+				return
+			default:
+			}
+			s.wnd.PostEvent(CustomEvent{Type: sparkDataEvent, Payload: sparkData{src: s, value: v}})
+		}
+		if err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package clui
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSparkChartDataSource(t *testing.T) {
+	wnd := CreateWindow(0, 0, 20, 10, "Test")
+	chart := CreateSparkChart(wnd, 10, 5, Fixed)
+
+	if !chart.SetDataSource(strings.NewReader("cpu\n1\n 2.5 \n3"), '\n') {
+		t.Fatal("Data source must start inside a Window")
+	}
+	deadline := time.Now().Add(time.Second)
+	for len(chart.data) < 3 && time.Now().Before(deadline) {
+		wnd.bus.deliver()
+		time.Sleep(time.Millisecond)
+	}
+	if len(chart.data) != 3 || chart.data[1] != 2.5 || chart.data[2] != 3 {
+		t.Errorf("Invalid data %v", chart.data)
+	}
+
+	r, w := io.Pipe()
+	chart.SetDataSource(r, ';')
+	chart.StopDataSource()
+	if _, err := w.Write([]byte("5;")); err == nil {
+		t.Error("StopDataSource must close the reader")
+	}
+	wnd.bus.deliver()
+	if len(chart.data) != 3 {
+		t.Errorf("Stopped source must not add data: %v", chart.data)
+	}
+
+	if CreateSparkChart(nil, 10, 5, Fixed).SetDataSource(strings.NewReader("1"), '\n') {
+		t.Error("Chart outside a Window cannot have a data source")
+	}
+}
@@ -0,0 +1,245 @@
+package clui
+
+import (
+	xs "github.com/huandu/xstrings"
+	"sync"
+	"time"
+)
+
+// SpinnerStyle is a set of animation frames used by Spinner
+type SpinnerStyle int
+
+// Built-in Spinner styles
+const (
+	// SpinnerDots - growing line of dots
+	SpinnerDots SpinnerStyle = iota
+	// SpinnerBraille - rotating Braille pattern
+	SpinnerBraille
+	// SpinnerArc - rotating arc
+	SpinnerArc
+	// SpinnerBounce - a ball bouncing inside brackets
+	SpinnerBounce
+)
+
+var spinnerFrames = map[SpinnerStyle][]string{
+	SpinnerDots:    {".  ", ".. ", "...", " ..", "  .", "   "},
+	SpinnerBraille: {"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"},
+	SpinnerArc:     {"◜", "◠", "◝", "◞", "◡", "◟"},
+	SpinnerBounce:  {"[=   ]", "[ =  ]", "[  = ]", "[   =]", "[  = ]", "[ =  ]"},
+}
+
+/*
+Spinner is a control that shows an animation while an operation with
+unknown duration is running: network calls, database queries etc.
+The label is displayed after the animation.
+
+After Start is called the Spinner changes the animation frame a few
+times per second(see SetFPS) and asks the library to repaint the
+screen. Stop freezes the last displayed frame.
+
+Events:
+
+	OnTick - called every time the animation frame changes. The
+	    callback gets the number of frames displayed since the start.
+	    It is called from the Spinner goroutine
+*/
+type Spinner struct {
+	BaseControl
+	style SpinnerStyle
+	fps   int
+
+	// mtx guards the frame number changed by the Spinner goroutine
+	mtx    sync.Mutex
+	frame  int
+	stop   chan struct{}
+	onTick func(int)
+}
+
+/*
+CreateSpinner creates a new Spinner. The Spinner is stopped.
+parent - is container that keeps the control.
+width - is minimal width of the control.
+label - text displayed after the animation.
+scale - the way of scaling the control when the parent is resized. Use DoNotScale constant if the
+control should keep its original size.
+*/
+func CreateSpinner(parent Control, width int, label string, scale int) *Spinner {
+	s := new(Spinner)
+	s.parent = parent
+	s.fps = 10
+	s.style = SpinnerBraille
+
+	if width == AutoSize {
+		width = s.frameWidth() + 1 + xs.Len(label)
+	}
+
+	s.SetSize(width, 1)
+	s.SetConstraints(width, 1)
+	s.SetTitle(label)
+	s.tabSkip = true
+	s.SetScale(scale)
+
+	if parent != nil {
+		parent.AddChild(s)
+	}
+
+	return s
+}
+
+// frameWidth returns width of the widest frame of the current style
+func (s *Spinner) frameWidth() int {
+	w := 0
+	for _, f := range spinnerFrames[s.style] {
+		if l := xs.Len(f); l > w {
+			w = l
+		}
+	}
+	return w
+}
+
+// currentFrame returns the text of the displayed frame
+func (s *Spinner) currentFrame() string {
+	s.mtx.Lock()
+	frame := s.frame
+	s.mtx.Unlock()
+
+	frames := spinnerFrames[s.style]
+	return frames[frame%len(frames)]
+}
+
+// Draw repaints the control on its View surface
+func (s *Spinner) Draw() {
+	PushAttributes()
+	defer PopAttributes()
+
+	x, y := s.Pos()
+	w, h := s.Size()
+
+	fg, bg := RealColor(s.fg, ColorText), RealColor(s.bg, ColorBack)
+	if !s.Enabled() {
+		fg = RealColor(s.fg, ColorDisabledText)
+	}
+
+	SetTextColor(fg)
+	SetBackColor(bg)
+	FillRect(x, y, w, h, ' ')
+
+	SetTextColor(RealColor(s.fgActive, ColorSpinnerText))
+	DrawRawText(x, y, CutText(s.currentFrame(), w))
+
+	fw := s.frameWidth() + 1
+	if s.title != "" && w > fw {
+		SetTextColor(fg)
+		DrawText(x+fw, y, SliceColorized(s.title, 0, w-fw))
+	}
+}
+
+// tick switches to the next frame and redraws the screen. The
+// frame is not changed if the Spinner has been stopped
+func (s *Spinner) tick(stop chan struct{}) {
+	s.mtx.Lock()
+	select {
+	case <-stop:
+		s.mtx.Unlock()
+		return
+	default:
+	}
+	s.frame++
+	frame := s.frame
+	s.mtx.Unlock()
+
+	if s.onTick != nil {
+		s.onTick(frame)
+	}
+	if loop != nil {
+		PutEvent(Event{Type: EventRedraw})
+	}
+}
+
+// Start starts the animation. It does nothing if the Spinner
+// is already running
+func (s *Spinner) Start() {
+	if s.stop != nil {
+		return
+	}
+
+	stop := make(chan struct{})
+	s.stop = stop
+	ticker := time.NewTicker(time.Second / time.Duration(s.fps))
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.tick(stop)
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the animation. The Spinner keeps displaying the last frame
+func (s *Spinner) Stop() {
+	if s.stop == nil {
+		return
+	}
+
+	s.mtx.Lock()
+	close(s.stop)
+	s.mtx.Unlock()
+	s.stop = nil
+}
+
+// IsRunning returns true if the animation is running
+func (s *Spinner) IsRunning() bool {
+	return s.stop != nil
+}
+
+// Style returns the current animation style
+func (s *Spinner) Style() SpinnerStyle {
+	return s.style
+}
+
+// SetStyle changes the animation style. The control width is not changed
+func (s *Spinner) SetStyle(style SpinnerStyle) {
+	if _, ok := spinnerFrames[style]; !ok {
+		return
+	}
+	s.style = style
+}
+
+// Label returns the text displayed after the animation
+func (s *Spinner) Label() string {
+	return s.title
+}
+
+// SetLabel changes the text displayed after the animation
+func (s *Spinner) SetLabel(label string) {
+	s.SetTitle(label)
+}
+
+// FPS returns the number of frames displayed per second
+func (s *Spinner) FPS() int {
+	return s.fps
+}
+
+// SetFPS changes the number of frames displayed per second. A
+// running Spinner is restarted with the new frame rate
+func (s *Spinner) SetFPS(n int) {
+	if n < 1 || n == s.fps {
+		return
+	}
+
+	s.fps = n
+	if s.IsRunning() {
+		s.Stop()
+		s.Start()
+	}
+}
+
+// OnTick sets the callback that is called every time the
+// animation frame changes
+func (s *Spinner) OnTick(fn func(int)) {
+	s.onTick = fn
+}
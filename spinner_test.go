@@ -0,0 +1,42 @@
+package clui
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSpinnerFrames(t *testing.T) {
+	s := CreateSpinner(nil, 14, "Loading", Fixed)
+	s.SetStyle(SpinnerBounce)
+
+	if out := renderToString(s); !strings.HasPrefix(out, "[=   ] Loading") {
+		t.Errorf("Invalid first frame %q", out)
+	}
+	s.tick(nil)
+	s.tick(nil)
+	if out := renderToString(s); !strings.HasPrefix(out, "[  = ]") {
+		t.Errorf("Invalid third frame %q", out)
+	}
+}
+
+func TestSpinnerStartStop(t *testing.T) {
+	s := CreateSpinner(nil, AutoSize, "", Fixed)
+	s.SetFPS(100)
+	s.Start()
+	if !s.IsRunning() {
+		t.Fatal("Spinner must be running")
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	s.Stop()
+	if s.IsRunning() {
+		t.Error("Spinner must be stopped")
+	}
+
+	frame := s.currentFrame()
+	time.Sleep(50 * time.Millisecond)
+	if s.frame == 0 || s.currentFrame() != frame {
+		t.Errorf("Stopped Spinner must keep the last frame: %v", s.frame)
+	}
+}
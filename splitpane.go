@@ -0,0 +1,451 @@
+package clui
+
+import (
+	term "github.com/nsf/termbox-go"
+)
+
+/*
+SplitPane is a container that displays two controls side by side and
+a divider between them. Horizontal SplitPane puts the first control to
+the left and the second one to the right, Vertical SplitPane puts the
+first control above the second one. The divider position is defined by
+the ratio: the part of the space that the first control takes.
+
+Alt+Arrow moves the divider by one cell when the SplitPane or one of
+its children is active(terminals do not report Ctrl modifier for arrow
+keys, so Alt is used instead). Dragging the divider with mouse moves
+it as well. The divider never makes a pane smaller than its minimal
+size, but if the SplitPane is collapsible, moving the divider further
+collapses the pane entirely, and moving it back restores the pane.
+
+Events:
+
+	OnRatioChange - called every time the divider is moved by a user
+*/
+type SplitPane struct {
+	BaseControl
+	direction   Direction
+	first       Control
+	second      Control
+	ratio       float64
+	minFirst    int
+	minSecond   int
+	collapsible bool
+	dragging    bool
+
+	onRatioChange func(float64)
+}
+
+/*
+CreateSplitPane creates a new SplitPane with the divider in the middle.
+parent - is container that keeps the control.
+width and heigth - are minimal size of the control.
+scale - the way of scaling the control when the parent is resized. Use DoNotScale constant if the
+control should keep its original size.
+*/
+func CreateSplitPane(parent Control, width, height int, scale int) *SplitPane {
+	s := new(SplitPane)
+
+	if width == AutoSize {
+		width = 10
+	}
+	if height == AutoSize {
+		height = 5
+	}
+
+	s.SetSize(width, height)
+	s.SetConstraints(width, height)
+	s.SetTabStop(false)
+	s.SetScale(scale)
+	s.direction = Horizontal
+	s.ratio = 0.5
+	s.parent = parent
+
+	if parent != nil {
+		parent.AddChild(s)
+	}
+
+	return s
+}
+
+// length returns the SplitPane size along the direction of splitting
+func (s *SplitPane) length() int {
+	if s.direction == Horizontal {
+		return s.width
+	}
+	return s.height
+}
+
+// space returns the space for both panes: the size without the divider
+func (s *SplitPane) space() int {
+	if l := s.length(); l > 1 {
+		return l - 1
+	}
+	return 0
+}
+
+// clampFirst adjusts the size of the first pane to the minimal sizes.
+// prev is the size before the change: it defines the direction of the
+// divider move for collapsible SplitPane
+func (s *SplitPane) clampFirst(size, prev int) int {
+	space := s.space()
+	if s.collapsible {
+		if size > 0 && size < s.minFirst {
+			if size < prev {
+				size = 0
+			} else {
+				size = s.minFirst
+			}
+		}
+		if second := space - size; second > 0 && second < s.minSecond {
+			if size > prev {
+				size = space
+			} else {
+				size = space - s.minSecond
+			}
+		}
+	} else {
+		if size > space-s.minSecond {
+			size = space - s.minSecond
+		}
+		if size < s.minFirst {
+			size = s.minFirst
+		}
+	}
+
+	if size > space {
+		size = space
+	}
+	if size < 0 {
+		size = 0
+	}
+	return size
+}
+
+// firstSize returns the size of the first pane
+func (s *SplitPane) firstSize() int {
+	// a hidden pane takes no space like a collapsed one
+	if s.first != nil && !s.first.Visible() {
+		return 0
+	}
+	if s.second != nil && !s.second.Visible() {
+		return s.space()
+	}
+	size := int(s.ratio*float64(s.space()) + 0.5)
+	if s.collapsible && (size == 0 || size == s.space()) {
+		return size
+	}
+	return s.clampFirst(size, size)
+}
+
+// moveDivider sets the new size of the first pane after a user action
+func (s *SplitPane) moveDivider(size int) {
+	space := s.space()
+	if space == 0 {
+		return
+	}
+
+	size = s.clampFirst(size, s.firstSize())
+	ratio := float64(size) / float64(space)
+	if ratio == s.ratio {
+		return
+	}
+
+	s.ratio = ratio
+	s.arrange()
+	if s.onRatioChange != nil {
+		go s.onRatioChange(ratio)
+	}
+}
+
+// paneRect returns the screen area of the first or the second pane
+func (s *SplitPane) paneRect(first bool) (x, y, w, h int) {
+	size := s.firstSize()
+	offset, length := 0, size
+	if !first {
+		offset, length = size+1, s.space()-size
+	}
+
+	if s.direction == Horizontal {
+		return s.x + offset, s.y, length, s.height
+	}
+	return s.x, s.y + offset, s.width, length
+}
+
+// arrange sets sizes and positions of the panes and updates the list of
+// children: a collapsed pane is not a child, so it is not displayed and
+// does not get focus
+func (s *SplitPane) arrange() {
+	s.children = nil
+	for idx, ctrl := range []Control{s.first, s.second} {
+		x, y, w, h := s.paneRect(idx == 0)
+		if ctrl == nil || !ctrl.Visible() || w == 0 || h == 0 {
+			continue
+		}
+		s.children = append(s.children, ctrl)
+		ctrl.SetPos(x, y)
+		ctrl.SetSize(w, h)
+	}
+}
+
+// ResizeChildren recalculates the pane sizes
+func (s *SplitPane) ResizeChildren() {
+	s.arrange()
+	for _, ctrl := range s.children {
+		ctrl.ResizeChildren()
+	}
+}
+
+// PlaceChildren recalculates the pane positions
+func (s *SplitPane) PlaceChildren() {
+	s.arrange()
+	for _, ctrl := range s.children {
+		ctrl.PlaceChildren()
+	}
+}
+
+// MinimalSize returns the size that fits the minimal sizes of both
+// panes and the divider
+func (s *SplitPane) MinimalSize() (int, int) {
+	length := s.minFirst + s.minSecond + 1
+	cross := 0
+	for _, ctrl := range []Control{s.first, s.second} {
+		if ctrl == nil {
+			continue
+		}
+		w, h := ctrl.MinimalSize()
+		if s.direction != Horizontal {
+			w = h
+		}
+		if w > cross {
+			cross = w
+		}
+	}
+
+	w, h := length, cross
+	if s.direction != Horizontal {
+		w, h = cross, length
+	}
+	if w < s.minW {
+		w = s.minW
+	}
+	if h < s.minH {
+		h = s.minH
+	}
+	return w, h
+}
+
+// Draw repaints the panes and the divider
+func (s *SplitPane) Draw() {
+	PushAttributes()
+	defer PopAttributes()
+
+	for _, ctrl := range s.children {
+		// a pane never covers the divider even if its minimal size
+		// is greater than the space it gets
+		PushClipRect(s.paneRect(ctrl == s.first))
+		ctrl.Draw()
+		PopClip()
+	}
+
+	fg, bg := RealColor(s.fg, ColorSplitPaneText), RealColor(s.bg, ColorSplitPaneBack)
+	if s.dragging {
+		fg, bg = RealColor(s.fgActive, ColorSplitPaneActiveText), RealColor(s.bgActive, ColorSplitPaneActiveBack)
+	}
+	SetTextColor(fg)
+	SetBackColor(bg)
+
+	size := s.firstSize()
+	parts := []rune(SysObject(ObjSplitPane))
+	if s.direction == Horizontal {
+		FillRect(s.x+size, s.y, 1, s.height, parts[0])
+	} else {
+		FillRect(s.x, s.y+size, s.width, 1, parts[1])
+	}
+}
+
+// onDivider returns true if the screen point is on the divider
+func (s *SplitPane) onDivider(x, y int) bool {
+	pos := s.firstSize()
+	if s.direction == Horizontal {
+		return x == s.x+pos && y >= s.y && y < s.y+s.height
+	}
+	return y == s.y+pos && x >= s.x && x < s.x+s.width
+}
+
+// processKey moves the divider with Alt+Arrow
+func (s *SplitPane) processKey(ev Event) bool {
+	if ev.Mod != term.ModAlt {
+		return false
+	}
+
+	delta := 0
+	switch {
+	case s.direction == Horizontal && ev.Key == term.KeyArrowLeft,
+		s.direction != Horizontal && ev.Key == term.KeyArrowUp:
+		delta = -1
+	case s.direction == Horizontal && ev.Key == term.KeyArrowRight,
+		s.direction != Horizontal && ev.Key == term.KeyArrowDown:
+		delta = 1
+	default:
+		return false
+	}
+
+	s.moveDivider(s.firstSize() + delta)
+	return true
+}
+
+// processDrag moves the divider with mouse: a user presses the left
+// button over the divider and drags it to the new position
+func (s *SplitPane) processDrag(ev Event) bool {
+	switch {
+	case s.dragging && ev.Key == term.MouseLeft && ev.Mod == term.ModMotion:
+		pos := ev.X - s.x
+		if s.direction != Horizontal {
+			pos = ev.Y - s.y
+		}
+		s.moveDivider(pos)
+		return true
+	case s.dragging && ev.Key == term.MouseRelease:
+		s.dragging = false
+		ReleaseEvents()
+		return true
+	case !s.dragging && ev.Key == term.MouseLeft && ev.Mod == 0 && s.onDivider(ev.X, ev.Y):
+		s.dragging = true
+		GrabEvents(s)
+		return true
+	}
+
+	return false
+}
+
+/*
+ProcessEvent processes all events come from the control parent. If a control
+processes an event it should return true. If the method returns false it means
+that the control do not want or cannot process the event and the caller sends
+the event to the control parent
+*/
+func (s *SplitPane) ProcessEvent(event Event) bool {
+	switch event.Type {
+	case EventKey:
+		if SendEventToChild(s, event) {
+			return true
+		}
+		return s.Enabled() && s.processKey(event)
+	case EventMouse:
+		if s.Enabled() && s.processDrag(event) {
+			return true
+		}
+	}
+
+	return SendEventToChild(s, event)
+}
+
+//----------------- own methods -------------------------
+
+// First returns the control displayed in the first pane
+func (s *SplitPane) First() Control {
+	return s.first
+}
+
+// SetFirst sets the control displayed in the first pane: the left
+// one or the top one
+func (s *SplitPane) SetFirst(ctrl Control) {
+	s.first = s.adopt(ctrl)
+	s.arrange()
+}
+
+// Second returns the control displayed in the second pane
+func (s *SplitPane) Second() Control {
+	return s.second
+}
+
+// SetSecond sets the control displayed in the second pane: the right
+// one or the bottom one
+func (s *SplitPane) SetSecond(ctrl Control) {
+	s.second = s.adopt(ctrl)
+	s.arrange()
+}
+
+// adopt makes the control the SplitPane child
+func (s *SplitPane) adopt(ctrl Control) Control {
+	if ctrl != nil {
+		ctrl.SetParent(s)
+	}
+	return ctrl
+}
+
+// Orientation returns the direction of splitting
+func (s *SplitPane) Orientation() Direction {
+	return s.direction
+}
+
+// SetOrientation sets the direction of splitting: Horizontal puts the
+// panes side by side, Vertical puts the first pane above the second one
+func (s *SplitPane) SetOrientation(dir Direction) {
+	s.direction = dir
+	s.arrange()
+}
+
+// Ratio returns the part of the space that the first pane takes
+func (s *SplitPane) Ratio() float64 {
+	return s.ratio
+}
+
+// SetRatio moves the divider: ratio is the part of the space that the
+// first pane takes, from 0 to 1. The divider position is adjusted to
+// the minimal pane sizes
+func (s *SplitPane) SetRatio(ratio float64) {
+	if ratio < 0 {
+		ratio = 0
+	}
+	if ratio > 1 {
+		ratio = 1
+	}
+	s.ratio = ratio
+	s.arrange()
+}
+
+// MinFirst returns the minimal size of the first pane
+func (s *SplitPane) MinFirst() int {
+	return s.minFirst
+}
+
+// SetMinFirst sets the minimal size of the first pane
+func (s *SplitPane) SetMinFirst(size int) {
+	if size >= 0 {
+		s.minFirst = size
+		s.arrange()
+	}
+}
+
+// MinSecond returns the minimal size of the second pane
+func (s *SplitPane) MinSecond() int {
+	return s.minSecond
+}
+
+// SetMinSecond sets the minimal size of the second pane
+func (s *SplitPane) SetMinSecond(size int) {
+	if size >= 0 {
+		s.minSecond = size
+		s.arrange()
+	}
+}
+
+// Collapsible returns true if a user can collapse a pane entirely
+func (s *SplitPane) Collapsible() bool {
+	return s.collapsible
+}
+
+// SetCollapsible allows or forbids collapsing a pane by moving the
+// divider beyond the pane minimal size
+func (s *SplitPane) SetCollapsible(collapsible bool) {
+	s.collapsible = collapsible
+	s.arrange()
+}
+
+// OnRatioChange sets the callback that is called every time a user
+// moves the divider. The argument is the new ratio
+func (s *SplitPane) OnRatioChange(fn func(float64)) {
+	s.onRatioChange = fn
+}
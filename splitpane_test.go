@@ -0,0 +1,58 @@
+package clui
+
+import (
+	term "github.com/nsf/termbox-go"
+	"testing"
+)
+
+func TestSplitPane(t *testing.T) {
+	initThemeManager()
+	initComposer()
+	split := CreateSplitPane(nil, 21, 3, Fixed)
+	left := CreateFrame(split, 1, 1, BorderNone, Fixed)
+	right := CreateFrame(split, 1, 1, BorderNone, Fixed)
+	split.SetFirst(left)
+	split.SetSecond(right)
+	split.SetMinFirst(4)
+	split.SetMinSecond(4)
+
+	if w, _ := left.Size(); w != 10 {
+		t.Errorf("Invalid first pane width %v", w)
+	}
+	if x, _ := right.Pos(); x != 11 {
+		t.Errorf("Invalid second pane position %v", x)
+	}
+
+	changed := make(chan float64, 10)
+	split.OnRatioChange(func(r float64) { changed <- r })
+	alt := Event{Type: EventKey, Key: term.KeyArrowLeft, Mod: term.ModAlt}
+	if !split.ProcessEvent(alt) {
+		t.Fatal("Alt+Left must move the divider")
+	}
+	if w, _ := left.Size(); w != 9 || <-changed != 0.45 {
+		t.Errorf("Invalid first pane width %v", w)
+	}
+
+	split.SetRatio(0)
+	if w, _ := left.Size(); w != 4 {
+		t.Errorf("The first pane must keep its minimal size: %v", w)
+	}
+
+	split.SetCollapsible(true)
+	split.ProcessEvent(alt)
+	if len(split.Children()) != 1 || split.Children()[0] != right {
+		t.Error("Moving the divider beyond the minimal size must collapse the pane")
+	}
+	split.ProcessEvent(Event{Type: EventKey, Key: term.KeyArrowRight, Mod: term.ModAlt})
+	if w, _ := left.Size(); w != 4 || len(split.Children()) != 2 {
+		t.Errorf("Moving the divider back must restore the pane: %v", w)
+	}
+
+	// dragging the divider
+	split.ProcessEvent(Event{Type: EventMouse, Key: term.MouseLeft, X: 4, Y: 1})
+	split.ProcessEvent(Event{Type: EventMouse, Key: term.MouseLeft, Mod: term.ModMotion, X: 12, Y: 1})
+	split.ProcessEvent(Event{Type: EventMouse, Key: term.MouseRelease, X: 12, Y: 1})
+	if w, _ := left.Size(); w != 12 || split.dragging {
+		t.Errorf("Dragging must move the divider: %v", w)
+	}
+}
@@ -0,0 +1,190 @@
+package clui
+
+import (
+	term "github.com/nsf/termbox-go"
+)
+
+// statusSection is a named slot of StatusBar
+type statusSection struct {
+	id     string
+	text   string
+	width  int
+	align  Align
+	fg, bg term.Attribute
+}
+
+/*
+StatusBar is a one row strip that is docked to the bottom of its parent
+and displays a few named sections. Sections are displayed from left to
+right in the order they were added and are divided with a vertical line.
+A section with zero width takes all the space that is left by the other
+sections.
+
+StatusBar always occupies the bottom row of the parent client area, so
+add it as the last child of a vertically packed container to keep other
+controls above it.
+*/
+type StatusBar struct {
+	BaseControl
+	sections []statusSection
+}
+
+/*
+CreateStatusBar creates a new StatusBar. Its width always equals the
+width of the parent client area.
+parent - is container that keeps the control.
+*/
+func CreateStatusBar(parent Control) *StatusBar {
+	s := new(StatusBar)
+
+	s.SetSize(1, 1)
+	s.SetConstraints(1, 1)
+	s.parent = parent
+	s.tabSkip = true
+	s.SetScale(Fixed)
+	s.sections = make([]statusSection, 0)
+
+	if parent != nil {
+		parent.AddChild(s)
+	}
+
+	return s
+}
+
+// SetPos docks the StatusBar to the bottom of the parent client
+// area. The coordinates are used only if the StatusBar does not
+// have a parent
+func (s *StatusBar) SetPos(x, y int) {
+	if s.parent == nil {
+		s.BaseControl.SetPos(x, y)
+		return
+	}
+
+	px, py := s.parent.Pos()
+	pw, ph := s.parent.Size()
+	padX, padY := s.parent.Paddings()
+
+	s.x, s.y = px+padX, py+ph-padY-1
+	if w := pw - 2*padX; w > 0 {
+		s.width = w
+	}
+}
+
+// SetSize changes the StatusBar width. StatusBar height cannot be
+// changed - it equals 1 always
+func (s *StatusBar) SetSize(width, height int) {
+	s.BaseControl.SetSize(width, 1)
+}
+
+// sectionIndex returns the position of the section or -1 if the
+// section does not exist
+func (s *StatusBar) sectionIndex(id string) int {
+	for idx, sec := range s.sections {
+		if sec.id == id {
+			return idx
+		}
+	}
+	return -1
+}
+
+// sectionWidths returns actual width of every section
+func (s *StatusBar) sectionWidths() []int {
+	widths := make([]int, len(s.sections))
+	left := s.width - len(s.sections) + 1
+	expand := -1
+	for idx, sec := range s.sections {
+		if sec.width == 0 {
+			expand = idx
+			continue
+		}
+		widths[idx] = sec.width
+		left -= sec.width
+	}
+
+	if expand != -1 && left > 0 {
+		widths[expand] = left
+	}
+	return widths
+}
+
+// Draw repaints the control on its View surface
+func (s *StatusBar) Draw() {
+	PushAttributes()
+	defer PopAttributes()
+
+	fg, bg := RealColor(s.fg, ColorStatusBarText), RealColor(s.bg, ColorStatusBarBack)
+	SetTextColor(fg)
+	SetBackColor(bg)
+	FillRect(s.x, s.y, s.width, 1, ' ')
+
+	divider := []rune(SysObject(ObjSingleBorder))[1]
+	x := s.x
+	maxX := s.x + s.width
+	for idx, w := range s.sectionWidths() {
+		if x >= maxX {
+			break
+		}
+		if w > maxX-x {
+			w = maxX - x
+		}
+
+		if idx > 0 {
+			SetTextColor(fg)
+			SetBackColor(bg)
+			PutChar(x-1, s.y, divider)
+		}
+
+		sec := s.sections[idx]
+		SetTextColor(RealColor(sec.fg, ColorStatusBarText))
+		SetBackColor(RealColor(sec.bg, ColorStatusBarBack))
+		FillRect(x, s.y, w, 1, ' ')
+		DrawTextAligned(x, s.y, w, sec.text, sec.align)
+
+		x += w + 1
+	}
+}
+
+// own methods
+
+// AddSection appends a new named section. A section with zero width
+// expands to fill the remaining space, only one such section is
+// allowed. Returns false if a section with the same id exists or
+// the StatusBar already has an expanding section
+func (s *StatusBar) AddSection(id string, width int, align Align) bool {
+	if width < 0 || s.sectionIndex(id) != -1 {
+		return false
+	}
+	if width == 0 {
+		for _, sec := range s.sections {
+			if sec.width == 0 {
+				return false
+			}
+		}
+	}
+
+	s.sections = append(s.sections, statusSection{id: id, width: width, align: align})
+	return true
+}
+
+// SetSection changes the text of the section
+func (s *StatusBar) SetSection(id string, text string) {
+	if idx := s.sectionIndex(id); idx != -1 {
+		s.sections[idx].text = text
+	}
+}
+
+// Section returns the text of the section
+func (s *StatusBar) Section(id string) string {
+	if idx := s.sectionIndex(id); idx != -1 {
+		return s.sections[idx].text
+	}
+	return ""
+}
+
+// SetSectionColors changes text and background colors of the section.
+// Use ColorDefault to return to the theme colors
+func (s *StatusBar) SetSectionColors(id string, fg, bg term.Attribute) {
+	if idx := s.sectionIndex(id); idx != -1 {
+		s.sections[idx].fg, s.sections[idx].bg = fg, bg
+	}
+}
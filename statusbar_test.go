@@ -0,0 +1,47 @@
+package clui
+
+import (
+	"testing"
+)
+
+func TestStatusBar(t *testing.T) {
+	frame := CreateFrame(nil, 22, 6, BorderNone, Fixed)
+	frame.SetPaddings(1, 1)
+	frame.SetPos(2, 3)
+	sb := CreateStatusBar(frame)
+
+	if !sb.AddSection("mode", 6, AlignLeft) || !sb.AddSection("file", 0, AlignLeft) ||
+		!sb.AddSection("pos", 5, AlignRight) {
+		t.Fatal("Failed to add sections")
+	}
+	if sb.AddSection("more", 0, AlignLeft) {
+		t.Error("Only one expanding section is allowed")
+	}
+	if sb.AddSection("pos", 3, AlignLeft) {
+		t.Error("Section ids must be unique")
+	}
+
+	frame.PlaceChildren()
+	if x, y := sb.Pos(); x != 3 || y != 7 {
+		t.Errorf("StatusBar must be docked to the bottom: %v:%v", x, y)
+	}
+	if w, h := sb.Size(); w != 20 || h != 1 {
+		t.Errorf("Invalid StatusBar size: %vx%v", w, h)
+	}
+
+	sb = CreateStatusBar(nil)
+	sb.SetSize(20, 1)
+	sb.AddSection("mode", 6, AlignLeft)
+	sb.AddSection("file", 0, AlignLeft)
+	sb.AddSection("pos", 5, AlignRight)
+	sb.SetSection("mode", "INS")
+	sb.SetSection("file", "main.go")
+	sb.SetSection("pos", "1:1")
+	if sb.Section("file") != "main.go" {
+		t.Errorf("Invalid section text %q", sb.Section("file"))
+	}
+
+	if out := renderToString(sb); out != "INS   │main.go│  1:1" {
+		t.Errorf("Invalid output %q", out)
+	}
+}
@@ -0,0 +1,219 @@
+package clui
+
+import (
+	"fmt"
+	term "github.com/nsf/termbox-go"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// styleRule is a list of properties applied to controls that match the
+// selector: a control type name or #ID
+type styleRule struct {
+	selector string
+	props    map[string]string
+}
+
+// styleSheet keeps the rules loaded with LoadStyleSheet. A selector can
+// appear several times, its rules are applied in the order they were
+// loaded
+type styleSheet struct {
+	rules map[string][]map[string]string
+}
+
+// ID returns the identifier of the control used by style sheet selectors
+func (c *BaseControl) ID() string {
+	return c.id
+}
+
+// SetID changes the identifier of the control. The style sheet rules
+// for the new ID are applied immediately if the control is inside a
+// Window
+func (c *BaseControl) SetID(id string) {
+	c.id = id
+	if self := c.owner(); self != nil {
+		applyStyle(self, false)
+	}
+}
+
+// parseStyleSheet parses the text that consists of rules like:
+//
+//	SparkChart { fg: 2; bg: black }
+//	#cpu { fg: red bold; height: 5 }
+func parseStyleSheet(css string) ([]styleRule, error) {
+	var rules []styleRule
+	rest := css
+	for {
+		open := strings.IndexRune(rest, '{')
+		if open == -1 {
+			if strings.TrimSpace(rest) != "" {
+				return nil, fmt.Errorf("Rule without properties: %q", strings.TrimSpace(rest))
+			}
+			return rules, nil
+		}
+		end := strings.IndexRune(rest, '}')
+		if end < open {
+			return nil, fmt.Errorf("Unclosed rule: %q", strings.TrimSpace(rest))
+		}
+
+		selector := strings.TrimSpace(rest[:open])
+		if selector == "" || strings.ContainsAny(selector, " \t\r\n") {
+			return nil, fmt.Errorf("Invalid selector: %q", selector)
+		}
+
+		rule := styleRule{selector: selector, props: make(map[string]string)}
+		for _, decl := range strings.Split(rest[open+1:end], ";") {
+			decl = strings.TrimSpace(decl)
+			if decl == "" {
+				continue
+			}
+			parts := strings.SplitN(decl, ":", 2)
+			if len(parts) != 2 {
+				return nil, fmt.Errorf("Invalid property %q of %v", decl, selector)
+			}
+			key := strings.ToLower(strings.TrimSpace(parts[0]))
+			value := strings.TrimSpace(parts[1])
+			if err := checkStyleProperty(key, value); err != nil {
+				return nil, fmt.Errorf("%v: %v", selector, err)
+			}
+			rule.props[key] = value
+		}
+		rules = append(rules, rule)
+		rest = rest[end+1:]
+	}
+}
+
+// styleColor converts the property value to a color. The value is a
+// color in the format used by themes: a name with modifiers, an index
+// in 256-color palette, or #RRGGBB
+func styleColor(value string) (term.Attribute, bool) {
+	if strings.ToLower(value) == "default" {
+		return ColorDefault, true
+	}
+	clr := StringToColor(value)
+	return clr, clr&colorMask != 0 || isRGBColor(clr)
+}
+
+// checkStyleProperty returns an error if the property is unknown or its
+// value is invalid
+func checkStyleProperty(key, value string) error {
+	switch key {
+	case "fg", "bg", "activefg", "activebg":
+		if _, ok := styleColor(value); !ok {
+			return fmt.Errorf("Invalid color %q of %v", value, key)
+		}
+	case "width", "height":
+		if n, err := strconv.Atoi(value); err != nil || n < 1 {
+			return fmt.Errorf("Invalid size %q of %v", value, key)
+		}
+	default:
+		return fmt.Errorf("Unknown property %q", key)
+	}
+	return nil
+}
+
+// applyStyleProps changes control properties. Sizes change both the
+// current and the minimal size of the control, like the size arguments
+// of Create functions
+func applyStyleProps(ctrl Control, props map[string]string) {
+	for key, value := range props {
+		switch key {
+		case "fg":
+			clr, _ := styleColor(value)
+			ctrl.SetTextColor(clr)
+		case "bg":
+			clr, _ := styleColor(value)
+			ctrl.SetBackColor(clr)
+		case "activefg":
+			clr, _ := styleColor(value)
+			ctrl.SetActiveTextColor(clr)
+		case "activebg":
+			clr, _ := styleColor(value)
+			ctrl.SetActiveBackColor(clr)
+		}
+	}
+
+	w, h := ctrl.Size()
+	minW, minH := ctrl.Constraints()
+	if v, ok := props["width"]; ok {
+		w, _ = strconv.Atoi(v)
+		minW = w
+	}
+	if v, ok := props["height"]; ok {
+		h, _ = strconv.Atoi(v)
+		minH = h
+	}
+	ctrl.SetConstraints(minW, minH)
+	ctrl.SetSize(w, h)
+}
+
+// typeName returns the name of the control type used by selectors
+func typeName(ctrl Control) string {
+	t := reflect.TypeOf(ctrl)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Name()
+}
+
+// applyStyle applies the style sheet of the Window that contains the
+// control: at first the rules for the control type, and then the rules
+// for its ID, so ID rules win. If byType is false only ID rules are
+// applied
+func applyStyle(ctrl Control, byType bool) {
+	root := ctrl.Parent()
+	for root != nil && root.Parent() != nil {
+		root = root.Parent()
+	}
+	wnd, ok := root.(*Window)
+	if !ok || wnd.styles == nil {
+		return
+	}
+
+	if byType {
+		for _, props := range wnd.styles.rules[typeName(ctrl)] {
+			applyStyleProps(ctrl, props)
+		}
+	}
+	if own, ok := ctrl.(baseOwner); ok && own.baseControl().id != "" {
+		for _, props := range wnd.styles.rules["#"+own.baseControl().id] {
+			applyStyleProps(ctrl, props)
+		}
+	}
+}
+
+// LoadStyleSheet parses the style sheet and applies it to all existing
+// Window children and to every control created inside the Window later.
+// A rule consists of a selector - a control type name(e.g, SparkChart)
+// or # followed by a control ID - and a list of properties in braces.
+// Supported properties: fg, bg, activefg, activebg - colors in the same
+// format as in themes, and width, height - the control size. Every new
+// style sheet is added to the previous ones, the later rules win.
+// Returns an error if the text is invalid, and nothing is applied in
+// this case
+func (c *Window) LoadStyleSheet(css string) error {
+	rules, err := parseStyleSheet(css)
+	if err != nil {
+		return err
+	}
+
+	if c.styles == nil {
+		c.styles = &styleSheet{rules: make(map[string][]map[string]string)}
+	}
+	for _, rule := range rules {
+		c.styles.rules[rule.selector] = append(c.styles.rules[rule.selector], rule.props)
+	}
+
+	var walk func(Control)
+	walk = func(p Control) {
+		for _, ctrl := range p.Children() {
+			applyStyle(ctrl, true)
+			walk(ctrl)
+		}
+	}
+	walk(c)
+	c.ResizeChildren()
+	c.PlaceChildren()
+	return nil
+}
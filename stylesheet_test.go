@@ -0,0 +1,36 @@
+package clui
+
+import (
+	"testing"
+)
+
+func TestStyleSheet(t *testing.T) {
+	wnd := CreateWindow(0, 0, 40, 20, "Test")
+	if err := wnd.LoadStyleSheet("SparkChart { fg: 2; bg: black }\n#cpu { fg: red bold; height: 5 }"); err != nil {
+		t.Fatal(err)
+	}
+
+	chart := CreateSparkChart(wnd, 10, 3, Fixed)
+	if chart.TextColor() != StringToColor("2") || chart.BackColor() != ColorBlack {
+		t.Errorf("Type rule must be applied at creation: %v %v", chart.TextColor(), chart.BackColor())
+	}
+
+	chart.SetID("cpu")
+	if chart.TextColor() != ColorRedBold || chart.BackColor() != ColorBlack {
+		t.Errorf("ID rule must override type rule: %v", chart.TextColor())
+	}
+	if _, h := chart.Size(); h != 5 {
+		t.Errorf("ID rule must change the size: %v", h)
+	}
+
+	lbl := CreateLabel(wnd, 5, 1, "", Fixed)
+	if lbl.TextColor() != ColorDefault {
+		t.Error("Rules must not be applied to other types")
+	}
+
+	for _, css := range []string{"Label { fg: nocolor }", "Label { size: 1 }", "Label fg: red", "Label { fg: red"} {
+		if wnd.LoadStyleSheet(css) == nil {
+			t.Errorf("Invalid style sheet must fail: %q", css)
+		}
+	}
+}
@@ -151,6 +151,9 @@ func CreateTableView(parent Control, width, height int, scale int) *TableView {
 	l.onSelectCell = nil
 	l.lastEventCol = -1
 	l.lastEventRow = -1
+	l.OnMouseScroll(func(delta int) {
+		l.scrollRows(delta * wheelScrollLines)
+	})
 
 	if parent != nil {
 		parent.AddChild(l)
@@ -327,8 +330,7 @@ func (l *TableView) drawCells() {
 			SetTextColor(info.Fg)
 			SetBackColor(info.Bg)
 			FillRect(l.x+dx, l.y+dy, length, 1, ' ')
-			shift, text := AlignColorizedText(info.Text, length, info.Alignment)
-			DrawText(l.x+dx+shift, l.y+dy, text)
+			DrawTextAligned(l.x+dx, l.y+dy, length, info.Text, info.Alignment)
 
 			dx += c.Width
 			if l.showVLines && dx < l.width-1 && colNo < len(l.columns)-1 {
@@ -587,6 +589,19 @@ func (l *TableView) EnsureRowVisible() {
 	}
 }
 
+// scrollRows scrolls the table vertically by dy rows. The selected
+// cell does not change
+func (l *TableView) scrollRows(dy int) {
+	top := l.topRow + dy
+	if max := l.rowCount - (l.height - 3); top > max {
+		top = max
+	}
+	if top < 0 {
+		top = 0
+	}
+	l.topRow = top
+}
+
 func (l *TableView) mouseToCol(dx int) int {
 	shift := l.counterWidth()
 	if l.showVLines {
@@ -0,0 +1,46 @@
+// Package testutil helps to write integration tests for clui applications.
+// Together with clui.MockCanvas it runs the whole application event
+// processing in memory without a terminal
+package testutil
+
+import (
+	ui "github.com/VladimirMarkelov/clui"
+	term "github.com/nsf/termbox-go"
+)
+
+// Simulator injects synthetic events into the same dispatch path the
+// main loop uses for real termbox events. After every event the screen
+// is repainted, like the main loop does
+type Simulator struct{}
+
+// Simulate is used to generate events: Simulate.KeyPress(view, ...)
+var Simulate Simulator
+
+// KeyPress activates the View and sends it a key press event. Use
+// key 0 and non-zero ch to simulate typing a character
+func (Simulator) KeyPress(view *ui.Window, key term.Key, ch rune, mod term.Modifier) {
+	ui.ActivateWindow(view)
+	dispatch(ui.Event{Type: ui.EventKey, Key: key, Ch: ch, Mod: mod})
+}
+
+// MouseClick presses and releases a mouse button at the point of the
+// View. x and y are relative to the View top left corner. button is
+// term.MouseLeft, term.MouseRight, or term.MouseMiddle.
+// A click on a View that is not the top one only activates the View,
+// the same way as it happens with a real mouse
+func (Simulator) MouseClick(view *ui.Window, x, y int, button term.Key) {
+	vx, vy := view.Pos()
+	x, y = x+vx, y+vy
+	dispatch(ui.Event{Type: ui.EventMouse, Key: button, X: x, Y: y})
+	dispatch(ui.Event{Type: ui.EventMouse, Key: term.MouseRelease, X: x, Y: y})
+}
+
+// Resize simulates changing the terminal size
+func (Simulator) Resize(w, h int) {
+	dispatch(ui.Event{Type: ui.EventResize, Width: w, Height: h})
+}
+
+func dispatch(ev ui.Event) {
+	ui.ProcessEvent(ev)
+	ui.RefreshScreen()
+}
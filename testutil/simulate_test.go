@@ -0,0 +1,45 @@
+package testutil
+
+import (
+	ui "github.com/VladimirMarkelov/clui"
+	term "github.com/nsf/termbox-go"
+	"testing"
+)
+
+func TestSimulate(t *testing.T) {
+	m := ui.CreateMockCanvas(40, 12)
+	defer m.Close()
+
+	view := ui.CreateMockView("Form")
+	view.SetPack(ui.Vertical)
+	view.SetConstraints(20, 5)
+	edit := ui.CreateEditField(view, 10, "", 1)
+	check := ui.CreateCheckBox(view, 10, "Agree", 1)
+	view.ResizeChildren()
+	view.PlaceChildren()
+	ui.ActivateControl(view, edit)
+
+	Simulate.KeyPress(view, 0, 'a', 0)
+	Simulate.KeyPress(view, 0, 'b', 0)
+	if edit.Title() != "ab" {
+		t.Errorf("Typed text must go to the edit field: %q", edit.Title())
+	}
+
+	Simulate.KeyPress(view, term.KeyTab, 0, 0)
+	if !check.Active() {
+		t.Error("Tab must move focus to the next control")
+	}
+
+	x, y := check.Pos()
+	vx, vy := view.Pos()
+	Simulate.MouseClick(view, x-vx, y-vy, term.MouseLeft)
+	if check.State() != 1 {
+		t.Errorf("Click must toggle the check box: %v", check.State())
+	}
+	m.AssertTextAt(t, x, y, "[X]")
+
+	Simulate.Resize(30, 6)
+	if w, h := view.Size(); w != 30 || h != 6 {
+		t.Errorf("View must fit the resized screen: %vx%v", w, h)
+	}
+}
@@ -0,0 +1,68 @@
+package testutil
+
+import (
+	"fmt"
+	ui "github.com/VladimirMarkelov/clui"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// UpdateSnapshotsEnv is the environment variable to regenerate golden
+// files: CLUI_UPDATE_SNAPSHOTS=1 go test. An environment variable is
+// used instead of a flag so that it does not clash with the flags of
+// the test packages
+const UpdateSnapshotsEnv = "CLUI_UPDATE_SNAPSHOTS"
+
+// AssertSnapshot compares the View snapshot with the golden file. If the
+// file does not exist or UpdateSnapshotsEnv variable is set, the file is
+// written instead and the assertion passes. Otherwise the test fails
+// with the first row that differs
+func AssertSnapshot(t testing.TB, view *ui.Window, goldenFile string) {
+	t.Helper()
+	got := view.Snapshot()
+
+	data, err := ioutil.ReadFile(goldenFile)
+	if os.Getenv(UpdateSnapshotsEnv) != "" || os.IsNotExist(err) {
+		if err := os.MkdirAll(filepath.Dir(goldenFile), 0755); err != nil {
+			t.Fatalf("Failed to create golden file directory: %v", err)
+		}
+		if err := ioutil.WriteFile(goldenFile, []byte(got), 0644); err != nil {
+			t.Fatalf("Failed to write golden file: %v", err)
+		}
+		t.Logf("Golden file %s written", goldenFile)
+		return
+	}
+	if err != nil {
+		t.Fatalf("Failed to read golden file: %v", err)
+	}
+
+	if diff := snapshotDiff(string(data), got); diff != "" {
+		t.Errorf("Snapshot differs from %s: %s", goldenFile, diff)
+	}
+}
+
+// snapshotDiff returns the description of the first row that differs in
+// two snapshots or an empty string if the snapshots are the same
+func snapshotDiff(expected, got string) string {
+	if expected == got {
+		return ""
+	}
+
+	want, have := strings.Split(expected, "\n"), strings.Split(got, "\n")
+	for i := 0; i < len(want) || i < len(have); i++ {
+		var w, h string
+		if i < len(want) {
+			w = want[i]
+		}
+		if i < len(have) {
+			h = have[i]
+		}
+		if w != h {
+			return fmt.Sprintf("row %d\nexpected: %q\n     got: %q", i, w, h)
+		}
+	}
+	return fmt.Sprintf("%d rows expected, got %d", len(want), len(have))
+}
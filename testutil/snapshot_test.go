@@ -0,0 +1,75 @@
+package testutil
+
+import (
+	ui "github.com/VladimirMarkelov/clui"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type fakeT struct {
+	testing.TB
+	failed bool
+}
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Errorf(format string, args ...interface{}) {
+	f.failed = true
+}
+
+func (f *fakeT) Logf(format string, args ...interface{}) {}
+
+func TestAssertSnapshot(t *testing.T) {
+	m := ui.CreateMockCanvas(20, 5)
+	defer m.Close()
+
+	dir, err := ioutil.TempDir("", "clui")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	golden := filepath.Join(dir, "testdata", "view.golden")
+
+	view := ui.CreateMockView("Snap")
+	lbl := ui.CreateLabel(view, 8, 1, "Hello", ui.Fixed)
+	view.ResizeChildren()
+	view.PlaceChildren()
+
+	AssertSnapshot(t, view, golden)
+	data, err := ioutil.ReadFile(golden)
+	if err != nil || string(data) != view.Snapshot() {
+		t.Fatalf("The first run must create the golden file: %v", err)
+	}
+
+	ft := &fakeT{TB: t}
+	AssertSnapshot(ft, view, golden)
+	if ft.failed {
+		t.Error("The same snapshot must pass")
+	}
+
+	lbl.SetTitle("World")
+	AssertSnapshot(ft, view, golden)
+	if !ft.failed {
+		t.Error("Changed snapshot must fail")
+	}
+
+	os.Setenv(UpdateSnapshotsEnv, "1")
+	defer os.Unsetenv(UpdateSnapshotsEnv)
+	ft = &fakeT{TB: t}
+	AssertSnapshot(ft, view, golden)
+	data, err = ioutil.ReadFile(golden)
+	if ft.failed || err != nil || string(data) != view.Snapshot() {
+		t.Errorf("The golden file must be updated: %v", err)
+	}
+}
+
+func TestSnapshotDiff(t *testing.T) {
+	if d := snapshotDiff("ab\ncd", "ab\ncx"); d != "row 1\nexpected: \"cd\"\n     got: \"cx\"" {
+		t.Errorf("Invalid diff %q", d)
+	}
+	if d := snapshotDiff("ab", "ab"); d != "" {
+		t.Errorf("Same snapshots must not differ: %q", d)
+	}
+}
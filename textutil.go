@@ -1,12 +1,18 @@
 package clui
 
 import (
+	"fmt"
 	xs "github.com/huandu/xstrings"
 	term "github.com/nsf/termbox-go"
 	"regexp"
+	"strconv"
 	"strings"
 )
 
+// colorMask keeps only the color bits of an attribute and drops
+// modifiers like bold or underline
+const colorMask = term.AttrBold - 1
+
 // Ellipsize truncates text to maxWidth by replacing a
 // substring in the middle with ellipsis and keeping
 // the beginning and ending of the string untouched.
@@ -193,7 +199,9 @@ func UnColorizeText(str string) string {
 // Note: some terminals do not support all modifiers, e.g,
 // Windows one understands only bold/bright - it makes the
 // color brighter with the modidierA
-// Examples: "red bold", "green+underline+bold"
+// A number from 0 to 255 is an index in the 256-color palette,
+// and #RRGGBB is a 24-bit color
+// Examples: "red bold", "green+underline+bold", "208 bold", "#FF8000"
 func StringToColor(str string) term.Attribute {
 	var parts []string
 	if strings.ContainsRune(str, '+') {
@@ -231,6 +239,12 @@ func StringToColor(str string) term.Attribute {
 		c, ok := cmap[item]
 		if ok {
 			clr |= c
+		} else if idx, err := strconv.Atoi(item); err == nil && idx >= 0 && idx < 256 {
+			clr |= Color256(uint8(idx))
+		} else if len(item) == 7 && item[0] == '#' {
+			if rgb, err := strconv.ParseUint(item[1:], 16, 32); err == nil {
+				clr |= RGBColor(uint8(rgb>>16), uint8(rgb>>8), uint8(rgb))
+			}
 		}
 	}
 
@@ -244,9 +258,14 @@ func ColorToString(attr term.Attribute) string {
 		"", "black", "red", "green", "yellow",
 		"blue", "magenta", "cyan", "white"}
 
-	rawClr := attr & 15
-	if rawClr < 8 {
+	rawClr := attr & colorMask
+	if isRGBColor(attr) {
+		r, g, b := term.AttributeToRGB(attr)
+		out += fmt.Sprintf("#%02X%02X%02X ", r, g, b)
+	} else if int(rawClr) < len(colors) {
 		out += colors[rawClr] + " "
+	} else {
+		out += strconv.Itoa(int(rawClr)-1) + " "
 	}
 
 	if attr&term.AttrBold != 0 {
@@ -261,3 +280,21 @@ func ColorToString(attr term.Attribute) string {
 
 	return strings.TrimSpace(out)
 }
+
+// wrapText splits the text to lines that are not longer than width.
+// Lines are broken at word boundaries if it is possible
+func wrapText(text string, width int) []string {
+	lines := make([]string, 0)
+	for _, para := range strings.Split(text, "\n") {
+		line := []rune(para)
+		starts := wrapLine(line, width)
+		for idx, start := range starts {
+			end := len(line)
+			if idx < len(starts)-1 {
+				end = starts[idx+1]
+			}
+			lines = append(lines, strings.TrimRight(string(line[start:end]), " "))
+		}
+	}
+	return lines
+}
@@ -141,3 +141,16 @@ func TestUnColorizeText(t *testing.T) {
 		}
 	}
 }
+
+func TestWrapText(t *testing.T) {
+	lines := wrapText("Save the document to disk\nCtrl+S", 12)
+	exp := []string{"Save the", "document to", "disk", "Ctrl+S"}
+	if len(lines) != len(exp) {
+		t.Fatalf("Invalid lines %q", lines)
+	}
+	for idx, line := range lines {
+		if line != exp[idx] {
+			t.Errorf("Line %v: %q expected %q", idx, line, exp[idx])
+		}
+	}
+}
@@ -75,7 +75,7 @@ To see the real world example of full featured theme, please see
 */
 type ThemeManager struct {
 	// available theme list
-	themes map[string]theme
+	themes map[string]Theme
 	// name of the current theme
 	current   string
 	themePath string
@@ -99,12 +99,12 @@ type ThemeDesc struct {
 }
 
 /*
-A theme structure. It keeps all colors, characters for the theme.
+Theme is a theme structure. It keeps all colors, characters for the theme.
 Parent property determines a theme name that is used if a requested
 theme object is not declared in the current one. If no parent is
 defined then the library uses default built-in theme.
 */
-type theme struct {
+type Theme struct {
 	parent  string
 	title   string
 	author  string
@@ -123,23 +123,37 @@ func initThemeManager() {
 // the default theme
 func ThemeReset() {
 	themeManager.current = defaultTheme
-	themeManager.themes = make(map[string]theme, 0)
+	themeManager.themes = make(map[string]Theme, 0)
 
-	defTheme := theme{parent: "", title: "Default Theme", author: "Vladimir V. Markelov", version: "1.0"}
+	defTheme := Theme{parent: "", title: "Default Theme", author: "Vladimir V. Markelov", version: "1.0"}
 	defTheme.colors = make(map[string]term.Attribute, 0)
 	defTheme.objects = make(map[string]string, 0)
 
 	defTheme.objects[ObjSingleBorder] = "─│┌┐└┘"
 	defTheme.objects[ObjDoubleBorder] = "═║╔╗╚╝"
+	defTheme.objects[ObjRoundedBorder] = "─│╭╮╰╯"
+	defTheme.objects[ObjBoldBorder] = "━┃┏┓┗┛"
 	defTheme.objects[ObjEdit] = "←→V"
 	defTheme.objects[ObjScrollBar] = "░■▲▼◄►"
 	defTheme.objects[ObjViewButtons] = "^↓○[]"
-	defTheme.objects[ObjCheckBox] = "[] X?"
+	defTheme.objects[ObjCheckBox] = "[] X~"
 	defTheme.objects[ObjRadio] = "() *"
 	defTheme.objects[ObjProgressBar] = "░▒"
 	defTheme.objects[ObjBarChart] = "█─│┌┐└┘┬┴├┤┼"
-	defTheme.objects[ObjSparkChart] = "█"
+	defTheme.objects[ObjSparkChart] = "█▓─"
 	defTheme.objects[ObjTableView] = "─│┼▼▲"
+	defTheme.objects[ObjSparkChartThreshold] = "┄"
+	defTheme.objects[ObjSparkChartMA] = "•"
+	defTheme.objects[ObjLineChart] = "█"
+	defTheme.objects[ObjAreaChart] = "█░"
+	defTheme.objects[ObjGauge] = "█░"
+	defTheme.objects[ObjHistogram] = "█•"
+	defTheme.objects[ObjTreeView] = "│├└─►▼"
+	defTheme.objects[ObjListBox] = "▶▼"
+	defTheme.objects[ObjSlider] = "▓░█"
+	defTheme.objects[ObjToggle] = "[]█"
+	defTheme.objects[ObjSplitPane] = "│─"
+	defTheme.objects[ObjDraggableButtons] = "_^○[]"
 
 	defTheme.colors[ColorDisabledText] = ColorBlackBold
 	defTheme.colors[ColorDisabledBack] = ColorWhite
@@ -168,8 +182,16 @@ func ThemeReset() {
 	defTheme.colors[ColorEditBack] = ColorWhite
 	defTheme.colors[ColorEditActiveText] = ColorBlack
 	defTheme.colors[ColorEditActiveBack] = ColorWhiteBold
+	defTheme.colors[ColorEditPlaceholder] = ColorBlackBold
+	defTheme.colors[ColorEditActivePlaceholder] = ColorWhite
+	defTheme.colors[ColorEditInvalidBack] = ColorRed
 	defTheme.colors[ColorSelectionText] = ColorYellow
 	defTheme.colors[ColorSelectionBack] = ColorBlue
+	defTheme.colors[ColorFilterMatchText] = ColorRed
+	defTheme.colors[ColorFilterBarText] = ColorBlack
+	defTheme.colors[ColorFilterBarBack] = ColorCyan
+	defTheme.colors[ColorListGroupText] = ColorWhiteBold
+	defTheme.colors[ColorListGroupBack] = ColorBlue
 
 	defTheme.colors[ColorScrollBack] = ColorBlackBold
 	defTheme.colors[ColorScrollText] = ColorWhite
@@ -181,9 +203,57 @@ func ThemeReset() {
 	defTheme.colors[ColorProgressActiveText] = ColorBlack
 	defTheme.colors[ColorProgressActiveBack] = ColorBlueBold
 	defTheme.colors[ColorProgressTitleText] = ColorWhite
+	defTheme.colors[ColorProgressBuffer] = ColorCyan
+
+	defTheme.colors[ColorSliderText] = ColorBlue
+	defTheme.colors[ColorSliderBack] = ColorBlackBold
+	defTheme.colors[ColorSliderActiveText] = ColorBlueBold
+	defTheme.colors[ColorSliderActiveBack] = ColorBlackBold
+	defTheme.colors[ColorSliderThumb] = ColorWhiteBold
+
+	defTheme.colors[ColorToggleOnText] = ColorWhiteBold
+	defTheme.colors[ColorToggleOnBack] = ColorGreen
+	defTheme.colors[ColorToggleOffText] = ColorWhite
+	defTheme.colors[ColorToggleOffBack] = ColorBlackBold
+
+	defTheme.colors[ColorSplitPaneText] = ColorWhite
+	defTheme.colors[ColorSplitPaneBack] = ColorBlackBold
+	defTheme.colors[ColorSplitPaneActiveText] = ColorWhiteBold
+	defTheme.colors[ColorSplitPaneActiveBack] = ColorBlue
+
+	defTheme.colors[ColorSpinnerText] = ColorCyanBold
+
+	defTheme.colors[ColorStatusBarText] = ColorBlack
+	defTheme.colors[ColorStatusBarBack] = ColorWhite
+
+	defTheme.colors[ColorToolbarText] = ColorBlack
+	defTheme.colors[ColorToolbarBack] = ColorWhite
+	defTheme.colors[ColorToolbarActiveText] = ColorWhiteBold
+	defTheme.colors[ColorToolbarActiveBack] = ColorBlue
+	defTheme.colors[ColorToolbarDisabledText] = ColorBlackBold
+
+	defTheme.colors[ColorMenuText] = ColorBlack
+	defTheme.colors[ColorMenuBack] = ColorWhite
+	defTheme.colors[ColorMenuActiveText] = ColorWhiteBold
+	defTheme.colors[ColorMenuActiveBack] = ColorBlue
+	defTheme.colors[ColorMenuDisabledText] = ColorBlackBold
+
+	defTheme.colors[ColorTooltipText] = ColorBlack
+	defTheme.colors[ColorTooltipBack] = ColorYellow
+
+	defTheme.colors[ColorModalBackdrop] = ColorBlack
+
+	defTheme.colors[ColorNotificationInfoText] = ColorWhiteBold
+	defTheme.colors[ColorNotificationInfoBack] = ColorBlue
+	defTheme.colors[ColorNotificationWarningText] = ColorBlack
+	defTheme.colors[ColorNotificationWarningBack] = ColorYellow
+	defTheme.colors[ColorNotificationErrorText] = ColorWhiteBold
+	defTheme.colors[ColorNotificationErrorBack] = ColorRed
 
 	defTheme.colors[ColorBarChartBack] = ColorBlack
 	defTheme.colors[ColorBarChartText] = ColorWhite
+	defTheme.colors[ColorBarChartSelectedBack] = ColorBlue
+	defTheme.colors[ColorBarChartSelectedText] = ColorYellowBold
 
 	defTheme.colors[ColorSparkChartBack] = ColorBlack
 	defTheme.colors[ColorSparkChartText] = ColorWhite
@@ -191,6 +261,70 @@ func ThemeReset() {
 	defTheme.colors[ColorSparkChartBarText] = ColorCyan
 	defTheme.colors[ColorSparkChartMaxBack] = ColorBlack
 	defTheme.colors[ColorSparkChartMaxText] = ColorCyanBold
+	defTheme.colors[ColorSparkChartNegBack] = ColorBlack
+	defTheme.colors[ColorSparkChartNegText] = ColorMagenta
+	defTheme.colors[ColorSparkChartMinBack] = ColorBlack
+	defTheme.colors[ColorSparkChartMinText] = ColorYellowBold
+	defTheme.colors[ColorSparkChartOverBack] = ColorBlack
+	defTheme.colors[ColorSparkChartOverText] = ColorRedBold
+	defTheme.colors[ColorSparkChartStatsBack] = ColorBlack
+	defTheme.colors[ColorSparkChartStatsText] = ColorWhiteBold
+	defTheme.colors[ColorSparkChartMABack] = ColorBlack
+	defTheme.colors[ColorSparkChartMAText] = ColorGreenBold
+	defTheme.colors[ColorLineChartBack] = ColorBlack
+	defTheme.colors[ColorLineChartText] = ColorWhite
+	defTheme.colors[ColorAreaChartBack] = ColorBlack
+	defTheme.colors[ColorAreaChartText] = ColorWhite
+	defTheme.colors[ColorGaugeBack] = ColorBlack
+	defTheme.colors[ColorGaugeText] = ColorWhite
+	defTheme.colors[ColorGaugeNormal] = ColorGreen
+	defTheme.colors[ColorGaugeWarn] = ColorYellow
+	defTheme.colors[ColorGaugeCrit] = ColorRed
+	defTheme.colors[ColorHistogramBack] = ColorBlack
+	defTheme.colors[ColorHistogramText] = ColorWhite
+	defTheme.colors[ColorHistogramBarBack] = ColorBlack
+	defTheme.colors[ColorHistogramBarText] = ColorCyan
+	defTheme.colors[ColorHistogramCDFBack] = ColorBlack
+	defTheme.colors[ColorHistogramCDFText] = ColorYellowBold
+	defTheme.colors[ColorHistogramStatsBack] = ColorBlack
+	defTheme.colors[ColorHistogramStatsText] = ColorWhiteBold
+	defTheme.colors[ColorHeatMapBack] = ColorBlack
+	defTheme.colors[ColorHeatMapText] = ColorWhite
+	defTheme.colors[ColorHeatMapCellBack] = ColorBlue
+	defTheme.colors[ColorWaterfallBack] = ColorBlack
+	defTheme.colors[ColorWaterfallText] = ColorWhite
+	defTheme.colors[ColorWaterfallUp] = ColorGreen
+	defTheme.colors[ColorWaterfallDown] = ColorRed
+	defTheme.colors[ColorWaterfallTotal] = ColorCyan
+	defTheme.colors[ColorDotMatrixOnText] = ColorGreenBold
+	defTheme.colors[ColorDotMatrixOnBack] = ColorBlack
+	defTheme.colors[ColorDotMatrixOffText] = ColorWhite
+	defTheme.colors[ColorDotMatrixOffBack] = ColorBlack
+	defTheme.colors[ColorVUMeterBack] = ColorBlack
+	defTheme.colors[ColorVUMeterText] = ColorWhite
+	defTheme.colors[ColorVUMeterNormal] = ColorGreen
+	defTheme.colors[ColorVUMeterHigh] = ColorYellow
+	defTheme.colors[ColorVUMeterClip] = ColorRed
+	defTheme.colors[ColorScatterPlotBack] = ColorBlack
+	defTheme.colors[ColorScatterPlotText] = ColorWhite
+	defTheme.colors[ColorScatterPlotPoint] = ColorCyanBold
+	defTheme.colors[ColorCandlestickBack] = ColorBlack
+	defTheme.colors[ColorCandlestickText] = ColorWhite
+	defTheme.colors[ColorCandlestickBull] = ColorGreen
+	defTheme.colors[ColorCandlestickBear] = ColorRed
+	defTheme.colors[ColorCandlestickMA] = ColorYellowBold
+	defTheme.colors[ColorProcessListNormal] = ColorWhite
+	defTheme.colors[ColorProcessListWarn] = ColorYellow
+	defTheme.colors[ColorProcessListCrit] = ColorRedBold
+	defTheme.colors[ColorHexDumpBack] = ColorBlack
+	defTheme.colors[ColorHexDumpText] = ColorWhite
+	defTheme.colors[ColorHexDumpOffset] = ColorCyan
+	defTheme.colors[ColorHexDumpHighlightBack] = ColorBlue
+	defTheme.colors[ColorHexDumpHighlightText] = ColorYellowBold
+	defTheme.colors[ColorTimelineBack] = ColorBlack
+	defTheme.colors[ColorTimelineText] = ColorWhite
+	defTheme.colors[ColorTimelineTask] = ColorBlue
+	defTheme.colors[ColorTimelineNow] = ColorRedBold
 
 	defTheme.colors[ColorTableText] = ColorWhite
 	defTheme.colors[ColorTableBack] = ColorBlack
@@ -201,6 +335,10 @@ func ThemeReset() {
 	defTheme.colors[ColorTableLineText] = ColorWhite
 	defTheme.colors[ColorTableHeaderText] = ColorWhite
 	defTheme.colors[ColorTableHeaderBack] = ColorBlack
+	defTheme.colors[ColorDataGridRowText] = ColorWhite
+	defTheme.colors[ColorDataGridRowBack] = ColorBlack
+	defTheme.colors[ColorDataGridAltRowText] = ColorWhite
+	defTheme.colors[ColorDataGridAltRowBack] = ColorBlue
 
 	themeManager.themes[defaultTheme] = defTheme
 }
@@ -230,7 +368,7 @@ func SysColor(color string) term.Attribute {
 			sch = themeManager.themes[sch.parent]
 			clr, okclr = sch.colors[color]
 
-			if ok {
+			if okclr {
 				break
 			} else {
 				if _, okSch := visited[sch.parent]; okSch {
@@ -272,7 +410,7 @@ func SysObject(object string) string {
 			sch = themeManager.themes[sch.parent]
 			obj, okobj = sch.objects[object]
 
-			if ok {
+			if okobj {
 				break
 			} else {
 				if _, okSch := visited[sch.parent]; okSch {
@@ -359,7 +497,7 @@ func (s *ThemeManager) LoadTheme(name string) {
 		return
 	}
 
-	theme := theme{parent: defaultTheme, title: "", author: ""}
+	theme := Theme{parent: defaultTheme, title: "", author: ""}
 	theme.colors = make(map[string]term.Attribute, 0)
 	theme.objects = make(map[string]string, 0)
 
@@ -429,7 +567,7 @@ func (s *ThemeManager) LoadTheme(name string) {
 				}
 			} else {
 				c := StringToColor(value)
-				if c%32 == 0 {
+				if c&colorMask == 0 && !isRGBColor(c) {
 					panic("Failed to read color: " + value)
 				}
 				theme.colors[key] = c
@@ -476,6 +614,8 @@ func ThemeInfo(name string) ThemeDesc {
 // Attribute selection work this way: if color is not ColorDefault,
 // it is returned as is, otherwise the function tries to load
 // color from the theme.
+// Palette and RGB colors are replaced with the closest colors the
+// terminal can display(see adjustColor).
 // clr - current object color
 // id - color ID in theme
 func RealColor(clr term.Attribute, id string) term.Attribute {
@@ -487,5 +627,5 @@ func RealColor(clr term.Attribute, id string) term.Attribute {
 		panic("Failed to load color value for " + id)
 	}
 
-	return clr
+	return adjustColor(clr)
 }
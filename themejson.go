@@ -0,0 +1,189 @@
+package clui
+
+import (
+	"encoding/json"
+	"fmt"
+	term "github.com/nsf/termbox-go"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+// themeJSON is a theme representation in JSON files
+type themeJSON struct {
+	Title   string                     `json:"title,omitempty"`
+	Author  string                     `json:"author,omitempty"`
+	Version string                     `json:"version,omitempty"`
+	Parent  string                     `json:"parent,omitempty"`
+	Colors  map[string]json.RawMessage `json:"colors,omitempty"`
+	Objects map[string]string          `json:"objects,omitempty"`
+}
+
+// MarshalJSON encodes the theme to JSON. Colors are saved as strings
+// in StringToColor format
+func (t Theme) MarshalJSON() ([]byte, error) {
+	tj := themeJSON{Title: t.title, Author: t.author, Version: t.version,
+		Parent: t.parent, Objects: t.objects}
+
+	tj.Colors = make(map[string]json.RawMessage, len(t.colors))
+	for key, clr := range t.colors {
+		value, err := json.Marshal(ColorToString(clr))
+		if err != nil {
+			return nil, err
+		}
+		tj.Colors[key] = value
+	}
+
+	return json.Marshal(tj)
+}
+
+// UnmarshalJSON decodes the theme from JSON. Returns an error if
+// a color value is invalid
+func (t *Theme) UnmarshalJSON(data []byte) error {
+	var tj themeJSON
+	if err := json.Unmarshal(data, &tj); err != nil {
+		return err
+	}
+
+	th := Theme{title: tj.Title, author: tj.Author, version: tj.Version, parent: tj.Parent}
+	if th.parent == "" {
+		th.parent = defaultTheme
+	}
+	th.colors = make(map[string]term.Attribute, len(tj.Colors))
+	th.objects = make(map[string]string, len(tj.Objects))
+
+	for key, raw := range tj.Colors {
+		clr, err := parseJSONColor(raw)
+		if err != nil {
+			return fmt.Errorf("color '%v': %v", key, err)
+		}
+		th.colors[key] = clr
+	}
+	for key, obj := range tj.Objects {
+		th.objects[key] = obj
+	}
+
+	*t = th
+	return nil
+}
+
+// parseJSONColor converts a JSON color value - a palette index or
+// a color description - to attribute
+func parseJSONColor(raw json.RawMessage) (term.Attribute, error) {
+	var idx int
+	if err := json.Unmarshal(raw, &idx); err == nil {
+		if idx < 0 || idx > 255 {
+			return ColorDefault, fmt.Errorf("palette index %v is out of range", idx)
+		}
+		return Color256(uint8(idx)), nil
+	}
+
+	var str string
+	if err := json.Unmarshal(raw, &str); err != nil {
+		return ColorDefault, fmt.Errorf("invalid value %v", string(raw))
+	}
+	clr := StringToColor(str)
+	if clr&colorMask == 0 && !isRGBColor(clr) {
+		return ColorDefault, fmt.Errorf("failed to read color '%v'", str)
+	}
+	return clr, nil
+}
+
+/*
+LoadThemeFromFile reads a theme from the JSON file. Use AddTheme
+to make the loaded theme available for SetCurrentTheme. If the file
+does not set the title, the file name is used.
+
+JSON theme format. All keys are optional:
+
+	{
+	    "title": "My Theme",
+	    "author": "John Doe",
+	    "version": "1.0",
+	    "parent": "default",
+	    "colors": {
+	        "ViewBack": "blue",
+	        "ViewText": "white bold",
+	        "EditBack": 24
+	    },
+	    "objects": {
+	        "SingleBorder": "─│┌┐└┘"
+	    }
+	}
+
+Color keys are the same as in text theme files: values of Color*
+constants. A color value is either a string in StringToColor format
+(e.g, "white bold" or "#FF8000") or a number - an index in the
+256-color palette. Object keys are
+values of Obj* constants. If "parent" is omitted the theme inherits
+the default one, so a theme may define only a few colors and take
+the rest from the built-in theme.
+*/
+func LoadThemeFromFile(path string) (Theme, error) {
+	var t Theme
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return t, err
+	}
+
+	if err = json.Unmarshal(data, &t); err != nil {
+		return t, fmt.Errorf("%v: %v", path, err)
+	}
+	if t.title == "" {
+		t.title = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	return t, nil
+}
+
+// SaveThemeToFile writes the theme to the file in JSON format
+func SaveThemeToFile(tm Theme, path string) error {
+	data, err := json.MarshalIndent(tm, "", "    ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+// AddTheme puts the theme to the theme manager cache with the name,
+// so it can be selected with SetCurrentTheme. An existing theme with
+// the same name is replaced. The default theme cannot be replaced.
+// ThemeReset and SetThemePath remove added themes from the cache
+func AddTheme(name string, tm Theme) {
+	if name == defaultTheme {
+		return
+	}
+	if tm.parent == "" {
+		tm.parent = defaultTheme
+	}
+	themeManager.themes[name] = tm
+}
+
+// Color returns the theme color by its id. ok is false if the
+// theme does not define the color
+func (t Theme) Color(id string) (clr term.Attribute, ok bool) {
+	clr, ok = t.colors[id]
+	return clr, ok
+}
+
+// SetColor changes the theme color
+func (t *Theme) SetColor(id string, clr term.Attribute) {
+	if t.colors == nil {
+		t.colors = make(map[string]term.Attribute)
+	}
+	t.colors[id] = clr
+}
+
+// Object returns the theme object by its id. ok is false if the
+// theme does not define the object
+func (t Theme) Object(id string) (obj string, ok bool) {
+	obj, ok = t.objects[id]
+	return obj, ok
+}
+
+// SetObject changes the theme object
+func (t *Theme) SetObject(id, obj string) {
+	if t.objects == nil {
+		t.objects = make(map[string]string)
+	}
+	t.objects[id] = obj
+}
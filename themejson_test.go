@@ -0,0 +1,76 @@
+package clui
+
+import (
+	term "github.com/nsf/termbox-go"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestThemeJSON(t *testing.T) {
+	initThemeManager()
+	defer ThemeReset()
+
+	dir, err := ioutil.TempDir("", "clui")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "blue.json")
+	data := `{"author": "Test", "colors": {"ViewBack": "blue", "ViewText": "white bold", "EditBack": 24},
+		"objects": {"SingleBorder": "-|++++"}}`
+	if err = ioutil.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tm, err := LoadThemeFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tm.title != "blue" || tm.author != "Test" || tm.parent != defaultTheme {
+		t.Errorf("Invalid theme info: %v/%v/%v", tm.title, tm.author, tm.parent)
+	}
+	if clr, _ := tm.Color(ColorEditBack); clr != term.Attribute(25) {
+		t.Errorf("Palette index must be loaded: %v", clr)
+	}
+
+	AddTheme("blue", tm)
+	if !SetCurrentTheme("blue") {
+		t.Fatal("Added theme must be selectable")
+	}
+	if clr := RealColor(ColorDefault, ColorViewText); clr != ColorWhiteBold {
+		t.Errorf("Theme color expected, got %v", clr)
+	}
+	if clr := RealColor(ColorDefault, ColorButtonBack); clr != ColorGreen {
+		t.Errorf("Missing color must be taken from default theme, got %v", clr)
+	}
+	if obj := SysObject(ObjDoubleBorder); obj != "═║╔╗╚╝" {
+		t.Errorf("Missing object must be taken from default theme, got %v", obj)
+	}
+
+	saved := filepath.Join(dir, "saved.json")
+	if err = SaveThemeToFile(tm, saved); err != nil {
+		t.Fatal(err)
+	}
+	tm2, err := LoadThemeFromFile(saved)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for key, clr := range tm.colors {
+		if c, ok := tm2.Color(key); !ok || c != clr {
+			t.Errorf("Color %v changed after saving: %v -> %v", key, clr, c)
+		}
+	}
+	if obj, _ := tm2.Object(ObjSingleBorder); obj != "-|++++" {
+		t.Errorf("Object changed after saving: %v", obj)
+	}
+
+	if err = ioutil.WriteFile(path, []byte(`{"colors": {"ViewBack": "grean"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = LoadThemeFromFile(path); err == nil {
+		t.Error("Invalid color must return an error")
+	}
+}
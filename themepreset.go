@@ -0,0 +1,136 @@
+package clui
+
+import (
+	term "github.com/nsf/termbox-go"
+	"strings"
+)
+
+// ThemePreset is a built-in theme that does not need theme files
+type ThemePreset int
+
+// Built-in theme presets
+const (
+	// ThemePresetDefault - the default theme
+	ThemePresetDefault ThemePreset = iota
+	// ThemePresetHighContrast - white, yellow, and black colors and
+	// ASCII characters only
+	ThemePresetHighContrast
+	// ThemePresetMonochrome - black and white colors and ASCII
+	// characters only
+	ThemePresetMonochrome
+)
+
+// theme names of presets in the theme manager cache
+const (
+	highContrastTheme = "highcontrast"
+	monochromeTheme   = "monochrome"
+)
+
+// asciiRunes replaces decorative theme characters with ASCII ones
+var asciiRunes = map[rune]rune{
+	'─': '-', '━': '-', '┄': '-', '═': '=',
+	'│': '|', '┃': '|', '║': '|',
+	'┌': '+', '┐': '+', '└': '+', '┘': '+',
+	'╭': '+', '╮': '+', '╰': '+', '╯': '+',
+	'┏': '+', '┓': '+', '┗': '+', '┛': '+',
+	'╔': '#', '╗': '#', '╚': '#', '╝': '#',
+	'┬': '+', '┴': '+', '├': '+', '┤': '+', '┼': '+',
+	'█': '#', '▓': '#', '▒': ':', '░': '.', '■': 'O',
+	'←': '<', '◄': '<', '→': '>', '►': '>', '▶': '>',
+	'▲': '^', '▼': 'v', '↓': 'v', '○': 'x', '•': '*',
+}
+
+// asciiObject returns the theme object with all non-ASCII characters
+// replaced. Unknown characters are replaced with '#'
+func asciiObject(obj string) string {
+	s := []rune(obj)
+	for i, r := range s {
+		if r < 128 {
+			continue
+		}
+		if a, ok := asciiRunes[r]; ok {
+			s[i] = a
+		} else {
+			s[i] = '#'
+		}
+	}
+	return string(s)
+}
+
+// presetColor returns the color for the theme color id: white on
+// black for regular items, black on white for active and selected
+// items, and yellow on black(white bold on black in monochrome mode)
+// for titles, headers, and alerts
+func presetColor(id string, mono bool) term.Attribute {
+	back := strings.HasSuffix(id, "Back") || strings.HasSuffix(id, "Backdrop") ||
+		strings.HasSuffix(id, "Shadow")
+	has := func(parts ...string) bool {
+		for _, p := range parts {
+			if strings.Contains(id, p) {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch {
+	case has("Active", "Selected", "Selection", "Thumb", "ToggleOn"):
+		if back {
+			return ColorWhite
+		}
+		return ColorBlack
+	case back:
+		return ColorBlack
+	case has("Disabled", "Placeholder"):
+		return ColorWhite
+	case has("Title", "Header", "Group", "Match", "Max", "Min", "Over", "Neg",
+		"Warn", "Crit", "Error", "Invalid", "MA"):
+		if mono {
+			return ColorWhiteBold
+		}
+		return ColorYellowBold
+	}
+	return ColorWhiteBold
+}
+
+// contrastTheme creates a theme that overrides every color and object
+// of the default theme
+func contrastTheme(title string, mono bool) Theme {
+	def := themeManager.themes[defaultTheme]
+	t := Theme{parent: defaultTheme, title: title, author: def.author, version: def.version}
+	for id := range def.colors {
+		t.SetColor(id, presetColor(id, mono))
+	}
+	for id, obj := range def.objects {
+		t.SetObject(id, asciiObject(obj))
+	}
+	return t
+}
+
+// HighContrastTheme creates a theme for low-vision users: it uses only
+// white, yellow, and black colors with high contrast pairings, and only
+// ASCII characters to draw borders, scrollbars, and other decorations
+func HighContrastTheme() Theme {
+	return contrastTheme("High Contrast Theme", false)
+}
+
+// MonochromeTheme creates a black and white theme that uses only ASCII
+// characters, for terminals without colors and screen readers
+func MonochromeTheme() Theme {
+	return contrastTheme("Monochrome Theme", true)
+}
+
+// SetThemePreset makes the built-in theme preset the current theme.
+// Repaint the screen to apply the change
+func SetThemePreset(preset ThemePreset) {
+	switch preset {
+	case ThemePresetHighContrast:
+		AddTheme(highContrastTheme, HighContrastTheme())
+		SetCurrentTheme(highContrastTheme)
+	case ThemePresetMonochrome:
+		AddTheme(monochromeTheme, MonochromeTheme())
+		SetCurrentTheme(monochromeTheme)
+	default:
+		SetCurrentTheme(defaultTheme)
+	}
+}
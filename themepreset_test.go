@@ -0,0 +1,44 @@
+package clui
+
+import (
+	"testing"
+)
+
+func TestThemePreset(t *testing.T) {
+	initThemeManager()
+	defer initThemeManager()
+
+	allowed := map[int]bool{
+		int(ColorWhite): true, int(ColorWhiteBold): true,
+		int(ColorBlack): true, int(ColorYellowBold): true,
+	}
+	th := HighContrastTheme()
+	for id, clr := range th.colors {
+		if !allowed[int(clr)] {
+			t.Errorf("Color %v is not high contrast: %v", id, ColorToString(clr))
+		}
+	}
+	for id, obj := range th.objects {
+		for _, r := range obj {
+			if r > 127 {
+				t.Errorf("Object %v is not ASCII: %q", id, obj)
+				break
+			}
+		}
+	}
+	if c, _ := th.Color(ColorButtonActiveBack); c != ColorWhite {
+		t.Errorf("Active items must be black on white: %v", ColorToString(c))
+	}
+	if c, _ := MonochromeTheme().Color(ColorSparkChartMaxText); c != ColorWhiteBold {
+		t.Errorf("Monochrome theme must not use yellow: %v", ColorToString(c))
+	}
+
+	SetThemePreset(ThemePresetHighContrast)
+	if CurrentTheme() != highContrastTheme || SysObject(ObjSingleBorder) != "-|++++" {
+		t.Errorf("High contrast preset is not applied: %v %q", CurrentTheme(), SysObject(ObjSingleBorder))
+	}
+	SetThemePreset(ThemePresetDefault)
+	if CurrentTheme() != defaultTheme {
+		t.Errorf("Default preset is not applied: %v", CurrentTheme())
+	}
+}
@@ -0,0 +1,290 @@
+package clui
+
+import (
+	term "github.com/nsf/termbox-go"
+	"sort"
+	"time"
+)
+
+// timelineTask is a task displayed by Timeline
+type timelineTask struct {
+	id         string
+	label      string
+	start, end time.Time
+	color      term.Attribute
+}
+
+/*
+Timeline is a Gantt-style chart that displays tasks as horizontal
+bars on a time scale: a bar starts at the task start time and ends at
+its end time. The top row displays the start and the end of the
+displayed time range, every next row is a lane of tasks. Tasks that
+overlap in time are placed in different lanes, a task goes to the
+first lane that is free at the task start. Lanes that do not fit
+the control are not displayed.
+Task labels are drawn inside bars if they fit. If NowMarker is true,
+a vertical line marks the current time.
+Arrow Left and Arrow Right scroll the time range by a tenth of its
+length. If the time range is not set, it spans all tasks
+*/
+type Timeline struct {
+	BaseControl
+	tasks      []timelineTask
+	start, end time.Time
+	showNow    bool
+	timeFormat string
+	// now returns the current time, tests replace it
+	now func() time.Time
+}
+
+/*
+CreateTimeline creates a new timeline.
+parent - is container that keeps the control.
+w and h - are minimal size of the control.
+scale - the way of scaling the control when the parent is resized. Use DoNotScale constant if the
+control should keep its original size.
+*/
+func CreateTimeline(parent Control, w, h int, scale int) *Timeline {
+	c := new(Timeline)
+
+	if w == AutoSize {
+		w = 40
+	}
+	if h == AutoSize {
+		h = 5
+	}
+
+	c.parent = parent
+
+	c.SetSize(w, h)
+	c.SetConstraints(w, h)
+	c.SetTabStop(true)
+	c.timeFormat = "15:04"
+	c.now = time.Now
+	c.SetScale(scale)
+
+	if parent != nil {
+		parent.AddChild(c)
+	}
+
+	return c
+}
+
+// Draw repaints the control on its View surface
+func (t *Timeline) Draw() {
+	PushAttributes()
+	defer PopAttributes()
+
+	fg, bg := RealColor(t.fg, ColorTimelineText), RealColor(t.bg, ColorTimelineBack)
+	SetTextColor(fg)
+	SetBackColor(bg)
+	FillRect(t.x, t.y, t.width, t.height, ' ')
+
+	start, end := t.TimeRange()
+	if !end.After(start) || t.width < 1 {
+		return
+	}
+
+	left, right := start.Format(t.timeFormat), end.Format(t.timeFormat)
+	DrawRawText(t.x, t.y, CutText(left, t.width))
+	if shift, s := AlignText(right, t.width, AlignRight); shift > len(left) {
+		DrawRawText(t.x+shift, t.y, s)
+	}
+
+	defClr := RealColor(ColorDefault, ColorTimelineTask)
+	for _, lt := range t.lanes() {
+		row := 1 + lt.lane
+		if row >= t.height {
+			continue
+		}
+
+		from, to := t.column(lt.task.start, start, end), t.column(lt.task.end, start, end)
+		if to <= 0 || from >= t.width {
+			continue
+		}
+		if from < 0 {
+			from = 0
+		}
+		if to > t.width {
+			to = t.width
+		}
+		if to == from {
+			to++
+		}
+
+		clr := lt.task.color
+		if clr == ColorDefault {
+			clr = defClr
+		}
+		SetBackColor(clr)
+		FillRect(t.x+from, t.y+row, to-from, 1, ' ')
+		SetTextColor(contrastColor(clr))
+		DrawRawText(t.x+from, t.y+row, CutText(lt.task.label, to-from))
+	}
+
+	if !t.showNow {
+		return
+	}
+	now := t.now()
+	if now.Before(start) || !now.Before(end) {
+		return
+	}
+	SetTextColor(RealColor(ColorDefault, ColorTimelineNow))
+	SetBackColor(bg)
+	cV := []rune(SysObject(ObjBarChart))[2]
+	col := t.column(now, start, end)
+	for row := 1; row < t.height; row++ {
+		PutChar(t.x+col, t.y+row, cV)
+	}
+}
+
+// column returns the column that displays the moment
+func (t *Timeline) column(tm, start, end time.Time) int {
+	return int(float64(tm.Sub(start)) / float64(end.Sub(start)) * float64(t.width))
+}
+
+// laneTask is a task with the index of its lane
+type laneTask struct {
+	task timelineTask
+	lane int
+}
+
+// lanes places tasks in lanes so that tasks in the same lane do not
+// overlap. Every task goes to the first free lane
+func (t *Timeline) lanes() []laneTask {
+	tasks := append([]timelineTask(nil), t.tasks...)
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return tasks[i].start.Before(tasks[j].start)
+	})
+
+	var ends []time.Time
+	res := make([]laneTask, 0, len(tasks))
+	for _, task := range tasks {
+		lane := len(ends)
+		for idx, e := range ends {
+			if !task.start.Before(e) {
+				lane = idx
+				break
+			}
+		}
+		if lane == len(ends) {
+			ends = append(ends, task.end)
+		} else {
+			ends[lane] = task.end
+		}
+		res = append(res, laneTask{task: task, lane: lane})
+	}
+	return res
+}
+
+/*
+ProcessEvent processes all events come from the control parent. If a control
+processes an event it should return true. If the method returns false it means
+that the control do not want or cannot process the event and the caller sends
+the event to the control parent
+*/
+func (t *Timeline) ProcessEvent(event Event) bool {
+	if !t.Active() || !t.Enabled() || event.Type != EventKey {
+		return false
+	}
+
+	start, end := t.TimeRange()
+	step := end.Sub(start) / 10
+	if step <= 0 {
+		return false
+	}
+
+	switch event.Key {
+	case term.KeyArrowLeft:
+		t.SetTimeRange(start.Add(-step), end.Add(-step))
+	case term.KeyArrowRight:
+		t.SetTimeRange(start.Add(step), end.Add(step))
+	default:
+		return false
+	}
+	return true
+}
+
+// AddTask adds a task to the timeline. The task is drawn with color,
+// use ColorDefault to draw it with ColorTimelineTask color. If a task
+// with the same id exists, it is replaced. Tasks that end before they
+// start are ignored
+func (t *Timeline) AddTask(id string, label string, start, end time.Time, color term.Attribute) {
+	if end.Before(start) {
+		return
+	}
+
+	task := timelineTask{id: id, label: label, start: start, end: end, color: color}
+	for idx := range t.tasks {
+		if t.tasks[idx].id == id {
+			t.tasks[idx] = task
+			return
+		}
+	}
+	t.tasks = append(t.tasks, task)
+}
+
+// RemoveTask removes the task from the timeline. Returns false if the
+// task does not exist
+func (t *Timeline) RemoveTask(id string) bool {
+	for idx := range t.tasks {
+		if t.tasks[idx].id == id {
+			t.tasks = append(t.tasks[:idx], t.tasks[idx+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// ClearTasks removes all tasks
+func (t *Timeline) ClearTasks() {
+	t.tasks = nil
+}
+
+// TimeRange returns the displayed time range. If the range is not set,
+// it is the range from the earliest task start to the latest task end
+func (t *Timeline) TimeRange() (time.Time, time.Time) {
+	if t.end.After(t.start) || len(t.tasks) == 0 {
+		return t.start, t.end
+	}
+
+	start, end := t.tasks[0].start, t.tasks[0].end
+	for _, task := range t.tasks[1:] {
+		if task.start.Before(start) {
+			start = task.start
+		}
+		if task.end.After(end) {
+			end = task.end
+		}
+	}
+	return start, end
+}
+
+// SetTimeRange changes the displayed time range. Use zero times to
+// display all tasks
+func (t *Timeline) SetTimeRange(start, end time.Time) {
+	t.start, t.end = start, end
+}
+
+// NowMarker returns if the timeline marks the current time
+func (t *Timeline) NowMarker() bool {
+	return t.showNow
+}
+
+// SetNowMarker turns on and off the vertical line at the current time
+func (t *Timeline) SetNowMarker(show bool) {
+	t.showNow = show
+}
+
+// TimeFormat returns the layout of the time range displayed in the top
+// row
+func (t *Timeline) TimeFormat() string {
+	return t.timeFormat
+}
+
+// SetTimeFormat changes the layout of the time range displayed in the
+// top row. It is the same as the layout of time.Format. The default
+// one is "15:04"
+func (t *Timeline) SetTimeFormat(layout string) {
+	t.timeFormat = layout
+}
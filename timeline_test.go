@@ -0,0 +1,71 @@
+package clui
+
+import (
+	term "github.com/nsf/termbox-go"
+	"testing"
+	"time"
+)
+
+func TestTimelineDraw(t *testing.T) {
+	mock := CreateMockCanvas(12, 3)
+	defer mock.Close()
+
+	base := time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC)
+	tl := CreateTimeline(nil, 12, 3, Fixed)
+	tl.SetTimeRange(base, base.Add(2*time.Hour))
+	tl.AddTask("a", "A", base, base.Add(time.Hour), ColorDefault)
+	tl.AddTask("b", "B", base.Add(30*time.Minute), base.Add(90*time.Minute), ColorGreen)
+	tl.AddTask("c", "C", base.Add(time.Hour), base.Add(2*time.Hour), ColorDefault)
+	tl.Draw()
+
+	mock.AssertTextAt(t, 0, 0, "10:00  12:00")
+	mock.AssertTextAt(t, 0, 1, "A     C     ")
+	mock.AssertTextAt(t, 0, 2, "   B        ")
+
+	task := RealColor(ColorDefault, ColorTimelineTask)
+	for _, x := range []int{0, 5, 6, 11} {
+		if bg := mock.Cell(x, 1).Bg; bg != task {
+			t.Errorf("Cell %v is not a task bar", x)
+		}
+	}
+	if bg := mock.Cell(2, 2).Bg; bg == ColorGreen {
+		t.Errorf("Cell 2 belongs to task B")
+	}
+	if bg := mock.Cell(3, 2).Bg; bg != ColorGreen {
+		t.Errorf("Cell 3 does not belong to task B")
+	}
+
+	tl.now = func() time.Time { return base.Add(90 * time.Minute) }
+	tl.SetNowMarker(true)
+	tl.Draw()
+	mock.AssertTextAt(t, 0, 1, "A     C  │  ")
+	mock.AssertTextAt(t, 0, 2, "   B     │  ")
+}
+
+func TestTimelineScroll(t *testing.T) {
+	base := time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC)
+	tl := CreateTimeline(nil, 20, 3, Fixed)
+	tl.AddTask("a", "A", base, base.Add(time.Hour), ColorDefault)
+	tl.AddTask("b", "B", base.Add(time.Hour), base.Add(100*time.Minute), ColorDefault)
+
+	if start, end := tl.TimeRange(); !start.Equal(base) || !end.Equal(base.Add(100*time.Minute)) {
+		t.Errorf("Invalid automatic range %v - %v", start, end)
+	}
+
+	tl.SetActive(true)
+	if !tl.ProcessEvent(Event{Type: EventKey, Key: term.KeyArrowRight}) {
+		t.Error("Arrow Right is not processed")
+	}
+	if start, end := tl.TimeRange(); !start.Equal(base.Add(10*time.Minute)) || !end.Equal(base.Add(110*time.Minute)) {
+		t.Errorf("Invalid range %v - %v after scroll", start, end)
+	}
+	tl.ProcessEvent(Event{Type: EventKey, Key: term.KeyArrowLeft})
+	tl.ProcessEvent(Event{Type: EventKey, Key: term.KeyArrowLeft})
+	if start, _ := tl.TimeRange(); !start.Equal(base.Add(-10 * time.Minute)) {
+		t.Errorf("Invalid range start %v after scroll", start)
+	}
+
+	if !tl.RemoveTask("a") || tl.RemoveTask("a") {
+		t.Error("RemoveTask failed")
+	}
+}
@@ -0,0 +1,193 @@
+package clui
+
+import (
+	xs "github.com/huandu/xstrings"
+	term "github.com/nsf/termbox-go"
+	"strings"
+)
+
+/*
+Toggle control is a switch for boolean settings. It displays a track
+with a thumb and the state label: the thumb is at the right side if
+the Toggle is on, and at the left side if it is off. On and off states
+use different colors. The Toggle title is displayed after the track.
+
+Space, Enter or a mouse click flips the state.
+*/
+type Toggle struct {
+	BaseControl
+	on       bool
+	onLabel  string
+	offLabel string
+	animated bool
+
+	onChange func(bool)
+}
+
+/*
+CreateToggle creates a new Toggle control.
+parent - is container that keeps the control.
+width - is minimal width of the control.
+title - text displayed after the track.
+scale - the way of scaling the control when the parent is resized. Use DoNotScale constant if the
+control should keep its original size.
+*/
+func CreateToggle(parent Control, width int, title string, scale int) *Toggle {
+	t := new(Toggle)
+	t.parent = parent
+	t.onLabel = "ON"
+	t.offLabel = "OFF"
+
+	if width == AutoSize {
+		width = t.trackWidth()
+		if title != "" {
+			width += xs.Len(title) + 1
+		}
+	}
+
+	t.SetSize(width, 1)
+	t.SetConstraints(width, 1)
+	t.SetTitle(title)
+	t.SetTabStop(true)
+	t.SetScale(scale)
+
+	if parent != nil {
+		parent.AddChild(t)
+	}
+
+	return t
+}
+
+// trackWidth returns width of the track: brackets, thumb, and the
+// longest label
+func (t *Toggle) trackWidth() int {
+	w := xs.Len(t.onLabel)
+	if l := xs.Len(t.offLabel); l > w {
+		w = l
+	}
+	return w + 3
+}
+
+// Draw repaints the control on its View surface
+func (t *Toggle) Draw() {
+	PushAttributes()
+	defer PopAttributes()
+
+	x, y := t.Pos()
+	w, h := t.Size()
+
+	fg, bg := RealColor(t.fg, ColorControlText), RealColor(t.bg, ColorControlBack)
+	if !t.Enabled() {
+		fg, bg = RealColor(t.fg, ColorControlDisabledText), RealColor(t.bg, ColorControlDisabledBack)
+	} else if t.Active() {
+		fg, bg = RealColor(t.fg, ColorControlActiveText), RealColor(t.bg, ColorControlActiveBack)
+	}
+
+	SetTextColor(fg)
+	SetBackColor(bg)
+	FillRect(x, y, w, h, ' ')
+
+	parts := []rune(SysObject(ObjToggle))
+	cOpen, cClose, cThumb := parts[0], parts[1], parts[2]
+
+	tw := t.trackWidth()
+	if w < tw {
+		return
+	}
+
+	label, trackFg, trackBg := t.offLabel, RealColor(t.fgActive, ColorToggleOffText), RealColor(t.bgActive, ColorToggleOffBack)
+	if t.on {
+		label, trackFg, trackBg = t.onLabel, RealColor(t.fgActive, ColorToggleOnText), RealColor(t.bgActive, ColorToggleOnBack)
+	}
+	label += strings.Repeat(" ", tw-3-xs.Len(label))
+
+	PutChar(x, y, cOpen)
+	PutChar(x+tw-1, y, cClose)
+	SetTextColor(trackFg)
+	SetBackColor(trackBg)
+	if t.on {
+		DrawRawText(x+1, y, label)
+		PutChar(x+tw-2, y, cThumb)
+	} else {
+		PutChar(x+1, y, cThumb)
+		DrawRawText(x+2, y, label)
+	}
+
+	if w > tw+1 {
+		SetTextColor(fg)
+		SetBackColor(bg)
+		DrawTextAligned(x+tw+1, y, w-tw-1, t.title, t.align)
+	}
+}
+
+/*
+ProcessEvent processes all events come from the control parent. If a control
+processes an event it should return true. If the method returns false it means
+that the control do not want or cannot process the event and the caller sends
+the event to the control parent
+*/
+func (t *Toggle) ProcessEvent(event Event) bool {
+	if (!t.Active() && event.Type == EventKey) || !t.Enabled() {
+		return false
+	}
+
+	if (event.Type == EventKey && (event.Key == term.KeySpace || event.Key == term.KeyCtrlM)) ||
+		event.Type == EventClick {
+		t.Toggle()
+		return true
+	}
+
+	return false
+}
+
+// IsOn returns true if the Toggle is on
+func (t *Toggle) IsOn() bool {
+	return t.on
+}
+
+// SetOn changes the Toggle state
+func (t *Toggle) SetOn(on bool) {
+	if on == t.on {
+		return
+	}
+
+	t.on = on
+	if t.onChange != nil {
+		go t.onChange(on)
+	}
+}
+
+// Toggle flips the Toggle state
+func (t *Toggle) Toggle() {
+	t.SetOn(!t.on)
+}
+
+// Labels returns texts displayed inside the track for on and
+// off states
+func (t *Toggle) Labels() (string, string) {
+	return t.onLabel, t.offLabel
+}
+
+// SetLabels changes texts displayed inside the track for on and
+// off states. The control width is not changed
+func (t *Toggle) SetLabels(onLabel, offLabel string) {
+	t.onLabel, t.offLabel = onLabel, offLabel
+}
+
+// SetAnimated enables sliding the thumb when the state changes.
+// The library does not have animation support yet, so the thumb
+// moves instantly
+func (t *Toggle) SetAnimated(animated bool) {
+	t.animated = animated
+}
+
+// Animated returns true if sliding the thumb is enabled
+func (t *Toggle) Animated() bool {
+	return t.animated
+}
+
+// OnChange sets the callback that is called whenever the state
+// of the Toggle is changed. Argument of callback is the new state
+func (t *Toggle) OnChange(fn func(bool)) {
+	t.onChange = fn
+}
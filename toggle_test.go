@@ -0,0 +1,31 @@
+package clui
+
+import (
+	term "github.com/nsf/termbox-go"
+	"strings"
+	"testing"
+)
+
+func TestToggle(t *testing.T) {
+	tg := CreateToggle(nil, AutoSize, "Wi-Fi", Fixed)
+	if w, _ := tg.Size(); w != 12 {
+		t.Errorf("Invalid auto width %v", w)
+	}
+
+	tg.SetActive(true)
+	tg.ProcessEvent(Event{Type: EventKey, Key: term.KeySpace})
+	if !tg.IsOn() {
+		t.Error("Space must turn the toggle on")
+	}
+	if out := renderToString(tg); !strings.HasPrefix(out, "[ON █] Wi-Fi") {
+		t.Errorf("Invalid output %q", out)
+	}
+
+	tg.ProcessEvent(Event{Type: EventKey, Key: term.KeyCtrlM})
+	if tg.IsOn() {
+		t.Error("Enter must turn the toggle off")
+	}
+	if out := renderToString(tg); !strings.HasPrefix(out, "[█OFF] Wi-Fi") {
+		t.Errorf("Invalid output %q", out)
+	}
+}
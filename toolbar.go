@@ -0,0 +1,301 @@
+package clui
+
+import (
+	xs "github.com/huandu/xstrings"
+	term "github.com/nsf/termbox-go"
+)
+
+// ButtonRef is a reference to a Toolbar button
+type ButtonRef int
+
+// toolButton is a Toolbar button or a separator
+type toolButton struct {
+	id        string
+	label     string
+	icon      rune
+	action    func()
+	disabled  bool
+	separator bool
+}
+
+// toolPlace is a position of a visible Toolbar button
+type toolPlace struct {
+	index int
+	x     int
+	width int
+}
+
+/*
+Toolbar is a horizontal strip of buttons. A button displays an optional
+icon(any character, e.g, a Nerd Font glyph or an ASCII one) and a label.
+Separators add a vertical line between groups of buttons.
+
+Tab, Arrow Left, and Arrow Right move the focus between enabled
+buttons. Tab on the last button moves the focus to the next control of
+the window. Enter, Space, or a mouse click runs the button action.
+
+Buttons that do not fit the Toolbar width are hidden and the overflow
+indicator >> is displayed at the right edge.
+*/
+type Toolbar struct {
+	BaseControl
+	buttons []toolButton
+	current int
+}
+
+/*
+CreateToolbar creates a new Toolbar.
+parent - is container that keeps the control.
+width - is minimal width of the control.
+scale - the way of scaling the control when the parent is resized. Use DoNotScale constant if the
+control should keep its original size.
+*/
+func CreateToolbar(parent Control, width int, scale int) *Toolbar {
+	t := new(Toolbar)
+
+	if width == AutoSize {
+		width = 10
+	}
+
+	t.SetSize(width, 1)
+	t.SetConstraints(width, 1)
+	t.parent = parent
+	t.current = -1
+	t.buttons = make([]toolButton, 0)
+
+	t.SetTabStop(true)
+	t.SetScale(scale)
+
+	if parent != nil {
+		parent.AddChild(t)
+	}
+
+	return t
+}
+
+// text returns the text displayed on the button
+func (b toolButton) text() string {
+	if b.separator {
+		return ""
+	}
+	if b.icon == 0 {
+		return " " + b.label + " "
+	}
+	if b.label == "" {
+		return " " + string(b.icon) + " "
+	}
+	return " " + string(b.icon) + " " + b.label + " "
+}
+
+// layout returns positions of visible buttons and true if some
+// buttons do not fit the Toolbar
+func (t *Toolbar) layout() ([]toolPlace, bool) {
+	places := make([]toolPlace, 0, len(t.buttons))
+	x := 0
+	for idx, btn := range t.buttons {
+		w := 1
+		if !btn.separator {
+			w = xs.Len(btn.text())
+		}
+
+		// the last button may use the space of the overflow indicator
+		limit := t.width - 2
+		if idx == len(t.buttons)-1 {
+			limit = t.width
+		}
+		if x+w > limit {
+			return places, true
+		}
+
+		places = append(places, toolPlace{index: idx, x: x, width: w})
+		x += w
+	}
+
+	return places, false
+}
+
+// Draw repaints the control on its View surface
+func (t *Toolbar) Draw() {
+	PushAttributes()
+	defer PopAttributes()
+
+	fg, bg := RealColor(t.fg, ColorToolbarText), RealColor(t.bg, ColorToolbarBack)
+	fgActive, bgActive := RealColor(t.fgActive, ColorToolbarActiveText), RealColor(t.bgActive, ColorToolbarActiveBack)
+	fgDisabled := RealColor(t.fg, ColorToolbarDisabledText)
+
+	SetTextColor(fg)
+	SetBackColor(bg)
+	FillRect(t.x, t.y, t.width, 1, ' ')
+
+	divider := []rune(SysObject(ObjSingleBorder))[1]
+	places, overflow := t.layout()
+	for _, p := range places {
+		btn := t.buttons[p.index]
+		switch {
+		case btn.separator:
+			SetTextColor(fg)
+			SetBackColor(bg)
+			PutChar(t.x+p.x, t.y, divider)
+			continue
+		case btn.disabled || !t.Enabled():
+			SetTextColor(fgDisabled)
+			SetBackColor(bg)
+		case p.index == t.current && t.Active():
+			SetTextColor(fgActive)
+			SetBackColor(bgActive)
+		default:
+			SetTextColor(fg)
+			SetBackColor(bg)
+		}
+		DrawRawText(t.x+p.x, t.y, btn.text())
+	}
+
+	if overflow {
+		SetTextColor(fg)
+		SetBackColor(bg)
+		DrawRawText(t.x+t.width-2, t.y, ">>")
+	}
+}
+
+// focusable returns true if the button can get the focus
+func (t *Toolbar) focusable(idx int, places []toolPlace) bool {
+	if idx < 0 || idx >= len(places) {
+		return false
+	}
+	btn := t.buttons[places[idx].index]
+	return !btn.separator && !btn.disabled
+}
+
+// moveFocus moves the focus to the next or previous enabled visible
+// button. Returns false if there is no such button
+func (t *Toolbar) moveFocus(forward bool) bool {
+	places, _ := t.layout()
+	dir := 1
+	if !forward {
+		dir = -1
+	}
+
+	for idx := t.current + dir; idx >= 0 && idx < len(places); idx += dir {
+		if t.focusable(idx, places) {
+			t.current = idx
+			return true
+		}
+	}
+	return false
+}
+
+// nextTabStop is called by Window when a user presses Tab. The
+// focus leaves the Toolbar after the last button
+func (t *Toolbar) nextTabStop(forward bool) bool {
+	return t.moveFocus(forward)
+}
+
+// press runs the action of the focused button
+func (t *Toolbar) press() {
+	places, _ := t.layout()
+	if !t.focusable(t.current, places) {
+		return
+	}
+
+	if action := t.buttons[places[t.current].index].action; action != nil {
+		go action()
+	}
+}
+
+/*
+ProcessEvent processes all events come from the control parent. If a control
+processes an event it should return true. If the method returns false it means
+that the control do not want or cannot process the event and the caller sends
+the event to the control parent
+*/
+func (t *Toolbar) ProcessEvent(event Event) bool {
+	if event.Type == EventActivate && event.X == 1 {
+		// a Toolbar gets the focus: start from the first button
+		t.current = -1
+		t.moveFocus(true)
+		return true
+	}
+
+	if !t.Active() || !t.Enabled() {
+		return false
+	}
+
+	switch event.Type {
+	case EventKey:
+		switch event.Key {
+		case term.KeyArrowLeft:
+			t.moveFocus(false)
+		case term.KeyArrowRight:
+			t.moveFocus(true)
+		case term.KeyCtrlM, term.KeySpace:
+			t.press()
+		default:
+			return false
+		}
+		return true
+	case EventMouse:
+		if event.Key != term.MouseLeft {
+			return false
+		}
+
+		places, _ := t.layout()
+		dx := event.X - t.x
+		for idx, p := range places {
+			if dx >= p.x && dx < p.x+p.width && t.focusable(idx, places) {
+				t.current = idx
+				t.press()
+				break
+			}
+		}
+		return true
+	}
+
+	return false
+}
+
+// own methods
+
+// AddButton appends a new button. icon can be 0 if the button
+// displays only its label. Returns the reference to the button
+func (t *Toolbar) AddButton(id string, label string, icon rune, action func()) ButtonRef {
+	t.buttons = append(t.buttons, toolButton{id: id, label: label, icon: icon, action: action})
+	return ButtonRef(len(t.buttons) - 1)
+}
+
+// Separator appends a vertical line to divide groups of buttons
+func (t *Toolbar) Separator() {
+	t.buttons = append(t.buttons, toolButton{separator: true})
+}
+
+// Button returns the reference to the button by its id or -1 if
+// the button does not exist
+func (t *Toolbar) Button(id string) ButtonRef {
+	for idx, btn := range t.buttons {
+		if !btn.separator && btn.id == id {
+			return ButtonRef(idx)
+		}
+	}
+	return -1
+}
+
+// SetButtonState enables or disables the button. Disabled buttons
+// are grayed out and cannot get the focus
+func (t *Toolbar) SetButtonState(ref ButtonRef, enabled bool) {
+	if ref < 0 || int(ref) >= len(t.buttons) || t.buttons[ref].separator {
+		return
+	}
+
+	t.buttons[ref].disabled = !enabled
+	places, _ := t.layout()
+	if t.current != -1 && !t.focusable(t.current, places) && !t.moveFocus(true) {
+		t.moveFocus(false)
+	}
+}
+
+// ButtonState returns true if the button is enabled
+func (t *Toolbar) ButtonState(ref ButtonRef) bool {
+	if ref < 0 || int(ref) >= len(t.buttons) {
+		return false
+	}
+	return !t.buttons[ref].disabled
+}
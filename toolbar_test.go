@@ -0,0 +1,42 @@
+package clui
+
+import (
+	term "github.com/nsf/termbox-go"
+	"testing"
+)
+
+func TestToolbar(t *testing.T) {
+	tb := CreateToolbar(nil, 22, Fixed)
+	tb.AddButton("new", "New", '+', nil)
+	open := tb.AddButton("open", "Open", 0, nil)
+	tb.Separator()
+	tb.AddButton("save", "Save", 0, nil)
+	tb.AddButton("quit", "Quit", 0, nil)
+
+	places, overflow := tb.layout()
+	if !overflow || len(places) != 4 {
+		t.Errorf("Invalid layout: %v buttons, overflow %v", len(places), overflow)
+	}
+	if out := renderToString(tb); out != " + New  Open │ Save >>" {
+		t.Errorf("Invalid output %q", out)
+	}
+
+	tb.SetActive(true)
+	tb.ProcessEvent(Event{Type: EventActivate, X: 1})
+	if tb.current != 0 {
+		t.Errorf("The first button must get the focus: %v", tb.current)
+	}
+
+	tb.SetButtonState(open, false)
+	if !tb.nextTabStop(true) || tb.current != 3 {
+		t.Errorf("Disabled buttons and separators must be skipped: %v", tb.current)
+	}
+	if tb.nextTabStop(true) {
+		t.Error("Tab on the last visible button must leave the toolbar")
+	}
+
+	tb.ProcessEvent(Event{Type: EventKey, Key: term.KeyArrowLeft})
+	if tb.current != 0 || tb.Button("save") != 3 {
+		t.Errorf("Invalid focus after Arrow Left: %v", tb.current)
+	}
+}
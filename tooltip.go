@@ -0,0 +1,176 @@
+package clui
+
+import (
+	xs "github.com/huandu/xstrings"
+	term "github.com/nsf/termbox-go"
+	"os"
+	"runtime"
+	"time"
+)
+
+// TooltipDelay is the time the mouse cursor must stay over a control
+// before its tooltip is displayed
+var TooltipDelay = 700 * time.Millisecond
+
+// tooltipMaxWidth is the maximal width of a tooltip text. Longer
+// lines are wrapped at word boundaries
+const tooltipMaxWidth = 40
+
+// Terminal sequences to turn on and off reporting mouse moves when no mouse
+// button is pressed. termbox asks a terminal to report mouse moves only
+// while a button is down, and tooltips need all moves
+const (
+	mouseHoverOn  = "\x1b[?1003h"
+	mouseHoverOff = "\x1b[?1003l"
+)
+
+// mouseHover is true if mouse move reporting was turned on by
+// startMouseHover and must be turned off when the library is closed
+var mouseHover bool
+
+// startMouseHover asks the terminal to report all mouse moves. It does
+// nothing if mode does not include mouse input or out is not a terminal,
+// e.g, the output is redirected to a file. Returns true if the reporting
+// is turned on
+func startMouseHover(out *os.File, mode term.InputMode) bool {
+	if runtime.GOOS == "windows" || mode&term.InputMouse == 0 || !isTerminal(out) {
+		return false
+	}
+	out.WriteString(mouseHoverOn)
+	return true
+}
+
+// stopMouseHover turns off reporting mouse moves if startMouseHover
+// turned it on
+func stopMouseHover(out *os.File) {
+	if mouseHover {
+		out.WriteString(mouseHoverOff)
+		mouseHover = false
+	}
+}
+
+// isTerminal returns true if the file is a character device
+func isTerminal(f *os.File) bool {
+	st, err := f.Stat()
+	return err == nil && st.Mode()&os.ModeCharDevice != 0
+}
+
+// tooltipBox is a floating box with a tooltip text. It is drawn on the
+// overlay layer
+type tooltipBox struct {
+	BaseControl
+	lines []string
+}
+
+func newTooltipBox(text string) *tooltipBox {
+	t := new(tooltipBox)
+	t.lines = wrapText(text, tooltipMaxWidth)
+
+	w := 0
+	for _, line := range t.lines {
+		if l := xs.Len(line); l > w {
+			w = l
+		}
+	}
+	t.SetSize(w+2, len(t.lines))
+
+	return t
+}
+
+// place moves the box below the control or above it if there is
+// not enough space below
+func (t *tooltipBox) place(ctrl Control) {
+	cx, cy := ctrl.Pos()
+	_, ch := ctrl.Size()
+	sw, sh := ScreenSize()
+
+	x, y := cx, cy+ch
+	if y+t.height > sh {
+		y = cy - t.height
+	}
+	if x+t.width > sw {
+		x = sw - t.width
+	}
+	if x < 0 {
+		x = 0
+	}
+	if y < 0 {
+		y = 0
+	}
+	t.SetPos(x, y)
+}
+
+// Draw repaints the box on the overlay layer
+func (t *tooltipBox) Draw() {
+	PushAttributes()
+	defer PopAttributes()
+
+	SetTextColor(RealColor(t.fg, ColorTooltipText))
+	SetBackColor(RealColor(t.bg, ColorTooltipBack))
+	FillRect(t.x, t.y, t.width, t.height, ' ')
+	for idx, line := range t.lines {
+		DrawRawText(t.x+1, t.y+idx, line)
+	}
+}
+
+// tooltipOwner returns the control or its closest parent that has
+// a tooltip. Returns nil if there is no such control
+func tooltipOwner(ctrl Control) Control {
+	for ctrl != nil {
+		if ctrl.Tooltip() != "" {
+			return ctrl
+		}
+		ctrl = ctrl.Parent()
+	}
+	return nil
+}
+
+// processHover restarts the tooltip timer when the mouse cursor moves
+// to another control
+func (c *Composer) processHover(ev Event) {
+	var ctrl Control
+	if wnd, hit := c.checkWindowUnderMouse(ev.X, ev.Y); wnd != nil && hit == HitInside {
+		ctrl = tooltipOwner(ChildAt(wnd, ev.X, ev.Y))
+	}
+	if ctrl == c.hover {
+		return
+	}
+
+	c.hideTooltip()
+	if ctrl == nil {
+		return
+	}
+
+	c.hover = ctrl
+	seq := c.hoverSeq
+	c.hoverTimer = time.AfterFunc(TooltipDelay, func() {
+		PutEvent(Event{Type: EventTooltip, X: seq})
+	})
+}
+
+// showTooltip displays the tooltip of the hovered control if the
+// mouse cursor has not left the control since the timer started
+func (c *Composer) showTooltip(seq int) {
+	if seq != c.hoverSeq || c.hover == nil || c.tooltip != nil {
+		return
+	}
+
+	c.tooltip = newTooltipBox(c.hover.Tooltip())
+	c.tooltip.place(c.hover)
+	showOverlay(c.tooltip)
+}
+
+// hideTooltip closes the displayed tooltip and cancels the pending one
+func (c *Composer) hideTooltip() {
+	if c.hoverTimer != nil {
+		c.hoverTimer.Stop()
+		c.hoverTimer = nil
+	}
+	c.hoverSeq++
+	c.hover = nil
+
+	if c.tooltip != nil {
+		hideOverlay(c.tooltip)
+		c.tooltip = nil
+	}
+}
@@ -0,0 +1,79 @@
+package clui
+
+import (
+	term "github.com/nsf/termbox-go"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestTooltipHover(t *testing.T) {
+	initComposer()
+	saved, savedDelay := canvas, TooltipDelay
+	canvas = newMemoryCanvas(40, 20)
+	TooltipDelay = time.Hour
+	defer func() { canvas, TooltipDelay = saved, savedDelay }()
+
+	wnd := CreateWindow(0, 0, 30, 10, "Test")
+	comp.windows = append(comp.windows, wnd)
+	btn := CreateButton(wnd, 8, 2, "Ok", Fixed)
+	btn.SetTooltip("Apply changes")
+	wnd.PlaceChildren()
+	bx, by := btn.Pos()
+	_, bh := btn.Size()
+
+	hover := Event{Type: EventMouse, Key: term.MouseRelease, Mod: term.ModMotion, X: bx, Y: by}
+	comp.processMouse(hover)
+	if comp.hover != btn {
+		t.Fatal("The hovered button must start the tooltip timer")
+	}
+	comp.showTooltip(comp.hoverSeq - 1)
+	if comp.tooltip != nil {
+		t.Error("Outdated timer must not display the tooltip")
+	}
+	comp.showTooltip(comp.hoverSeq)
+	if comp.tooltip == nil || len(comp.overlays) != 1 {
+		t.Fatal("The tooltip must be displayed")
+	}
+	if x, y := comp.tooltip.Pos(); x != bx || y != by+bh {
+		t.Errorf("The tooltip must be below the control: %v:%v", x, y)
+	}
+	if out := renderToString(comp.tooltip); out != " Apply changes " {
+		t.Errorf("Invalid output %q", out)
+	}
+
+	hover.X, hover.Y = 20, 8
+	comp.processMouse(hover)
+	if comp.hover != nil || comp.tooltip != nil || len(comp.overlays) != 0 {
+		t.Error("The tooltip must disappear when the mouse moves away")
+	}
+}
+
+func TestMouseHoverNotTerminal(t *testing.T) {
+	f, err := ioutil.TempFile("", "clui")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	if isTerminal(f) {
+		t.Error("A regular file is not a terminal")
+	}
+	for _, mode := range []term.InputMode{term.InputEsc, term.InputAlt | term.InputMouse} {
+		if startMouseHover(f, mode) {
+			t.Errorf("Mouse moves reporting must be off for mode %v", mode)
+		}
+	}
+
+	mouseHover = false
+	stopMouseHover(f)
+	st, err := f.Stat()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.Size() != 0 {
+		t.Errorf("Nothing must be written to a file: %v", st.Size())
+	}
+}
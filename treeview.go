@@ -0,0 +1,509 @@
+package clui
+
+import (
+	xs "github.com/huandu/xstrings"
+	term "github.com/nsf/termbox-go"
+)
+
+// TreeNode is an item of TreeView. A node displays its children
+// only if it is expanded
+type TreeNode struct {
+	Label    string
+	Children []*TreeNode
+	Expanded bool
+}
+
+// AddChild appends a new child to the node and returns the child
+func (n *TreeNode) AddChild(label string) *TreeNode {
+	child := &TreeNode{Label: label}
+	n.Children = append(n.Children, child)
+	return child
+}
+
+// treeRow is a visible node of TreeView with extra information
+// required to draw it
+type treeRow struct {
+	node   *TreeNode
+	parent *TreeNode
+	depth  int
+	// for every level from 1 to depth: whether the node or its
+	// ancestor at the level is the last child of its parent
+	last []bool
+}
+
+/*
+TreeView is control to display hierarchical data and allow to user
+to select any node. Nodes with children can be expanded and collapsed.
+Content is scrollable with arrow keys or by clicking up and bottom
+buttons on the scroll.
+
+Keyboard navigation: arrow up and down, Home, End, PgUp, and PgDn
+change the selected node. Enter and Space toggle the selected node.
+Arrow right expands the selected node or selects its first child if
+the node is already expanded. Arrow left collapses the selected node
+or selects its parent if the node is already collapsed.
+
+TreeView calls onSelect function every time a user changes the
+selected node with mouse or using keyboard.
+*/
+type TreeView struct {
+	BaseControl
+	root      *TreeNode
+	selected  *TreeNode
+	topLine   int
+	buttonPos int
+
+	onSelect   func(*TreeNode)
+	onKeyPress func(term.Key) bool
+}
+
+/*
+CreateTreeView creates a new tree view.
+parent - is container that keeps the control.
+width and heigth - are minimal size of the control.
+scale - the way of scaling the control when the parent is resized. Use DoNotScale constant if the
+control should keep its original size.
+*/
+func CreateTreeView(parent Control, width, height int, scale int) *TreeView {
+	t := new(TreeView)
+
+	if height == AutoSize {
+		height = 3
+	}
+	if width == AutoSize {
+		width = 10
+	}
+
+	t.SetSize(width, height)
+	t.SetConstraints(width, height)
+	t.parent = parent
+	t.buttonPos = -1
+
+	t.SetTabStop(true)
+	t.SetScale(scale)
+
+	if parent != nil {
+		parent.AddChild(t)
+	}
+
+	return t
+}
+
+// visibleRows returns all nodes that are displayed: the root
+// and children of expanded nodes
+func (t *TreeView) visibleRows() []treeRow {
+	rows := make([]treeRow, 0)
+	if t.root == nil {
+		return rows
+	}
+
+	var walk func(node, parent *TreeNode, depth int, last []bool)
+	walk = func(node, parent *TreeNode, depth int, last []bool) {
+		rows = append(rows, treeRow{node: node, parent: parent, depth: depth, last: last})
+		if !node.Expanded {
+			return
+		}
+
+		for idx, child := range node.Children {
+			childLast := make([]bool, len(last), len(last)+1)
+			copy(childLast, last)
+			childLast = append(childLast, idx == len(node.Children)-1)
+			walk(child, node, depth+1, childLast)
+		}
+	}
+	walk(t.root, nil, 0, []bool{})
+
+	return rows
+}
+
+// selectedIndex returns the index of the selected node in the
+// list of visible ones or -1 if no node is selected
+func (t *TreeView) selectedIndex(rows []treeRow) int {
+	return t.rowIndex(rows, t.selected)
+}
+
+func (t *TreeView) drawScroll(rows []treeRow) {
+	PushAttributes()
+	defer PopAttributes()
+
+	pos := ThumbPosition(t.selectedIndex(rows), len(rows), t.height)
+	t.buttonPos = pos
+
+	DrawScrollBar(t.x+t.width-1, t.y, 1, t.height, pos)
+}
+
+// rowPrefix returns the tree lines and the expand mark that are
+// displayed before the node label
+func rowPrefix(r treeRow) string {
+	parts := []rune(SysObject(ObjTreeView))
+	chV, chT, chL, chH := parts[0], parts[1], parts[2], parts[3]
+	chCollapsed, chExpanded := parts[4], parts[5]
+
+	prefix := make([]rune, 0, r.depth*2+2)
+	for level := 1; level < r.depth; level++ {
+		if r.last[level-1] {
+			prefix = append(prefix, ' ', ' ')
+		} else {
+			prefix = append(prefix, chV, ' ')
+		}
+	}
+	if r.depth > 0 {
+		if r.last[r.depth-1] {
+			prefix = append(prefix, chL, chH)
+		} else {
+			prefix = append(prefix, chT, chH)
+		}
+	}
+
+	if len(r.node.Children) == 0 {
+		prefix = append(prefix, ' ')
+	} else if r.node.Expanded {
+		prefix = append(prefix, chExpanded)
+	} else {
+		prefix = append(prefix, chCollapsed)
+	}
+
+	return string(prefix)
+}
+
+func (t *TreeView) drawItems(rows []treeRow) {
+	PushAttributes()
+	defer PopAttributes()
+
+	maxWidth := t.width - 1
+
+	fg, bg := RealColor(t.fg, ColorEditText), RealColor(t.bg, ColorEditBack)
+	if t.Active() {
+		fg, bg = RealColor(t.fg, ColorEditActiveText), RealColor(t.bg, ColorEditActiveBack)
+	}
+	fgSel, bgSel := RealColor(t.fgActive, ColorSelectionText), RealColor(t.bgActive, ColorSelectionBack)
+
+	for dy := 0; dy < t.height && t.topLine+dy < len(rows); dy++ {
+		r := rows[t.topLine+dy]
+		prefix := rowPrefix(r)
+
+		SetTextColor(fg)
+		SetBackColor(bg)
+		DrawRawText(t.x, t.y+dy, CutText(prefix, maxWidth))
+
+		f, b := fg, bg
+		if r.node == t.selected {
+			f, b = fgSel, bgSel
+		}
+		shift := xs.Len(prefix)
+		if shift < maxWidth {
+			SetTextColor(f)
+			SetBackColor(b)
+			DrawRawText(t.x+shift, t.y+dy, CutText(r.node.Label, maxWidth-shift))
+		}
+	}
+}
+
+// Draw repaints the control on its View surface
+func (t *TreeView) Draw() {
+	PushAttributes()
+	defer PopAttributes()
+
+	fg, bg := RealColor(t.fg, ColorEditText), RealColor(t.bg, ColorEditBack)
+	if t.Active() {
+		fg, bg = RealColor(t.fg, ColorEditActiveText), RealColor(t.bg, ColorEditActiveBack)
+	}
+	SetTextColor(fg)
+	SetBackColor(bg)
+	FillRect(t.x, t.y, t.width, t.height, ' ')
+
+	rows := t.visibleRows()
+	t.drawItems(rows)
+	t.drawScroll(rows)
+}
+
+// selectRow selects the node which number in the list of
+// visible nodes is idx and calls onSelect if the selected
+// node changes
+func (t *TreeView) selectRow(rows []treeRow, idx int) {
+	if len(rows) == 0 {
+		return
+	}
+
+	if idx < 0 {
+		idx = 0
+	}
+	if idx >= len(rows) {
+		idx = len(rows) - 1
+	}
+
+	node := rows[idx].node
+	changed := node != t.selected
+	t.selected = node
+	t.ensureVisible(rows)
+
+	if changed && t.onSelect != nil {
+		go t.onSelect(node)
+	}
+}
+
+func (t *TreeView) moveBy(dy int) {
+	rows := t.visibleRows()
+	idx := t.selectedIndex(rows)
+	if idx == -1 {
+		t.selectRow(rows, 0)
+		return
+	}
+
+	t.selectRow(rows, idx+dy)
+}
+
+// ensureVisible scrolls the tree to make the selected node visible
+func (t *TreeView) ensureVisible(rows []treeRow) {
+	length := len(rows)
+	if length <= t.height {
+		t.topLine = 0
+		return
+	}
+
+	if t.topLine > length-t.height {
+		t.topLine = length - t.height
+	}
+
+	idx := t.selectedIndex(rows)
+	if idx == -1 {
+		return
+	}
+
+	if idx < t.topLine {
+		t.topLine = idx
+	} else if idx >= t.topLine+t.height {
+		t.topLine = idx - t.height + 1
+	}
+}
+
+// EnsureVisible makes the currently selected node visible and
+// scrolls the tree if it is required
+func (t *TreeView) EnsureVisible() {
+	t.ensureVisible(t.visibleRows())
+}
+
+func (t *TreeView) toggle(node *TreeNode) {
+	if node == nil || len(node.Children) == 0 {
+		return
+	}
+
+	if node.Expanded {
+		t.Collapse(node)
+	} else {
+		t.Expand(node)
+	}
+}
+
+func (t *TreeView) processMouseClick(ev Event) bool {
+	if ev.Key != term.MouseLeft {
+		return false
+	}
+
+	dx := ev.X - t.x
+	dy := ev.Y - t.y
+	rows := t.visibleRows()
+
+	if dx == t.width-1 {
+		if dy < 0 || dy >= t.height || len(rows) < 2 {
+			return true
+		}
+
+		if dy == 0 {
+			t.moveBy(-1)
+			return true
+		}
+		if dy == t.height-1 {
+			t.moveBy(1)
+			return true
+		}
+
+		t.buttonPos = dy
+		newPos := ItemByThumbPosition(t.buttonPos, len(rows), t.height)
+		if newPos >= 0 {
+			t.selectRow(rows, newPos)
+		}
+		return true
+	}
+
+	if dx < 0 || dx >= t.width || dy < 0 || dy >= t.height {
+		return true
+	}
+
+	idx := t.topLine + dy
+	if idx >= len(rows) {
+		return true
+	}
+
+	// click on the expand mark toggles the node
+	if dx == rows[idx].depth*2 {
+		t.toggle(rows[idx].node)
+		rows = t.visibleRows()
+	}
+	t.selectRow(rows, idx)
+
+	return true
+}
+
+/*
+ProcessEvent processes all events come from the control parent. If a control
+processes an event it should return true. If the method returns false it means
+that the control do not want or cannot process the event and the caller sends
+the event to the control parent
+*/
+func (t *TreeView) ProcessEvent(event Event) bool {
+	if !t.Active() || !t.Enabled() {
+		return false
+	}
+
+	switch event.Type {
+	case EventKey:
+		if t.onKeyPress != nil {
+			res := t.onKeyPress(event.Key)
+			if res {
+				return true
+			}
+		}
+
+		switch event.Key {
+		case term.KeyHome:
+			t.selectRow(t.visibleRows(), 0)
+			return true
+		case term.KeyEnd:
+			rows := t.visibleRows()
+			t.selectRow(rows, len(rows)-1)
+			return true
+		case term.KeyArrowUp:
+			t.moveBy(-1)
+			return true
+		case term.KeyArrowDown:
+			t.moveBy(1)
+			return true
+		case term.KeyPgup:
+			t.moveBy(-t.height)
+			return true
+		case term.KeyPgdn:
+			t.moveBy(t.height)
+			return true
+		case term.KeyArrowRight:
+			node := t.selected
+			if node == nil || len(node.Children) == 0 {
+				return true
+			}
+			if !node.Expanded {
+				t.Expand(node)
+			} else {
+				t.moveBy(1)
+			}
+			return true
+		case term.KeyArrowLeft:
+			rows := t.visibleRows()
+			idx := t.selectedIndex(rows)
+			if idx == -1 {
+				return true
+			}
+			if rows[idx].node.Expanded && len(rows[idx].node.Children) > 0 {
+				t.Collapse(rows[idx].node)
+			} else if rows[idx].parent != nil {
+				t.selectRow(rows, t.rowIndex(rows, rows[idx].parent))
+			}
+			return true
+		case term.KeyCtrlM, term.KeySpace:
+			t.toggle(t.selected)
+			return true
+		default:
+			return false
+		}
+	case EventMouse:
+		return t.processMouseClick(event)
+	}
+
+	return false
+}
+
+// rowIndex returns the index of the node in the list of visible
+// nodes or -1 if the node is not visible
+func (t *TreeView) rowIndex(rows []treeRow, node *TreeNode) int {
+	for idx, r := range rows {
+		if r.node == node {
+			return idx
+		}
+	}
+
+	return -1
+}
+
+// own methods
+
+// Root returns the root node of the tree
+func (t *TreeView) Root() *TreeNode {
+	return t.root
+}
+
+// SetRoot replaces the tree displayed by the control. The root
+// node becomes selected
+func (t *TreeView) SetRoot(node *TreeNode) {
+	t.root = node
+	t.selected = node
+	t.topLine = 0
+}
+
+// Expand displays children of the node
+func (t *TreeView) Expand(node *TreeNode) {
+	if node == nil {
+		return
+	}
+
+	node.Expanded = true
+	t.EnsureVisible()
+}
+
+// Collapse hides children of the node. If the selected node is
+// hidden after collapsing then the collapsed node becomes selected
+func (t *TreeView) Collapse(node *TreeNode) {
+	if node == nil {
+		return
+	}
+
+	node.Expanded = false
+
+	rows := t.visibleRows()
+	if t.selected != nil && t.selectedIndex(rows) == -1 {
+		t.selectRow(rows, t.rowIndex(rows, node))
+		return
+	}
+	t.ensureVisible(rows)
+}
+
+// SelectedNode returns the currently selected node or nil
+// if no node is selected
+func (t *TreeView) SelectedNode() *TreeNode {
+	return t.selected
+}
+
+// SelectNode makes the node selected if the node is visible.
+// Returns true if the node is selected successfully
+func (t *TreeView) SelectNode(node *TreeNode) bool {
+	rows := t.visibleRows()
+	idx := t.rowIndex(rows, node)
+	if idx == -1 {
+		return false
+	}
+
+	t.selectRow(rows, idx)
+	return true
+}
+
+// OnSelect sets a callback that is called every time
+// the selected node is changed
+func (t *TreeView) OnSelect(fn func(*TreeNode)) {
+	t.onSelect = fn
+}
+
+// OnKeyPress sets the callback that is called when a user presses a Key while
+// the controls is active. If a handler processes the key it should return
+// true. If handler returns false it means that the default handler will
+// process the key
+func (t *TreeView) OnKeyPress(fn func(term.Key) bool) {
+	t.onKeyPress = fn
+}
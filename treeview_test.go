@@ -0,0 +1,47 @@
+package clui
+
+import (
+	"testing"
+)
+
+func TestTreeView(t *testing.T) {
+	root := &TreeNode{Label: "root", Expanded: true}
+	a := root.AddChild("a")
+	a.AddChild("a1")
+	a.AddChild("a2")
+	root.AddChild("b")
+
+	tree := CreateTreeView(nil, 10, 2, Fixed)
+	tree.SetRoot(root)
+
+	if len(tree.visibleRows()) != 3 {
+		t.Errorf("Visible node count must be %v instead of %v", 3, len(tree.visibleRows()))
+	}
+
+	tree.Expand(a)
+	rows := tree.visibleRows()
+	if len(rows) != 5 {
+		t.Errorf("Visible node count must be %v instead of %v", 5, len(rows))
+	}
+	if rows[3].node.Label != "a2" || rows[3].depth != 2 {
+		t.Errorf("The fourth node must be %v at level %v, found %v at level %v", "a2", 2, rows[3].node.Label, rows[3].depth)
+	}
+
+	if !tree.SelectNode(rows[3].node) {
+		t.Errorf("Node a2 must be selected")
+	}
+	if tree.topLine != 2 {
+		t.Errorf("Top line must be %v instead of %v", 2, tree.topLine)
+	}
+
+	tree.Collapse(a)
+	if tree.SelectedNode() != a {
+		t.Errorf("Collapsed node must be selected instead of %v", tree.SelectedNode().Label)
+	}
+	if len(tree.visibleRows()) != 3 {
+		t.Errorf("Visible node count must be %v instead of %v", 3, len(tree.visibleRows()))
+	}
+	if tree.topLine != 1 {
+		t.Errorf("Top line must be %v instead of %v", 1, tree.topLine)
+	}
+}
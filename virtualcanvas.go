@@ -0,0 +1,169 @@
+package clui
+
+/*
+Scrollable is an object which content is larger than the area that
+displays it. Scroll bars use the interface to draw the thumb position
+and to scroll the content.
+*/
+type Scrollable interface {
+	// VirtualSize returns the full size of the content
+	VirtualSize() (int, int)
+	// ViewportSize returns the size of the visible area
+	ViewportSize() (int, int)
+	// ViewportOffset returns the position of the visible area
+	// top left corner inside the content
+	ViewportOffset() (int, int)
+	// SetViewportOffset scrolls the content
+	SetViewportOffset(x, y int)
+}
+
+/*
+VirtualCanvas is a drawing area which logical size is larger than its
+size on the screen. A widget draws its full content to the VirtualCanvas
+using logical coordinates and the regular drawing functions (DrawText,
+FillRect, DrawFrame etc): the top left corner of the content is 0:0.
+The VirtualCanvas translates coordinates by the viewport offset and
+clips everything outside the viewport, so the widget becomes scrollable
+without any scroll math.
+
+All drawing must be done between Begin and End calls:
+
+	vc.Begin()
+	DrawText(0, 0, "the first line of a long text")
+	vc.End()
+	DrawScrollBars(vc, x, y, w, h)
+*/
+type VirtualCanvas struct {
+	x, y          int
+	width, height int
+	virtW, virtH  int
+	offX, offY    int
+
+	// the canvas state saved by Begin
+	savedOrigin [2]int
+	savedClip   rect
+}
+
+// CreateVirtualCanvas creates a new VirtualCanvas with the logical
+// size. Set the screen area with SetPos and SetSize before drawing
+func CreateVirtualCanvas(width, height int) *VirtualCanvas {
+	v := new(VirtualCanvas)
+	v.virtW, v.virtH = width, height
+	return v
+}
+
+// Pos returns the screen position of the viewport
+func (v *VirtualCanvas) Pos() (int, int) {
+	return v.x, v.y
+}
+
+// SetPos changes the screen position of the viewport
+func (v *VirtualCanvas) SetPos(x, y int) {
+	v.x, v.y = x, y
+}
+
+// Size returns the size of the viewport on the screen
+func (v *VirtualCanvas) Size() (int, int) {
+	return v.width, v.height
+}
+
+// SetSize changes the size of the viewport on the screen
+func (v *VirtualCanvas) SetSize(width, height int) {
+	v.width, v.height = width, height
+	v.SetViewportOffset(v.offX, v.offY)
+}
+
+// VirtualSize returns the logical size of the canvas
+func (v *VirtualCanvas) VirtualSize() (int, int) {
+	return v.virtW, v.virtH
+}
+
+// SetVirtualSize changes the logical size of the canvas
+func (v *VirtualCanvas) SetVirtualSize(width, height int) {
+	v.virtW, v.virtH = width, height
+	v.SetViewportOffset(v.offX, v.offY)
+}
+
+// ViewportSize returns the size of the viewport. It is the same as Size
+func (v *VirtualCanvas) ViewportSize() (int, int) {
+	return v.width, v.height
+}
+
+// ViewportOffset returns the logical coordinates of the viewport
+// top left corner
+func (v *VirtualCanvas) ViewportOffset() (int, int) {
+	return v.offX, v.offY
+}
+
+// SetViewportOffset pans the view. The offset is clamped so the
+// viewport does not go outside the logical area
+func (v *VirtualCanvas) SetViewportOffset(x, y int) {
+	v.offX = clampOffset(x, v.virtW-v.width)
+	v.offY = clampOffset(y, v.virtH-v.height)
+}
+
+// ScrollBy moves the viewport by dx columns and dy rows
+func (v *VirtualCanvas) ScrollBy(dx, dy int) {
+	v.SetViewportOffset(v.offX+dx, v.offY+dy)
+}
+
+func clampOffset(offset, max int) int {
+	if offset > max {
+		offset = max
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return offset
+}
+
+// Begin switches drawing functions to the logical coordinates of the
+// VirtualCanvas. The clipping rectangle is limited by the viewport and
+// by the current clipping rectangle. Every Begin call must be paired
+// with End call
+func (v *VirtualCanvas) Begin() {
+	v.savedOrigin = [2]int{canvas.originX, canvas.originY}
+	v.savedClip = rect{x: canvas.clipX, y: canvas.clipY, w: canvas.clipW, h: canvas.clipH}
+
+	cx, cy, cw, ch := clip(v.x, v.y, v.width, v.height)
+	dx, dy := v.x-v.offX, v.y-v.offY
+	canvas.originX += dx
+	canvas.originY += dy
+	canvas.clipX, canvas.clipY = cx-dx, cy-dy
+	canvas.clipW, canvas.clipH = cw, ch
+}
+
+// End restores the coordinates and the clipping rectangle that were
+// active before Begin call
+func (v *VirtualCanvas) End() {
+	canvas.originX, canvas.originY = v.savedOrigin[0], v.savedOrigin[1]
+	c := v.savedClip
+	canvas.clipX, canvas.clipY, canvas.clipW, canvas.clipH = c.x, c.y, c.w, c.h
+}
+
+// DrawScrollBars draws scroll bars of the Scrollable along the right
+// and bottom edges of the area. A scroll bar is drawn only if the
+// content does not fit the viewport in that direction
+func DrawScrollBars(s Scrollable, x, y, w, h int) {
+	vw, vh := s.VirtualSize()
+	pw, ph := s.ViewportSize()
+	ox, oy := s.ViewportOffset()
+
+	showV, showH := vh > ph, vw > pw
+	if showV {
+		length := h
+		if showH {
+			length--
+		}
+		pos := ThumbPosition(oy, vh-ph+1, length)
+		DrawScrollBar(x+w-1, y, 1, length, pos)
+	}
+	if showH {
+		length := w
+		if showV {
+			length--
+		}
+		pos := ThumbPosition(ox, vw-pw+1, length)
+		DrawScrollBar(x, y+h-1, length, 1, pos)
+	}
+}
@@ -0,0 +1,49 @@
+package clui
+
+import (
+	"testing"
+)
+
+func TestVirtualCanvas(t *testing.T) {
+	if themeManager == nil {
+		initThemeManager()
+	}
+
+	vc := CreateVirtualCanvas(10, 6)
+	vc.SetPos(1, 1)
+	vc.SetSize(4, 3)
+	vc.SetViewportOffset(20, 20)
+	if x, y := vc.ViewportOffset(); x != 6 || y != 3 {
+		t.Errorf("Offset must be clamped: %v:%v", x, y)
+	}
+
+	vc.SetViewportOffset(3, 2)
+	out := drawToString(6, 5, func() {
+		vc.Begin()
+		DrawFrame(0, 0, 10, 6, BorderThin)
+		DrawText(3, 2, "abcdef")
+		vc.End()
+		PutChar(0, 0, '+')
+	})
+	want := "+     \n abcd \n      \n      \n      \n"
+	if out != want {
+		t.Errorf("Invalid viewport:\n%v", out)
+	}
+
+	vc.SetViewportOffset(6, 0)
+	out = drawToString(6, 5, func() {
+		vc.Begin()
+		DrawFrame(0, 0, 10, 6, BorderThin)
+		vc.End()
+	})
+	want = "      \n ───┐ \n    │ \n    │ \n      \n"
+	if out != want {
+		t.Errorf("Invalid viewport after scrolling:\n%v", out)
+	}
+
+	out = drawToString(6, 5, func() { DrawScrollBars(vc, 0, 0, 6, 5) })
+	want = "     ▲\n     ■\n     ░\n     ▼\n◄░░■► \n"
+	if out != want {
+		t.Errorf("Invalid scroll bars:\n%v", out)
+	}
+}
@@ -0,0 +1,234 @@
+package clui
+
+import (
+	term "github.com/nsf/termbox-go"
+	"math"
+)
+
+/*
+VUMeter is a control that displays an audio signal level in dB
+as a bar. The bar is divided into three zones: normal level is
+drawn with ColorVUMeterNormal color, the level above the high
+threshold(-6dB by default) with ColorVUMeterHigh color, and the
+level above the clip threshold(0dB by default) with ColorVUMeterClip
+color. In vertical orientation(default one) the bar grows from
+bottom to top, in horizontal one - from left to right.
+If peak hold is on, the meter displays a mark at the highest recent
+level. The mark does not fall down at once: every SetLevel call that
+sets a lower level moves the mark down by PeakDecay dB until it
+reaches the current level
+*/
+type VUMeter struct {
+	BaseControl
+	level     float64
+	min, max  float64
+	high      float64
+	clip      float64
+	direction Direction
+	peakHold  bool
+	peak      float64
+	decay     float64
+}
+
+/*
+CreateVUMeter creates a new VU meter. The default range is from -60dB
+to +6dB.
+parent - is container that keeps the control.
+w and h - are minimal size of the control.
+scale - the way of scaling the control when the parent is resized. Use DoNotScale constant if the
+control should keep its original size.
+*/
+func CreateVUMeter(parent Control, w, h int, scale int) *VUMeter {
+	c := new(VUMeter)
+
+	if w == AutoSize {
+		w = 1
+	}
+	if h == AutoSize {
+		h = 10
+	}
+
+	c.parent = parent
+
+	c.SetSize(w, h)
+	c.SetConstraints(w, h)
+	c.tabSkip = true
+	c.min, c.max = -60, 6
+	c.high, c.clip = -6, 0
+	c.direction = Vertical
+	c.decay = 0.5
+	c.level, c.peak = c.min, c.min
+	c.SetScale(scale)
+
+	if parent != nil {
+		parent.AddChild(c)
+	}
+
+	return c
+}
+
+// Draw repaints the control on its View surface
+func (v *VUMeter) Draw() {
+	PushAttributes()
+	defer PopAttributes()
+
+	fg, bg := RealColor(v.fg, ColorVUMeterText), RealColor(v.bg, ColorVUMeterBack)
+	SetTextColor(fg)
+	SetBackColor(bg)
+	FillRect(v.x, v.y, v.width, v.height, ' ')
+
+	length := v.height
+	if v.direction == Horizontal {
+		length = v.width
+	}
+	if length <= 0 {
+		return
+	}
+
+	ch := []rune(SysObject(ObjBarChart))[0]
+	filled := v.cellsFor(v.level, length)
+	for i := 0; i < filled; i++ {
+		SetTextColor(v.zoneColor(v.cellLevel(i, length)))
+		v.drawCell(i, ch)
+	}
+
+	if !v.peakHold {
+		return
+	}
+	if p := v.cellsFor(v.peak, length) - 1; p >= filled {
+		SetTextColor(v.zoneColor(v.cellLevel(p, length)))
+		mark := '─'
+		if v.direction == Horizontal {
+			mark = '│'
+		}
+		v.drawCell(p, mark)
+	}
+}
+
+// drawCell fills the i-th cell of the bar counting from the bar base
+func (v *VUMeter) drawCell(i int, ch rune) {
+	if v.direction == Horizontal {
+		FillRect(v.x+i, v.y, 1, v.height, ch)
+	} else {
+		FillRect(v.x, v.y+v.height-1-i, v.width, 1, ch)
+	}
+}
+
+// cellsFor returns the number of bar cells that display the level
+func (v *VUMeter) cellsFor(db float64, length int) int {
+	n := int(math.Round((db - v.min) / (v.max - v.min) * float64(length)))
+	if n < 0 {
+		return 0
+	}
+	if n > length {
+		return length
+	}
+	return n
+}
+
+// cellLevel returns the highest level displayed by the i-th cell
+func (v *VUMeter) cellLevel(i, length int) float64 {
+	return v.min + float64(i+1)/float64(length)*(v.max-v.min)
+}
+
+// zoneColor returns the color of the zone the level belongs to
+func (v *VUMeter) zoneColor(db float64) term.Attribute {
+	switch {
+	case db >= v.clip:
+		return RealColor(ColorDefault, ColorVUMeterClip)
+	case db >= v.high:
+		return RealColor(ColorDefault, ColorVUMeterHigh)
+	}
+	return RealColor(ColorDefault, ColorVUMeterNormal)
+}
+
+// Level returns the current level in dB
+func (v *VUMeter) Level() float64 {
+	return v.level
+}
+
+// SetLevel changes the current level in dB. The level is displayed
+// clipped by the meter range. If peak hold is on the peak mark moves
+// up to the new level or decays to it
+func (v *VUMeter) SetLevel(db float64) {
+	v.level = db
+	switch {
+	case db >= v.peak:
+		v.peak = db
+	case v.peak-v.decay < db:
+		v.peak = db
+	default:
+		v.peak -= v.decay
+	}
+}
+
+// Peak returns the level of the peak mark in dB
+func (v *VUMeter) Peak() float64 {
+	return v.peak
+}
+
+// PeakHold returns if the meter displays the peak mark
+func (v *VUMeter) PeakHold() bool {
+	return v.peakHold
+}
+
+// SetPeakHold turns on and off the peak mark. Turning it on resets
+// the peak to the current level
+func (v *VUMeter) SetPeakHold(hold bool) {
+	if hold && !v.peakHold {
+		v.peak = v.level
+	}
+	v.peakHold = hold
+}
+
+// PeakDecay returns how many dB the peak mark falls down by every
+// SetLevel call
+func (v *VUMeter) PeakDecay() float64 {
+	return v.decay
+}
+
+// SetPeakDecay changes how many dB the peak mark falls down by every
+// SetLevel call. Values less than or equal to 0 are ignored
+func (v *VUMeter) SetPeakDecay(db float64) {
+	if db > 0 {
+		v.decay = db
+	}
+}
+
+// Orientation returns the direction in which the bar grows
+func (v *VUMeter) Orientation() Direction {
+	return v.direction
+}
+
+// SetOrientation sets the direction in which the bar grows:
+// Vertical(default) - from bottom to top, or Horizontal - from
+// left to right
+func (v *VUMeter) SetOrientation(dir Direction) {
+	v.direction = dir
+}
+
+// Range returns the lowest and the highest levels of the meter in dB
+func (v *VUMeter) Range() (float64, float64) {
+	return v.min, v.max
+}
+
+// SetRange changes the displayed range. The call is ignored if min
+// is not less than max
+func (v *VUMeter) SetRange(min, max float64) {
+	if min >= max {
+		return
+	}
+	v.min, v.max = min, max
+}
+
+// Thresholds returns the levels where the high and the clip zones
+// start
+func (v *VUMeter) Thresholds() (float64, float64) {
+	return v.high, v.clip
+}
+
+// SetThresholds changes the levels where the high and the clip zones
+// start. The defaults are -6dB and 0dB
+func (v *VUMeter) SetThresholds(high, clip float64) {
+	v.high, v.clip = high, clip
+}
@@ -0,0 +1,39 @@
+package clui
+
+import (
+	"testing"
+)
+
+func TestVUMeterZones(t *testing.T) {
+	mock := CreateMockCanvas(6, 1)
+	defer mock.Close()
+
+	meter := CreateVUMeter(nil, 6, 1, Fixed)
+	meter.SetOrientation(Horizontal)
+	meter.SetRange(-12, 0)
+	meter.SetPeakHold(true)
+	meter.SetLevel(0)
+	meter.SetLevel(-4)
+
+	// the peak decays by 0.5dB per update and stays in the last cell
+	if meter.Peak() != -0.5 {
+		t.Errorf("Peak == %v, want -0.5", meter.Peak())
+	}
+
+	meter.Draw()
+	mock.AssertTextAt(t, 0, 0, "████ │")
+	for x, clr := range []string{ColorVUMeterNormal, ColorVUMeterNormal, ColorVUMeterHigh, ColorVUMeterHigh} {
+		if fg := mock.Cell(x, 0).Fg; fg != RealColor(ColorDefault, clr) {
+			t.Errorf("Cell %v color %v, want %v", x, fg, clr)
+		}
+	}
+	if fg := mock.Cell(5, 0).Fg; fg != RealColor(ColorDefault, ColorVUMeterClip) {
+		t.Errorf("Peak color %v, want clip color", fg)
+	}
+
+	// the peak never falls below the current level
+	meter.SetLevel(-0.8)
+	if meter.Peak() != -0.8 {
+		t.Errorf("Peak == %v, want -0.8", meter.Peak())
+	}
+}
@@ -0,0 +1,256 @@
+package clui
+
+import (
+	"math"
+)
+
+// waterfallSegment is one change of the running total
+type waterfallSegment struct {
+	label string
+	delta float64
+}
+
+/*
+WaterfallChart is a control that displays how a running total
+changes: every bar represents an increment or a decrement of the
+total. The bar floats at the level of the previous total and ends
+at the new one, so the bars make a staircase from the base value
+to the final total. Increments are drawn with ColorWaterfallUp
+color, decrements with ColorWaterfallDown one.
+An optional total bar at the end of the chart displays the final
+total from zero. Segment labels are displayed under bars if the
+chart is higher than 2 rows. All bars have the same width, the
+bars that do not fit the chart are not displayed.
+The chart is useful to display financial P&L statements and
+budget changes
+*/
+type WaterfallChart struct {
+	BaseControl
+	base       float64
+	segments   []waterfallSegment
+	showTotal  bool
+	totalLabel string
+	barWidth   int
+	gap        int
+}
+
+/*
+CreateWaterfallChart creates a new waterfall chart.
+parent - is container that keeps the control.
+w and h - are minimal size of the control.
+scale - the way of scaling the control when the parent is resized. Use DoNotScale constant if the
+control should keep its original size.
+*/
+func CreateWaterfallChart(parent Control, w, h int, scale int) *WaterfallChart {
+	c := new(WaterfallChart)
+
+	if w == AutoSize {
+		w = 10
+	}
+	if h == AutoSize {
+		h = 5
+	}
+
+	c.parent = parent
+
+	c.SetSize(w, h)
+	c.SetConstraints(w, h)
+	c.tabSkip = true
+	c.barWidth = 3
+	c.gap = 1
+	c.totalLabel = "Total"
+	c.SetScale(scale)
+
+	if parent != nil {
+		parent.AddChild(c)
+	}
+
+	return c
+}
+
+// Draw repaints the control on its View surface
+func (w *WaterfallChart) Draw() {
+	PushAttributes()
+	defer PopAttributes()
+
+	fg, bg := RealColor(w.fg, ColorWaterfallText), RealColor(w.bg, ColorWaterfallBack)
+	SetTextColor(fg)
+	SetBackColor(bg)
+	FillRect(w.x, w.y, w.width, w.height, ' ')
+
+	if len(w.segments) == 0 || w.barWidth <= 0 {
+		return
+	}
+
+	h := w.barHeight()
+	if h < 1 {
+		return
+	}
+
+	ch := []rune(SysObject(ObjBarChart))[0]
+	upClr := RealColor(ColorDefault, ColorWaterfallUp)
+	downClr := RealColor(ColorDefault, ColorWaterfallDown)
+	totalClr := RealColor(ColorDefault, ColorWaterfallTotal)
+
+	lo, hi := w.limits()
+	pos := 0
+	for idx, bar := range w.bars() {
+		if pos+w.barWidth > w.width {
+			break
+		}
+
+		clr := upClr
+		switch {
+		case w.showTotal && idx == len(w.segments):
+			clr = totalClr
+		case bar.to < bar.from:
+			clr = downClr
+		}
+
+		from, to := w.rowOf(bar.from, lo, hi, h), w.rowOf(bar.to, lo, hi, h)
+		if from > to {
+			from, to = to, from
+		}
+		// a zero change is still displayed as a thin bar
+		if from == to {
+			if to < h {
+				to++
+			} else {
+				from--
+			}
+		}
+		SetTextColor(clr)
+		SetBackColor(bg)
+		FillRect(w.x+pos, w.y+h-to, w.barWidth, to-from, ch)
+
+		if w.height > 2 {
+			SetTextColor(fg)
+			shift, s := AlignText(bar.label, w.barWidth, AlignCenter)
+			DrawRawText(w.x+pos+shift, w.y+h, s)
+		}
+
+		pos += w.barWidth + w.gap
+	}
+}
+
+// waterfallBar is the bar to draw: it starts at the total before the
+// change and ends at the total after it
+type waterfallBar struct {
+	label    string
+	from, to float64
+}
+
+// bars returns all bars of the chart including the total one
+func (w *WaterfallChart) bars() []waterfallBar {
+	bars := make([]waterfallBar, 0, len(w.segments)+1)
+	total := w.base
+	for _, s := range w.segments {
+		bars = append(bars, waterfallBar{label: s.label, from: total, to: total + s.delta})
+		total += s.delta
+	}
+	if w.showTotal {
+		bars = append(bars, waterfallBar{label: w.totalLabel, from: 0, to: total})
+	}
+	return bars
+}
+
+// barHeight returns the number of rows for bars. The bottom row
+// displays labels
+func (w *WaterfallChart) barHeight() int {
+	if w.height > 2 {
+		return w.height - 1
+	}
+	return w.height
+}
+
+// limits returns the lowest and the highest levels of all bars
+func (w *WaterfallChart) limits() (float64, float64) {
+	lo, hi := w.base, w.base
+	for _, bar := range w.bars() {
+		lo = math.Min(lo, math.Min(bar.from, bar.to))
+		hi = math.Max(hi, math.Max(bar.from, bar.to))
+	}
+	return lo, hi
+}
+
+// rowOf converts the level to the number of rows from the bottom of
+// the bar area
+func (w *WaterfallChart) rowOf(v, lo, hi float64, h int) int {
+	if hi == lo {
+		return h
+	}
+	return int(math.Round((v - lo) / (hi - lo) * float64(h)))
+}
+
+// AddSegment appends a change of the running total. Positive delta
+// is an increment, negative one is a decrement
+func (w *WaterfallChart) AddSegment(label string, delta float64) {
+	w.segments = append(w.segments, waterfallSegment{label: label, delta: delta})
+}
+
+// ClearSegments removes all changes of the running total
+func (w *WaterfallChart) ClearSegments() {
+	w.segments = nil
+}
+
+// BaseValue returns the initial value of the running total
+func (w *WaterfallChart) BaseValue() float64 {
+	return w.base
+}
+
+// SetBaseValue changes the initial value of the running total. The
+// first bar starts at this level
+func (w *WaterfallChart) SetBaseValue(v float64) {
+	w.base = v
+}
+
+// Total returns the running total after all changes
+func (w *WaterfallChart) Total() float64 {
+	total := w.base
+	for _, s := range w.segments {
+		total += s.delta
+	}
+	return total
+}
+
+// TotalBar returns if the chart displays the final total bar
+func (w *WaterfallChart) TotalBar() bool {
+	return w.showTotal
+}
+
+// SetTotalBar turns on and off the bar at the end of the chart that
+// displays the final total from zero
+func (w *WaterfallChart) SetTotalBar(visible bool) {
+	w.showTotal = visible
+}
+
+// TotalLabel returns the label of the total bar
+func (w *WaterfallChart) TotalLabel() string {
+	return w.totalLabel
+}
+
+// SetTotalLabel changes the label of the total bar. The default
+// label is "Total"
+func (w *WaterfallChart) SetTotalLabel(label string) {
+	w.totalLabel = label
+}
+
+// BarWidth returns the width of every bar
+func (w *WaterfallChart) BarWidth() int {
+	return w.barWidth
+}
+
+// SetBarWidth changes the width of bars
+func (w *WaterfallChart) SetBarWidth(width int) {
+	w.barWidth = width
+}
+
+// BarGap returns the space width between two adjacent bars
+func (w *WaterfallChart) BarGap() int {
+	return w.gap
+}
+
+// SetBarGap changes the space width between two adjacent bars
+func (w *WaterfallChart) SetBarGap(gap int) {
+	w.gap = gap
+}
@@ -0,0 +1,25 @@
+package clui
+
+import (
+	"testing"
+)
+
+func TestWaterfallChart(t *testing.T) {
+	initThemeManager()
+	chart := CreateWaterfallChart(nil, 5, 5, Fixed)
+	chart.SetBarWidth(1)
+	chart.SetBaseValue(2)
+	chart.AddSegment("a", 2)
+	chart.AddSegment("b", -1)
+	chart.SetTotalBar(true)
+
+	if chart.Total() != 3 {
+		t.Errorf("Total == %v, want 3", chart.Total())
+	}
+
+	// bars float at the previous total, the total bar starts at zero
+	want := "█ █  \n█   █\n    █\n    █\na b T"
+	if got := renderToString(chart); got != want {
+		t.Errorf("Invalid waterfall chart:\n%v", got)
+	}
+}
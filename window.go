@@ -16,10 +16,34 @@ type Window struct {
 	origHeight int
 	origX      int
 	origY      int
-	hidden     bool
+	// button clicked when a user presses Enter
+	defButton *Button
 
 	onClose   func(Event) bool
 	onKeyDown func(Event) bool
+	onResize  func(int, int)
+	// fit the Window to the terminal after it is resized
+	autoResize bool
+
+	// modal dialog displayed above the Window content and the
+	// control that was active before the dialog was shown
+	dialog    Dialog
+	prevFocus Control
+	// queue of notifications displayed by ShowNotification
+	notices *notifier
+	// subscribers and queue of custom events
+	bus *eventBus
+	// global shortcuts added with RegisterShortcut
+	shortcuts *shortcutList
+	// keys that move the focus between focus groups: key -> source
+	// group -> target group
+	groupNav map[KeyCombo]map[string]string
+	// stack of focus traps added with PushFocusTrap
+	traps []focusTrap
+	// the child expanded to the whole screen with SetFullscreen
+	fullscreen *fullscreenState
+	// rules loaded with LoadStyleSheet
+	styles *styleSheet
 }
 
 func CreateWindow(x, y, w, h int, title string) *Window {
@@ -36,10 +60,14 @@ func CreateWindow(x, y, w, h int, title string) *Window {
 	wnd.SetPos(x, y)
 	wnd.SetTitle(title)
 	wnd.buttons = ButtonClose | ButtonBottom | ButtonMaximize
+	wnd.autoResize = true
 	wnd.children = make([]Control, 0)
 	wnd.SetPaddings(1, 1)
 	wnd.SetGaps(1, 0)
 	wnd.SetScale(1)
+	wnd.notices = newNotifier()
+	wnd.bus = newEventBus()
+	wnd.shortcuts = newShortcutList()
 
 	return wnd
 }
@@ -123,6 +151,11 @@ func (wnd *Window) drawButtons() {
 }
 
 func (wnd *Window) Draw() {
+	if wnd.fullscreen != nil {
+		wnd.drawFullscreen()
+		return
+	}
+
 	PushAttributes()
 	defer PopAttributes()
 
@@ -139,9 +172,18 @@ func (wnd *Window) Draw() {
 	wnd.drawFrame()
 	wnd.drawTitle()
 	wnd.drawButtons()
+
+	if wnd.dialog != nil {
+		wnd.drawModal()
+	}
+	wnd.notices.draw(wnd)
 }
 
 func (c *Window) HitTest(x, y int) HitResult {
+	if c.fullscreen != nil {
+		return HitInside
+	}
+
 	if x > c.x && x < c.x+c.width-1 &&
 		y > c.y && y < c.y+c.height-1 {
 		return HitInside
@@ -203,13 +245,41 @@ func (c *Window) HitTest(x, y int) HitResult {
 	return HitOutside
 }
 
+// tabFocus moves the focus to the next child of the parent that
+// has tab-stop feature on
+func tabFocus(parent Control) {
+	aC := ActiveControl(parent)
+	if tc, ok := aC.(tabConsumer); ok && tc.nextTabStop(true) {
+		return
+	}
+	changeFocus(aC, NextControl(parent, aC, true))
+}
+
 func (c *Window) ProcessEvent(ev Event) bool {
+	if c.dialog != nil {
+		switch ev.Type {
+		case EventKey, EventMouse, EventClick:
+			return c.processModalEvent(ev)
+		case EventMove, EventResize:
+			defer c.placeModal()
+		}
+	}
+	if c.fullscreen != nil && c.processFullscreenEvent(ev) {
+		return true
+	}
+
 	switch ev.Type {
 	case EventMove:
 		c.PlaceChildren()
 	case EventResize:
 		c.ResizeChildren()
 		c.PlaceChildren()
+		if c.fullscreen != nil {
+			c.fitFullscreen()
+		}
+		if c.onResize != nil {
+			c.onResize(c.width, c.height)
+		}
 	case EventClose:
 		if c.onClose != nil {
 			if !c.onClose(ev) {
@@ -218,30 +288,63 @@ func (c *Window) ProcessEvent(ev Event) bool {
 		}
         return true
 	case EventKey:
+		if action := c.shortcutFor(ev); action != nil {
+			action()
+			return true
+		}
+		if c.navigateGroups(ev) {
+			return true
+		}
 		if ev.Key == term.KeyTab {
-			aC := ActiveControl(c)
-			nC := NextControl(c, aC, true)
-			if nC != aC {
-				if aC != nil {
-					aC.SetActive(false)
-					aC.ProcessEvent(Event{Type: EventActivate, X: 0})
+			tabFocus(c.focusRoot())
+			return true
+		} else {
+			if ev.Mod == term.ModAlt && ev.Key == term.KeyF10 {
+				aC := ActiveControl(c)
+				if aC == nil {
+					aC = c
 				}
-				if nC != nil {
-					nC.SetActive(true)
-					nC.ProcessEvent(Event{Type: EventActivate, X: 1})
+				if cm := contextMenuFor(aC); cm != nil {
+					x, y := aC.Pos()
+					cm.Show(x, y+1)
+					return true
+				}
+			}
+			if ev.Mod == term.ModAlt && ev.Ch != 0 {
+				hk := FindFirstControl(c, func(ctrl Control) bool {
+					_, ok := ctrl.(hotKeyHandler)
+					return ok
+				})
+				if hk != nil && hk.(hotKeyHandler).processHotKey(ev) {
+					return true
 				}
 			}
-			return true
-		} else {
 			if SendEventToChild(c, ev) {
 				return true
 			}
-			if c.onKeyDown != nil {
-				return c.onKeyDown(ev)
+			if c.onKeyDown != nil && c.onKeyDown(ev) {
+				return true
+			}
+			if ev.Key == term.KeyEnter && c.defButton != nil && c.defButton.Enabled() {
+				c.defButton.click(ev)
+				return true
 			}
 			return false
 		}
 	default:
+		if (ev.Type == EventMouse || ev.Type == EventClick) && c.outsideTrap(ev) {
+			return true
+		}
+		if ev.Type == EventMouse && ev.Key == term.MouseRight {
+			child := ChildAt(c, ev.X, ev.Y)
+			if cm := contextMenuFor(child); cm != nil {
+				if child != c {
+					ActivateControl(c, child)
+				}
+				cm.Show(ev.X, ev.Y)
+				return true
+			}
+		}
 		if ev.Type == EventMouse && ev.Key == term.MouseLeft {
 			DeactivateControls(c)
 		}
@@ -259,6 +362,21 @@ func (w *Window) OnKeyDown(fn func(Event) bool) {
 	w.onKeyDown = fn
 }
 
+// DefaultButton returns the button that is clicked when a user
+// presses Enter and the active control does not process it
+func (w *Window) DefaultButton() *Button {
+	return w.defButton
+}
+
+// SetDefaultButton sets the button that is clicked when a user
+// presses Enter. Before clicking the default button, with Enter or
+// with mouse, the window validates all its EditFields and the click
+// is ignored if any of them is invalid. Pass nil to remove the
+// default button
+func (w *Window) SetDefaultButton(btn *Button) {
+	w.defButton = btn
+}
+
 // SetMaximized opens the view to full screen or restores its
 // previous size
 func (w *Window) SetMaximized(maximize bool) {
@@ -282,6 +400,61 @@ func (w *Window) SetMaximized(maximize bool) {
 	w.PlaceChildren()
 }
 
+// fitScreen reflows the Window after the terminal is resized: maximized
+// Window takes the whole new screen, and other Windows shrink and move
+// to stay inside the screen
+func (w *Window) fitScreen(width, height int) {
+	x, y := w.Pos()
+	ww, wh := w.Size()
+	if w.maximized {
+		x, y, ww, wh = 0, 0, width, height
+	} else {
+		if ww > width {
+			ww = width
+		}
+		if wh > height {
+			wh = height
+		}
+		if x+ww > width {
+			x = width - ww
+		}
+		if y+wh > height {
+			y = height - wh
+		}
+		if x < 0 {
+			x = 0
+		}
+		if y < 0 {
+			y = 0
+		}
+	}
+
+	w.SetPos(x, y)
+	w.SetSize(ww, wh)
+	w.ProcessEvent(Event{Type: EventResize})
+}
+
+// AutoResize returns if the Window reflows automatically after the
+// terminal is resized
+func (w *Window) AutoResize() bool {
+	return w.autoResize
+}
+
+// SetAutoResize turns on and off reflowing the Window after the terminal
+// is resized. It is on by default. If it is off, the application
+// handles the terminal resize itself, even for maximized Window
+func (w *Window) SetAutoResize(auto bool) {
+	w.autoResize = auto
+}
+
+// OnResize sets the callback that is called every time the Window size
+// changes and its children are rearranged: after the terminal is resized
+// or after a user resizes the Window. The callback gets the new Window
+// width and height
+func (w *Window) OnResize(fn func(width, height int)) {
+	w.onResize = fn
+}
+
 // Maximized returns if the view is in full screen mode
 func (w *Window) Maximized() bool {
 	return w.maximized
@@ -0,0 +1,33 @@
+package clui
+
+import (
+	"testing"
+)
+
+func TestWindowAutoResize(t *testing.T) {
+	wnd := CreateWindow(20, 10, 30, 10, "Test")
+	wnd.SetConstraints(10, 5)
+	CreateFrame(wnd, 5, 3, BorderNone, 1)
+
+	var got []int
+	wnd.OnResize(func(w, h int) { got = append(got, w, h) })
+
+	wnd.fitScreen(40, 15)
+	if x, y := wnd.Pos(); x != 10 || y != 5 {
+		t.Errorf("Window must move inside the screen: %v:%v", x, y)
+	}
+	if w, h := wnd.Size(); w != 30 || h != 10 {
+		t.Errorf("Window that fits the screen must keep its size: %vx%v", w, h)
+	}
+
+	wnd.fitScreen(20, 8)
+	if x, y := wnd.Pos(); x != 0 || y != 0 {
+		t.Errorf("Invalid position %v:%v", x, y)
+	}
+	if w, h := wnd.Size(); w != 20 || h != 8 {
+		t.Errorf("Window must shrink to the screen: %vx%v", w, h)
+	}
+	if len(got) != 4 || got[2] != 20 || got[3] != 8 {
+		t.Errorf("OnResize must be called after every reflow: %v", got)
+	}
+}